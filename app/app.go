@@ -29,6 +29,8 @@ import (
 	"github.com/hdac-io/friday/x/slashing"
 	"github.com/hdac-io/friday/x/staking"
 	"github.com/hdac-io/friday/x/supply"
+	"github.com/hdac-io/friday/x/upgrade"
+	upgradeclient "github.com/hdac-io/friday/x/upgrade/client"
 )
 
 const appName = "FridayApp"
@@ -40,6 +42,9 @@ var (
 	// default home directories for friday server daemon
 	DefaultNodeHome = os.ExpandEnv("$HOME/.nodef")
 
+	// default unix socket of the execution engine the app connects to
+	DefaultEESocket = os.ExpandEnv("$HOME/.casperlabs/.casper-node.sock")
+
 	// The module BasicManager is in charge of setting up basic,
 	// non-dependant module elements, such as codec registration
 	// and genesis verification.
@@ -51,12 +56,14 @@ var (
 		staking.AppModuleBasic{},
 		mint.AppModuleBasic{},
 		distr.AppModuleBasic{},
-		gov.NewAppModuleBasic(paramsclient.ProposalHandler, distr.ProposalHandler),
+		gov.NewAppModuleBasic(paramsclient.ProposalHandler, distr.ProposalHandler,
+			upgradeclient.ProposalHandler, upgradeclient.CancelProposalHandler),
 		params.AppModuleBasic{},
 		slashing.AppModuleBasic{},
 		supply.AppModuleBasic{},
 		nickname.AppModuleBasic{},
 		executionlayer.AppModuleBasic{},
+		upgrade.AppModuleBasic{},
 	)
 
 	// module account permissions
@@ -105,6 +112,7 @@ type FridayApp struct {
 	paramsKeeper         params.Keeper
 	nicknameKeeper       nickname.NicknameKeeper
 	executionLayerKeeper executionlayer.ExecutionLayerKeeper
+	upgradeKeeper        upgrade.Keeper
 
 	// the module manager
 	mm *module.Manager
@@ -112,7 +120,7 @@ type FridayApp struct {
 
 // NewFridayApp returns a reference to an initialized FridayApp.
 func NewFridayApp(logger log.Logger, db dbm.DB, traceStore io.Writer, loadLatest bool,
-	invCheckPeriod uint, baseAppOptions ...func(*bam.BaseApp)) *FridayApp {
+	invCheckPeriod uint, eeSocket string, elMetrics *executionlayer.Metrics, baseAppOptions ...func(*bam.BaseApp)) *FridayApp {
 
 	cdc := MakeCodec()
 
@@ -126,6 +134,7 @@ func NewFridayApp(logger log.Logger, db dbm.DB, traceStore io.Writer, loadLatest
 		gov.StoreKey, params.StoreKey,
 		nickname.StoreKey,
 		executionlayer.HashMapStoreKey,
+		upgrade.StoreKey,
 	)
 	tkeys := sdk.NewTransientStoreKeys(staking.TStoreKey, params.TStoreKey)
 
@@ -146,6 +155,7 @@ func NewFridayApp(logger log.Logger, db dbm.DB, traceStore io.Writer, loadLatest
 	distrSubspace := app.paramsKeeper.Subspace(distr.DefaultParamspace)
 	slashingSubspace := app.paramsKeeper.Subspace(slashing.DefaultParamspace)
 	govSubspace := app.paramsKeeper.Subspace(gov.DefaultParamspace)
+	executionLayerSubspace := app.paramsKeeper.Subspace(executionlayer.DefaultParamspace)
 
 	// add keepers
 	app.accountKeeper = auth.NewAccountKeeper(app.cdc, keys[auth.StoreKey], authSubspace, auth.ProtoBaseAccount)
@@ -166,16 +176,33 @@ func NewFridayApp(logger log.Logger, db dbm.DB, traceStore io.Writer, loadLatest
 	app.executionLayerKeeper = executionlayer.NewExecutionLayerKeeper(
 		app.cdc,
 		keys[executionlayer.HashMapStoreKey],
-		os.ExpandEnv("$HOME/.casperlabs/.casper-node.sock"),
+		executionLayerSubspace,
+		eeSocket,
 		app.accountKeeper,
 		app.nicknameKeeper,
 	)
+	app.executionLayerKeeper.SetPruning(app.BaseApp.Pruning())
+	if elMetrics != nil {
+		app.executionLayerKeeper.SetMetrics(elMetrics)
+	}
+
+	app.upgradeKeeper = upgrade.NewKeeper(app.cdc, keys[upgrade.StoreKey])
+	// The handlers of the upgrades this binary applies are registered here, e.g.
+	//	app.upgradeKeeper.SetUpgradeHandler("name", func(ctx sdk.Context, plan upgrade.Plan) {
+	//		upgrade.MigrateStore(ctx.KVStore(keys[nickname.StoreKey]), prefix, migrateFn)
+	//	})
+	app.upgradeKeeper.SetUpgradeHandler(executionlayer.MigrationUpgradeName, func(ctx sdk.Context, plan upgrade.Plan) {
+		app.executionLayerKeeper.MigrateParams(ctx)
+		app.executionLayerKeeper.MigrateCommissions(ctx)
+		app.executionLayerKeeper.MigrateContractIndex(ctx)
+	})
 
 	// register the proposal types
 	govRouter := gov.NewRouter()
 	govRouter.AddRoute(gov.RouterKey, gov.ProposalHandler).
 		AddRoute(params.RouterKey, params.NewParamChangeProposalHandler(app.paramsKeeper)).
-		AddRoute(distr.RouterKey, distr.NewCommunityPoolSpendProposalHandler(app.distrKeeper))
+		AddRoute(distr.RouterKey, distr.NewCommunityPoolSpendProposalHandler(app.distrKeeper)).
+		AddRoute(upgrade.RouterKey, upgrade.NewSoftwareUpgradeProposalHandler(app.upgradeKeeper))
 	app.govKeeper = gov.NewKeeper(
 		app.cdc, keys[gov.StoreKey], app.paramsKeeper, govSubspace,
 		app.supplyKeeper, &stakingKeeper, gov.DefaultCodespace, govRouter,
@@ -202,14 +229,15 @@ func NewFridayApp(logger log.Logger, db dbm.DB, traceStore io.Writer, loadLatest
 		staking.NewAppModule(app.stakingKeeper, app.distrKeeper, app.accountKeeper, app.supplyKeeper),
 		nickname.NewAppModule(app.nicknameKeeper),
 		executionlayer.NewAppModule(app.executionLayerKeeper),
+		upgrade.NewAppModule(app.upgradeKeeper),
 	)
 
 	// During begin block slashing happens after distr.BeginBlocker so that
 	// there is nothing left over in the validator fee pool, so as to keep the
 	// CanWithdrawInvariant invariant.
-	app.mm.SetOrderBeginBlockers(executionlayer.ModuleName)
+	app.mm.SetOrderBeginBlockers(upgrade.ModuleName, executionlayer.ModuleName)
 
-	app.mm.SetOrderEndBlockers(executionlayer.ModuleName)
+	app.mm.SetOrderEndBlockers(gov.ModuleName, executionlayer.ModuleName)
 
 	// NOTE: The genutils module must occur after staking so that pools are
 	// properly initialized with tokens from genesis accounts.
@@ -219,6 +247,7 @@ func NewFridayApp(logger log.Logger, db dbm.DB, traceStore io.Writer, loadLatest
 		mint.ModuleName, supply.ModuleName, genutil.ModuleName,
 		nickname.ModuleName,
 		executionlayer.ModuleName,
+		upgrade.ModuleName,
 	)
 
 	app.mm.RegisterRoutes(app.Router(), app.QueryRouter())
@@ -230,7 +259,10 @@ func NewFridayApp(logger log.Logger, db dbm.DB, traceStore io.Writer, loadLatest
 	// initialize BaseApp
 	app.SetInitChainer(app.InitChainer)
 	app.SetBeginBlocker(app.BeginBlocker)
-	app.SetAnteHandler(auth.NewAnteHandler(app.accountKeeper, app.supplyKeeper, auth.DefaultSigVerificationGasConsumer))
+	app.SetAnteHandler(executionlayer.NewAnteHandler(
+		app.executionLayerKeeper,
+		auth.NewAnteHandler(app.accountKeeper, app.supplyKeeper, auth.DefaultSigVerificationGasConsumer),
+	))
 	app.SetEndBlocker(app.EndBlocker)
 
 	if loadLatest {
@@ -16,11 +16,11 @@ import (
 
 func TestFridaydExport(t *testing.T) {
 	db := db.NewMemDB()
-	fapp := NewFridayApp(log.NewTMLogger(log.NewSyncWriter(os.Stdout)), db, nil, true, 0)
+	fapp := NewFridayApp(log.NewTMLogger(log.NewSyncWriter(os.Stdout)), db, nil, true, 0, DefaultEESocket, nil)
 	setGenesis(fapp)
 
 	// Making a new app object with the db, so that initchain hasn't been called
-	newGapp := NewFridayApp(log.NewTMLogger(log.NewSyncWriter(os.Stdout)), db, nil, true, 0)
+	newGapp := NewFridayApp(log.NewTMLogger(log.NewSyncWriter(os.Stdout)), db, nil, true, 0, DefaultEESocket, nil)
 	_, _, err := newGapp.ExportAppStateAndValidators(false, []string{})
 	require.NoError(t, err, "ExportAppStateAndValidators should not have an error")
 }
@@ -28,7 +28,7 @@ func TestFridaydExport(t *testing.T) {
 // ensure that black listed addresses are properly set in bank keeper
 func TestBlackListedAddrs(t *testing.T) {
 	db := db.NewMemDB()
-	app := NewFridayApp(log.NewTMLogger(log.NewSyncWriter(os.Stdout)), db, nil, true, 0)
+	app := NewFridayApp(log.NewTMLogger(log.NewSyncWriter(os.Stdout)), db, nil, true, 0, DefaultEESocket, nil)
 
 	for acc := range maccPerms {
 		require.True(t, app.bankKeeper.BlacklistedAddr(app.supplyKeeper.GetModuleAddress(acc)))
@@ -42,6 +42,6 @@ func NewFridayAppUNSAFE(logger log.Logger, db dbm.DB, traceStore io.Writer, load
 	invCheckPeriod uint, baseAppOptions ...func(*baseapp.BaseApp),
 ) (fapp *FridayApp, keyMain, keyStaking *sdk.KVStoreKey, stakingKeeper staking.Keeper) {
 
-	fapp = NewFridayApp(logger, db, traceStore, loadLatest, invCheckPeriod, baseAppOptions...)
+	fapp = NewFridayApp(logger, db, traceStore, loadLatest, invCheckPeriod, DefaultEESocket, nil, baseAppOptions...)
 	return fapp, fapp.keys[baseapp.MainStoreKey], fapp.keys[staking.StoreKey], fapp.stakingKeeper
 }
@@ -81,6 +81,9 @@ type BaseApp struct {
 	// transaction. This is mainly used for DoS and spam prevention.
 	minGasPrices sdk.DecCoins
 
+	// pruning options of the multistore, for modules keeping per-height state of their own
+	pruning sdk.PruningOptions
+
 	// flag for sealing options and parameters to a BaseApp
 	sealed bool
 
@@ -92,6 +95,9 @@ type BaseApp struct {
 
 	// application's version string
 	appVersion string
+
+	// streams the state changes of the blocks, if set
+	streamingService StreamingService
 }
 
 var _ abci.Application = (*BaseApp)(nil)
@@ -190,12 +196,14 @@ func (app *BaseApp) MountTransientStores(keys map[string]*sdk.TransientStoreKey)
 // multistore, using a specified DB.
 func (app *BaseApp) MountStoreWithDB(key sdk.StoreKey, typ sdk.StoreType, db dbm.DB) {
 	app.cms.MountStoreWithDB(key, typ, db)
+	app.listenStore(key)
 }
 
 // MountStore mounts a store to the provided key in the BaseApp multistore,
 // using the default DB.
 func (app *BaseApp) MountStore(key sdk.StoreKey, typ sdk.StoreType) {
 	app.cms.MountStoreWithDB(key, typ, nil)
+	app.listenStore(key)
 }
 
 // LoadLatestVersion loads the latest application version. It will panic if
@@ -223,6 +231,11 @@ func (app *BaseApp) LastCommitID() sdk.CommitID {
 	return app.cms.LastCommitID()
 }
 
+// Pruning returns the pruning options of the multistore.
+func (app *BaseApp) Pruning() sdk.PruningOptions {
+	return app.pruning
+}
+
 // LastBlockHeight returns the last committed block height.
 func (app *BaseApp) LastBlockHeight() int64 {
 	return app.cms.LastCommitID().Version
@@ -264,6 +277,11 @@ func (app *BaseApp) initFromMainStore(baseKey *sdk.KVStoreKey) error {
 	return nil
 }
 
+func (app *BaseApp) setPruning(opts sdk.PruningOptions) {
+	app.pruning = opts
+	app.cms.SetPruning(opts)
+}
+
 func (app *BaseApp) setMinGasPrices(gasPrices sdk.DecCoins) {
 	app.minGasPrices = gasPrices
 }
@@ -276,6 +294,10 @@ func (app *BaseApp) setHaltTime(haltTime uint64) {
 	app.haltTime = haltTime
 }
 
+func (app *BaseApp) setStreamingService(s StreamingService) {
+	app.streamingService = s
+}
+
 // Router returns the router of the BaseApp.
 func (app *BaseApp) Router() sdk.Router {
 	if app.sealed {
@@ -310,8 +332,9 @@ func (app *BaseApp) setCheckState(header abci.Header) {
 // the context wrapping it.
 // It is called by InitChain() and BeginBlock(),
 // and deliverState is set nil on Commit().
+// The store listeners are notified of the writes to the deliverState.
 func (app *BaseApp) setDeliverState(header abci.Header) {
-	ms := app.cms.CacheMultiStore()
+	ms := app.cms.CacheMultiStoreWithListeners()
 	app.deliverState = &state{
 		ms:  ms,
 		ctx: sdk.NewContext(ms, header, false, app.logger),
@@ -415,6 +438,10 @@ func (app *BaseApp) InitChain(req abci.RequestInitChain) (res abci.ResponseInitC
 		}
 	}
 
+	if app.streamingService != nil {
+		app.streamingService.ListenInitChain(req, res)
+	}
+
 	// NOTE: We don't commit, but BeginBlock for block 1 starts from this
 	// deliverState.
 	return
@@ -677,6 +704,10 @@ func (app *BaseApp) BeginBlock(req abci.RequestBeginBlock) (res abci.ResponseBeg
 
 	// set the signed validators for addition to context in deliverTx
 	app.voteInfos = req.LastCommitInfo.GetVotes()
+
+	if app.streamingService != nil {
+		app.streamingService.ListenBeginBlock(req, res)
+	}
 	return
 }
 
@@ -716,7 +747,7 @@ func (app *BaseApp) DeliverTx(req abci.RequestDeliverTx) (res abci.ResponseDeliv
 		result = app.runTx(runTxModeDeliver, req.Tx, tx)
 	}
 
-	return abci.ResponseDeliverTx{
+	res = abci.ResponseDeliverTx{
 		Code:      uint32(result.Code),
 		Codespace: string(result.Codespace),
 		Data:      result.Data,
@@ -725,6 +756,11 @@ func (app *BaseApp) DeliverTx(req abci.RequestDeliverTx) (res abci.ResponseDeliv
 		GasUsed:   int64(result.GasUsed),   // TODO: Should type accept unsigned ints?
 		Events:    result.Events.ToABCIEvents(),
 	}
+
+	if app.streamingService != nil {
+		app.streamingService.ListenDeliverTx(req, res)
+	}
+	return res
 }
 
 // validateBasicTxMsgs executes basic validator calls for messages.
@@ -782,7 +818,7 @@ func (app *BaseApp) runMsgs(ctx sdk.Context, msgs []sdk.Msg, mode runTxMode) (re
 		var msgResult sdk.Result
 
 		// skip actual execution for CheckTx mode
-		msgResult = handler(ctx, msg, mode == runTxModeCheck)
+		msgResult = handler(ctx.WithMsgIndex(i), msg, mode == runTxModeCheck)
 
 		// Each message result's Data must be length prefixed in order to separate
 		// each result.
@@ -980,6 +1016,9 @@ func (app *BaseApp) EndBlock(req abci.RequestEndBlock) (res abci.ResponseEndBloc
 		res = app.endBlocker(app.deliverState.ctx, req)
 	}
 
+	if app.streamingService != nil {
+		app.streamingService.ListenEndBlock(req, res)
+	}
 	return
 }
 
@@ -1027,9 +1066,14 @@ func (app *BaseApp) Commit() (res abci.ResponseCommit) {
 	// empty/reset the deliver state
 	app.deliverState = nil
 
-	return abci.ResponseCommit{
+	res = abci.ResponseCommit{
 		Data: commitID.Hash,
 	}
+
+	if app.streamingService != nil {
+		app.streamingService.ListenCommit(res)
+	}
+	return res
 }
 
 // halt attempts to gracefully shutdown the node via SIGINT and SIGTERM falling
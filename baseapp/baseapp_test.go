@@ -1280,3 +1280,134 @@ func TestGetMaximumBlockGas(t *testing.T) {
 	app.setConsensusParams(&abci.ConsensusParams{Block: &abci.BlockParams{MaxGas: -5000000}})
 	require.Panics(t, func() { app.getMaximumBlockGas() })
 }
+
+type streamedChange struct {
+	storeKey string
+	key      string
+	oldValue int64
+	value    int64
+}
+
+// mockStreamingService collects the changes of the phases by the phase name
+type mockStreamingService struct {
+	listenKey sdk.StoreKey
+	pending   []streamedChange
+	phases    map[string][]streamedChange
+	txs       int
+	commits   int
+	closed    bool
+}
+
+func (s *mockStreamingService) Listeners(key sdk.StoreKey) []sdk.WriteListener {
+	if key != s.listenKey {
+		return nil
+	}
+	return []sdk.WriteListener{s}
+}
+
+func (s *mockStreamingService) OnWrite(storeKey sdk.StoreKey, key, oldValue, value []byte, delete bool) {
+	decode := func(bz []byte) int64 {
+		if len(bz) == 0 {
+			return 0
+		}
+		i, _ := binary.Varint(bz)
+		return i
+	}
+	s.pending = append(s.pending, streamedChange{storeKey.Name(), string(key), decode(oldValue), decode(value)})
+}
+
+func (s *mockStreamingService) take(phase string) {
+	s.phases[phase] = s.pending
+	s.pending = nil
+}
+
+func (s *mockStreamingService) ListenInitChain(abci.RequestInitChain, abci.ResponseInitChain) {
+	s.take("init")
+}
+
+func (s *mockStreamingService) ListenBeginBlock(abci.RequestBeginBlock, abci.ResponseBeginBlock) {
+	s.take("begin")
+}
+
+func (s *mockStreamingService) ListenDeliverTx(req abci.RequestDeliverTx, _ abci.ResponseDeliverTx) {
+	s.take(fmt.Sprintf("tx%d", s.txs))
+	s.txs++
+}
+
+func (s *mockStreamingService) ListenEndBlock(abci.RequestEndBlock, abci.ResponseEndBlock) {
+	s.take("end")
+}
+
+func (s *mockStreamingService) ListenCommit(abci.ResponseCommit) {
+	s.commits++
+}
+
+func (s *mockStreamingService) Close() error {
+	s.closed = true
+	return nil
+}
+
+// Test that the writes to the deliver state are streamed by the phases
+// and the writes to the check state are not.
+func TestStreamingService(t *testing.T) {
+	anteKey := []byte("ante-key")
+	deliverKey := []byte("deliver-key")
+	beginKey := []byte("begin-key")
+
+	streamingService := &mockStreamingService{listenKey: capKey1, phases: make(map[string][]streamedChange)}
+	anteOpt := func(bapp *BaseApp) { bapp.SetAnteHandler(anteHandlerTxTest(t, capKey1, anteKey)) }
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(routeMsgCounter, handlerMsgCounter(t, capKey1, deliverKey))
+	}
+	beginBlockerOpt := func(bapp *BaseApp) {
+		bapp.SetBeginBlocker(func(ctx sdk.Context, req abci.RequestBeginBlock) abci.ResponseBeginBlock {
+			setIntOnStore(ctx.KVStore(capKey1), beginKey, req.Header.Height)
+			ctx.KVStore(capKey2).Set(beginKey, []byte{1})
+			return abci.ResponseBeginBlock{}
+		})
+	}
+
+	app := setupBaseApp(t, SetStreamingService(streamingService), anteOpt, routerOpt, beginBlockerOpt)
+	require.True(t, app.cms.ListeningEnabled(capKey1))
+	require.False(t, app.cms.ListeningEnabled(capKey2))
+	app.InitChain(abci.RequestInitChain{})
+
+	codec := codec.New()
+	registerTestCodec(codec)
+
+	txBytes, err := codec.MarshalBinaryLengthPrefixed(newTxCounter(0, 0))
+	require.NoError(t, err)
+	require.True(t, app.CheckTx(abci.RequestCheckTx{Tx: txBytes}).IsOK())
+	require.Empty(t, streamingService.pending)
+
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+	for i, fail := range []bool{false, false, true} {
+		tx := newTxCounter(int64(i), int64(i))
+		tx.setFailOnHandler(fail)
+		txBytes, err := codec.MarshalBinaryLengthPrefixed(tx)
+		require.NoError(t, err)
+		app.DeliverTx(abci.RequestDeliverTx{Tx: txBytes})
+	}
+	app.EndBlock(abci.RequestEndBlock{})
+	app.Commit()
+
+	require.Equal(t, []streamedChange{{capKey1.Name(), string(beginKey), 0, 1}}, streamingService.phases["begin"])
+	require.Equal(t, []streamedChange{
+		{capKey1.Name(), string(anteKey), 0, 1},
+		{capKey1.Name(), string(deliverKey), 0, 1},
+	}, streamingService.phases["tx0"])
+	require.Equal(t, []streamedChange{
+		{capKey1.Name(), string(anteKey), 1, 2},
+		{capKey1.Name(), string(deliverKey), 1, 2},
+	}, streamingService.phases["tx1"])
+	// the changes of the failed handler are discarded
+	require.Equal(t, []streamedChange{
+		{capKey1.Name(), string(anteKey), 2, 3},
+	}, streamingService.phases["tx2"])
+	require.Empty(t, streamingService.phases["end"])
+	require.Equal(t, 1, streamingService.commits)
+	require.Empty(t, streamingService.pending)
+
+	require.NoError(t, app.Close())
+	require.True(t, streamingService.closed)
+}
@@ -15,7 +15,7 @@ import (
 
 // SetPruning sets a pruning option on the multistore associated with the app
 func SetPruning(opts sdk.PruningOptions) func(*BaseApp) {
-	return func(bap *BaseApp) { bap.cms.SetPruning(opts) }
+	return func(bap *BaseApp) { bap.setPruning(opts) }
 }
 
 // SetMinGasPrices returns an option that sets the minimum gas prices on the app.
@@ -38,6 +38,13 @@ func SetHaltTime(haltTime uint64) func(*BaseApp) {
 	return func(bap *BaseApp) { bap.setHaltTime(haltTime) }
 }
 
+// SetStreamingService returns a BaseApp option function that sets the service
+// streaming the state changes of the blocks. It must be set before the stores
+// are mounted.
+func SetStreamingService(s StreamingService) func(*BaseApp) {
+	return func(bap *BaseApp) { bap.setStreamingService(s) }
+}
+
 func (app *BaseApp) SetName(name string) {
 	if app.sealed {
 		panic("SetName() on sealed BaseApp")
@@ -0,0 +1,50 @@
+package baseapp
+
+import (
+	abci "github.com/hdac-io/tendermint/abci/types"
+
+	sdk "github.com/hdac-io/friday/types"
+)
+
+// StreamingService is notified of the state changes of the blocks, to stream
+// them out of the node.
+//
+// The listeners of the stores are notified of the writes to the deliver state
+// as they are made. Each Listen method is called at the end of its ABCI phase,
+// so the writes notified since the previous Listen call are the state changes
+// of the phase. The changes of a failed tx are its ante handler writes.
+type StreamingService interface {
+	// Listeners returns the listeners of the writes to the store of the key.
+	Listeners(key sdk.StoreKey) []sdk.WriteListener
+
+	ListenInitChain(req abci.RequestInitChain, res abci.ResponseInitChain)
+	ListenBeginBlock(req abci.RequestBeginBlock, res abci.ResponseBeginBlock)
+	ListenDeliverTx(req abci.RequestDeliverTx, res abci.ResponseDeliverTx)
+	ListenEndBlock(req abci.RequestEndBlock, res abci.ResponseEndBlock)
+
+	// ListenCommit is called after the state changes of the block are committed.
+	ListenCommit(res abci.ResponseCommit)
+
+	// Close is called when the node shuts down, after the last block is committed.
+	Close() error
+}
+
+// Close closes the streaming service, flushing the state changes of the committed blocks.
+// The server calls it once the node stops.
+func (app *BaseApp) Close() error {
+	if app.streamingService == nil {
+		return nil
+	}
+	return app.streamingService.Close()
+}
+
+// listenStore registers the listeners of the streaming service for the store
+// of the key.
+func (app *BaseApp) listenStore(key sdk.StoreKey) {
+	if app.streamingService == nil {
+		return
+	}
+	if listeners := app.streamingService.Listeners(key); len(listeners) > 0 {
+		app.cms.AddListeners(key, listeners)
+	}
+}
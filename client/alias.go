@@ -52,6 +52,9 @@ const (
 	FlagRPCWriteTimeout    = flags.FlagRPCWriteTimeout
 	FlagOutputDocument     = flags.FlagOutputDocument
 	FlagSkipConfirmation   = flags.FlagSkipConfirmation
+	FlagKeyringBackend     = flags.FlagKeyringBackend
+	FlagKeyringPassFile    = flags.FlagKeyringPassFile
+	FlagKeyringSigner      = flags.FlagKeyringSigner
 	DefaultKeyPass         = keys.DefaultKeyPass
 	FlagAddress            = keys.FlagAddress
 	FlagPublicKey          = keys.FlagPublicKey
@@ -59,6 +62,10 @@ const (
 	FlagDevice             = keys.FlagDevice
 	OutputFormatText       = keys.OutputFormatText
 	OutputFormatJSON       = keys.OutputFormatJSON
+	BackendLevelDB         = keys.BackendLevelDB
+	BackendFile            = keys.BackendFile
+	BackendMemory          = keys.BackendMemory
+	BackendExternal        = keys.BackendExternal
 	MinPassLength          = input.MinPassLength
 )
 
@@ -72,6 +79,7 @@ var (
 	GetCommands                        = flags.GetCommands
 	PostCommands                       = flags.PostCommands
 	RegisterRestServerFlags            = flags.RegisterRestServerFlags
+	AddKeyringFlags                    = flags.AddKeyringFlags
 	ParseGas                           = flags.ParseGas
 	NewCompletionCmd                   = flags.NewCompletionCmd
 	MarshalJSON                        = keys.MarshalJSON
@@ -86,6 +94,7 @@ var (
 	ReadPassphraseFromStdin            = keys.ReadPassphraseFromStdin
 	NewKeyBaseFromHomeFlag             = keys.NewKeyBaseFromHomeFlag
 	NewKeyBaseFromDir                  = keys.NewKeyBaseFromDir
+	NewKeyBase                         = keys.NewKeyBase
 	NewInMemoryKeyBase                 = keys.NewInMemoryKeyBase
 	NewRestServer                      = lcd.NewRestServer
 	ServeCommand                       = lcd.ServeCommand
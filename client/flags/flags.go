@@ -54,6 +54,9 @@ const (
 	FlagRPCWriteTimeout    = "write-timeout"
 	FlagOutputDocument     = "output-document" // inspired by wget -O
 	FlagSkipConfirmation   = "yes"
+	FlagKeyringBackend     = "keyring-backend"
+	FlagKeyringPassFile    = "keyring-passphrase-file"
+	FlagKeyringSigner      = "keyring-signer"
 )
 
 // LineBreak can be included in a command list to provide a blank line
@@ -116,6 +119,23 @@ func PostCommands(cmds ...*cobra.Command) []*cobra.Command {
 	return cmds
 }
 
+// AddKeyringFlags adds the flags selecting the keyring backend to the persistent flags
+// of the command, so that all of its subcommands share the keyring
+func AddKeyringFlags(cmd *cobra.Command) *cobra.Command {
+	cmd.PersistentFlags().String(FlagKeyringBackend, "leveldb",
+		"Keyring backend of the keys (leveldb|file|memory|external)")
+	cmd.PersistentFlags().String(FlagKeyringPassFile, "",
+		"File of the passphrase of the file keyring, instead of the FR_KEYRING_PASSPHRASE env or the prompt")
+	cmd.PersistentFlags().String(FlagKeyringSigner, "",
+		"Unix socket of the external signer, <home>/signer.sock by default")
+
+	viper.BindPFlag(FlagKeyringBackend, cmd.PersistentFlags().Lookup(FlagKeyringBackend))
+	viper.BindPFlag(FlagKeyringPassFile, cmd.PersistentFlags().Lookup(FlagKeyringPassFile))
+	viper.BindPFlag(FlagKeyringSigner, cmd.PersistentFlags().Lookup(FlagKeyringSigner))
+
+	return cmd
+}
+
 // RegisterRestServerFlags registers the flags required for rest server
 func RegisterRestServerFlags(cmd *cobra.Command) *cobra.Command {
 	cmd = GetCommands(cmd)[0]
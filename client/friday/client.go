@@ -0,0 +1,258 @@
+// Package friday is a Go client of the FRIDAY chain for backends. It is configured
+// programmatically instead of by the flags of the CLI, and builds, signs and broadcasts
+// the transactions of the chain operations with typed methods.
+package friday
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/hdac-io/tendermint/libs/log"
+	tmliteProxy "github.com/hdac-io/tendermint/lite/proxy"
+	rpcclient "github.com/hdac-io/tendermint/rpc/client"
+
+	"github.com/hdac-io/friday/app"
+	"github.com/hdac-io/friday/client/context"
+	"github.com/hdac-io/friday/client/flags"
+	"github.com/hdac-io/friday/codec"
+	"github.com/hdac-io/friday/crypto/keys"
+	sdk "github.com/hdac-io/friday/types"
+	"github.com/hdac-io/friday/x/auth/client/utils"
+	authtypes "github.com/hdac-io/friday/x/auth/types"
+	cliutil "github.com/hdac-io/friday/x/executionlayer/client/util"
+)
+
+// verifierCacheSize is the number of the validator sets cached by the lite verifier
+const verifierCacheSize = 10
+
+// Config is the configuration of a Client
+type Config struct {
+	// NodeURI is the tendermint RPC address of the node, e.g. tcp://localhost:26657
+	NodeURI string
+	// ChainID is the chain ID the transactions are signed for
+	ChainID string
+	// Codec of the app, app.MakeCodec() if nil
+	Codec *codec.Codec
+
+	// Keybase holds the keys signing the transactions
+	Keybase keys.Keybase
+	// Passphrase returns the passphrase of the key of the name
+	Passphrase func(keyName string) (string, error)
+
+	// TrustNode skips the verification of the query results against the signed headers
+	TrustNode bool
+	// VerifierHome is the directory of the lite verifier, required when the node is not trusted
+	VerifierHome string
+
+	// Gas is the gas limit of the transactions, unless SimulateGas
+	Gas uint64
+	// SimulateGas sets the gas limit to the gas estimated by the simulation of the
+	// transactions, multiplied by GasAdjustment
+	SimulateGas   bool
+	GasAdjustment float64
+	// Fees or GasPrices of the transactions, e.g. "10stake"
+	Fees      string
+	GasPrices string
+	Memo      string
+
+	// BroadcastMode is one of sync, async and block
+	BroadcastMode string
+}
+
+// DefaultConfig returns the configuration of a trusted node with the default gas
+func DefaultConfig(nodeURI, chainID string) Config {
+	return Config{
+		NodeURI:       nodeURI,
+		ChainID:       chainID,
+		TrustNode:     true,
+		Gas:           flags.DefaultGasLimit,
+		GasAdjustment: flags.DefaultGasAdjustment,
+		BroadcastMode: flags.BroadcastSync,
+	}
+}
+
+// Validate checks the configuration
+func (c Config) Validate() error {
+	if c.NodeURI == "" {
+		return fmt.Errorf("node URI is required")
+	}
+	if c.ChainID == "" {
+		return fmt.Errorf("chain ID is required")
+	}
+	if !c.TrustNode && c.VerifierHome == "" {
+		return fmt.Errorf("verifier home is required when the node is not trusted")
+	}
+	if c.Fees != "" && c.GasPrices != "" {
+		return fmt.Errorf("cannot provide both fees and gas prices")
+	}
+	if _, err := sdk.ParseCoins(c.Fees); err != nil {
+		return err
+	}
+	if _, err := sdk.ParseDecCoins(c.GasPrices); err != nil {
+		return err
+	}
+	switch c.BroadcastMode {
+	case flags.BroadcastSync, flags.BroadcastAsync, flags.BroadcastBlock:
+	default:
+		return fmt.Errorf("unknown broadcast mode %q, expected %s, %s or %s",
+			c.BroadcastMode, flags.BroadcastSync, flags.BroadcastAsync, flags.BroadcastBlock)
+	}
+	return nil
+}
+
+// Client queries and sends transactions to a node of the chain
+type Client struct {
+	config Config
+	cdc    *codec.Codec
+	cliCtx context.CLIContext
+}
+
+// NewClient returns a client of the node of the configuration
+func NewClient(config Config) (*Client, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	cdc := config.Codec
+	if cdc == nil {
+		cdc = app.MakeCodec()
+	}
+
+	node := rpcclient.NewHTTP(config.NodeURI, "/websocket")
+	cliCtx := context.CLIContext{
+		Codec:         cdc,
+		Client:        node,
+		Keybase:       config.Keybase,
+		NodeURI:       config.NodeURI,
+		TrustNode:     config.TrustNode,
+		BroadcastMode: config.BroadcastMode,
+		OutputFormat:  "json",
+		SkipConfirm:   true,
+	}
+
+	if !config.TrustNode {
+		verifier, err := tmliteProxy.NewVerifier(
+			config.ChainID, filepath.Join(config.VerifierHome, ".lite_verifier"),
+			node, log.NewNopLogger(), verifierCacheSize,
+		)
+		if err != nil {
+			return nil, err
+		}
+		cliCtx = cliCtx.WithVerifier(verifier)
+	}
+
+	return &Client{
+		config: config,
+		cdc:    cdc,
+		cliCtx: cliCtx,
+	}, nil
+}
+
+// Codec returns the codec of the client
+func (c *Client) Codec() *codec.Codec { return c.cdc }
+
+// CLIContext returns the context the client queries and broadcasts with
+func (c *Client) CLIContext() context.CLIContext { return c.cliCtx }
+
+// AtHeight returns a copy of the client querying the state of the height, the latest if 0
+func (c *Client) AtHeight(height int64) *Client {
+	client := *c
+	client.cliCtx = c.cliCtx.WithHeight(height)
+	return &client
+}
+
+// GetKey returns the key of a key name, address or nickname in the keybase.
+// With an empty from, the only key of the keybase is returned.
+func (c *Client) GetKey(from string) (keys.Info, error) {
+	if c.config.Keybase == nil {
+		return nil, fmt.Errorf("no keybase to sign with")
+	}
+	return cliutil.GetLocalWalletInfo(from, c.config.Keybase, c.cdc, c.cliCtx)
+}
+
+// ResolveAddress returns the address of a bech32 address or a nickname
+func (c *Client) ResolveAddress(addressOrNickname string) (sdk.AccAddress, error) {
+	addr, err := cliutil.GetAddress(c.cdc, c.cliCtx, addressOrNickname)
+	if err != nil {
+		return nil, err
+	}
+	if addr.Empty() {
+		return nil, fmt.Errorf("no nickname mapping of %s", addressOrNickname)
+	}
+	return addr, nil
+}
+
+// BroadcastMsgs signs the messages with the key of from and broadcasts them in a transaction.
+// An error is returned with the response when the transaction fails in CheckTx, or in
+// DeliverTx with the block broadcast mode.
+func (c *Client) BroadcastMsgs(from string, msgs ...sdk.Msg) (sdk.TxResponse, error) {
+	key, err := c.GetKey(from)
+	if err != nil {
+		return sdk.TxResponse{}, err
+	}
+
+	txBldr, err := c.prepareTxBuilder(key, msgs)
+	if err != nil {
+		return sdk.TxResponse{}, err
+	}
+
+	txBytes, err := c.signMsgs(txBldr, key, msgs)
+	if err != nil {
+		return sdk.TxResponse{}, err
+	}
+
+	res, err := c.cliCtx.BroadcastTx(txBytes)
+	if err != nil {
+		return res, err
+	}
+	if res.Code != 0 {
+		return res, fmt.Errorf("tx %s failed with code %d: %s", res.TxHash, res.Code, res.RawLog)
+	}
+	return res, nil
+}
+
+// txBuilder returns a builder of the gas and fee policy of the configuration
+func (c *Client) txBuilder() authtypes.TxBuilder {
+	return authtypes.NewTxBuilder(
+		utils.GetTxEncoder(c.cdc), 0, 0, c.config.Gas, c.config.GasAdjustment,
+		c.config.SimulateGas, c.config.ChainID, c.config.Memo, nil, nil,
+	).WithFees(c.config.Fees).WithGasPrices(c.config.GasPrices).WithKeybase(c.config.Keybase)
+}
+
+// prepareTxBuilder sets the account number and sequence of the key to the builder,
+// and the gas limit estimated by the simulation with SimulateGas
+func (c *Client) prepareTxBuilder(key keys.Info, msgs []sdk.Msg) (authtypes.TxBuilder, error) {
+	cliCtx := c.cliCtx.WithFromAddress(key.GetAddress()).WithFromName(key.GetName())
+
+	txBldr, err := utils.PrepareTxBuilder(c.txBuilder(), cliCtx)
+	if err != nil {
+		return txBldr, err
+	}
+
+	if txBldr.SimulateAndExecute() {
+		return utils.EnrichWithGas(txBldr, cliCtx, msgs)
+	}
+	return txBldr, nil
+}
+
+func (c *Client) signMsgs(txBldr authtypes.TxBuilder, key keys.Info, msgs []sdk.Msg) ([]byte, error) {
+	for _, msg := range msgs {
+		if err := msg.ValidateBasic(); err != nil {
+			return nil, err
+		}
+	}
+
+	var passphrase string
+	if key.GetType() == keys.TypeLocal {
+		if c.config.Passphrase == nil {
+			return nil, fmt.Errorf("no passphrase of the key %s", key.GetName())
+		}
+		var err error
+		passphrase, err = c.config.Passphrase(key.GetName())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return txBldr.BuildAndSign(key.GetName(), passphrase, msgs)
+}
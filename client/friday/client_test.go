@@ -0,0 +1,122 @@
+package friday
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hdac-io/friday/client/flags"
+	"github.com/hdac-io/friday/crypto/keys"
+	sdk "github.com/hdac-io/friday/types"
+	authtypes "github.com/hdac-io/friday/x/auth/types"
+	"github.com/hdac-io/friday/x/executionlayer/types"
+)
+
+const testPassphrase = "12345678"
+
+func newTestClient(t *testing.T) (*Client, keys.Info) {
+	kb := keys.NewInMemory()
+	info, _, err := kb.CreateMnemonic("alice", keys.English, testPassphrase, keys.Secp256k1)
+	require.NoError(t, err)
+
+	config := DefaultConfig("tcp://localhost:26657", "friday-test")
+	config.Keybase = kb
+	config.Passphrase = func(string) (string, error) { return testPassphrase, nil }
+	c, err := NewClient(config)
+	require.NoError(t, err)
+
+	return c, info
+}
+
+func TestConfigValidate(t *testing.T) {
+	config := DefaultConfig("tcp://localhost:26657", "friday-test")
+	require.NoError(t, config.Validate())
+
+	invalid := config
+	invalid.NodeURI = ""
+	require.Error(t, invalid.Validate())
+
+	invalid = config
+	invalid.ChainID = ""
+	require.Error(t, invalid.Validate())
+
+	invalid = config
+	invalid.TrustNode = false
+	require.Error(t, invalid.Validate())
+
+	invalid = config
+	invalid.Fees = "10stake"
+	invalid.GasPrices = "0.1stake"
+	require.Error(t, invalid.Validate())
+
+	invalid = config
+	invalid.Fees = "stake10"
+	require.Error(t, invalid.Validate())
+
+	invalid = config
+	invalid.BroadcastMode = "commit"
+	require.Error(t, invalid.Validate())
+
+	config.BroadcastMode = flags.BroadcastBlock
+	require.NoError(t, config.Validate())
+}
+
+func TestGetKey(t *testing.T) {
+	c, info := newTestClient(t)
+
+	// the only key of the keybase is taken without from
+	key, err := c.GetKey("")
+	require.NoError(t, err)
+	require.Equal(t, info.GetAddress(), key.GetAddress())
+
+	key, err = c.GetKey("alice")
+	require.NoError(t, err)
+	require.Equal(t, info.GetAddress(), key.GetAddress())
+
+	// an address is resolved without querying the nickname
+	key, err = c.GetKey(info.GetAddress().String())
+	require.NoError(t, err)
+	require.Equal(t, "alice", key.GetName())
+}
+
+func TestSignMsgs(t *testing.T) {
+	c, info := newTestClient(t)
+	txBldr := c.txBuilder().WithAccountNumber(3).WithSequence(7)
+
+	msg := types.NewMsgBond("system:bond", info.GetAddress(), "1000000000000000000", "10000000000000000")
+	txBytes, err := c.signMsgs(txBldr, info, []sdk.Msg{msg})
+	require.NoError(t, err)
+
+	var tx authtypes.StdTx
+	require.NoError(t, c.Codec().UnmarshalBinaryLengthPrefixed(txBytes, &tx))
+	require.Equal(t, []sdk.Msg{msg}, tx.GetMsgs())
+	require.Equal(t, uint64(flags.DefaultGasLimit), tx.Fee.Gas)
+	require.Len(t, tx.Signatures, 1)
+
+	signBytes := authtypes.StdSignBytes("friday-test", 3, 7, tx.Fee, tx.Msgs, tx.Memo)
+	require.True(t, info.GetPubKey().VerifyBytes(signBytes, tx.Signatures[0].Signature))
+
+	// stateless checks of the messages are made before signing
+	_, err = c.signMsgs(txBldr, info, []sdk.Msg{types.NewMsgBond("system:bond", nil, "1", "1")})
+	require.Error(t, err)
+
+	c.config.Passphrase = nil
+	_, err = c.signMsgs(txBldr, info, []sdk.Msg{msg})
+	require.Error(t, err)
+}
+
+func TestParseContractArgs(t *testing.T) {
+	args, err := compactArgs(`[ {"name": "amount", "value": {"u512": {"value": "100"}}} ]`)
+	require.NoError(t, err)
+	require.Equal(t, `[{"name":"amount","value":{"u512":{"value":"100"}}}]`, args)
+
+	args, err = compactArgs("")
+	require.NoError(t, err)
+	require.Equal(t, "", args)
+
+	_, err = compactArgs(`{"name": "amount"}`)
+	require.Error(t, err)
+
+	_, err = parseContractAddress("friday1qqqqqqqq")
+	require.Error(t, err)
+}
@@ -0,0 +1,134 @@
+package friday
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/casper/consensus/state"
+
+	sdk "github.com/hdac-io/friday/types"
+	"github.com/hdac-io/friday/x/executionlayer"
+	cliutil "github.com/hdac-io/friday/x/executionlayer/client/util"
+	"github.com/hdac-io/friday/x/executionlayer/types"
+)
+
+// The EE does not return merkle proofs of the values it's queried, so the EE values are not
+// proven even when the node is not trusted. Only the EE state root of a height is verified
+// against the signed header.
+
+// GetBalance returns the balance of the address or nickname
+func (c *Client) GetBalance(addressOrNickname string) (cliutil.Hdac, error) {
+	addr, err := c.ResolveAddress(addressOrNickname)
+	if err != nil {
+		return "", err
+	}
+
+	return c.queryHdac(executionlayer.QueryEEBalanceDetail, types.QueryGetBalanceDetail{Address: addr})
+}
+
+// GetStake returns the bonded amount of the address or nickname
+func (c *Client) GetStake(addressOrNickname string) (cliutil.Hdac, error) {
+	addr, err := c.ResolveAddress(addressOrNickname)
+	if err != nil {
+		return "", err
+	}
+
+	return c.queryHdac(executionlayer.QueryStakeDetail, types.QueryGetStakeDetail{Address: addr})
+}
+
+// GetVote returns the amount voted by the address or nickname
+func (c *Client) GetVote(addressOrNickname string) (cliutil.Hdac, error) {
+	addr, err := c.ResolveAddress(addressOrNickname)
+	if err != nil {
+		return "", err
+	}
+
+	return c.queryHdac(executionlayer.QueryVoteDetail, types.QueryGetVoteDetail{Address: addr})
+}
+
+// GetDappVote returns the amount voted to the dapp of the contract address
+func (c *Client) GetDappVote(dapp string) (cliutil.Hdac, error) {
+	return c.queryHdac(executionlayer.QueryVoteDetail, types.QueryGetVoteDetail{Dapp: dapp})
+}
+
+// GetReward returns the unclaimed reward of the address or nickname
+func (c *Client) GetReward(addressOrNickname string) (cliutil.Hdac, error) {
+	addr, err := c.ResolveAddress(addressOrNickname)
+	if err != nil {
+		return "", err
+	}
+
+	return c.queryHdac(executionlayer.QueryReward, types.NewQueryGetReward(addr))
+}
+
+// GetCommission returns the unclaimed commission of the address or nickname
+func (c *Client) GetCommission(addressOrNickname string) (cliutil.Hdac, error) {
+	addr, err := c.ResolveAddress(addressOrNickname)
+	if err != nil {
+		return "", err
+	}
+
+	return c.queryHdac(executionlayer.QueryCommission, types.NewQueryGetCommission(addr))
+}
+
+// QueryContract returns the decoded JSON of the value of the key, one of address, uref,
+// hash and local, at the path
+func (c *Client) QueryContract(keyType, keyData, path string) (json.RawMessage, error) {
+	queryData := types.QueryExecutionLayerDetail{
+		KeyType: keyType,
+		KeyData: keyData,
+		Path:    path,
+	}
+
+	res, err := c.queryWithData(executionlayer.QueryEEDecodedDetail, queryData)
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(res), nil
+}
+
+// GetContracts returns the contracts of the contract registry matching the non-empty filters
+func (c *Client) GetContracts(deployer sdk.AccAddress, name string, codeHash []byte) (types.ContractInfos, error) {
+	res, err := c.queryWithData(executionlayer.QueryContract, types.NewQueryContractParams(deployer, name, codeHash))
+	if err != nil {
+		return nil, err
+	}
+
+	var contracts types.ContractInfos
+	if err := c.cdc.UnmarshalJSON(res, &contracts); err != nil {
+		return nil, err
+	}
+	return contracts, nil
+}
+
+// GetEEState returns the EE state root of the height of the client, or of the latest
+// provable height, with the height
+func (c *Client) GetEEState() ([]byte, int64, error) {
+	return cliutil.QueryEEState(c.cliCtx)
+}
+
+func (c *Client) queryWithData(route string, queryData interface{}) ([]byte, error) {
+	bz, err := c.cdc.MarshalJSON(queryData)
+	if err != nil {
+		return nil, err
+	}
+
+	res, _, err := c.cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.ModuleName, route), bz)
+	return res, err
+}
+
+// queryHdac queries an EE value of bigsun, and returns it in Hdac
+func (c *Client) queryHdac(route string, queryData interface{}) (cliutil.Hdac, error) {
+	res, err := c.queryWithData(route, queryData)
+	if err != nil {
+		return "", err
+	}
+
+	out := &state.Value{}
+	if err := jsonpb.Unmarshal(bytes.NewReader(res), out); err != nil {
+		return "", err
+	}
+	return cliutil.ToHdac(cliutil.Bigsun(out.GetStringValue())), nil
+}
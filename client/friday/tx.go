@@ -0,0 +1,265 @@
+package friday
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/util"
+
+	sdk "github.com/hdac-io/friday/types"
+	cliutil "github.com/hdac-io/friday/x/executionlayer/client/util"
+	"github.com/hdac-io/friday/x/executionlayer/types"
+	nicknametypes "github.com/hdac-io/friday/x/nickname/types"
+)
+
+// The from of the transactions is a key name, address or nickname of a key in the keybase,
+// and the amounts and fees are in Hdac, e.g. "1.5".
+
+// Transfer transfers the amount to the address or nickname
+func (c *Client) Transfer(from, to string, amount, fee cliutil.Hdac) (sdk.TxResponse, error) {
+	toAddr, err := c.ResolveAddress(to)
+	if err != nil {
+		return sdk.TxResponse{}, err
+	}
+
+	return c.broadcastEEMsg(from, amount, fee, func(fromAddr sdk.AccAddress, amount, fee string) sdk.Msg {
+		return types.NewMsgTransfer("transfer", fromAddr, toAddr, amount, fee)
+	})
+}
+
+// Bond bonds the amount
+func (c *Client) Bond(from string, amount, fee cliutil.Hdac) (sdk.TxResponse, error) {
+	return c.broadcastEEMsg(from, amount, fee, func(fromAddr sdk.AccAddress, amount, fee string) sdk.Msg {
+		return types.NewMsgBond("system:bond", fromAddr, amount, fee)
+	})
+}
+
+// Unbond unbonds the amount
+func (c *Client) Unbond(from string, amount, fee cliutil.Hdac) (sdk.TxResponse, error) {
+	return c.broadcastEEMsg(from, amount, fee, func(fromAddr sdk.AccAddress, amount, fee string) sdk.Msg {
+		return types.NewMsgUnBond("system:unbond", fromAddr, amount, fee)
+	})
+}
+
+// Delegate delegates the amount to the validator of the address or nickname
+func (c *Client) Delegate(from, validator string, amount, fee cliutil.Hdac) (sdk.TxResponse, error) {
+	valAddr, err := c.ResolveAddress(validator)
+	if err != nil {
+		return sdk.TxResponse{}, err
+	}
+
+	return c.broadcastEEMsg(from, amount, fee, func(fromAddr sdk.AccAddress, amount, fee string) sdk.Msg {
+		return types.NewMsgDelegate("system:delegate", fromAddr, valAddr, amount, fee)
+	})
+}
+
+// Undelegate undelegates the amount from the validator of the address or nickname
+func (c *Client) Undelegate(from, validator string, amount, fee cliutil.Hdac) (sdk.TxResponse, error) {
+	valAddr, err := c.ResolveAddress(validator)
+	if err != nil {
+		return sdk.TxResponse{}, err
+	}
+
+	return c.broadcastEEMsg(from, amount, fee, func(fromAddr sdk.AccAddress, amount, fee string) sdk.Msg {
+		return types.NewMsgUndelegate("system:undelegate", fromAddr, valAddr, amount, fee)
+	})
+}
+
+// Redelegate moves the amount delegated to the source validator to the destination validator
+func (c *Client) Redelegate(from, srcValidator, destValidator string, amount, fee cliutil.Hdac) (sdk.TxResponse, error) {
+	srcValAddr, err := c.ResolveAddress(srcValidator)
+	if err != nil {
+		return sdk.TxResponse{}, err
+	}
+	destValAddr, err := c.ResolveAddress(destValidator)
+	if err != nil {
+		return sdk.TxResponse{}, err
+	}
+
+	return c.broadcastEEMsg(from, amount, fee, func(fromAddr sdk.AccAddress, amount, fee string) sdk.Msg {
+		return types.NewMsgRedelegate("system:redelegate", fromAddr, srcValAddr, destValAddr, amount, fee)
+	})
+}
+
+// Vote votes the amount to the contract of the uref or hash address
+func (c *Client) Vote(from, contract string, amount, fee cliutil.Hdac) (sdk.TxResponse, error) {
+	contractAddr, err := parseContractAddress(contract)
+	if err != nil {
+		return sdk.TxResponse{}, err
+	}
+
+	return c.broadcastEEMsg(from, amount, fee, func(fromAddr sdk.AccAddress, amount, fee string) sdk.Msg {
+		return types.NewMsgVote("system:vote", fromAddr, contractAddr, amount, fee)
+	})
+}
+
+// Unvote withdraws the amount voted to the contract of the uref or hash address
+func (c *Client) Unvote(from, contract string, amount, fee cliutil.Hdac) (sdk.TxResponse, error) {
+	contractAddr, err := parseContractAddress(contract)
+	if err != nil {
+		return sdk.TxResponse{}, err
+	}
+
+	return c.broadcastEEMsg(from, amount, fee, func(fromAddr sdk.AccAddress, amount, fee string) sdk.Msg {
+		return types.NewMsgUnvote("system:unvote", fromAddr, contractAddr, amount, fee)
+	})
+}
+
+// ClaimReward claims the reward of the delegations
+func (c *Client) ClaimReward(from string, fee cliutil.Hdac) (sdk.TxResponse, error) {
+	return c.claim(from, types.RewardString, types.RewardValue, fee)
+}
+
+// ClaimCommission claims the commission of the validator
+func (c *Client) ClaimCommission(from string, fee cliutil.Hdac) (sdk.TxResponse, error) {
+	return c.claim(from, types.CommissionString, types.CommissionValue, fee)
+}
+
+func (c *Client) claim(from, rewardOrCommission string, isRewardOrCommission bool, fee cliutil.Hdac) (sdk.TxResponse, error) {
+	return c.broadcastEEMsg(from, "0", fee, func(fromAddr sdk.AccAddress, _, fee string) sdk.Msg {
+		return types.NewMsgClaim(fmt.Sprintf("system:claim_%s", rewardOrCommission), fromAddr, isRewardOrCommission, fee)
+	})
+}
+
+// RunWasm runs the WASM session code with the JSON arguments
+func (c *Client) RunWasm(from string, code []byte, args string, fee cliutil.Hdac) (sdk.TxResponse, error) {
+	return c.runContract(from, util.WASM, "wasm_file_direct_execution", code, args, fee)
+}
+
+// RunContract runs the stored contract of the type, one of uref, hash and name, with the
+// JSON arguments. The contract is a bech32 address of the uref or hash, or a name of a
+// contract stored in the account of from.
+func (c *Client) RunContract(from string, contractType util.ContractType, contract, args string, fee cliutil.Hdac) (sdk.TxResponse, error) {
+	switch contractType {
+	case util.HASH:
+		contractHashAddr, err := sdk.ContractHashAddressFromBech32(contract)
+		if err != nil {
+			return sdk.TxResponse{}, err
+		}
+		return c.runContract(from, contractType, contract, contractHashAddr.Bytes(), args, fee)
+	case util.UREF:
+		contractUrefAddr, err := sdk.ContractUrefAddressFromBech32(contract)
+		if err != nil {
+			return sdk.TxResponse{}, err
+		}
+		return c.runContract(from, contractType, contract, contractUrefAddr.Bytes(), args, fee)
+	case util.NAME:
+		return c.runContract(from, contractType, "", []byte(contract), args, fee)
+	default:
+		return sdk.TxResponse{}, fmt.Errorf("type must be one of name, uref, or hash")
+	}
+}
+
+func (c *Client) runContract(from string, sessionType util.ContractType, contractAddress string,
+	sessionCode []byte, args string, fee cliutil.Hdac) (sdk.TxResponse, error) {
+	sessionArgs, err := compactArgs(args)
+	if err != nil {
+		return sdk.TxResponse{}, err
+	}
+
+	return c.broadcastEEMsg(from, "0", fee, func(fromAddr sdk.AccAddress, _, fee string) sdk.Msg {
+		if sessionType == util.NAME {
+			contractAddress = fmt.Sprintf("%s:%s", fromAddr.String(), sessionCode)
+		}
+		return types.NewMsgExecute(contractAddress, fromAddr, sessionType, sessionCode, sessionArgs, fee)
+	})
+}
+
+// DeployContract runs the WASM installer with the JSON arguments and registers the created
+// contract under the name
+func (c *Client) DeployContract(from string, code []byte, name, args string, fee cliutil.Hdac) (sdk.TxResponse, error) {
+	installArgs, err := compactArgs(args)
+	if err != nil {
+		return sdk.TxResponse{}, err
+	}
+
+	return c.broadcastEEMsg(from, "0", fee, func(fromAddr sdk.AccAddress, _, fee string) sdk.Msg {
+		return types.NewMsgDeployContract("wasm_file_direct_execution", fromAddr, name, code, installArgs, fee, nil)
+	})
+}
+
+// SetNickname registers the nickname of the address of from
+func (c *Client) SetNickname(from, nickname string) (sdk.TxResponse, error) {
+	key, err := c.GetKey(from)
+	if err != nil {
+		return sdk.TxResponse{}, err
+	}
+
+	msg := nicknametypes.NewMsgSetNickname(nicknametypes.NewName(nickname), key.GetAddress())
+	return c.BroadcastMsgs(key.GetName(), msg)
+}
+
+// ChangeNicknameKey maps the nickname owned by from to the new address
+func (c *Client) ChangeNicknameKey(from, nickname, newAddress string) (sdk.TxResponse, error) {
+	key, err := c.GetKey(from)
+	if err != nil {
+		return sdk.TxResponse{}, err
+	}
+	newAddr, err := sdk.AccAddressFromBech32(newAddress)
+	if err != nil {
+		return sdk.TxResponse{}, err
+	}
+
+	msg := nicknametypes.NewMsgChangeKey(nickname, key.GetAddress(), newAddr)
+	return c.BroadcastMsgs(key.GetName(), msg)
+}
+
+// broadcastEEMsg converts the amount and fee to bigsun, and broadcasts the message built
+// for the address of from
+func (c *Client) broadcastEEMsg(from string, amount, fee cliutil.Hdac,
+	newMsg func(fromAddr sdk.AccAddress, amount, fee string) sdk.Msg) (sdk.TxResponse, error) {
+	key, err := c.GetKey(from)
+	if err != nil {
+		return sdk.TxResponse{}, err
+	}
+
+	bigsunAmount, err := cliutil.ToBigsun(amount)
+	if err != nil {
+		return sdk.TxResponse{}, err
+	}
+	bigsunFee, err := cliutil.ToBigsun(fee)
+	if err != nil {
+		return sdk.TxResponse{}, err
+	}
+
+	msg := newMsg(key.GetAddress(), string(bigsunAmount), string(bigsunFee))
+	return c.BroadcastMsgs(key.GetName(), msg)
+}
+
+// parseContractAddress parses the bech32 address of a contract uref or hash
+func parseContractAddress(contract string) (sdk.ContractAddress, error) {
+	switch {
+	case strings.HasPrefix(contract, sdk.Bech32PrefixContractURef):
+		addr, err := sdk.ContractUrefAddressFromBech32(contract)
+		if err != nil {
+			return nil, err
+		}
+		return addr, nil
+	case strings.HasPrefix(contract, sdk.Bech32PrefixContractHash):
+		addr, err := sdk.ContractHashAddressFromBech32(contract)
+		if err != nil {
+			return nil, err
+		}
+		return addr, nil
+	default:
+		return nil, fmt.Errorf("Malformed contract address")
+	}
+}
+
+// compactArgs validates and compacts the JSON arguments of a contract as the CLI does
+func compactArgs(args string) (string, error) {
+	if len(args) == 0 {
+		return "", nil
+	}
+
+	var jsonData []map[string]interface{}
+	if err := json.Unmarshal([]byte(args), &jsonData); err != nil {
+		return "", err
+	}
+	bz, err := json.Marshal(jsonData)
+	if err != nil {
+		return "", err
+	}
+	return string(bz), nil
+}
@@ -3,8 +3,10 @@ package keys
 import (
 	"bufio"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/spf13/viper"
 	"github.com/hdac-io/tendermint/libs/cli"
@@ -22,6 +24,32 @@ const (
 
 	// defaultKeyDBName is the client's subdirectory where keys are stored.
 	defaultKeyDBName = "keys"
+
+	// defaultSignerSocket is the socket of the external signer in the client's home.
+	defaultSignerSocket = "signer.sock"
+)
+
+// Keyring backends selected by --keyring-backend
+const (
+	// BackendLevelDB stores the keys in a LevelDB under the client's home
+	BackendLevelDB = "leveldb"
+	// BackendFile stores the keys in a file encrypted with the keyring passphrase
+	BackendFile = "file"
+	// BackendMemory keeps the keys in memory for the lifetime of the process, for tests
+	BackendMemory = "memory"
+	// BackendExternal signs with the keys of an external signer over a local socket
+	BackendExternal = "external"
+)
+
+// keyringPassphraseKey is the viper key of the passphrase of the file keyring, which is
+// read from the environment, e.g. FR_KEYRING_PASSPHRASE for clif
+const keyringPassphraseKey = "keyring-passphrase"
+
+var (
+	// inMemoryKeyBase is shared by the keybases of the memory backend of the process
+	inMemoryKeyBase keys.Keybase
+	// keyringPassphrase is the passphrase of the file keyring once it's read
+	keyringPassphrase string
 )
 
 type bechKeyOutFn func(keyInfo keys.Info) (keys.KeyOutput, error)
@@ -81,9 +109,39 @@ func NewKeyBaseFromHomeFlag() (keys.Keybase, error) {
 	return NewKeyBaseFromDir(rootDir)
 }
 
-// NewKeyBaseFromDir initializes a keybase at a particular dir.
+// NewKeyBaseFromDir initializes a keybase at a particular dir, with the keyring backend
+// of --keyring-backend.
 func NewKeyBaseFromDir(rootDir string) (keys.Keybase, error) {
-	return getLazyKeyBaseFromDir(rootDir)
+	return NewKeyBase(viper.GetString(flags.FlagKeyringBackend), rootDir)
+}
+
+// NewKeyBase initializes a keybase of the backend at a particular dir. An empty backend
+// is the LevelDB keybase.
+func NewKeyBase(backend, rootDir string) (keys.Keybase, error) {
+	switch backend {
+	case "", BackendLevelDB:
+		return getLazyKeyBaseFromDir(rootDir)
+
+	case BackendFile:
+		return keys.NewEncryptedFile(defaultKeyDBName, filepath.Join(rootDir, "keys"), getKeyringPassphrase), nil
+
+	case BackendMemory:
+		if inMemoryKeyBase == nil {
+			inMemoryKeyBase = keys.NewInMemory()
+		}
+		return inMemoryKeyBase, nil
+
+	case BackendExternal:
+		socket := viper.GetString(flags.FlagKeyringSigner)
+		if socket == "" {
+			socket = filepath.Join(rootDir, defaultSignerSocket)
+		}
+		return keys.NewExternalSigner(socket), nil
+
+	default:
+		return nil, fmt.Errorf("unknown keyring backend %q, expected %s, %s, %s or %s",
+			backend, BackendLevelDB, BackendFile, BackendMemory, BackendExternal)
+	}
 }
 
 // NewInMemoryKeyBase returns a storage-less keybase.
@@ -93,6 +151,34 @@ func getLazyKeyBaseFromDir(rootDir string) (keys.Keybase, error) {
 	return keys.New(defaultKeyDBName, filepath.Join(rootDir, "keys")), nil
 }
 
+// getKeyringPassphrase returns the passphrase of the file keyring from the file of
+// --keyring-passphrase-file, the environment or STDIN, in order
+func getKeyringPassphrase() (string, error) {
+	if keyringPassphrase != "" {
+		return keyringPassphrase, nil
+	}
+
+	passphrase := viper.GetString(keyringPassphraseKey)
+	if file := viper.GetString(flags.FlagKeyringPassFile); file != "" {
+		bz, err := ioutil.ReadFile(file)
+		if err != nil {
+			return "", fmt.Errorf("failed to read the keyring passphrase: %v", err)
+		}
+		passphrase = strings.TrimRight(string(bz), "\r\n")
+	}
+
+	if passphrase == "" {
+		var err error
+		passphrase, err = input.GetPassword("Keyring passphrase:", bufio.NewReader(os.Stdin))
+		if err != nil {
+			return "", fmt.Errorf("Error reading keyring passphrase: %v", err)
+		}
+	}
+
+	keyringPassphrase = passphrase
+	return passphrase, nil
+}
+
 func printKeyInfo(keyInfo keys.Info, bechKeyOut bechKeyOutFn) {
 	ko, err := bechKeyOut(keyInfo)
 	if err != nil {
@@ -0,0 +1,57 @@
+package keys
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hdac-io/friday/client/flags"
+	"github.com/hdac-io/friday/crypto/keys"
+	"github.com/hdac-io/friday/tests"
+)
+
+func TestNewKeyBase(t *testing.T) {
+	kbHome, cleanUp := tests.NewTestCaseDir(t)
+	defer cleanUp()
+
+	// the memory keybase is shared in the process
+	kb, err := NewKeyBase(BackendMemory, kbHome)
+	require.NoError(t, err)
+	_, err = kb.CreateAccount("memory", tests.TestMnemonic, "", "12345678", 0, 0)
+	require.NoError(t, err)
+	kb, err = NewKeyBase(BackendMemory, kbHome)
+	require.NoError(t, err)
+	_, err = kb.Get("memory")
+	require.NoError(t, err)
+
+	// the file keybase is unlocked with the passphrase of the file
+	passFile := filepath.Join(kbHome, "passphrase")
+	require.NoError(t, ioutil.WriteFile(passFile, []byte("keyring-passphrase\n"), 0600))
+	viper.Set(flags.FlagKeyringPassFile, passFile)
+	defer viper.Set(flags.FlagKeyringPassFile, "")
+	defer func() { keyringPassphrase = "" }()
+
+	viper.Set(flags.FlagKeyringBackend, BackendFile)
+	defer viper.Set(flags.FlagKeyringBackend, "")
+	kb, err = NewKeyBaseFromDir(kbHome)
+	require.NoError(t, err)
+	_, err = kb.CreateAccount("file", tests.TestMnemonic, "", "12345678", 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, "keyring-passphrase", keyringPassphrase)
+
+	kb = keys.NewEncryptedFile(defaultKeyDBName, filepath.Join(kbHome, "keys"),
+		func() (string, error) { return "keyring-passphrase", nil })
+	_, err = kb.Get("file")
+	require.NoError(t, err)
+
+	kb, err = NewKeyBase(BackendExternal, kbHome)
+	require.NoError(t, err)
+	_, err = kb.List()
+	require.Error(t, err, "no signer listening")
+
+	_, err = NewKeyBase("os", kbHome)
+	require.Error(t, err)
+}
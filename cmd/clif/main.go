@@ -50,6 +50,9 @@ func main() {
 
 	// Add --chain-id to persistent flags and mark it required
 	rootCmd.PersistentFlags().String(client.FlagChainID, "", "Chain ID of tendermint node")
+
+	// Add --keyring-backend and the flags of the backends to persistent flags
+	client.AddKeyringFlags(rootCmd)
 	rootCmd.PersistentPreRunE = func(_ *cobra.Command, _ []string) error {
 		return initConfig(rootCmd)
 	}
@@ -58,6 +61,7 @@ func main() {
 	rootCmd.AddCommand(
 		eecmd.GetHdacCustomCmd(cdc),
 		eecmd.GetContractCmd(cdc),
+		eecmd.GetTokenCmd(cdc),
 		nicknamecmd.GetRootCmd(cdc),
 		client.LineBreak,
 		queryCmd(cdc),
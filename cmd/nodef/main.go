@@ -61,6 +61,7 @@ func main() {
 	rootCmd.AddCommand(client.NewCompletionCmd(rootCmd, true))
 	rootCmd.AddCommand(testnetCmd(ctx, cdc, app.ModuleBasics, genaccounts.AppModuleBasic{}))
 	rootCmd.AddCommand(replayCmd())
+	rootCmd.AddCommand(snapshotCmd(ctx, cdc))
 
 	server.AddCommands(ctx, cdc, rootCmd, newApp, exportAppStateAndTMValidators)
 
@@ -75,12 +76,27 @@ func main() {
 }
 
 func newApp(logger log.Logger, db dbm.DB, traceStore io.Writer) abci.Application {
-	return app.NewFridayApp(
-		logger, db, traceStore, true, invCheckPeriod,
+	baseAppOptions := []func(*baseapp.BaseApp){
 		baseapp.SetPruning(store.NewPruningOptionsFromString(viper.GetString("pruning"))),
 		baseapp.SetMinGasPrices(viper.GetString(server.FlagMinGasPrices)),
 		baseapp.SetHaltHeight(uint64(viper.GetInt(server.FlagHaltHeight))),
-	)
+	}
+
+	streamingService, err := server.NewStreamingService(logger)
+	if err != nil {
+		panic(err)
+	}
+	if streamingService != nil {
+		baseAppOptions = append(baseAppOptions, baseapp.SetStreamingService(streamingService))
+	}
+
+	// exposed on the prometheus endpoint of tendermint, configured by the [instrumentation] section of config.toml
+	var elMetrics *executionlayer.Metrics
+	if viper.GetBool("instrumentation.prometheus") {
+		elMetrics = executionlayer.PrometheusMetrics(viper.GetString("instrumentation.namespace"))
+	}
+
+	return app.NewFridayApp(logger, db, traceStore, true, invCheckPeriod, app.DefaultEESocket, elMetrics, baseAppOptions...)
 }
 
 func exportAppStateAndTMValidators(
@@ -88,13 +104,13 @@ func exportAppStateAndTMValidators(
 ) (json.RawMessage, []tmtypes.GenesisValidator, error) {
 
 	if height != -1 {
-		gApp := app.NewFridayApp(logger, db, traceStore, false, uint(1))
+		gApp := app.NewFridayApp(logger, db, traceStore, false, uint(1), app.DefaultEESocket, nil)
 		err := gApp.LoadHeight(height)
 		if err != nil {
 			return nil, nil, err
 		}
 		return gApp.ExportAppStateAndValidators(forZeroHeight, jailWhiteList)
 	}
-	gApp := app.NewFridayApp(logger, db, traceStore, true, uint(1))
+	gApp := app.NewFridayApp(logger, db, traceStore, true, uint(1), app.DefaultEESocket, nil)
 	return gApp.ExportAppStateAndValidators(forZeroHeight, jailWhiteList)
 }
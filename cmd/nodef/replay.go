@@ -93,7 +93,7 @@ func replayTxs(rootDir string) error {
 	// Application
 	fmt.Fprintln(os.Stderr, "Creating application")
 	myapp := app.NewFridayApp(
-		ctx.Logger, appDB, traceStoreWriter, true, uint(1),
+		ctx.Logger, appDB, traceStoreWriter, true, uint(1), app.DefaultEESocket, nil,
 		baseapp.SetPruning(store.PruneEverything), // nothing
 	)
 
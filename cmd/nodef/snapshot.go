@@ -0,0 +1,659 @@
+package main
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/grpc"
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/casper/consensus/state"
+	"github.com/hdac-io/tendermint/libs/cli"
+	tmsm "github.com/hdac-io/tendermint/state"
+	tmstore "github.com/hdac-io/tendermint/store"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/hdac-io/friday/app"
+	"github.com/hdac-io/friday/codec"
+	"github.com/hdac-io/friday/server"
+	"github.com/hdac-io/friday/store"
+	"github.com/hdac-io/friday/store/iavl"
+	"github.com/hdac-io/friday/store/rootmulti"
+	sdk "github.com/hdac-io/friday/types"
+	eltypes "github.com/hdac-io/friday/x/executionlayer/types"
+)
+
+const (
+	flagLatestHeight   = "latest-height"
+	flagOutput         = "output"
+	flagEEDataDir      = "ee-data-dir"
+	flagEESocket       = "ee-socket"
+	flagEETimeout      = "ee-timeout"
+	flagTrustedHeight  = "trusted-height"
+	flagTrustedAppHash = "trusted-app-hash"
+
+	snapshotFormat          = 2
+	snapshotManifestName    = "manifest.json"
+	snapshotAppName         = "application.snapshot"
+	snapshotStateName       = "tendermint/state.snapshot"
+	snapshotBlockStoreName  = "tendermint/blockstore.snapshot"
+	snapshotEEDir           = "ee"
+	eeGlobalStateDir        = "global_state"
+	eeGlobalStateDataFile   = "data.lmdb"
+	snapshotEEQueryInterval = 2 * time.Second
+
+	// entries written to a db at once while restoring
+	snapshotBatchSize = 10000
+)
+
+// snapshotManifest is the first entry of a snapshot archive and describes the rest of it
+type snapshotManifest struct {
+	Format            int            `json:"format"`
+	Height            int64          `json:"height"`
+	AppHash           string         `json:"app_hash"`
+	EEStateHash       string         `json:"ee_state_hash"`
+	AppEntries        snapshotFile   `json:"app_entries"`
+	StateEntries      snapshotFile   `json:"state_entries"`
+	BlockStoreEntries snapshotFile   `json:"block_store_entries"`
+	EEFiles           []snapshotFile `json:"ee_files"`
+}
+
+type snapshotFile struct {
+	Path   string `json:"path"`
+	SHA256 string `json:"sha256"`
+	Size   int64  `json:"size"`
+}
+
+func snapshotCmd(ctx *server.Context, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Create or restore a snapshot of the application and EE state",
+	}
+
+	cmd.AddCommand(
+		createSnapshotCmd(ctx, cdc),
+		restoreSnapshotCmd(ctx, cdc),
+	)
+
+	return cmd
+}
+
+func createSnapshotCmd(ctx *server.Context, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Write a snapshot archive of the state at the latest height",
+		Long: `Write a snapshot archive of every IAVL store at the latest height of the node, together
+with the Tendermint state and block store, and the EE global state holding the EE post-state
+of the height.
+
+The node and the execution engine must be stopped while the snapshot is created.
+A snapshot can only be taken at the latest height: Tendermint keeps the state of the latest
+height only, and the EE has no export of a single state, so its global state data file is
+archived as is, holding the EE states of every height up to the latest one. There is no
+snapshot of a past height; --latest-height only guards against the node having moved on,
+and fails if the latest height is another one.`,
+		Example: "nodef snapshot create --output friday-1000.tar.gz",
+		Args:    cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			config := ctx.Config
+			config.SetRoot(viper.GetString(cli.HomeFlag))
+
+			manifest, err := createSnapshot(cdc, config.DBDir(), viper.GetString(flagEEDataDir),
+				viper.GetInt64(flagLatestHeight), viper.GetString(flagOutput))
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(os.Stderr, "Snapshot of height %d written\n", manifest.Height)
+			fmt.Fprintf(os.Stderr, "App hash: %s\nEE state hash: %s\n", manifest.AppHash, manifest.EEStateHash)
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64(flagLatestHeight, 0, "Expected latest height of the node, which the snapshot is taken at")
+	cmd.Flags().String(flagOutput, "", "Archive to write, friday-snapshot-<height>.tar.gz by default")
+	cmd.Flags().String(flagEEDataDir, os.ExpandEnv("$HOME/.casperlabs"), "Data directory of the execution engine")
+
+	return cmd
+}
+
+func restoreSnapshotCmd(ctx *server.Context, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "restore <archive>",
+		Short: "Restore the state from a snapshot archive",
+		Long: `Restore the application db, the Tendermint state and block store, and the EE global state
+from a snapshot archive. None of them must exist yet.
+
+The archive is not trusted: the app hash of the restored stores must be the one given by
+--trusted-app-hash at --trusted-height, taken from a source you trust, like a node of your
+own. The Tendermint state of the archive must be at the height with the same app hash, and
+the EE post-state hash is read from the verified application db.
+
+Once the files are restored, start the execution engine on the EE data directory. The
+restore waits up to --ee-timeout for the engine to serve the restored EE state, and removes
+the restored state if any check fails.`,
+		Example: "nodef snapshot restore friday-1000.tar.gz --trusted-height 1000 --trusted-app-hash <hex>",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			config := ctx.Config
+			config.SetRoot(viper.GetString(cli.HomeFlag))
+
+			trustedHeight := viper.GetInt64(flagTrustedHeight)
+			if trustedHeight <= 0 {
+				return fmt.Errorf("--%s must be positive", flagTrustedHeight)
+			}
+			trustedAppHash, err := hex.DecodeString(viper.GetString(flagTrustedAppHash))
+			if err != nil || len(trustedAppHash) == 0 {
+				return fmt.Errorf("--%s must be the app hash in hex", flagTrustedAppHash)
+			}
+
+			manifest, err := restoreSnapshot(cdc, args[0], config.DBDir(), viper.GetString(flagEEDataDir),
+				viper.GetString(flagEESocket), viper.GetDuration(flagEETimeout), trustedHeight, trustedAppHash)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(os.Stderr, "Snapshot of height %d restored\n", manifest.Height)
+			fmt.Fprintf(os.Stderr, "App hash: %s\nEE state hash: %s\n", manifest.AppHash, manifest.EEStateHash)
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64(flagTrustedHeight, 0, "Height of the trusted app hash, which must be the height of the snapshot")
+	cmd.Flags().String(flagTrustedAppHash, "", "Trusted app hash of the height in hex")
+	cmd.Flags().String(flagEEDataDir, os.ExpandEnv("$HOME/.casperlabs"), "Data directory of the execution engine")
+	cmd.Flags().String(flagEESocket, app.DefaultEESocket, "Socket of the execution engine")
+	cmd.Flags().Duration(flagEETimeout, 10*time.Minute, "Time to wait for the execution engine to serve the restored EE state")
+
+	return cmd
+}
+
+// createSnapshot writes a snapshot archive of the latest height, which must be latestHeight unless it's 0
+func createSnapshot(cdc *codec.Codec, dbDir, eeDataDir string, latestHeight int64, output string) (manifest snapshotManifest, err error) {
+	appDB, err := sdk.NewLevelDB("application", dbDir)
+	if err != nil {
+		return manifest, err
+	}
+	defer appDB.Close()
+	stateDB, err := sdk.NewLevelDB("state", dbDir)
+	if err != nil {
+		return manifest, err
+	}
+	defer stateDB.Close()
+	blockStoreDB, err := sdk.NewLevelDB("blockstore", dbDir)
+	if err != nil {
+		return manifest, err
+	}
+	defer blockStoreDB.Close()
+
+	tmState := tmsm.LoadState(stateDB)
+	if tmState.LastBlockHeight == 0 {
+		return manifest, fmt.Errorf("no Tendermint state in %s", dbDir)
+	}
+	height := tmState.LastBlockHeight
+	if latestHeight != 0 && latestHeight != height {
+		return manifest, fmt.Errorf("latest height is %d, not %d; a snapshot can only be taken at the latest height",
+			height, latestHeight)
+	}
+	if err := checkTendermintState(tmState, tmstore.NewBlockStore(blockStoreDB), height); err != nil {
+		return manifest, err
+	}
+	if output == "" {
+		output = fmt.Sprintf("friday-snapshot-%d.tar.gz", height)
+	}
+
+	eeState, _, err := loadEEState(cdc, appDB, height)
+	if err != nil {
+		return manifest, err
+	}
+
+	var commitID sdk.CommitID
+	appFile, appEntries, err := spoolSnapshotItems(snapshotAppName, func(fn rootmulti.SnapshotItemFunc) (err error) {
+		commitID, err = rootmulti.ExportSnapshot(appDB, height, fn)
+		return err
+	})
+	if err != nil {
+		return manifest, err
+	}
+	defer removeSpoolFile(appFile)
+	if !bytes.Equal(commitID.Hash, tmState.AppHash) {
+		return manifest, fmt.Errorf("app hash at height %d is %X, but %X in the Tendermint state",
+			height, commitID.Hash, tmState.AppHash)
+	}
+
+	stateFile, stateEntries, err := spoolSnapshotItems(snapshotStateName, exportDB(stateDB))
+	if err != nil {
+		return manifest, err
+	}
+	defer removeSpoolFile(stateFile)
+	blockStoreFile, blockStoreEntries, err := spoolSnapshotItems(snapshotBlockStoreName, exportDB(blockStoreDB))
+	if err != nil {
+		return manifest, err
+	}
+	defer removeSpoolFile(blockStoreFile)
+
+	eeFile, err := checksumEEFile(eeDataDir, eeGlobalStateDir+"/"+eeGlobalStateDataFile)
+	if err != nil {
+		return manifest, err
+	}
+
+	manifest = snapshotManifest{
+		Format:            snapshotFormat,
+		Height:            height,
+		AppHash:           hex.EncodeToString(commitID.Hash),
+		EEStateHash:       hex.EncodeToString(eeState),
+		AppEntries:        appEntries,
+		StateEntries:      stateEntries,
+		BlockStoreEntries: blockStoreEntries,
+		EEFiles:           []snapshotFile{eeFile},
+	}
+
+	out, err := os.OpenFile(output, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return manifest, err
+	}
+	defer out.Close()
+
+	gz := gzip.NewWriter(out)
+	tw := tar.NewWriter(gz)
+
+	manifestBytes, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return manifest, err
+	}
+	if err := writeTarEntry(tw, snapshotManifestName, int64(len(manifestBytes)), bytes.NewReader(manifestBytes)); err != nil {
+		return manifest, err
+	}
+
+	for _, spooled := range []struct {
+		file  *os.File
+		entry snapshotFile
+	}{{appFile, appEntries}, {stateFile, stateEntries}, {blockStoreFile, blockStoreEntries}} {
+		if _, err := spooled.file.Seek(0, io.SeekStart); err != nil {
+			return manifest, err
+		}
+		if err := writeTarEntry(tw, spooled.entry.Path, spooled.entry.Size, spooled.file); err != nil {
+			return manifest, err
+		}
+	}
+
+	for _, file := range manifest.EEFiles {
+		f, err := os.Open(filepath.Join(eeDataDir, filepath.FromSlash(file.Path)))
+		if err != nil {
+			return manifest, err
+		}
+		err = writeTarEntry(tw, snapshotEEDir+"/"+file.Path, file.Size, f)
+		f.Close()
+		if err != nil {
+			return manifest, err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return manifest, err
+	}
+	return manifest, gz.Close()
+}
+
+func restoreSnapshot(cdc *codec.Codec, archive, dbDir, eeDataDir, eeSocket string, eeTimeout time.Duration,
+	trustedHeight int64, trustedAppHash []byte) (manifest snapshotManifest, err error) {
+	eeStateDir := filepath.Join(eeDataDir, eeGlobalStateDir)
+	restoredDirs := []string{eeStateDir}
+	for _, name := range []string{"application", "state", "blockstore"} {
+		restoredDirs = append(restoredDirs, filepath.Join(dbDir, name+".db"))
+	}
+	for _, dir := range restoredDirs {
+		if _, err := os.Stat(dir); err == nil {
+			return manifest, fmt.Errorf("%s already exists", dir)
+		}
+	}
+
+	in, err := os.Open(archive)
+	if err != nil {
+		return manifest, err
+	}
+	defer in.Close()
+
+	gz, err := gzip.NewReader(in)
+	if err != nil {
+		return manifest, err
+	}
+	tr := tar.NewReader(gz)
+
+	header, err := tr.Next()
+	if err != nil {
+		return manifest, err
+	}
+	if header.Name != snapshotManifestName {
+		return manifest, fmt.Errorf("%s is not a snapshot archive", archive)
+	}
+	if err := json.NewDecoder(tr).Decode(&manifest); err != nil {
+		return manifest, err
+	}
+	if manifest.Format != snapshotFormat {
+		return manifest, fmt.Errorf("unsupported snapshot format %d", manifest.Format)
+	}
+	if manifest.Height != trustedHeight {
+		return manifest, fmt.Errorf("snapshot is of height %d, not the trusted height %d", manifest.Height, trustedHeight)
+	}
+
+	dbs := make(map[string]dbm.DB)
+	defer func() {
+		for _, db := range dbs {
+			db.Close()
+		}
+		if err != nil {
+			for _, dir := range restoredDirs {
+				os.RemoveAll(dir)
+			}
+		}
+	}()
+	for _, name := range []string{"application", "state", "blockstore"} {
+		db, err := sdk.NewLevelDB(name, dbDir)
+		if err != nil {
+			return manifest, err
+		}
+		dbs[name] = db
+	}
+	entryDBs := map[string]dbm.DB{
+		snapshotAppName:        dbs["application"],
+		snapshotStateName:      dbs["state"],
+		snapshotBlockStoreName: dbs["blockstore"],
+	}
+
+	expected := map[string]snapshotFile{
+		snapshotAppName:        manifest.AppEntries,
+		snapshotStateName:      manifest.StateEntries,
+		snapshotBlockStoreName: manifest.BlockStoreEntries,
+	}
+	for _, file := range manifest.EEFiles {
+		expected[snapshotEEDir+"/"+file.Path] = file
+	}
+
+	for {
+		header, err = tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return manifest, err
+		}
+
+		file, ok := expected[header.Name]
+		if !ok {
+			return manifest, fmt.Errorf("unexpected entry %s", header.Name)
+		}
+		delete(expected, header.Name)
+
+		hasher := sha256.New()
+		reader := io.TeeReader(tr, hasher)
+		if db, ok := entryDBs[header.Name]; ok {
+			err = importSnapshotItems(db, bufio.NewReader(reader))
+		} else {
+			err = restoreEEFile(eeDataDir, file.Path, reader)
+		}
+		if err != nil {
+			return manifest, err
+		}
+
+		if sum := hex.EncodeToString(hasher.Sum(nil)); sum != file.SHA256 {
+			return manifest, fmt.Errorf("checksum of %s is %s, expected %s", header.Name, sum, file.SHA256)
+		}
+	}
+	for name := range expected {
+		return manifest, fmt.Errorf("%s is missing in the archive", name)
+	}
+
+	appDB := dbs["application"]
+	commitID, err := rootmulti.VerifySnapshot(appDB, manifest.Height)
+	if err != nil {
+		return manifest, err
+	}
+	if !bytes.Equal(commitID.Hash, trustedAppHash) {
+		return manifest, fmt.Errorf("app hash is %X, but the trusted app hash is %X", commitID.Hash, trustedAppHash)
+	}
+	manifest.AppHash = hex.EncodeToString(commitID.Hash)
+
+	tmState := tmsm.LoadState(dbs["state"])
+	if tmState.LastBlockHeight != manifest.Height || !bytes.Equal(tmState.AppHash, trustedAppHash) {
+		return manifest, fmt.Errorf("Tendermint state is at height %d with the app hash %X, expected %d with %X",
+			tmState.LastBlockHeight, tmState.AppHash, manifest.Height, trustedAppHash)
+	}
+	if err := checkTendermintState(tmState, tmstore.NewBlockStore(dbs["blockstore"]), manifest.Height); err != nil {
+		return manifest, err
+	}
+
+	// the EE post-state hash is in the verified hash map store
+	eeState, protocolVersion, err := loadEEState(cdc, appDB, manifest.Height)
+	if err != nil {
+		return manifest, err
+	}
+	if eeStateHash := hex.EncodeToString(eeState); eeStateHash != manifest.EEStateHash {
+		return manifest, fmt.Errorf("EE state hash is %s, expected %s", eeStateHash, manifest.EEStateHash)
+	}
+
+	fmt.Fprintf(os.Stderr, "EE global state restored to %s, waiting for the execution engine on %s\n", eeStateDir, eeSocket)
+	return manifest, waitForEEState(eeSocket, eeState, protocolVersion, eeTimeout)
+}
+
+// checkTendermintState checks the block store has the last block of the state at the height
+func checkTendermintState(tmState tmsm.State, blockStore *tmstore.BlockStore, height int64) error {
+	meta := blockStore.LoadBlockMeta(height)
+	if meta == nil {
+		return fmt.Errorf("no block of height %d in the block store", height)
+	}
+	if !meta.BlockID.Equals(tmState.LastBlockID) {
+		return fmt.Errorf("block %s of height %d is not the last block %s of the Tendermint state",
+			meta.BlockID, height, tmState.LastBlockID)
+	}
+	return nil
+}
+
+// waitForEEState queries the execution engine for the system account at the EE state until it's
+// served or the timeout passes
+func waitForEEState(eeSocket string, eeState []byte, protocolVersion state.ProtocolVersion, timeout time.Duration) error {
+	client := grpc.Connect(eeSocket)
+	deadline := time.Now().Add(timeout)
+	for {
+		_, errStr := grpc.Query(client, eeState, "address", eltypes.SYSTEM_ACCOUNT, []string{}, &protocolVersion)
+		if errStr == "" {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("EE state %X is not available from the execution engine: %s", eeState, errStr)
+		}
+		time.Sleep(snapshotEEQueryInterval)
+	}
+}
+
+// loadEEState reads the EE post-state hash and the protocol version of the height
+// from the execution layer hash map store.
+func loadEEState(cdc *codec.Codec, db dbm.DB, height int64) ([]byte, state.ProtocolVersion, error) {
+	var protocolVersion state.ProtocolVersion
+
+	storeDB := dbm.NewPrefixDB(db, []byte("s/k:"+eltypes.HashMapStoreKey+"/"))
+	commitStore, err := iavl.LoadStore(storeDB, sdk.CommitID{Version: height}, store.PruneNothing, false)
+	if err != nil {
+		return nil, protocolVersion, err
+	}
+	hashMapStore := commitStore.(sdk.KVStore)
+
+	var unitHash eltypes.UnitHashMap
+	if err := cdc.UnmarshalBinaryBare(hashMapStore.Get(eltypes.GetEEStateKey(height)), &unitHash); err != nil {
+		return nil, protocolVersion, err
+	}
+	if len(unitHash.EEState) != 32 {
+		return nil, protocolVersion, fmt.Errorf("no EE state at height %d", height)
+	}
+
+	if bz := hashMapStore.Get([]byte(eltypes.ProtoclVersionKey)); bz != nil {
+		if err := cdc.UnmarshalBinaryBare(bz, &protocolVersion); err != nil {
+			return nil, protocolVersion, err
+		}
+	}
+
+	return unitHash.EEState, protocolVersion, nil
+}
+
+// checksumEEFile returns the checksum of a file of the EE data directory
+func checksumEEFile(eeDataDir, path string) (snapshotFile, error) {
+	f, err := os.Open(filepath.Join(eeDataDir, filepath.FromSlash(path)))
+	if err != nil {
+		return snapshotFile{}, err
+	}
+	defer f.Close()
+
+	hasher := sha256.New()
+	size, err := io.Copy(hasher, f)
+	if err != nil {
+		return snapshotFile{}, err
+	}
+	return snapshotFile{
+		Path:   path,
+		SHA256: hex.EncodeToString(hasher.Sum(nil)),
+		Size:   size,
+	}, nil
+}
+
+// spoolSnapshotItems writes the items exported to a temporary file, as the size of a tar entry
+// goes before its content, and returns the file with its entry of the manifest
+func spoolSnapshotItems(path string, export func(rootmulti.SnapshotItemFunc) error) (*os.File, snapshotFile, error) {
+	f, err := ioutil.TempFile("", "friday-snapshot")
+	if err != nil {
+		return nil, snapshotFile{}, err
+	}
+
+	hasher := sha256.New()
+	writer := bufio.NewWriter(io.MultiWriter(f, hasher))
+	err = export(func(key, value []byte) error {
+		if err := writeSnapshotItem(writer, key); err != nil {
+			return err
+		}
+		return writeSnapshotItem(writer, value)
+	})
+	if err == nil {
+		err = writer.Flush()
+	}
+	var size int64
+	if err == nil {
+		size, err = f.Seek(0, io.SeekCurrent)
+	}
+	if err != nil {
+		removeSpoolFile(f)
+		return nil, snapshotFile{}, err
+	}
+	return f, snapshotFile{Path: path, SHA256: hex.EncodeToString(hasher.Sum(nil)), Size: size}, nil
+}
+
+func removeSpoolFile(f *os.File) {
+	f.Close()
+	os.Remove(f.Name())
+}
+
+// exportDB exports every entry of the db
+func exportDB(db dbm.DB) func(rootmulti.SnapshotItemFunc) error {
+	return func(fn rootmulti.SnapshotItemFunc) error {
+		iter := db.Iterator(nil, nil)
+		defer iter.Close()
+		for ; iter.Valid(); iter.Next() {
+			if err := fn(iter.Key(), iter.Value()); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func restoreEEFile(eeDataDir, path string, r io.Reader) error {
+	path = filepath.Clean(filepath.FromSlash(path))
+	if filepath.IsAbs(path) || strings.HasPrefix(path, "..") {
+		return fmt.Errorf("invalid path %s", path)
+	}
+
+	path = filepath.Join(eeDataDir, path)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(f, r)
+	return err
+}
+
+func importSnapshotItems(db dbm.DB, r *bufio.Reader) error {
+	batch := db.NewBatch()
+	count := 0
+	for {
+		key, err := readSnapshotItem(r)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		value, err := readSnapshotItem(r)
+		if err != nil {
+			return err
+		}
+
+		batch.Set(key, value)
+		count++
+		if count%snapshotBatchSize == 0 {
+			batch.Write()
+			batch.Close()
+			batch = db.NewBatch()
+		}
+	}
+	batch.WriteSync()
+	batch.Close()
+	return nil
+}
+
+func writeTarEntry(tw *tar.Writer, name string, size int64, r io.Reader) error {
+	if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: size}); err != nil {
+		return err
+	}
+	_, err := io.CopyN(tw, r, size)
+	return err
+}
+
+// snapshot items are prefixed with their uvarint length
+func writeSnapshotItem(w io.Writer, item []byte) error {
+	buf := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutUvarint(buf, uint64(len(item)))
+	if _, err := w.Write(buf[:n]); err != nil {
+		return err
+	}
+	_, err := w.Write(item)
+	return err
+}
+
+func readSnapshotItem(r *bufio.Reader) ([]byte, error) {
+	size, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+	item := make([]byte, size)
+	if _, err := io.ReadFull(r, item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
@@ -0,0 +1,297 @@
+package keys
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/hdac-io/tendermint/crypto"
+	cryptoAmino "github.com/hdac-io/tendermint/crypto/encoding/amino"
+
+	"github.com/hdac-io/friday/crypto/keys/hd"
+	"github.com/hdac-io/friday/crypto/keys/keyerror"
+	"github.com/hdac-io/friday/crypto/keys/mintkey"
+	sdk "github.com/hdac-io/friday/types"
+)
+
+var _ Keybase = externalKeybase{}
+
+// Methods of the external signer protocol
+const (
+	SignerMethodList = "list"
+	SignerMethodSign = "sign"
+)
+
+const (
+	signerDialTimeout = 5 * time.Second
+	// signing may wait for the approval of the signer, e.g. on a hardware device
+	signerSignTimeout = 2 * time.Minute
+)
+
+// SignerRequest is a request of the external signer protocol.
+//
+// The protocol speaks a single JSON line of request and a single JSON line of response
+// per connection over a local unix socket. Public keys are amino encoded, and binary
+// fields are base64 in JSON.
+//
+//   {"method": "list"}
+//   -> {"keys": [{"name": "alice", "pubkey": "<pubkey>"}]}
+//   {"method": "sign", "name": "alice", "msg": "<bytes to sign>"}
+//   -> {"signature": "<signature>", "pubkey": "<pubkey>"}
+//
+// A failed request is answered with {"error": "<message>"}.
+type SignerRequest struct {
+	Method string `json:"method"`
+	Name   string `json:"name,omitempty"`
+	Msg    []byte `json:"msg,omitempty"`
+}
+
+// SignerKey is a key held by the external signer
+type SignerKey struct {
+	Name   string `json:"name"`
+	PubKey []byte `json:"pubkey"`
+}
+
+// SignerResponse is a response of the external signer protocol
+type SignerResponse struct {
+	Keys      []SignerKey `json:"keys,omitempty"`
+	Signature []byte      `json:"signature,omitempty"`
+	PubKey    []byte      `json:"pubkey,omitempty"`
+	Error     string      `json:"error,omitempty"`
+}
+
+// externalKeybase lists and signs with the keys of an external signer. The private keys
+// never leave the signer, so the keys can be neither created nor exported.
+type externalKeybase struct {
+	socket string
+}
+
+// NewExternalSigner creates a keybase of the keys of the external signer listening on
+// the unix socket.
+func NewExternalSigner(socket string) Keybase {
+	return externalKeybase{socket: socket}
+}
+
+func (ekb externalKeybase) List() ([]Info, error) {
+	res, err := ekb.request(SignerRequest{Method: SignerMethodList}, signerDialTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]Info, 0, len(res.Keys))
+	for _, key := range res.Keys {
+		pub, err := cryptoAmino.PubKeyFromBytes(key.PubKey)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid public key of %s from the external signer", key.Name)
+		}
+		infos = append(infos, newExternalInfo(key.Name, pub))
+	}
+	return infos, nil
+}
+
+func (ekb externalKeybase) Get(name string) (Info, error) {
+	infos, err := ekb.List()
+	if err != nil {
+		return nil, err
+	}
+	for _, info := range infos {
+		if info.GetName() == name {
+			return info, nil
+		}
+	}
+	return nil, keyerror.NewErrKeyNotFound(name)
+}
+
+func (ekb externalKeybase) GetByAddress(address sdk.AccAddress) (Info, error) {
+	infos, err := ekb.List()
+	if err != nil {
+		return nil, err
+	}
+	for _, info := range infos {
+		if info.GetAddress().Equals(address) {
+			return info, nil
+		}
+	}
+	return nil, fmt.Errorf("key with address %s not found", address)
+}
+
+// Sign asks the external signer to sign the msg with the named key. The passphrase is
+// ignored, as the signer unlocks its keys by itself.
+func (ekb externalKeybase) Sign(name, _ string, msg []byte) ([]byte, crypto.PubKey, error) {
+	res, err := ekb.request(SignerRequest{Method: SignerMethodSign, Name: name, Msg: msg}, signerSignTimeout)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pub, err := cryptoAmino.PubKeyFromBytes(res.PubKey)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "invalid public key of %s from the external signer", name)
+	}
+	if !pub.VerifyBytes(msg, res.Signature) {
+		return nil, nil, fmt.Errorf("invalid signature of %s from the external signer", name)
+	}
+	return res.Signature, pub, nil
+}
+
+func (ekb externalKeybase) Delete(name, _ string, _ bool) error {
+	return ekb.unsupported("delete")
+}
+
+func (ekb externalKeybase) CreateMnemonic(name string, _ Language, _ string, _ SigningAlgo) (Info, string, error) {
+	return nil, "", ekb.unsupported("create")
+}
+
+func (ekb externalKeybase) CreateAccount(name, _, _, _ string, _ uint32, _ uint32) (Info, error) {
+	return nil, ekb.unsupported("create")
+}
+
+func (ekb externalKeybase) Derive(name, _, _, _ string, _ hd.BIP44Params) (Info, error) {
+	return nil, ekb.unsupported("create")
+}
+
+func (ekb externalKeybase) CreateLedger(name string, _ SigningAlgo, _ string, _, _ uint32) (Info, error) {
+	return nil, ekb.unsupported("create")
+}
+
+func (ekb externalKeybase) CreateOffline(name string, _ crypto.PubKey) (Info, error) {
+	return nil, ekb.unsupported("create")
+}
+
+func (ekb externalKeybase) CreateMulti(name string, _ crypto.PubKey) (Info, error) {
+	return nil, ekb.unsupported("create")
+}
+
+func (ekb externalKeybase) Update(name, _ string, _ func() (string, error)) error {
+	return ekb.unsupported("update")
+}
+
+func (ekb externalKeybase) Import(name string, _ string) error {
+	return ekb.unsupported("import")
+}
+
+func (ekb externalKeybase) ImportPrivKey(name string, _ string, _ string) error {
+	return ekb.unsupported("import")
+}
+
+func (ekb externalKeybase) ImportPubKey(name string, _ string) error {
+	return ekb.unsupported("import")
+}
+
+func (ekb externalKeybase) Export(name string) (string, error) {
+	return "", ekb.unsupported("export")
+}
+
+func (ekb externalKeybase) ExportPubKey(name string) (string, error) {
+	info, err := ekb.Get(name)
+	if err != nil {
+		return "", err
+	}
+	return mintkey.ArmorPubKeyBytes(info.GetPubKey().Bytes()), nil
+}
+
+func (ekb externalKeybase) ExportPrivKey(name string, _ string, _ string) (string, error) {
+	return "", ekb.unsupported("export")
+}
+
+func (ekb externalKeybase) ExportPrivateKeyObject(name string, _ string) (crypto.PrivKey, error) {
+	return nil, ekb.unsupported("export")
+}
+
+func (ekb externalKeybase) CloseDB() {}
+
+func (ekb externalKeybase) unsupported(op string) error {
+	return fmt.Errorf("cannot %s keys of the external signer", op)
+}
+
+func (ekb externalKeybase) request(req SignerRequest, timeout time.Duration) (SignerResponse, error) {
+	var res SignerResponse
+
+	conn, err := net.DialTimeout("unix", ekb.socket, signerDialTimeout)
+	if err != nil {
+		return res, errors.Wrap(err, "failed to connect to the external signer")
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return res, err
+	}
+
+	if err := json.NewEncoder(conn).Encode(req); err != nil {
+		return res, errors.Wrap(err, "failed to send the request to the external signer")
+	}
+	line, err := bufio.NewReader(conn).ReadBytes('\n')
+	if err != nil {
+		return res, errors.Wrap(err, "failed to read the response of the external signer")
+	}
+	if err := json.Unmarshal(line, &res); err != nil {
+		return res, errors.Wrap(err, "invalid response of the external signer")
+	}
+	if res.Error != "" {
+		return res, fmt.Errorf("external signer: %s", res.Error)
+	}
+	return res, nil
+}
+
+// ServeSigner serves the keys of the keybase on the listener with the external signer
+// protocol, unlocking them with the passphrases returned by passphrase. It returns when
+// the listener is closed.
+func ServeSigner(listener net.Listener, kb Keybase, passphrase func(name string) (string, error)) error {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return err
+		}
+		go serveSignerConn(conn, kb, passphrase)
+	}
+}
+
+func serveSignerConn(conn net.Conn, kb Keybase, passphrase func(name string) (string, error)) {
+	defer conn.Close()
+
+	var req SignerRequest
+	line, err := bufio.NewReader(conn).ReadBytes('\n')
+	if err == nil {
+		err = json.Unmarshal(line, &req)
+	}
+
+	var res SignerResponse
+	if err == nil {
+		res, err = handleSignerRequest(req, kb, passphrase)
+	}
+	if err != nil {
+		res = SignerResponse{Error: err.Error()}
+	}
+	_ = json.NewEncoder(conn).Encode(res)
+}
+
+func handleSignerRequest(req SignerRequest, kb Keybase, passphrase func(name string) (string, error)) (SignerResponse, error) {
+	switch req.Method {
+	case SignerMethodList:
+		infos, err := kb.List()
+		if err != nil {
+			return SignerResponse{}, err
+		}
+		keys := make([]SignerKey, len(infos))
+		for i, info := range infos {
+			keys[i] = SignerKey{Name: info.GetName(), PubKey: info.GetPubKey().Bytes()}
+		}
+		return SignerResponse{Keys: keys}, nil
+
+	case SignerMethodSign:
+		pass, err := passphrase(req.Name)
+		if err != nil {
+			return SignerResponse{}, err
+		}
+		sig, pub, err := kb.Sign(req.Name, pass, req.Msg)
+		if err != nil {
+			return SignerResponse{}, err
+		}
+		return SignerResponse{Signature: sig, PubKey: pub.Bytes()}, nil
+
+	default:
+		return SignerResponse{}, fmt.Errorf("unknown method %q", req.Method)
+	}
+}
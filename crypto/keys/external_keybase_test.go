@@ -0,0 +1,59 @@
+package keys
+
+import (
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hdac-io/friday/tests"
+)
+
+func TestExternalSigner(t *testing.T) {
+	dir, cleanup := tests.NewTestCaseDir(t)
+	defer cleanup()
+
+	signerKb := NewInMemory()
+	info, _, err := signerKb.CreateMnemonic("alice", English, "12345678", Secp256k1)
+	require.NoError(t, err)
+
+	socket := filepath.Join(dir, "signer.sock")
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+	defer listener.Close()
+	go ServeSigner(listener, signerKb, func(string) (string, error) { return "12345678", nil })
+
+	kb := NewExternalSigner(socket)
+	l, err := kb.List()
+	require.NoError(t, err)
+	require.Len(t, l, 1)
+	require.Equal(t, TypeExternal, l[0].GetType())
+	require.Equal(t, info.GetAddress(), l[0].GetAddress())
+
+	i, err := kb.GetByAddress(info.GetAddress())
+	require.NoError(t, err)
+	require.Equal(t, "alice", i.GetName())
+	_, err = kb.Get("bob")
+	require.Error(t, err)
+
+	msg := []byte("to be signed")
+	sig, pub, err := kb.Sign("alice", "", msg)
+	require.NoError(t, err)
+	require.Equal(t, info.GetPubKey(), pub)
+	require.True(t, pub.VerifyBytes(msg, sig))
+
+	// errors of the signer are returned
+	_, _, err = kb.Sign("bob", "", msg)
+	require.Error(t, err)
+
+	// the keys stay in the signer
+	_, _, err = kb.CreateMnemonic("bob", English, "12345678", Secp256k1)
+	require.Error(t, err)
+	_, err = kb.ExportPrivKey("alice", "", "12345678")
+	require.Error(t, err)
+	require.Error(t, kb.Delete("alice", "", true))
+
+	_, err = NewExternalSigner(filepath.Join(dir, "none.sock")).List()
+	require.Error(t, err)
+}
@@ -0,0 +1,363 @@
+package keys
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/hdac-io/tendermint/crypto"
+	cmn "github.com/hdac-io/tendermint/libs/common"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/hdac-io/friday/crypto/keys/hd"
+	sdk "github.com/hdac-io/friday/types"
+)
+
+var _ Keybase = fileKeybase{}
+
+// KeyringScryptN is the scrypt cost parameter of the keyring files written.
+// It is stored in the file, so a keyring is always opened with the cost it was written with.
+var KeyringScryptN = 1 << 15
+
+const (
+	keyringFileVersion = 1
+	keyringFileSuffix  = ".keyring"
+	keyringScryptR     = 8
+	keyringScryptP     = 1
+	keyringKeyLen      = 32
+	keyringSaltLen     = 32
+	keyringNonceLen    = 24
+)
+
+// keyringFile is the on-disk format of an encrypted keyring. Data is the JSON of the
+// entries of the keybase sealed by XSalsa20-Poly1305 with the key derived from the
+// keyring passphrase by scrypt.
+type keyringFile struct {
+	Version int    `json:"version"`
+	KDF     string `json:"kdf"`
+	N       int    `json:"n"`
+	R       int    `json:"r"`
+	P       int    `json:"p"`
+	Salt    []byte `json:"salt"`
+	Nonce   []byte `json:"nonce"`
+	Data    []byte `json:"data"`
+}
+
+// fileKeybase keeps the keys in a single encrypted file, which is decrypted into
+// memory for each operation and written back at once when the keys are changed.
+type fileKeybase struct {
+	name       string
+	dir        string
+	passphrase func() (string, error)
+}
+
+// NewEncryptedFile creates a keybase stored in the file <dir>/<name>.keyring, encrypted
+// with the keyring passphrase returned by passphrase. The private keys in it are
+// encrypted with their own passphrases as in the other keybases.
+func NewEncryptedFile(name, dir string, passphrase func() (string, error)) Keybase {
+	if err := cmn.EnsureDir(dir, 0700); err != nil {
+		panic(fmt.Sprintf("failed to create Keybase directory: %s", err))
+	}
+
+	return fileKeybase{name: name, dir: dir, passphrase: passphrase}
+}
+
+func (fkb fileKeybase) List() ([]Info, error) {
+	var infos []Info
+	err := fkb.view(func(kb Keybase) (err error) {
+		infos, err = kb.List()
+		return
+	})
+	return infos, err
+}
+
+func (fkb fileKeybase) Get(name string) (Info, error) {
+	var info Info
+	err := fkb.view(func(kb Keybase) (err error) {
+		info, err = kb.Get(name)
+		return
+	})
+	return info, err
+}
+
+func (fkb fileKeybase) GetByAddress(address sdk.AccAddress) (Info, error) {
+	var info Info
+	err := fkb.view(func(kb Keybase) (err error) {
+		info, err = kb.GetByAddress(address)
+		return
+	})
+	return info, err
+}
+
+func (fkb fileKeybase) Delete(name, passphrase string, skipPass bool) error {
+	return fkb.update(func(kb Keybase) error {
+		return kb.Delete(name, passphrase, skipPass)
+	})
+}
+
+func (fkb fileKeybase) Sign(name, passphrase string, msg []byte) (sig []byte, pub crypto.PubKey, err error) {
+	err = fkb.view(func(kb Keybase) (err error) {
+		sig, pub, err = kb.Sign(name, passphrase, msg)
+		return
+	})
+	return
+}
+
+func (fkb fileKeybase) CreateMnemonic(name string, language Language, passwd string, algo SigningAlgo) (info Info, seed string, err error) {
+	err = fkb.update(func(kb Keybase) (err error) {
+		info, seed, err = kb.CreateMnemonic(name, language, passwd, algo)
+		return
+	})
+	return
+}
+
+func (fkb fileKeybase) CreateAccount(name, mnemonic, bip39Passwd, encryptPasswd string, account uint32, index uint32) (info Info, err error) {
+	err = fkb.update(func(kb Keybase) (err error) {
+		info, err = kb.CreateAccount(name, mnemonic, bip39Passwd, encryptPasswd, account, index)
+		return
+	})
+	return
+}
+
+func (fkb fileKeybase) Derive(name, mnemonic, bip39Passwd, encryptPasswd string, params hd.BIP44Params) (info Info, err error) {
+	err = fkb.update(func(kb Keybase) (err error) {
+		info, err = kb.Derive(name, mnemonic, bip39Passwd, encryptPasswd, params)
+		return
+	})
+	return
+}
+
+func (fkb fileKeybase) CreateLedger(name string, algo SigningAlgo, hrp string, account, index uint32) (info Info, err error) {
+	err = fkb.update(func(kb Keybase) (err error) {
+		info, err = kb.CreateLedger(name, algo, hrp, account, index)
+		return
+	})
+	return
+}
+
+func (fkb fileKeybase) CreateOffline(name string, pubkey crypto.PubKey) (info Info, err error) {
+	err = fkb.update(func(kb Keybase) (err error) {
+		info, err = kb.CreateOffline(name, pubkey)
+		return
+	})
+	return
+}
+
+func (fkb fileKeybase) CreateMulti(name string, pubkey crypto.PubKey) (info Info, err error) {
+	err = fkb.update(func(kb Keybase) (err error) {
+		info, err = kb.CreateMulti(name, pubkey)
+		return
+	})
+	return
+}
+
+func (fkb fileKeybase) Update(name, oldpass string, getNewpass func() (string, error)) error {
+	return fkb.update(func(kb Keybase) error {
+		return kb.Update(name, oldpass, getNewpass)
+	})
+}
+
+func (fkb fileKeybase) Import(name string, armor string) error {
+	return fkb.update(func(kb Keybase) error {
+		return kb.Import(name, armor)
+	})
+}
+
+func (fkb fileKeybase) ImportPrivKey(name string, armor string, passphrase string) error {
+	return fkb.update(func(kb Keybase) error {
+		return kb.ImportPrivKey(name, armor, passphrase)
+	})
+}
+
+func (fkb fileKeybase) ImportPubKey(name string, armor string) error {
+	return fkb.update(func(kb Keybase) error {
+		return kb.ImportPubKey(name, armor)
+	})
+}
+
+func (fkb fileKeybase) Export(name string) (armor string, err error) {
+	err = fkb.view(func(kb Keybase) (err error) {
+		armor, err = kb.Export(name)
+		return
+	})
+	return
+}
+
+func (fkb fileKeybase) ExportPubKey(name string) (armor string, err error) {
+	err = fkb.view(func(kb Keybase) (err error) {
+		armor, err = kb.ExportPubKey(name)
+		return
+	})
+	return
+}
+
+func (fkb fileKeybase) ExportPrivateKeyObject(name string, passphrase string) (priv crypto.PrivKey, err error) {
+	err = fkb.view(func(kb Keybase) (err error) {
+		priv, err = kb.ExportPrivateKeyObject(name, passphrase)
+		return
+	})
+	return
+}
+
+func (fkb fileKeybase) ExportPrivKey(name string, decryptPassphrase string,
+	encryptPassphrase string) (armor string, err error) {
+	err = fkb.view(func(kb Keybase) (err error) {
+		armor, err = kb.ExportPrivKey(name, decryptPassphrase, encryptPassphrase)
+		return
+	})
+	return
+}
+
+func (fkb fileKeybase) CloseDB() {}
+
+func (fkb fileKeybase) filename() string {
+	return filepath.Join(fkb.dir, fkb.name+keyringFileSuffix)
+}
+
+// view runs fn on the keys of the file
+func (fkb fileKeybase) view(fn func(Keybase) error) error {
+	db, _, err := fkb.load()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	return fn(newDbKeybase(db))
+}
+
+// update runs fn on the keys of the file, and writes them back if fn succeeds
+func (fkb fileKeybase) update(fn func(Keybase) error) error {
+	db, passphrase, err := fkb.load()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	if err := fn(newDbKeybase(db)); err != nil {
+		return err
+	}
+
+	if passphrase == "" {
+		// the keyring is created with the first key
+		if passphrase, err = fkb.getPassphrase(); err != nil {
+			return err
+		}
+	}
+	return fkb.store(db, passphrase)
+}
+
+func (fkb fileKeybase) getPassphrase() (string, error) {
+	if fkb.passphrase == nil {
+		return "", fmt.Errorf("no passphrase of the keyring %s", fkb.filename())
+	}
+	passphrase, err := fkb.passphrase()
+	if err != nil {
+		return "", err
+	}
+	if passphrase == "" {
+		return "", fmt.Errorf("keyring passphrase must not be empty")
+	}
+	return passphrase, nil
+}
+
+// load decrypts the keys of the file into an in-memory DB. The passphrase is asked only
+// when the file exists, and is returned with the keys.
+func (fkb fileKeybase) load() (dbm.DB, string, error) {
+	db := dbm.NewMemDB()
+
+	bz, err := ioutil.ReadFile(fkb.filename())
+	if os.IsNotExist(err) {
+		return db, "", nil
+	} else if err != nil {
+		return nil, "", err
+	}
+
+	var file keyringFile
+	if err := json.Unmarshal(bz, &file); err != nil {
+		return nil, "", fmt.Errorf("failed to parse keyring %s: %s", fkb.filename(), err.Error())
+	}
+	if file.Version != keyringFileVersion || file.KDF != "scrypt" {
+		return nil, "", fmt.Errorf("unsupported keyring %s: version %d, kdf %s", fkb.filename(), file.Version, file.KDF)
+	}
+	if len(file.Nonce) != keyringNonceLen {
+		return nil, "", fmt.Errorf("invalid nonce of keyring %s", fkb.filename())
+	}
+
+	passphrase, err := fkb.getPassphrase()
+	if err != nil {
+		return nil, "", err
+	}
+	key, err := scrypt.Key([]byte(passphrase), file.Salt, file.N, file.R, file.P, keyringKeyLen)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var secretKey [keyringKeyLen]byte
+	var nonce [keyringNonceLen]byte
+	copy(secretKey[:], key)
+	copy(nonce[:], file.Nonce)
+	data, ok := secretbox.Open(nil, file.Data, &nonce, &secretKey)
+	if !ok {
+		return nil, "", fmt.Errorf("failed to decrypt keyring %s: wrong passphrase", fkb.filename())
+	}
+
+	var entries map[string][]byte
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, "", fmt.Errorf("failed to parse keyring %s: %s", fkb.filename(), err.Error())
+	}
+	for k, v := range entries {
+		db.Set([]byte(k), v)
+	}
+	return db, passphrase, nil
+}
+
+// store encrypts the keys of the DB with a new salt and replaces the file with them
+func (fkb fileKeybase) store(db dbm.DB, passphrase string) error {
+	entries := make(map[string][]byte)
+	iter := db.Iterator(nil, nil)
+	for ; iter.Valid(); iter.Next() {
+		entries[string(iter.Key())] = iter.Value()
+	}
+	iter.Close()
+
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+
+	file := keyringFile{
+		Version: keyringFileVersion,
+		KDF:     "scrypt",
+		N:       KeyringScryptN,
+		R:       keyringScryptR,
+		P:       keyringScryptP,
+		Salt:    crypto.CRandBytes(keyringSaltLen),
+		Nonce:   crypto.CRandBytes(keyringNonceLen),
+	}
+	key, err := scrypt.Key([]byte(passphrase), file.Salt, file.N, file.R, file.P, keyringKeyLen)
+	if err != nil {
+		return err
+	}
+
+	var secretKey [keyringKeyLen]byte
+	var nonce [keyringNonceLen]byte
+	copy(secretKey[:], key)
+	copy(nonce[:], file.Nonce)
+	file.Data = secretbox.Seal(nil, data, &nonce, &secretKey)
+
+	bz, err := json.Marshal(file)
+	if err != nil {
+		return err
+	}
+
+	tmp := fkb.filename() + ".tmp"
+	if err := ioutil.WriteFile(tmp, bz, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, fkb.filename())
+}
@@ -0,0 +1,78 @@
+package keys
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hdac-io/friday/tests"
+)
+
+func TestFileKeyManagement(t *testing.T) {
+	defer func(n int) { KeyringScryptN = n }(KeyringScryptN)
+	KeyringScryptN = 1 << 10
+	dir, cleanup := tests.NewTestCaseDir(t)
+	defer cleanup()
+
+	asked := 0
+	passphrase := func() (string, error) {
+		asked++
+		return "keyring-passphrase", nil
+	}
+	kb := NewEncryptedFile("keybasename", dir, passphrase)
+
+	// an empty keyring is read without the passphrase
+	l, err := kb.List()
+	require.NoError(t, err)
+	require.Empty(t, l)
+	require.Equal(t, 0, asked)
+
+	n1, n2, p1 := "personal", "business", "1234"
+	i1, _, err := kb.CreateMnemonic(n1, English, p1, Secp256k1)
+	require.NoError(t, err)
+	_, _, err = kb.CreateMnemonic(n2, English, p1, Secp256k1)
+	require.NoError(t, err)
+
+	// the keys are neither in plain text nor readable without the passphrase
+	bz, err := ioutil.ReadFile(filepath.Join(dir, "keybasename.keyring"))
+	require.NoError(t, err)
+	require.False(t, strings.Contains(string(bz), n1))
+
+	wrong := NewEncryptedFile("keybasename", dir, func() (string, error) { return "wrong", nil })
+	_, err = wrong.List()
+	require.Error(t, err)
+	_, _, err = wrong.CreateMnemonic("other", English, p1, Secp256k1)
+	require.Error(t, err)
+
+	// a new keybase of the file reads the keys
+	kb = NewEncryptedFile("keybasename", dir, passphrase)
+	l, err = kb.List()
+	require.NoError(t, err)
+	require.Len(t, l, 2)
+	i, err := kb.GetByAddress(i1.GetAddress())
+	require.NoError(t, err)
+	require.Equal(t, n1, i.GetName())
+
+	msg := []byte("to be signed")
+	sig, pub, err := kb.Sign(n1, p1, msg)
+	require.NoError(t, err)
+	require.Equal(t, i1.GetPubKey(), pub)
+	require.True(t, pub.VerifyBytes(msg, sig))
+	_, _, err = kb.Sign(n1, "wrong", msg)
+	require.Error(t, err)
+
+	// failed changes aren't written
+	require.Error(t, kb.Delete(n1, "wrong", false))
+	require.NoError(t, kb.Delete(n2, p1, false))
+	l, err = kb.List()
+	require.NoError(t, err)
+	require.Len(t, l, 1)
+	require.Equal(t, n1, l[0].GetName())
+
+	empty := NewEncryptedFile("empty", dir, func() (string, error) { return "", nil })
+	_, _, err = empty.CreateMnemonic(n1, English, p1, Secp256k1)
+	require.Error(t, err)
+}
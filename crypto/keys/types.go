@@ -64,17 +64,19 @@ type KeyType uint
 
 // Info KeyTypes
 const (
-	TypeLocal   KeyType = 0
-	TypeLedger  KeyType = 1
-	TypeOffline KeyType = 2
-	TypeMulti   KeyType = 3
+	TypeLocal    KeyType = 0
+	TypeLedger   KeyType = 1
+	TypeOffline  KeyType = 2
+	TypeMulti    KeyType = 3
+	TypeExternal KeyType = 4
 )
 
 var keyTypes = map[KeyType]string{
-	TypeLocal:   "local",
-	TypeLedger:  "ledger",
-	TypeOffline: "offline",
-	TypeMulti:   "multi",
+	TypeLocal:    "local",
+	TypeLedger:   "ledger",
+	TypeOffline:  "offline",
+	TypeMulti:    "multi",
+	TypeExternal: "external",
 }
 
 // String implements the stringer interface for KeyType.
@@ -101,6 +103,7 @@ var (
 	_ Info = &ledgerInfo{}
 	_ Info = &offlineInfo{}
 	_ Info = &multiInfo{}
+	_ Info = &externalInfo{}
 )
 
 // localInfo is the public information about a locally stored key
@@ -278,6 +281,45 @@ func (i multiInfo) GetPath() (*hd.BIP44Params, error) {
 	return nil, fmt.Errorf("BIP44 Paths are not available for this type")
 }
 
+// externalInfo is the public information about a key held by an external signer.
+// It is never stored in the keybases.
+type externalInfo struct {
+	Name   string        `json:"name"`
+	PubKey crypto.PubKey `json:"pubkey"`
+}
+
+func newExternalInfo(name string, pub crypto.PubKey) Info {
+	return &externalInfo{
+		Name:   name,
+		PubKey: pub,
+	}
+}
+
+// GetType implements Info interface
+func (i externalInfo) GetType() KeyType {
+	return TypeExternal
+}
+
+// GetName implements Info interface
+func (i externalInfo) GetName() string {
+	return i.Name
+}
+
+// GetPubKey implements Info interface
+func (i externalInfo) GetPubKey() crypto.PubKey {
+	return i.PubKey
+}
+
+// GetAddress implements Info interface
+func (i externalInfo) GetAddress() types.AccAddress {
+	return i.PubKey.Address().Bytes()
+}
+
+// GetPath implements Info interface
+func (i externalInfo) GetPath() (*hd.BIP44Params, error) {
+	return nil, fmt.Errorf("BIP44 Paths are not available for this type")
+}
+
 // encoding info
 func writeInfo(i Info) []byte {
 	return cdc.MustMarshalBinaryLengthPrefixed(i)
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/hdac-io/friday/store/streaming"
 	sdk "github.com/hdac-io/friday/types"
 )
 
@@ -34,9 +35,32 @@ type BaseConfig struct {
 	HaltTime uint64 `mapstructure:"halt-time"`
 }
 
+// StreamingConfig defines the streaming of the state changes of the blocks
+// to local sinks
+type StreamingConfig struct {
+	// Sinks of the state changes, "file:<path>" or "unix:<socket path>".
+	// The state changes are not streamed without a sink.
+	Sinks []string `mapstructure:"sinks"`
+
+	// Stores whose changes are streamed by name, every store if empty.
+	Stores []string `mapstructure:"stores"`
+
+	// Encoding of the state changes, "json" or "proto".
+	Encoding string `mapstructure:"encoding"`
+
+	// BufferSize is the number of blocks which can wait for the sinks.
+	BufferSize int `mapstructure:"buffer-size"`
+
+	// OnFull is the policy when the buffer is full: "block" holds up the node
+	// until the sinks catch up, "drop" drops the state changes of the block.
+	OnFull string `mapstructure:"on-full"`
+}
+
 // Config defines the server's top level configuration
 type Config struct {
 	BaseConfig `mapstructure:",squash"`
+
+	Streaming StreamingConfig `mapstructure:"streaming"`
 }
 
 // SetMinGasPrices sets the validator's minimum gas prices.
@@ -68,9 +92,18 @@ func (c *Config) GetMinGasPrices() sdk.DecCoins {
 
 // DefaultConfig returns server's default configuration.
 func DefaultConfig() *Config {
+	streamingConfig := streaming.DefaultConfig()
+
 	return &Config{
-		BaseConfig{
+		BaseConfig: BaseConfig{
 			MinGasPrices: defaultMinGasPrices,
 		},
+		Streaming: StreamingConfig{
+			Sinks:      []string{},
+			Stores:     []string{},
+			Encoding:   streamingConfig.Encoding,
+			BufferSize: streamingConfig.BufferSize,
+			OnFull:     streamingConfig.OnFull,
+		},
 	}
 }
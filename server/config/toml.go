@@ -32,6 +32,32 @@ halt-height = {{ .BaseConfig.HaltHeight }}
 # Note: State will not be committed on the corresponding height and any logs
 # indicating such can be safely ignored.
 halt-time = {{ .BaseConfig.HaltTime }}
+
+##### state streaming config options #####
+[streaming]
+
+# Sinks where the state changes of each committed block are written, grouped
+# by the begin block, the txs and the end block. A sink is either
+# "file:<path>" to append to a file, or "unix:<socket path>" to write to a
+# unix socket. A block is written again over a new connection until a reader
+# of the socket takes it, so a missing reader fills the buffer below.
+# The --state-listeners flag of the start command overrides it.
+# The state changes are not streamed without a sink.
+sinks = [{{ range .Streaming.Sinks }}"{{ . }}", {{ end }}]
+
+# Names of the stores whose changes are streamed, every store if empty.
+stores = [{{ range .Streaming.Stores }}"{{ . }}", {{ end }}]
+
+# Encoding of the state changes, "json" or "proto". Each block is prefixed
+# with the uvarint length of its encoding.
+encoding = "{{ .Streaming.Encoding }}"
+
+# Number of blocks which can wait for the sinks.
+buffer-size = {{ .Streaming.BufferSize }}
+
+# What to do when the buffer is full: "block" holds up the node until the
+# sinks catch up, "drop" drops the state changes of the block.
+on-full = "{{ .Streaming.OnFull }}"
 `
 
 var configTemplate *template.Template
@@ -6,11 +6,15 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/spf13/viper"
+
 	abci "github.com/hdac-io/tendermint/abci/types"
 	"github.com/hdac-io/tendermint/libs/log"
 	tmtypes "github.com/hdac-io/tendermint/types"
 	dbm "github.com/tendermint/tm-db"
 
+	"github.com/hdac-io/friday/server/config"
+	"github.com/hdac-io/friday/store/streaming"
 	sdk "github.com/hdac-io/friday/types"
 )
 
@@ -41,3 +45,30 @@ func openTraceWriter(traceWriterFile string) (w io.Writer, err error) {
 	}
 	return
 }
+
+// NewStreamingService creates the service streaming the state changes of the
+// blocks, configured by the [streaming] section of app.toml. The sinks of the
+// --state-listeners flag override the sinks of the config. It returns nil if
+// there is no sink.
+func NewStreamingService(logger log.Logger) (*streaming.Service, error) {
+	conf, err := config.ParseConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	sinks := conf.Streaming.Sinks
+	if flagSinks := viper.GetStringSlice(FlagStateListeners); len(flagSinks) > 0 {
+		sinks = flagSinks
+	}
+	if len(sinks) == 0 {
+		return nil, nil
+	}
+
+	return streaming.NewService(streaming.Config{
+		Sinks:      sinks,
+		Stores:     conf.Streaming.Stores,
+		Encoding:   conf.Streaming.Encoding,
+		BufferSize: conf.Streaming.BufferSize,
+		OnFull:     conf.Streaming.OnFull,
+	}, logger)
+}
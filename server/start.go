@@ -4,12 +4,14 @@ package server
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"runtime/pprof"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"github.com/hdac-io/tendermint/abci/server"
+	abci "github.com/hdac-io/tendermint/abci/types"
 	tcmd "github.com/hdac-io/tendermint/cmd/tendermint/commands"
 	cmn "github.com/hdac-io/tendermint/libs/common"
 	"github.com/hdac-io/tendermint/node"
@@ -29,6 +31,7 @@ const (
 	FlagMinGasPrices   = "minimum-gas-prices"
 	FlagHaltHeight     = "halt-height"
 	FlagHaltTime       = "halt-time"
+	FlagStateListeners = "state-listeners"
 )
 
 // StartCmd runs the service passed in, either stand-alone or in-process with
@@ -54,6 +57,10 @@ will not be able to commit subsequent blocks.
 
 For profiling and benchmarking purposes, CPU profiling can be enabled via the '--cpu-profile' flag
 which accepts a path for the resulting pprof file.
+
+The state changes of each committed block can be streamed to local sinks via the '--state-listeners'
+flag, which accepts a comma separated list of 'file:<path>' and 'unix:<socket path>'. The stores,
+the encoding and the buffering of the streaming are configured in the [streaming] section of app.toml.
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if !viper.GetBool(flagWithTendermint) {
@@ -80,6 +87,7 @@ which accepts a path for the resulting pprof file.
 	cmd.Flags().Uint64(FlagHaltHeight, 0, "Height at which to gracefully halt the chain and shutdown the node")
 	cmd.Flags().Uint64(FlagHaltTime, 0, "Minimum block time (in Unix seconds) at which to gracefully halt the chain and shutdown the node")
 	cmd.Flags().String(flagCPUProfile, "", "Enable CPU profiling and write to the provided file")
+	cmd.Flags().StringSlice(FlagStateListeners, nil, "Stream the state changes of the blocks to the sinks (file:<path>, unix:<socket path>)")
 
 	// add support for all Tendermint-specific command line options
 	tcmd.AddNodeFlags(cmd)
@@ -120,6 +128,7 @@ func startStandAlone(ctx *Context, appCreator AppCreator) error {
 		if err != nil {
 			cmn.Exit(err.Error())
 		}
+		closeApp(ctx, app)
 	})
 
 	// run forever (the node will not be returned)
@@ -199,6 +208,7 @@ func startInProcess(ctx *Context, appCreator AppCreator) (*node.Node, error) {
 		if tmNode.IsRunning() {
 			_ = tmNode.Stop()
 		}
+		closeApp(ctx, app)
 
 		if cpuProfileCleanup != nil {
 			cpuProfileCleanup()
@@ -210,3 +220,15 @@ func startInProcess(ctx *Context, appCreator AppCreator) (*node.Node, error) {
 	// run forever (the node will not be returned)
 	select {}
 }
+
+// closeApp closes the app once it commits no more blocks, if it holds resources to release,
+// like the sinks of the streaming service
+func closeApp(ctx *Context, app abci.Application) {
+	closer, ok := app.(io.Closer)
+	if !ok {
+		return
+	}
+	if err := closer.Close(); err != nil {
+		ctx.Logger.Error("failed to close the app", "err", err)
+	}
+}
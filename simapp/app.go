@@ -143,6 +143,7 @@ func NewSimApp(
 	distrSubspace := app.paramsKeeper.Subspace(distr.DefaultParamspace)
 	slashingSubspace := app.paramsKeeper.Subspace(slashing.DefaultParamspace)
 	govSubspace := app.paramsKeeper.Subspace(gov.DefaultParamspace)
+	executionLayerSubspace := app.paramsKeeper.Subspace(executionlayer.DefaultParamspace)
 
 	// add keepers
 	app.accountKeeper = auth.NewAccountKeeper(app.cdc, keys[auth.StoreKey], authSubspace, auth.ProtoBaseAccount)
@@ -160,10 +161,12 @@ func NewSimApp(
 	app.executionLayerKeeper = executionlayer.NewExecutionLayerKeeper(
 		app.cdc,
 		keys[executionlayer.HashMapStoreKey],
+		executionLayerSubspace,
 		os.ExpandEnv("$HOME/.casperlabs/.casper-node.sock"),
 		app.accountKeeper,
 		app.nicknameKeeper,
 	)
+	app.executionLayerKeeper.SetPruning(app.BaseApp.Pruning())
 
 	// register the proposal types
 	govRouter := gov.NewRouter()
@@ -201,7 +204,7 @@ func NewSimApp(
 	// CanWithdrawInvariant invariant.
 	app.mm.SetOrderBeginBlockers(executionlayer.ModuleName)
 
-	app.mm.SetOrderEndBlockers(executionlayer.ModuleName)
+	app.mm.SetOrderEndBlockers(gov.ModuleName, executionlayer.ModuleName)
 
 	// NOTE: The genutils moodule must occur after staking so that pools are
 	// properly initialized with tokens from genesis accounts.
@@ -220,7 +223,10 @@ func NewSimApp(
 	// initialize BaseApp
 	app.SetInitChainer(app.InitChainer)
 	app.SetBeginBlocker(app.BeginBlocker)
-	app.SetAnteHandler(auth.NewAnteHandler(app.accountKeeper, app.supplyKeeper, auth.DefaultSigVerificationGasConsumer))
+	app.SetAnteHandler(executionlayer.NewAnteHandler(
+		app.executionLayerKeeper,
+		auth.NewAnteHandler(app.accountKeeper, app.supplyKeeper, auth.DefaultSigVerificationGasConsumer),
+	))
 	app.SetEndBlocker(app.EndBlocker)
 
 	if loadLatest {
@@ -7,6 +7,7 @@ import (
 
 	"github.com/hdac-io/friday/store/cachekv"
 	"github.com/hdac-io/friday/store/dbadapter"
+	"github.com/hdac-io/friday/store/listenkv"
 	"github.com/hdac-io/friday/store/types"
 )
 
@@ -24,6 +25,10 @@ type Store struct {
 
 	traceWriter  io.Writer
 	traceContext types.TraceContext
+
+	// listeners of the writes to the stores, including the writes of the
+	// cache-wraps of the Store when they are written
+	listeners map[types.StoreKey][]types.WriteListener
 }
 
 var _ types.CacheMultiStore = Store{}
@@ -32,6 +37,7 @@ func NewFromKVStore(
 	store types.KVStore,
 	stores map[types.StoreKey]types.CacheWrapper, keys map[string]types.StoreKey,
 	traceWriter io.Writer, traceContext types.TraceContext,
+	listeners map[types.StoreKey][]types.WriteListener,
 ) Store {
 	cms := Store{
 		db:           cachekv.NewStore(store),
@@ -39,6 +45,7 @@ func NewFromKVStore(
 		keys:         keys,
 		traceWriter:  traceWriter,
 		traceContext: traceContext,
+		listeners:    listeners,
 	}
 
 	for key, store := range stores {
@@ -56,16 +63,22 @@ func NewStore(
 	db dbm.DB,
 	stores map[types.StoreKey]types.CacheWrapper, keys map[string]types.StoreKey,
 	traceWriter io.Writer, traceContext types.TraceContext,
+	listeners map[types.StoreKey][]types.WriteListener,
 ) Store {
-	return NewFromKVStore(dbadapter.Store{db}, stores, keys, traceWriter, traceContext)
+	return NewFromKVStore(dbadapter.Store{db}, stores, keys, traceWriter, traceContext, listeners)
 }
 
 func newCacheMultiStoreFromCMS(cms Store) Store {
 	stores := make(map[types.StoreKey]types.CacheWrapper)
 	for k, v := range cms.stores {
 		stores[k] = v
+		if cms.ListeningEnabled(k) {
+			// the cache-wrap writes through the listeners, which are not
+			// notified of the writes to the cache-wrap itself
+			stores[k] = listenkv.NewStore(v.(types.KVStore), k, cms.listeners[k])
+		}
 	}
-	return NewFromKVStore(cms.db, stores, nil, cms.traceWriter, cms.traceContext)
+	return NewFromKVStore(cms.db, stores, nil, cms.traceWriter, cms.traceContext, nil)
 }
 
 // SetTracer sets the tracer for the MultiStore that the underlying
@@ -96,6 +109,11 @@ func (cms Store) TracingEnabled() bool {
 	return cms.traceWriter != nil
 }
 
+// ListeningEnabled returns if the store of the key has listeners.
+func (cms Store) ListeningEnabled(key types.StoreKey) bool {
+	return len(cms.listeners[key]) > 0
+}
+
 // GetStoreType returns the type of the store.
 func (cms Store) GetStoreType() types.StoreType {
 	return types.StoreTypeMulti
@@ -135,10 +153,16 @@ func (cms Store) CacheMultiStoreWithVersion(_ int64) (types.CacheMultiStore, err
 
 // GetStore returns an underlying Store by key.
 func (cms Store) GetStore(key types.StoreKey) types.Store {
+	if cms.ListeningEnabled(key) {
+		return listenkv.NewStore(cms.stores[key].(types.KVStore), key, cms.listeners[key])
+	}
 	return cms.stores[key].(types.Store)
 }
 
 // GetKVStore returns an underlying KVStore by key.
 func (cms Store) GetKVStore(key types.StoreKey) types.KVStore {
+	if cms.ListeningEnabled(key) {
+		return listenkv.NewStore(cms.stores[key].(types.KVStore), key, cms.listeners[key])
+	}
 	return cms.stores[key].(types.KVStore)
 }
@@ -0,0 +1,91 @@
+package listenkv
+
+import (
+	"io"
+
+	"github.com/hdac-io/friday/store/cachekv"
+	"github.com/hdac-io/friday/store/tracekv"
+	"github.com/hdac-io/friday/store/types"
+)
+
+var _ types.KVStore = &Store{}
+
+// Store implements the KVStore interface with listening enabled.
+// Writes are delegated to the parent KVStore, then the listeners are
+// notified of the key, the previous and the new value of the write.
+type Store struct {
+	parent    types.KVStore
+	listeners []types.WriteListener
+	storeKey  types.StoreKey
+}
+
+// NewStore returns a reference to a new listenkv Store given a parent
+// KVStore implementation and the listeners of its writes.
+func NewStore(parent types.KVStore, storeKey types.StoreKey, listeners []types.WriteListener) *Store {
+	return &Store{parent: parent, listeners: listeners, storeKey: storeKey}
+}
+
+// Get implements the KVStore interface. It delegates the Get call to the
+// parent KVStore.
+func (s *Store) Get(key []byte) []byte {
+	return s.parent.Get(key)
+}
+
+// Set implements the KVStore interface. It delegates the Set call to the
+// parent KVStore and notifies the listeners of the write.
+func (s *Store) Set(key []byte, value []byte) {
+	types.AssertValidKey(key)
+	types.AssertValidValue(value)
+	oldValue := s.parent.Get(key)
+	s.parent.Set(key, value)
+	s.onWrite(key, oldValue, value, false)
+}
+
+// Delete implements the KVStore interface. It delegates the Delete call to
+// the parent KVStore and notifies the listeners of the delete.
+func (s *Store) Delete(key []byte) {
+	oldValue := s.parent.Get(key)
+	s.parent.Delete(key)
+	s.onWrite(key, oldValue, nil, true)
+}
+
+// Has implements the KVStore interface. It delegates the Has call to the
+// parent KVStore.
+func (s *Store) Has(key []byte) bool {
+	return s.parent.Has(key)
+}
+
+// Iterator implements the KVStore interface. It delegates the Iterator call
+// to the parent KVStore.
+func (s *Store) Iterator(start, end []byte) types.Iterator {
+	return s.parent.Iterator(start, end)
+}
+
+// ReverseIterator implements the KVStore interface. It delegates the
+// ReverseIterator call to the parent KVStore.
+func (s *Store) ReverseIterator(start, end []byte) types.Iterator {
+	return s.parent.ReverseIterator(start, end)
+}
+
+// GetStoreType implements the KVStore interface. It returns the underlying
+// KVStore type.
+func (s *Store) GetStoreType() types.StoreType {
+	return s.parent.GetStoreType()
+}
+
+// CacheWrap implements the KVStore interface. The writes of the cache-wrap
+// are notified when the cache-wrap is written.
+func (s *Store) CacheWrap() types.CacheWrap {
+	return cachekv.NewStore(s)
+}
+
+// CacheWrapWithTrace implements the KVStore interface.
+func (s *Store) CacheWrapWithTrace(w io.Writer, tc types.TraceContext) types.CacheWrap {
+	return cachekv.NewStore(tracekv.NewStore(s, w, tc))
+}
+
+func (s *Store) onWrite(key, oldValue, value []byte, delete bool) {
+	for _, l := range s.listeners {
+		l.OnWrite(s.storeKey, key, oldValue, value, delete)
+	}
+}
@@ -0,0 +1,78 @@
+package listenkv_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/hdac-io/friday/store/dbadapter"
+	"github.com/hdac-io/friday/store/listenkv"
+	"github.com/hdac-io/friday/store/types"
+)
+
+func bz(s string) []byte { return []byte(s) }
+
+var testStoreKey = types.NewKVStoreKey("listen_test")
+
+type write struct {
+	key, oldValue, value []byte
+	delete               bool
+}
+
+type mockListener struct {
+	writes []write
+}
+
+func (l *mockListener) OnWrite(storeKey types.StoreKey, key, oldValue, value []byte, delete bool) {
+	if storeKey != testStoreKey {
+		panic("unexpected store key")
+	}
+	l.writes = append(l.writes, write{key, oldValue, value, delete})
+}
+
+func newListenKVStore(listener types.WriteListener) *listenkv.Store {
+	memDB := dbadapter.Store{DB: dbm.NewMemDB()}
+	return listenkv.NewStore(memDB, testStoreKey, []types.WriteListener{listener})
+}
+
+func TestListenKVStoreSetDelete(t *testing.T) {
+	listener := &mockListener{}
+	store := newListenKVStore(listener)
+
+	store.Set(bz("key1"), bz("value1"))
+	store.Set(bz("key1"), bz("value2"))
+	store.Delete(bz("key1"))
+	require.Nil(t, store.Get(bz("key1")))
+
+	require.Equal(t, []write{
+		{bz("key1"), nil, bz("value1"), false},
+		{bz("key1"), bz("value1"), bz("value2"), false},
+		{bz("key1"), bz("value2"), nil, true},
+	}, listener.writes)
+
+	require.Panics(t, func() { store.Set(nil, bz("value")) })
+	require.Panics(t, func() { store.Set(bz("key"), nil) })
+}
+
+func TestListenKVStoreCacheWrap(t *testing.T) {
+	listener := &mockListener{}
+	store := newListenKVStore(listener)
+	store.Set(bz("key1"), bz("value1"))
+	listener.writes = nil
+
+	cache := store.CacheWrap().(types.KVStore)
+	cache.Set(bz("key1"), bz("value2"))
+	cache.Set(bz("key2"), bz("value2"))
+	cache.Set(bz("key2"), bz("value3"))
+	require.Empty(t, listener.writes)
+
+	// the writes of the cache-wrap are notified when it's written
+	cache.(types.CacheKVStore).Write()
+	require.Equal(t, []write{
+		{bz("key1"), bz("value1"), bz("value2"), false},
+		{bz("key2"), nil, bz("value3"), false},
+	}, listener.writes)
+	require.Equal(t, bz("value3"), store.Get(bz("key2")))
+}
@@ -0,0 +1,227 @@
+package rootmulti
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/hdac-io/tendermint/crypto/tmhash"
+	amino "github.com/tendermint/go-amino"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/hdac-io/friday/store/types"
+)
+
+// Key prefixes of the IAVL node db, see github.com/hdac-io/iavl/nodedb.go
+const (
+	iavlNodePrefix = 'n' // n<hash>
+	iavlRootPrefix = 'r' // r<version>
+)
+
+// SnapshotItemFunc receives a raw db entry of a snapshot
+type SnapshotItemFunc func(key, value []byte) error
+
+// ExportSnapshot calls fn with every db entry needed to load the multistore at the version:
+// the commit info of the version, and the root and the nodes reachable from it of each IAVL store.
+// Older versions, orphans and nodes of other versions are left out.
+func ExportSnapshot(db dbm.DB, version int64, fn SnapshotItemFunc) (types.CommitID, error) {
+	cInfo, err := getCommitInfo(db, version)
+	if err != nil {
+		return types.CommitID{}, err
+	}
+
+	cInfoKey := []byte(fmt.Sprintf(commitInfoKeyFmt, version))
+	if err := fn(cInfoKey, db.Get(cInfoKey)); err != nil {
+		return types.CommitID{}, err
+	}
+	latestBytes, _ := cdc.MarshalBinaryLengthPrefixed(version)
+	if err := fn([]byte(latestVersionKey), latestBytes); err != nil {
+		return types.CommitID{}, err
+	}
+
+	for _, si := range cInfo.StoreInfos {
+		prefix := []byte("s/k:" + si.Name + "/")
+		storeDB := dbm.NewPrefixDB(db, prefix)
+		prefixedFn := func(key, value []byte) error {
+			return fn(append(append([]byte{}, prefix...), key...), value)
+		}
+
+		rootKey := iavlRootKey(version)
+		rootHash := storeDB.Get(rootKey)
+		if rootHash == nil {
+			// not an IAVL store, every entry is needed
+			if err := exportAll(storeDB, prefixedFn); err != nil {
+				return types.CommitID{}, err
+			}
+			continue
+		}
+
+		if err := prefixedFn(rootKey, rootHash); err != nil {
+			return types.CommitID{}, err
+		}
+		if err := walkIAVLNodes(storeDB, rootHash, prefixedFn); err != nil {
+			return types.CommitID{}, fmt.Errorf("store %s: %v", si.Name, err)
+		}
+	}
+
+	return cInfo.CommitID(), nil
+}
+
+// VerifySnapshot checks the multistore at the version imported from a snapshot.
+// The hash of every IAVL node is recomputed from its content, so the returned
+// commit ID can be trusted to be the one of the exported multistore.
+func VerifySnapshot(db dbm.DB, version int64) (types.CommitID, error) {
+	if latest := getLatestVersion(db); latest != version {
+		return types.CommitID{}, fmt.Errorf("latest version %d is not the snapshot version %d", latest, version)
+	}
+
+	cInfo, err := getCommitInfo(db, version)
+	if err != nil {
+		return types.CommitID{}, err
+	}
+
+	for _, si := range cInfo.StoreInfos {
+		storeDB := dbm.NewPrefixDB(db, []byte("s/k:"+si.Name+"/"))
+
+		rootHash := storeDB.Get(iavlRootKey(version))
+		if rootHash == nil {
+			continue
+		}
+		if !bytes.Equal(rootHash, si.Core.CommitID.Hash) && !(len(rootHash) == 0 && len(si.Core.CommitID.Hash) == 0) {
+			return types.CommitID{}, fmt.Errorf("store %s: root hash %X does not match the commit info %X",
+				si.Name, rootHash, si.Core.CommitID.Hash)
+		}
+		if err := walkIAVLNodes(storeDB, rootHash, func(key, value []byte) error {
+			if key[0] != iavlNodePrefix {
+				return nil
+			}
+			hash, err := iavlNodeHash(value)
+			if err != nil {
+				return err
+			}
+			if !bytes.Equal(hash, key[1:]) {
+				return fmt.Errorf("node %X has the hash %X", key[1:], hash)
+			}
+			return nil
+		}); err != nil {
+			return types.CommitID{}, fmt.Errorf("store %s: %v", si.Name, err)
+		}
+	}
+
+	return cInfo.CommitID(), nil
+}
+
+func exportAll(db dbm.DB, fn SnapshotItemFunc) error {
+	iter := db.Iterator(nil, nil)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		if err := fn(iter.Key(), iter.Value()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// walkIAVLNodes calls fn with every node reachable from the root
+func walkIAVLNodes(db dbm.DB, rootHash []byte, fn SnapshotItemFunc) error {
+	if len(rootHash) == 0 {
+		return nil
+	}
+
+	stack := [][]byte{rootHash}
+	for len(stack) > 0 {
+		hash := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		key := append([]byte{iavlNodePrefix}, hash...)
+		value := db.Get(key)
+		if value == nil {
+			return fmt.Errorf("node %X not found", hash)
+		}
+		if err := fn(key, value); err != nil {
+			return err
+		}
+
+		node, err := decodeIAVLNode(value)
+		if err != nil {
+			return fmt.Errorf("node %X: %v", hash, err)
+		}
+		if node.height > 0 {
+			stack = append(stack, node.rightHash, node.leftHash)
+		}
+	}
+
+	return nil
+}
+
+type iavlNode struct {
+	height    int8
+	size      int64
+	version   int64
+	key       []byte
+	value     []byte
+	leftHash  []byte
+	rightHash []byte
+}
+
+// decodeIAVLNode decodes a node in the format of iavl.MakeNode
+func decodeIAVLNode(buf []byte) (node iavlNode, err error) {
+	var n int
+	if node.height, n, err = amino.DecodeInt8(buf); err != nil {
+		return node, err
+	}
+	buf = buf[n:]
+	if node.size, n, err = amino.DecodeVarint(buf); err != nil {
+		return node, err
+	}
+	buf = buf[n:]
+	if node.version, n, err = amino.DecodeVarint(buf); err != nil {
+		return node, err
+	}
+	buf = buf[n:]
+	if node.key, n, err = amino.DecodeByteSlice(buf); err != nil {
+		return node, err
+	}
+	buf = buf[n:]
+
+	if node.height == 0 {
+		node.value, _, err = amino.DecodeByteSlice(buf)
+		return node, err
+	}
+
+	if node.leftHash, n, err = amino.DecodeByteSlice(buf); err != nil {
+		return node, err
+	}
+	buf = buf[n:]
+	node.rightHash, _, err = amino.DecodeByteSlice(buf)
+	return node, err
+}
+
+// iavlNodeHash computes the hash of a serialized node as iavl does
+func iavlNodeHash(buf []byte) ([]byte, error) {
+	node, err := decodeIAVLNode(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	w := new(bytes.Buffer)
+	_ = amino.EncodeInt8(w, node.height)
+	_ = amino.EncodeVarint(w, node.size)
+	_ = amino.EncodeVarint(w, node.version)
+	if node.height == 0 {
+		_ = amino.EncodeByteSlice(w, node.key)
+		_ = amino.EncodeByteSlice(w, tmhash.Sum(node.value))
+	} else {
+		_ = amino.EncodeByteSlice(w, node.leftHash)
+		_ = amino.EncodeByteSlice(w, node.rightHash)
+	}
+
+	return tmhash.Sum(w.Bytes()), nil
+}
+
+func iavlRootKey(version int64) []byte {
+	key := make([]byte, 9)
+	key[0] = iavlRootPrefix
+	binary.BigEndian.PutUint64(key[1:], uint64(version))
+	return key
+}
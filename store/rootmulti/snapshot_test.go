@@ -0,0 +1,86 @@
+package rootmulti
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/hdac-io/friday/store/types"
+)
+
+func TestSnapshotExportImport(t *testing.T) {
+	db := dbm.NewMemDB()
+	ms := newMultiStoreWithMounts(db)
+	require.NoError(t, ms.LoadLatestVersion())
+
+	var cID types.CommitID
+	for i := 0; i < 5; i++ {
+		store1 := ms.getStoreByName("store1").(types.KVStore)
+		store2 := ms.getStoreByName("store2").(types.KVStore)
+		for j := 0; j < 20; j++ {
+			store1.Set([]byte(fmt.Sprintf("key%d", j)), []byte(fmt.Sprintf("value%d-%d", i, j)))
+		}
+		store2.Set([]byte(fmt.Sprintf("key%d", i)), []byte("value"))
+		cID = ms.Commit()
+	}
+
+	// store3 is left empty
+	snapshot := map[string][]byte{}
+	exported, err := ExportSnapshot(db, cID.Version, func(key, value []byte) error {
+		snapshot[string(key)] = value
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, cID, exported)
+
+	newDB := dbm.NewMemDB()
+	for key, value := range snapshot {
+		newDB.Set([]byte(key), value)
+	}
+
+	verified, err := VerifySnapshot(newDB, cID.Version)
+	require.NoError(t, err)
+	require.Equal(t, cID, verified)
+
+	restored := newMultiStoreWithMounts(newDB)
+	require.NoError(t, restored.LoadLatestVersion())
+	require.Equal(t, cID, restored.LastCommitID())
+	store1 := restored.getStoreByName("store1").(types.KVStore)
+	require.Equal(t, []byte("value4-7"), store1.Get([]byte("key7")))
+	store2 := restored.getStoreByName("store2").(types.KVStore)
+	require.Equal(t, []byte("value"), store2.Get([]byte("key0")))
+
+	// the restored store keeps committing
+	store1.Set([]byte("key7"), []byte("new"))
+	require.Equal(t, cID.Version+1, restored.Commit().Version)
+
+	_, err = ExportSnapshot(db, cID.Version+1, func(key, value []byte) error { return nil })
+	require.Error(t, err)
+}
+
+func TestSnapshotVerifyTampered(t *testing.T) {
+	db := dbm.NewMemDB()
+	ms := newMultiStoreWithMounts(db)
+	require.NoError(t, ms.LoadLatestVersion())
+
+	store1 := ms.getStoreByName("store1").(types.KVStore)
+	store1.Set([]byte("wind"), []byte("blows"))
+	store1.Set([]byte("water"), []byte("flows"))
+	cID := ms.Commit()
+
+	newDB := dbm.NewMemDB()
+	_, err := ExportSnapshot(db, cID.Version, func(key, value []byte) error {
+		if node, err := decodeIAVLNode(value); err == nil && node.height == 0 && string(node.key) == "wind" {
+			value = append([]byte{}, value...)
+			value[len(value)-1] = 'X'
+		}
+		newDB.Set(key, value)
+		return nil
+	})
+	require.NoError(t, err)
+
+	_, err = VerifySnapshot(newDB, cID.Version)
+	require.Error(t, err)
+}
@@ -0,0 +1,88 @@
+package streaming
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+// Encodings of the state changes written to the sinks
+const (
+	// EncodingJSON is the amino JSON of BlockChanges
+	EncodingJSON = "json"
+	// EncodingProto is the protobuf binary of BlockChanges, as in changes.proto
+	EncodingProto = "proto"
+)
+
+// KVChange is a write to a store
+type KVChange struct {
+	StoreKey string `json:"store_key"`
+	Key      []byte `json:"key"`
+	OldValue []byte `json:"old_value,omitempty"`
+	NewValue []byte `json:"new_value,omitempty"`
+	Delete   bool   `json:"delete,omitempty"`
+}
+
+// TxChanges is the state changes of a tx of a block
+type TxChanges struct {
+	Index   uint32     `json:"index"`
+	Hash    []byte     `json:"hash"`
+	Code    uint32     `json:"code"`
+	Changes []KVChange `json:"changes"`
+}
+
+// BlockChanges is the state changes of a committed block, grouped by the ABCI phases
+type BlockChanges struct {
+	Height     int64       `json:"height"`
+	Time       time.Time   `json:"time"`
+	AppHash    []byte      `json:"app_hash"`
+	InitChain  []KVChange  `json:"init_chain,omitempty"`
+	BeginBlock []KVChange  `json:"begin_block"`
+	Txs        []TxChanges `json:"txs"`
+	EndBlock   []KVChange  `json:"end_block"`
+}
+
+// EncodeBlockChanges encodes the changes in the encoding, prefixed with the uvarint length
+// of the encoded changes
+func EncodeBlockChanges(encoding string, changes BlockChanges) ([]byte, error) {
+	var bz []byte
+	var err error
+	switch encoding {
+	case EncodingJSON:
+		bz, err = cdc.MarshalJSON(changes)
+	case EncodingProto:
+		bz, err = cdc.MarshalBinaryBare(changes)
+	default:
+		return nil, fmt.Errorf("unknown encoding %s", encoding)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutUvarint(prefix, uint64(len(bz)))
+	return append(prefix[:n], bz...), nil
+}
+
+// DecodeBlockChanges decodes the changes encoded by EncodeBlockChanges, and returns
+// the number of the bytes read
+func DecodeBlockChanges(encoding string, bz []byte) (changes BlockChanges, n int, err error) {
+	size, prefixLen := binary.Uvarint(bz)
+	if prefixLen <= 0 {
+		return changes, 0, fmt.Errorf("invalid length prefix")
+	}
+	n = prefixLen + int(size)
+	if len(bz) < n {
+		return changes, 0, fmt.Errorf("expected %d bytes, got %d", n, len(bz))
+	}
+
+	switch encoding {
+	case EncodingJSON:
+		err = cdc.UnmarshalJSON(bz[prefixLen:n], &changes)
+	case EncodingProto:
+		err = cdc.UnmarshalBinaryBare(bz[prefixLen:n], &changes)
+	default:
+		err = fmt.Errorf("unknown encoding %s", encoding)
+	}
+	return changes, n, err
+}
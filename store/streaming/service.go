@@ -0,0 +1,254 @@
+package streaming
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	abci "github.com/hdac-io/tendermint/abci/types"
+	"github.com/hdac-io/tendermint/crypto/tmhash"
+	"github.com/hdac-io/tendermint/libs/log"
+
+	"github.com/hdac-io/friday/store/types"
+)
+
+// Policies when the buffer of the blocks waiting for the sinks is full
+const (
+	// OnFullBlock waits for the sinks, holding up the node until there is room in the buffer
+	OnFullBlock = "block"
+	// OnFullDrop drops the state changes of the block
+	OnFullDrop = "drop"
+)
+
+// Config is the configuration of a Service
+type Config struct {
+	// Sinks of the state changes, "file:<path>" or "unix:<socket path>"
+	Sinks []string
+	// Stores whose changes are streamed by name, every store if empty
+	Stores []string
+	// Encoding of the state changes, "json" or "proto"
+	Encoding string
+	// BufferSize is the number of blocks which can wait for the sinks
+	BufferSize int
+	// OnFull is the policy when the buffer is full, "block" or "drop"
+	OnFull string
+}
+
+// DefaultConfig returns the default configuration without any sink
+func DefaultConfig() Config {
+	return Config{
+		Encoding:   EncodingJSON,
+		BufferSize: 100,
+		OnFull:     OnFullBlock,
+	}
+}
+
+// Validate checks the configuration
+func (c Config) Validate() error {
+	if len(c.Sinks) == 0 {
+		return fmt.Errorf("no sink of the state changes")
+	}
+	if c.Encoding != EncodingJSON && c.Encoding != EncodingProto {
+		return fmt.Errorf("unknown encoding %q, expected %s or %s", c.Encoding, EncodingJSON, EncodingProto)
+	}
+	if c.BufferSize < 0 {
+		return fmt.Errorf("negative buffer size %d", c.BufferSize)
+	}
+	if c.OnFull != OnFullBlock && c.OnFull != OnFullDrop {
+		return fmt.Errorf("unknown policy %q when the buffer is full, expected %s or %s", c.OnFull, OnFullBlock, OnFullDrop)
+	}
+	return nil
+}
+
+// Service collects the writes to the stores by the ABCI phases of the blocks, and writes
+// the state changes of each committed block to the sinks.
+// The sinks are written in the background, with up to the buffer size of blocks waiting.
+type Service struct {
+	config Config
+	stores map[string]bool
+	sinks  []Sink
+	logger log.Logger
+
+	// changes since the last ABCI phase, and of the block being executed
+	changes []KVChange
+	block   BlockChanges
+
+	queue   chan BlockChanges
+	done    chan struct{}
+	dropped uint64
+}
+
+var _ types.WriteListener = (*Service)(nil)
+
+// NewService creates the sinks of the configuration and starts writing to them
+func NewService(config Config, logger log.Logger) (*Service, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	sinks := make([]Sink, 0, len(config.Sinks))
+	for _, spec := range config.Sinks {
+		sink, err := NewSink(spec)
+		if err != nil {
+			for _, s := range sinks {
+				s.Close()
+			}
+			return nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+
+	return newService(config, sinks, logger), nil
+}
+
+func newService(config Config, sinks []Sink, logger log.Logger) *Service {
+	stores := make(map[string]bool, len(config.Stores))
+	for _, name := range config.Stores {
+		stores[name] = true
+	}
+
+	s := &Service{
+		config: config,
+		stores: stores,
+		sinks:  sinks,
+		logger: logger.With("module", "state-listeners"),
+		queue:  make(chan BlockChanges, config.BufferSize),
+		done:   make(chan struct{}),
+	}
+	go s.writeSinks()
+
+	return s
+}
+
+// Listeners returns the Service as the listener of the store of the key,
+// if the store is streamed
+func (s *Service) Listeners(key types.StoreKey) []types.WriteListener {
+	if len(s.stores) != 0 && !s.stores[key.Name()] {
+		return nil
+	}
+	return []types.WriteListener{s}
+}
+
+// OnWrite implements WriteListener
+func (s *Service) OnWrite(storeKey types.StoreKey, key, oldValue, value []byte, delete bool) {
+	s.changes = append(s.changes, KVChange{
+		StoreKey: storeKey.Name(),
+		Key:      copyBytes(key),
+		OldValue: copyBytes(oldValue),
+		NewValue: copyBytes(value),
+		Delete:   delete,
+	})
+}
+
+// ListenInitChain takes the changes of InitChain, which are committed with the first block
+func (s *Service) ListenInitChain(req abci.RequestInitChain, res abci.ResponseInitChain) {
+	s.block.InitChain = s.takeChanges()
+}
+
+// ListenBeginBlock takes the changes of BeginBlock
+func (s *Service) ListenBeginBlock(req abci.RequestBeginBlock, res abci.ResponseBeginBlock) {
+	s.block.Height = req.Header.Height
+	s.block.Time = req.Header.Time
+	s.block.BeginBlock = s.takeChanges()
+}
+
+// ListenDeliverTx takes the changes of a tx
+func (s *Service) ListenDeliverTx(req abci.RequestDeliverTx, res abci.ResponseDeliverTx) {
+	s.block.Txs = append(s.block.Txs, TxChanges{
+		Index:   uint32(len(s.block.Txs)),
+		Hash:    tmhash.Sum(req.Tx),
+		Code:    res.Code,
+		Changes: s.takeChanges(),
+	})
+}
+
+// ListenEndBlock takes the changes of EndBlock
+func (s *Service) ListenEndBlock(req abci.RequestEndBlock, res abci.ResponseEndBlock) {
+	s.block.EndBlock = s.takeChanges()
+}
+
+// ListenCommit queues the changes of the committed block for the sinks
+func (s *Service) ListenCommit(res abci.ResponseCommit) {
+	block := s.block
+	block.AppHash = res.Data
+	s.block = BlockChanges{}
+
+	select {
+	case s.queue <- block:
+		return
+	default:
+	}
+
+	if s.config.OnFull == OnFullDrop {
+		atomic.AddUint64(&s.dropped, 1)
+		s.logger.Error("state listener buffer is full, dropping the state changes", "height", block.Height)
+		return
+	}
+
+	s.logger.Info("state listener buffer is full, waiting for the sinks", "height", block.Height)
+	s.queue <- block
+}
+
+// Dropped returns the number of the blocks whose changes were dropped as the buffer was full
+func (s *Service) Dropped() uint64 {
+	return atomic.LoadUint64(&s.dropped)
+}
+
+// closeTimeout is how long Close waits for the sinks to take the queued changes
+const closeTimeout = 10 * time.Second
+
+// Close writes the queued changes to the sinks and closes them. The sinks which take no
+// changes within the timeout are closed, and the changes left are dropped.
+func (s *Service) Close() error {
+	close(s.queue)
+	select {
+	case <-s.done:
+	case <-time.After(closeTimeout):
+		s.logger.Error("sinks took no state changes before the timeout, dropping the queued changes",
+			"blocks", len(s.queue))
+		for _, sink := range s.sinks {
+			sink.Close()
+		}
+		<-s.done
+	}
+
+	var err error
+	for _, sink := range s.sinks {
+		if closeErr := sink.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+func (s *Service) writeSinks() {
+	defer close(s.done)
+
+	for block := range s.queue {
+		bz, err := EncodeBlockChanges(s.config.Encoding, block)
+		if err != nil {
+			s.logger.Error("failed to encode the state changes", "height", block.Height, "err", err)
+			continue
+		}
+
+		for i, sink := range s.sinks {
+			if err := sink.Write(bz); err != nil {
+				s.logger.Error("failed to write the state changes",
+					"sink", s.config.Sinks[i], "height", block.Height, "err", err)
+			}
+		}
+	}
+}
+
+func (s *Service) takeChanges() []KVChange {
+	changes := s.changes
+	s.changes = nil
+	return changes
+}
+
+func copyBytes(bz []byte) []byte {
+	if bz == nil {
+		return nil
+	}
+	return append([]byte{}, bz...)
+}
@@ -0,0 +1,246 @@
+package streaming
+
+import (
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/hdac-io/tendermint/abci/types"
+	"github.com/hdac-io/tendermint/crypto/tmhash"
+	"github.com/hdac-io/tendermint/libs/log"
+
+	"github.com/hdac-io/friday/store/types"
+)
+
+var (
+	testKeyA = types.NewKVStoreKey("a")
+	testKeyB = types.NewKVStoreKey("b")
+)
+
+func TestEncodeDecodeBlockChanges(t *testing.T) {
+	changes := BlockChanges{
+		Height:     3,
+		Time:       time.Unix(100, 0).UTC(),
+		AppHash:    []byte("apphash"),
+		BeginBlock: []KVChange{{StoreKey: "a", Key: []byte("k"), NewValue: []byte("v")}},
+		Txs: []TxChanges{{
+			Index: 0, Hash: []byte("hash"), Code: 1,
+			Changes: []KVChange{{StoreKey: "a", Key: []byte("k"), OldValue: []byte("v"), Delete: true}},
+		}},
+	}
+
+	for _, encoding := range []string{EncodingJSON, EncodingProto} {
+		bz, err := EncodeBlockChanges(encoding, changes)
+		require.NoError(t, err)
+
+		// two blocks written back to back are read one by one
+		stream := append(append([]byte{}, bz...), bz...)
+		decoded, n, err := DecodeBlockChanges(encoding, stream)
+		require.NoError(t, err)
+		require.Equal(t, len(bz), n)
+		require.Equal(t, changes, decoded)
+
+		_, _, err = DecodeBlockChanges(encoding, bz[:len(bz)-1])
+		require.Error(t, err)
+	}
+
+	_, err := EncodeBlockChanges("xml", changes)
+	require.Error(t, err)
+}
+
+func TestConfigValidate(t *testing.T) {
+	config := DefaultConfig()
+	require.Error(t, config.Validate())
+
+	config.Sinks = []string{"file:changes"}
+	require.NoError(t, config.Validate())
+
+	invalid := config
+	invalid.Encoding = "xml"
+	require.Error(t, invalid.Validate())
+
+	invalid = config
+	invalid.BufferSize = -1
+	require.Error(t, invalid.Validate())
+
+	invalid = config
+	invalid.OnFull = "retry"
+	require.Error(t, invalid.Validate())
+
+	_, err := NewSink("tcp:localhost:26657")
+	require.Error(t, err)
+	_, err = NewSink("file:")
+	require.Error(t, err)
+}
+
+func TestServiceFileSink(t *testing.T) {
+	dir, err := ioutil.TempDir("", "streaming")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	for _, encoding := range []string{EncodingJSON, EncodingProto} {
+		path := filepath.Join(dir, "changes."+encoding)
+		config := DefaultConfig()
+		config.Sinks = []string{SinkFile + ":" + path}
+		config.Stores = []string{testKeyA.Name()}
+		config.Encoding = encoding
+
+		s, err := NewService(config, log.NewNopLogger())
+		require.NoError(t, err)
+		require.Len(t, s.Listeners(testKeyA), 1)
+		require.Empty(t, s.Listeners(testKeyB))
+
+		s.OnWrite(testKeyA, []byte("genesis"), nil, []byte("1"), false)
+		s.ListenInitChain(abci.RequestInitChain{}, abci.ResponseInitChain{})
+		s.OnWrite(testKeyA, []byte("begin"), nil, []byte("1"), false)
+		s.ListenBeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}}, abci.ResponseBeginBlock{})
+		s.OnWrite(testKeyA, []byte("tx"), []byte("0"), []byte("1"), false)
+		s.ListenDeliverTx(abci.RequestDeliverTx{Tx: []byte("tx0")}, abci.ResponseDeliverTx{})
+		s.ListenDeliverTx(abci.RequestDeliverTx{Tx: []byte("tx1")}, abci.ResponseDeliverTx{Code: 5})
+		s.OnWrite(testKeyA, []byte("end"), []byte("1"), nil, true)
+		s.ListenEndBlock(abci.RequestEndBlock{}, abci.ResponseEndBlock{})
+		s.ListenCommit(abci.ResponseCommit{Data: []byte("apphash1")})
+
+		s.ListenBeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}}, abci.ResponseBeginBlock{})
+		s.ListenEndBlock(abci.RequestEndBlock{}, abci.ResponseEndBlock{})
+		s.ListenCommit(abci.ResponseCommit{Data: []byte("apphash2")})
+		require.NoError(t, s.Close())
+
+		bz, err := ioutil.ReadFile(path)
+		require.NoError(t, err)
+
+		block1, n, err := DecodeBlockChanges(encoding, bz)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), block1.Height)
+		require.Equal(t, []byte("apphash1"), block1.AppHash)
+		require.Equal(t, []KVChange{{StoreKey: "a", Key: []byte("genesis"), NewValue: []byte("1")}}, block1.InitChain)
+		require.Equal(t, []KVChange{{StoreKey: "a", Key: []byte("begin"), NewValue: []byte("1")}}, block1.BeginBlock)
+		require.Len(t, block1.Txs, 2)
+		require.Equal(t, uint32(0), block1.Txs[0].Index)
+		require.Equal(t, tmhash.Sum([]byte("tx0")), block1.Txs[0].Hash)
+		require.Equal(t, []KVChange{{StoreKey: "a", Key: []byte("tx"), OldValue: []byte("0"), NewValue: []byte("1")}}, block1.Txs[0].Changes)
+		require.Equal(t, uint32(1), block1.Txs[1].Index)
+		require.Equal(t, uint32(5), block1.Txs[1].Code)
+		require.Empty(t, block1.Txs[1].Changes)
+		require.Equal(t, []KVChange{{StoreKey: "a", Key: []byte("end"), OldValue: []byte("1"), Delete: true}}, block1.EndBlock)
+
+		block2, m, err := DecodeBlockChanges(encoding, bz[n:])
+		require.NoError(t, err)
+		require.Equal(t, len(bz), n+m)
+		require.Equal(t, int64(2), block2.Height)
+		require.Equal(t, []byte("apphash2"), block2.AppHash)
+		require.Empty(t, block2.InitChain)
+		require.Empty(t, block2.Txs)
+	}
+}
+
+// blockingSink blocks each write until it's released
+type blockingSink struct {
+	writing chan int64
+	release chan struct{}
+	written []int64
+}
+
+func (s *blockingSink) Write(bz []byte) error {
+	block, _, err := DecodeBlockChanges(EncodingJSON, bz)
+	if err != nil {
+		return err
+	}
+	s.writing <- block.Height
+	<-s.release
+	s.written = append(s.written, block.Height)
+	return nil
+}
+
+func (s *blockingSink) Close() error { return nil }
+
+func TestServiceDropWhenFull(t *testing.T) {
+	sink := &blockingSink{writing: make(chan int64, 10), release: make(chan struct{})}
+	config := DefaultConfig()
+	config.Sinks = []string{"test"}
+	config.BufferSize = 1
+	config.OnFull = OnFullDrop
+	s := newService(config, []Sink{sink}, log.NewNopLogger())
+
+	commit := func(height int64) {
+		s.ListenBeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: height}}, abci.ResponseBeginBlock{})
+		s.ListenEndBlock(abci.RequestEndBlock{}, abci.ResponseEndBlock{})
+		s.ListenCommit(abci.ResponseCommit{})
+	}
+
+	// the first block is being written, the second waits in the buffer and the third is dropped
+	commit(1)
+	require.Equal(t, int64(1), <-sink.writing)
+	commit(2)
+	commit(3)
+	require.Equal(t, uint64(1), s.Dropped())
+
+	close(sink.release)
+	require.NoError(t, s.Close())
+	require.Equal(t, []int64{1, 2}, sink.written)
+}
+
+func TestUnixSocketSinkReconnect(t *testing.T) {
+	dir, err := ioutil.TempDir("", "streaming")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "sink.sock")
+	listener, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	defer listener.Close()
+
+	// the first reader disconnects after a block, and the second one reads the rest
+	disconnected := make(chan struct{})
+	received := make(chan []byte, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		bz := make([]byte, 3)
+		_, _ = io.ReadFull(conn, bz)
+		conn.Close()
+		close(disconnected)
+
+		conn, err = listener.Accept()
+		if err != nil {
+			return
+		}
+		bz, _ = ioutil.ReadAll(conn)
+		received <- bz
+	}()
+
+	sink := NewUnixSocketSink(path)
+	require.NoError(t, sink.Write([]byte("abc")))
+	<-disconnected
+	require.NoError(t, sink.Write([]byte("def")))
+	require.NoError(t, sink.Write([]byte("ghi")))
+	require.NoError(t, sink.Close())
+	require.Equal(t, []byte("defghi"), <-received)
+}
+
+func TestUnixSocketSinkClose(t *testing.T) {
+	dir, err := ioutil.TempDir("", "streaming")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// a write to a socket without a reader waits until the sink is closed
+	sink := NewUnixSocketSink(filepath.Join(dir, "sink.sock"))
+	written := make(chan error)
+	go func() { written <- sink.Write([]byte("abc")) }()
+
+	select {
+	case err := <-written:
+		t.Fatalf("write returned without a reader: %v", err)
+	case <-time.After(300 * time.Millisecond):
+	}
+	require.NoError(t, sink.Close())
+	require.Equal(t, errSinkClosed, <-written)
+}
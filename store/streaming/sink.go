@@ -0,0 +1,176 @@
+package streaming
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Schemes of the sink specs
+const (
+	SinkFile = "file"
+	SinkUnix = "unix"
+)
+
+// Sink receives the encoded state changes of the blocks
+type Sink interface {
+	// Write writes the length-prefixed state changes of a block.
+	Write(bz []byte) error
+	Close() error
+}
+
+// NewSink creates a sink from a spec of "file:<path>" or "unix:<socket path>"
+func NewSink(spec string) (Sink, error) {
+	parts := strings.SplitN(spec, ":", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return nil, fmt.Errorf("invalid sink %q, expected file:<path> or unix:<socket path>", spec)
+	}
+
+	switch parts[0] {
+	case SinkFile:
+		return NewFileSink(parts[1])
+	case SinkUnix:
+		return NewUnixSocketSink(parts[1]), nil
+	default:
+		return nil, fmt.Errorf("unknown sink %q, expected file:<path> or unix:<socket path>", spec)
+	}
+}
+
+// FileSink appends the state changes to a file
+type FileSink struct {
+	file *os.File
+}
+
+var _ Sink = (*FileSink)(nil)
+
+// NewFileSink opens the file to append the state changes to
+func NewFileSink(path string) (*FileSink, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &FileSink{file: file}, nil
+}
+
+// Write implements Sink
+func (s *FileSink) Write(bz []byte) error {
+	_, err := s.file.Write(bz)
+	return err
+}
+
+// Close implements Sink
+func (s *FileSink) Close() error {
+	return s.file.Close()
+}
+
+// UnixSocketSink writes the state changes to a unix socket. The socket is dialed on the first
+// write. A block whose write fails is written whole again over a new connection, retrying until
+// it's written or the sink is closed, so a reader which disconnects holds up the sinks, and then
+// the node as the buffer of the service fills, rather than missing blocks. The reader of a broken
+// connection discards the partial block it read, as the new connection starts with the block.
+// A block fully written into the socket buffer before the reader disconnects is not sent again.
+type UnixSocketSink struct {
+	path string
+
+	mtx       sync.Mutex
+	conn      net.Conn
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+var _ Sink = (*UnixSocketSink)(nil)
+
+const (
+	unixSocketDialTimeout = 5 * time.Second
+	unixSocketMinRetry    = 100 * time.Millisecond
+	unixSocketMaxRetry    = 5 * time.Second
+)
+
+var errSinkClosed = errors.New("sink is closed")
+
+// NewUnixSocketSink returns a sink of the unix socket of the path
+func NewUnixSocketSink(path string) *UnixSocketSink {
+	return &UnixSocketSink{path: path, closed: make(chan struct{})}
+}
+
+// Write implements Sink. It returns once the block is written, or with an error once the sink
+// is closed.
+func (s *UnixSocketSink) Write(bz []byte) error {
+	retry := time.Duration(0)
+	for {
+		select {
+		case <-s.closed:
+			return errSinkClosed
+		case <-time.After(retry):
+		}
+
+		if err := s.write(bz); err == nil || err == errSinkClosed {
+			return err
+		}
+		if retry *= 2; retry < unixSocketMinRetry {
+			retry = unixSocketMinRetry
+		} else if retry > unixSocketMaxRetry {
+			retry = unixSocketMaxRetry
+		}
+	}
+}
+
+func (s *UnixSocketSink) write(bz []byte) error {
+	conn, err := s.connect()
+	if err != nil {
+		return err
+	}
+	if _, err := conn.Write(bz); err != nil {
+		s.disconnect(conn)
+		return err
+	}
+	return nil
+}
+
+func (s *UnixSocketSink) connect() (net.Conn, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	select {
+	case <-s.closed:
+		return nil, errSinkClosed
+	default:
+	}
+	if s.conn == nil {
+		conn, err := net.DialTimeout("unix", s.path, unixSocketDialTimeout)
+		if err != nil {
+			return nil, err
+		}
+		s.conn = conn
+	}
+	return s.conn, nil
+}
+
+func (s *UnixSocketSink) disconnect(conn net.Conn) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	conn.Close()
+	if s.conn == conn {
+		s.conn = nil
+	}
+}
+
+// Close implements Sink. It interrupts a write in progress.
+func (s *UnixSocketSink) Close() error {
+	s.closeOnce.Do(func() { close(s.closed) })
+
+	s.mtx.Lock()
+	conn := s.conn
+	s.conn = nil
+	s.mtx.Unlock()
+
+	if conn == nil {
+		return nil
+	}
+	return conn.Close()
+}
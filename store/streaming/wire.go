@@ -0,0 +1,7 @@
+package streaming
+
+import (
+	"github.com/hdac-io/friday/codec"
+)
+
+var cdc = codec.New()
@@ -0,0 +1,8 @@
+package types
+
+// WriteListener is notified of the writes to a KVStore.
+type WriteListener interface {
+	// OnWrite is called after the key is set or deleted, with the value of the
+	// key before the write. The value is nil if delete is true.
+	OnWrite(storeKey StoreKey, key, oldValue, value []byte, delete bool)
+}
@@ -99,6 +99,18 @@ type CommitMultiStore interface {
 	// must be idempotent (return the same commit id). Otherwise the behavior is
 	// undefined.
 	LoadVersion(ver int64) error
+
+	// AddListeners adds listeners of the writes to the store of the key.
+	AddListeners(key StoreKey, listeners []WriteListener)
+
+	// ListeningEnabled returns if the store of the key has listeners.
+	ListeningEnabled(key StoreKey) bool
+
+	// CacheMultiStoreWithListeners cache-wraps the MultiStore like
+	// CacheMultiStore, and notifies the listeners of the writes to the
+	// cache-wrapped stores. The writes of the cache-wrapped stores to the
+	// underlying stores are not notified.
+	CacheMultiStoreWithListeners() CacheMultiStore
 }
 
 //---------subsp-------------------------------
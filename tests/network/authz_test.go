@@ -0,0 +1,73 @@
+package network
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/hdac-io/friday/types"
+	"github.com/hdac-io/friday/x/executionlayer/types"
+)
+
+// TestExecChargesFailedMsgs checks a grant is charged for every msg of an exec, even when a later
+// msg fails after the deploys of the earlier ones are committed
+func TestExecChargesFailedMsgs(t *testing.T) {
+	n := New(t, DefaultConfig())
+	defer n.Cleanup()
+
+	granterName := n.Validators[0].Moniker
+	granter := n.Validators[0].Address
+	grantee, err := n.NewAccount("grantee")
+	require.NoError(t, err)
+	recipient, err := n.NewAccount("recipient")
+	require.NoError(t, err)
+
+	res, err := n.SendTransfer(granterName, grantee, "1000000000000000000")
+	require.NoError(t, err)
+	require.Equal(t, uint32(0), res.Code, res.RawLog)
+
+	balanceStr, err := n.QueryBalance(granter)
+	require.NoError(t, err)
+	balance, _ := new(big.Int).SetString(balanceStr, 10)
+	fee, _ := new(big.Int).SetString(n.Config.Fee, 10)
+	amount := big.NewInt(1000000000000000000)
+	limit := new(big.Int).Mul(balance, big.NewInt(2))
+
+	grant := types.NewMsgGrant(granter, grantee, []string{types.GrantMsgTypeTransfer}, limit.String(), time.Now().Add(time.Hour).UTC())
+	res, err = n.BroadcastMsgs(granterName, grant)
+	require.NoError(t, err)
+	require.Equal(t, uint32(0), res.Code, res.RawLog)
+
+	// each transfer passes the check of the tx on its own, but the second one runs out of the
+	// balance once the first one is committed
+	all := new(big.Int).Sub(balance, fee)
+	exec := types.NewMsgExec(grantee, []sdk.Msg{
+		types.NewMsgTransfer("transfer", granter, recipient, amount.String(), n.Config.Fee),
+		types.NewMsgTransfer("transfer", granter, recipient, all.String(), n.Config.Fee),
+	})
+	res, err = n.BroadcastMsgs("grantee", exec)
+	require.NoError(t, err)
+	require.NotEqual(t, uint32(0), res.Code)
+
+	// both msgs are charged to the grant, and the first transfer and both fees to the granter
+	charged, err := n.QueryGrant(granter, grantee)
+	require.NoError(t, err)
+	spent := new(big.Int).Add(new(big.Int).Add(amount, fee), new(big.Int).Add(all, fee))
+	require.Equal(t, new(big.Int).Sub(limit, spent).String(), charged.SpendLimit)
+
+	balanceStr, err = n.QueryBalance(granter)
+	require.NoError(t, err)
+	paid := new(big.Int).Add(amount, new(big.Int).Mul(fee, big.NewInt(2)))
+	require.Equal(t, new(big.Int).Sub(balance, paid).String(), balanceStr)
+
+	balanceStr, err = n.QueryBalance(recipient)
+	require.NoError(t, err)
+	require.Equal(t, amount.String(), balanceStr)
+
+	// an exec can't share its tx with other msgs
+	res, err = n.BroadcastMsgs("grantee", exec, types.NewMsgTransfer("transfer", grantee, recipient, "1", n.Config.Fee))
+	require.NoError(t, err)
+	require.Equal(t, uint32(types.CodeInvalidExec), res.Code, res.RawLog)
+}
@@ -0,0 +1,585 @@
+package network
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/casper/consensus/state"
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/ipc"
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/ipc/transforms"
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/storedvalue"
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/util"
+	"google.golang.org/grpc"
+
+	"github.com/hdac-io/friday/x/executionlayer/types"
+)
+
+// deployCost is the cost the stand-in execution engine reports for every deploy
+const deployCost = "1000"
+
+var (
+	proxyContractHash = util.Blake2b256([]byte(types.ProxyContractName))
+	mintURef          = util.Blake2b256([]byte("mint"))
+	posContractHash   = util.Blake2b256([]byte(types.PosContractName))
+)
+
+// eeState is a state of the stand-in execution engine. Amounts are keyed by hex encoded addresses,
+// delegations by "delegator_validator" and votes by "user_dapp".
+type eeState struct {
+	balances    map[string]*big.Int
+	delegations map[string]*big.Int
+	votes       map[string]*big.Int
+}
+
+func newEEState() eeState {
+	return eeState{
+		balances:    map[string]*big.Int{},
+		delegations: map[string]*big.Int{},
+		votes:       map[string]*big.Int{},
+	}
+}
+
+func (s eeState) clone() eeState {
+	c := newEEState()
+	for _, m := range []struct{ src, dst map[string]*big.Int }{
+		{s.balances, c.balances}, {s.delegations, c.delegations}, {s.votes, c.votes},
+	} {
+		for k, v := range m.src {
+			m.dst[k] = new(big.Int).Set(v)
+		}
+	}
+	return c
+}
+
+// hash returns the state hash, which is derived from the content of the state only
+func (s eeState) hash() []byte {
+	var sb strings.Builder
+	for _, m := range []struct {
+		prefix string
+		values map[string]*big.Int
+	}{{"b", s.balances}, {"d", s.delegations}, {"a", s.votes}} {
+		for _, k := range sortedKeys(m.values) {
+			fmt.Fprintf(&sb, "%s:%s=%s;", m.prefix, k, m.values[k])
+		}
+	}
+	return util.Blake2b256([]byte(sb.String()))
+}
+
+// stakes returns the stakes of the validators, the sums of the delegations to them
+func (s eeState) stakes() map[string]*big.Int {
+	stakes := map[string]*big.Int{}
+	for k, v := range s.delegations {
+		validator := strings.Split(k, "_")[1]
+		if _, ok := stakes[validator]; !ok {
+			stakes[validator] = new(big.Int)
+		}
+		stakes[validator].Add(stakes[validator], v)
+	}
+	return stakes
+}
+
+// sub subtracts amount from the value of key in m. Emptied delegations and votes are removed,
+// while an emptied balance keeps its account.
+func sub(m map[string]*big.Int, key string, amount *big.Int, prune bool) error {
+	current, ok := m[key]
+	if !ok || current.Cmp(amount) < 0 {
+		return fmt.Errorf("insufficient amount of %s", key)
+	}
+	current.Sub(current, amount)
+	if prune && current.Sign() == 0 {
+		delete(m, key)
+	}
+	return nil
+}
+
+func add(m map[string]*big.Int, key string, amount *big.Int) {
+	if _, ok := m[key]; !ok {
+		m[key] = new(big.Int)
+	}
+	m[key].Add(m[key], amount)
+}
+
+// StandInEE is a deterministic in-process execution engine serving the gRPC API of the
+// CasperLabs EE on a unix socket. It models the balances, the delegations and the votes
+// of the accounts; sessions of the proxy contract other than transfers, bonding and voting
+// only pay the fee, and the other stored contracts and wasm sessions fail.
+type StandInEE struct {
+	ipc.UnimplementedExecutionEngineServiceServer
+
+	mtx    sync.Mutex
+	states map[string]eeState
+
+	server *grpc.Server
+}
+
+// NewStandInEE starts a stand-in execution engine listening on the socket
+func NewStandInEE(socket string) (*StandInEE, error) {
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		return nil, err
+	}
+
+	ee := &StandInEE{
+		states: map[string]eeState{},
+		server: grpc.NewServer(),
+	}
+	ipc.RegisterExecutionEngineServiceServer(ee.server, ee)
+	go ee.server.Serve(listener) // nolint: errcheck
+	return ee, nil
+}
+
+// Stop stops serving the execution engine
+func (ee *StandInEE) Stop() {
+	ee.server.Stop()
+}
+
+func (ee *StandInEE) getState(stateHash []byte) (eeState, bool) {
+	ee.mtx.Lock()
+	defer ee.mtx.Unlock()
+	s, ok := ee.states[hex.EncodeToString(stateHash)]
+	return s, ok
+}
+
+func (ee *StandInEE) putState(s eeState) []byte {
+	ee.mtx.Lock()
+	defer ee.mtx.Unlock()
+	stateHash := s.hash()
+	ee.states[hex.EncodeToString(stateHash)] = s
+	return stateHash
+}
+
+// RunGenesis loads the balances of the genesis accounts, and the delegations and the votes of
+// the state infos
+func (ee *StandInEE) RunGenesis(_ context.Context, req *ipc.ChainSpec_GenesisConfig) (*ipc.GenesisResponse, error) {
+	s := newEEState()
+	for _, account := range req.GetAccounts() {
+		balance, ok := new(big.Int).SetString(account.GetBalance().GetValue(), 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid balance of %s", hex.EncodeToString(account.GetPublicKey()))
+		}
+		s.balances[hex.EncodeToString(account.GetPublicKey())] = balance
+	}
+	for _, info := range req.GetStateInfos() {
+		values := strings.Split(info, "_")
+		if len(values) != 4 {
+			continue
+		}
+		amount, ok := new(big.Int).SetString(values[3], 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid amount of %s", info)
+		}
+		switch values[0] {
+		case storedvalue.DELEGATE_PREFIX:
+			add(s.delegations, values[1]+"_"+values[2], amount)
+		case storedvalue.VOTE_PREFIX:
+			add(s.votes, values[1]+"_"+values[2], amount)
+		}
+	}
+
+	return &ipc.GenesisResponse{
+		Result: &ipc.GenesisResponse_Success{Success: &ipc.GenesisResult{
+			PoststateHash: ee.putState(s),
+			Effect:        &ipc.ExecutionEffect{},
+		}},
+	}, nil
+}
+
+// Execute runs the deploys one after another on the parent state. The effect of a deploy
+// refers to its post state, which Commit picks up.
+func (ee *StandInEE) Execute(_ context.Context, req *ipc.ExecuteRequest) (*ipc.ExecuteResponse, error) {
+	s, ok := ee.getState(req.GetParentStateHash())
+	if !ok {
+		return &ipc.ExecuteResponse{
+			Result: &ipc.ExecuteResponse_MissingParent{MissingParent: &ipc.RootNotFound{Hash: req.GetParentStateHash()}},
+		}, nil
+	}
+
+	results := []*ipc.DeployResult{}
+	for _, deploy := range req.GetDeploys() {
+		var err error
+		s, err = executeDeploy(s, deploy)
+
+		result := &ipc.DeployResult_ExecutionResult{
+			Effects: &ipc.ExecutionEffect{TransformMap: []*transforms.TransformEntry{{
+				Key: &state.Key{Value: &state.Key_Hash_{Hash: &state.Key_Hash{Hash: ee.putState(s)}}},
+				Transform: &transforms.Transform{
+					TransformInstance: &transforms.Transform_Identity{Identity: &transforms.TransformIdentity{}},
+				},
+			}}},
+			Cost: &state.BigInt{Value: deployCost, BitWidth: 512},
+		}
+		if err != nil {
+			result.Error = &ipc.DeployError{
+				Value: &ipc.DeployError_ExecError{ExecError: &ipc.DeployError_ExecutionError{Message: err.Error()}},
+			}
+		}
+		results = append(results, &ipc.DeployResult{Value: &ipc.DeployResult_ExecutionResult_{ExecutionResult: result}})
+	}
+
+	return &ipc.ExecuteResponse{
+		Result: &ipc.ExecuteResponse_Success{Success: &ipc.ExecResult{DeployResults: results}},
+	}, nil
+}
+
+// Commit returns the last post state the effects refer to
+func (ee *StandInEE) Commit(_ context.Context, req *ipc.CommitRequest) (*ipc.CommitResponse, error) {
+	postStateHash := req.GetPrestateHash()
+	s, ok := ee.getState(postStateHash)
+	if !ok {
+		return &ipc.CommitResponse{
+			Result: &ipc.CommitResponse_MissingPrestate{MissingPrestate: &ipc.RootNotFound{Hash: postStateHash}},
+		}, nil
+	}
+	for _, effect := range req.GetEffects() {
+		stateHash := effect.GetKey().GetHash().GetHash()
+		if next, ok := ee.getState(stateHash); ok {
+			postStateHash, s = stateHash, next
+		}
+	}
+
+	stakes := s.stakes()
+	bonds := []*ipc.Bond{}
+	for _, validator := range sortedKeys(stakes) {
+		address, _ := hex.DecodeString(validator)
+		bonds = append(bonds, &ipc.Bond{
+			ValidatorPublicKey: address,
+			Stake:              &state.BigInt{Value: stakes[validator].String(), BitWidth: 512},
+		})
+	}
+
+	return &ipc.CommitResponse{
+		Result: &ipc.CommitResponse_Success{Success: &ipc.CommitResult{
+			PoststateHash:    postStateHash,
+			BondedValidators: bonds,
+		}},
+	}, nil
+}
+
+// Step leaves the state unchanged, as the stand-in has no rewards or pending unbondings
+func (ee *StandInEE) Step(_ context.Context, req *ipc.StepRequest) (*ipc.StepResponse, error) {
+	if _, ok := ee.getState(req.GetParentStateHash()); !ok {
+		return &ipc.StepResponse{
+			Result: &ipc.StepResponse_MissingParent{MissingParent: &ipc.RootNotFound{Hash: req.GetParentStateHash()}},
+		}, nil
+	}
+	return &ipc.StepResponse{
+		Result: &ipc.StepResponse_Success{Success: &ipc.StepResult{
+			PostStateHash: req.GetParentStateHash(),
+			Effect:        &ipc.ExecutionEffect{},
+		}},
+	}, nil
+}
+
+// Query answers the queries of the system account, the pos contract, the accounts and their
+// balances in the serialization of the EE
+func (ee *StandInEE) Query(_ context.Context, req *ipc.QueryRequest) (*ipc.QueryResponse, error) {
+	s, ok := ee.getState(req.GetStateHash())
+	if !ok {
+		return queryFailure("state %s not found", hex.EncodeToString(req.GetStateHash())), nil
+	}
+
+	path := strings.Join(req.GetPath(), "/")
+	switch key := req.GetBaseKey().GetValue().(type) {
+	case *state.Key_Address_:
+		address := key.Address.GetAccount()
+		if hex.EncodeToString(address) == hex.EncodeToString(types.SYSTEM_ACCOUNT) {
+			switch path {
+			case "":
+				return querySuccess(encodeAccount(address,
+					encodeNamedKey(types.ProxyContractName, encodeHashKey(proxyContractHash)),
+					encodeNamedKey("mint", encodeURefKey(mintURef)),
+					encodeNamedKey(types.PosContractName, encodeHashKey(posContractHash)),
+				)), nil
+			case types.PosContractName:
+				return querySuccess(encodePosContract(s)), nil
+			}
+		} else if _, ok := s.balances[hex.EncodeToString(address)]; ok && path == "" {
+			return querySuccess(encodeAccount(address, encodeNamedKey("mint", encodeURefKey(mintURef)))), nil
+		}
+	case *state.Key_Local_:
+		for address := range s.balances {
+			addressBytes, _ := hex.DecodeString(address)
+			if hex.EncodeToString(key.Local.GetHash()) == hex.EncodeToString(util.MakeLocalKey(mintURef, purse(addressBytes))) {
+				return querySuccess(encodeCLValue(storedvalue.TAG_KEY, encodeURefKey(balanceURef(addressBytes)))), nil
+			}
+		}
+	case *state.Key_Uref:
+		for address, balance := range s.balances {
+			addressBytes, _ := hex.DecodeString(address)
+			if hex.EncodeToString(key.Uref.GetUref()) == hex.EncodeToString(balanceURef(addressBytes)) {
+				return querySuccess(encodeCLValue(storedvalue.TAG_U512, encodeU512(balance))), nil
+			}
+		}
+	}
+
+	return queryFailure("value of %s at %q not found", req.GetBaseKey().String(), path), nil
+}
+
+func querySuccess(value []byte) *ipc.QueryResponse {
+	return &ipc.QueryResponse{Result: &ipc.QueryResponse_Success{Success: value}}
+}
+
+func queryFailure(format string, args ...interface{}) *ipc.QueryResponse {
+	return &ipc.QueryResponse{Result: &ipc.QueryResponse_Failure{Failure: fmt.Sprintf(format, args...)}}
+}
+
+// executeDeploy returns the state after the payment and the session of the deploy. The fee
+// is kept paid when the session fails. As the accounts have no associated keys, a deploy can
+// only be authorized by the key of its own account.
+func executeDeploy(parent eeState, deploy *ipc.DeployItem) (eeState, error) {
+	address := hex.EncodeToString(deploy.GetAddress())
+	for _, key := range deploy.GetAuthorizationKeys() {
+		if hex.EncodeToString(key) != address {
+			return parent, fmt.Errorf("authorization key %s is not associated with the account %s", hex.EncodeToString(key), address)
+		}
+	}
+
+	payment, err := proxyArgs(deploy.GetPayment())
+	if err != nil {
+		return parent, err
+	}
+	s := parent.clone()
+	if method := stringArg(payment, 0); method != types.PaymentMethodName {
+		return parent, fmt.Errorf("unknown payment method %s", method)
+	}
+	if err := sub(s.balances, address, u512Arg(payment, 1), false); err != nil {
+		return parent, err
+	}
+
+	session, err := proxyArgs(deploy.GetSession())
+	if err != nil {
+		return s, err
+	}
+	paid := s.clone()
+	if err := applySession(s, address, session); err != nil {
+		return paid, err
+	}
+	return s, nil
+}
+
+// applySession applies the proxy contract method of the session args to s
+func applySession(s eeState, from string, args []storedvalue.CLValue) error {
+	switch method := stringArg(args, 0); method {
+	case types.TransferMethodName:
+		to, amount := hex.EncodeToString(bytesArg(args, 1)), u512Arg(args, 2)
+		if err := sub(s.balances, from, amount, false); err != nil {
+			return err
+		}
+		add(s.balances, to, amount)
+	case types.BondMethodName, types.DelegateMethodName:
+		validator, amount := from, u512Arg(args, 1)
+		if method == types.DelegateMethodName {
+			validator, amount = hex.EncodeToString(bytesArg(args, 1)), u512Arg(args, 2)
+		}
+		if err := sub(s.balances, from, amount, false); err != nil {
+			return err
+		}
+		add(s.delegations, from+"_"+validator, amount)
+	case types.UnbondMethodName, types.UndelegateMethodName:
+		validator, amountIdx := from, 1
+		if method == types.UndelegateMethodName {
+			validator, amountIdx = hex.EncodeToString(bytesArg(args, 1)), 2
+		}
+		key := from + "_" + validator
+		amount := optionalU512Arg(args, amountIdx, s.delegations[key])
+		if err := sub(s.delegations, key, amount, true); err != nil {
+			return err
+		}
+		add(s.balances, from, amount)
+	case types.VoteMethodName:
+		dapp, amount := hex.EncodeToString(keyArg(args, 1)), u512Arg(args, 2)
+		if err := sub(s.balances, from, amount, false); err != nil {
+			return err
+		}
+		add(s.votes, from+"_"+dapp, amount)
+	case types.UnvoteMethodName:
+		key := from + "_" + hex.EncodeToString(keyArg(args, 1))
+		amount := optionalU512Arg(args, 2, s.votes[key])
+		if err := sub(s.votes, key, amount, true); err != nil {
+			return err
+		}
+		add(s.balances, from, amount)
+	}
+	return nil
+}
+
+// proxyArgs returns the args of a payload calling the proxy contract
+func proxyArgs(payload *ipc.DeployPayload) ([]storedvalue.CLValue, error) {
+	stored := payload.GetStoredContractHash()
+	if stored == nil || hex.EncodeToString(stored.GetHash()) != hex.EncodeToString(proxyContractHash) {
+		return nil, errors.New("only the proxy contract is supported")
+	}
+
+	abi := stored.GetArgs()
+	if len(abi) < storedvalue.SIZE_LENGTH {
+		return nil, errors.New("invalid args")
+	}
+	args := []storedvalue.CLValue{}
+	pos := storedvalue.SIZE_LENGTH
+	for i := 0; i < int(binary.LittleEndian.Uint32(abi)); i++ {
+		var arg storedvalue.CLValue
+		arg, err, length := arg.FromBytes(abi[pos:])
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+		pos += length
+	}
+	return args, nil
+}
+
+func stringArg(args []storedvalue.CLValue, i int) string {
+	if i >= len(args) || args[i].Tags[0] != storedvalue.TAG_STRING {
+		return ""
+	}
+	return string(args[i].Bytes[storedvalue.SIZE_LENGTH:])
+}
+
+func bytesArg(args []storedvalue.CLValue, i int) []byte {
+	if i >= len(args) {
+		return nil
+	}
+	if args[i].Tags[0] == storedvalue.TAG_LIST {
+		return args[i].Bytes[storedvalue.SIZE_LENGTH:]
+	}
+	return args[i].Bytes
+}
+
+func keyArg(args []storedvalue.CLValue, i int) []byte {
+	if i >= len(args) || len(args[i].Bytes) < 1+storedvalue.ADDRESS_LENGTH {
+		return nil
+	}
+	return args[i].Bytes[1 : 1+storedvalue.ADDRESS_LENGTH]
+}
+
+func u512Arg(args []storedvalue.CLValue, i int) *big.Int {
+	if i >= len(args) {
+		return new(big.Int)
+	}
+	return decodeU512(args[i].Bytes)
+}
+
+// optionalU512Arg returns the amount of an Option<U512> arg, or all when it is none
+func optionalU512Arg(args []storedvalue.CLValue, i int, all *big.Int) *big.Int {
+	if i >= len(args) || args[i].Tags[0] != storedvalue.TAG_OPTION {
+		return u512Arg(args, i)
+	}
+	if len(args[i].Bytes) == 0 || args[i].Bytes[0] == 0 {
+		if all == nil {
+			return new(big.Int)
+		}
+		return new(big.Int).Set(all)
+	}
+	return decodeU512(args[i].Bytes[1:])
+}
+
+func purse(address []byte) []byte {
+	return util.Blake2b256(append([]byte("purse"), address...))
+}
+
+func balanceURef(address []byte) []byte {
+	return util.Blake2b256(append([]byte("balance"), address...))
+}
+
+func sortedKeys(m map[string]*big.Int) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// The encoders below follow the parsers of the storedvalue package.
+
+func encodeU32(n int) []byte {
+	bz := make([]byte, storedvalue.SIZE_LENGTH)
+	binary.LittleEndian.PutUint32(bz, uint32(n))
+	return bz
+}
+
+func encodeU512(value *big.Int) []byte {
+	be := value.Bytes()
+	le := make([]byte, len(be))
+	for i, b := range be {
+		le[len(be)-1-i] = b
+	}
+	return append([]byte{byte(len(le))}, le...)
+}
+
+func decodeU512(bz []byte) *big.Int {
+	if len(bz) == 0 || len(bz) < 1+int(bz[0]) {
+		return new(big.Int)
+	}
+	le := bz[1 : 1+int(bz[0])]
+	be := make([]byte, len(le))
+	for i, b := range le {
+		be[len(le)-1-i] = b
+	}
+	return new(big.Int).SetBytes(be)
+}
+
+func encodeHashKey(hash []byte) []byte {
+	return append([]byte{byte(storedvalue.KEY_ID_HASH)}, hash...)
+}
+
+func encodeURefKey(uref []byte) []byte {
+	return append(append([]byte{byte(storedvalue.KEY_ID_UREF)}, uref...), byte(state.Key_URef_READ_ADD_WRITE))
+}
+
+func encodeNamedKey(name string, key []byte) []byte {
+	return append(append(encodeU32(len(name)), name...), key...)
+}
+
+func encodeCLValue(tag storedvalue.CL_TYPE_TAG, bz []byte) []byte {
+	value := storedvalue.NewClValue(bz, []storedvalue.CL_TYPE_TAG{tag})
+	return append([]byte{byte(storedvalue.TYPE_CL_VALUE)}, value.ToBytes()...)
+}
+
+// encodeAccount encodes an account with its own key as the single associated key
+func encodeAccount(address []byte, namedKeys ...[]byte) []byte {
+	bz := append([]byte{byte(storedvalue.TYPE_ACCOUNT)}, address...)
+	bz = append(bz, encodeU32(len(namedKeys))...)
+	for _, namedKey := range namedKeys {
+		bz = append(bz, namedKey...)
+	}
+	bz = append(append(bz, purse(address)...), byte(state.Key_URef_READ_ADD_WRITE))
+	bz = append(append(bz, encodeU32(1)...), address...)
+	// the weight of the associated key, then the deployment and key management thresholds
+	return append(bz, 1, 1, 1)
+}
+
+// encodePosContract encodes the pos contract with the stakes, the delegations and the votes in its named keys
+func encodePosContract(s eeState) []byte {
+	namedKeys := [][]byte{}
+	stakes := s.stakes()
+	for _, validator := range sortedKeys(stakes) {
+		name := strings.Join([]string{storedvalue.VALIDATOR_PREFIX, validator, stakes[validator].String()}, "_")
+		namedKeys = append(namedKeys, encodeNamedKey(name, encodeHashKey(posContractHash)))
+	}
+	for _, m := range []struct {
+		prefix string
+		values map[string]*big.Int
+	}{{storedvalue.DELEGATE_PREFIX, s.delegations}, {storedvalue.VOTE_PREFIX, s.votes}} {
+		for _, k := range sortedKeys(m.values) {
+			name := strings.Join([]string{m.prefix, k, m.values[k].String()}, "_")
+			namedKeys = append(namedKeys, encodeNamedKey(name, encodeHashKey(posContractHash)))
+		}
+	}
+
+	bz := append([]byte{byte(storedvalue.TYPE_CONTRACT)}, encodeU32(0)...)
+	bz = append(bz, encodeU32(len(namedKeys))...)
+	for _, namedKey := range namedKeys {
+		bz = append(bz, namedKey...)
+	}
+	// protocol version 1.0.0
+	return append(append(append(bz, encodeU32(1)...), encodeU32(0)...), encodeU32(0)...)
+}
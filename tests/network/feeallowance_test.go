@@ -0,0 +1,65 @@
+package network
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hdac-io/friday/x/executionlayer/types"
+)
+
+// TestSponsoredTransfer checks the fee of a sponsored deploy is funded from the account of the fee
+// payer, so that a sender without a balance for the fee can deploy
+func TestSponsoredTransfer(t *testing.T) {
+	n := New(t, DefaultConfig())
+	defer n.Cleanup()
+
+	payerName := n.Validators[0].Moniker
+	payer := n.Validators[0].Address
+	sender, err := n.NewAccount("sender")
+	require.NoError(t, err)
+	recipient, err := n.NewAccount("recipient")
+	require.NoError(t, err)
+
+	// the sender holds the amount it transfers, but not the fee
+	amount := "1000000000000000000"
+	res, err := n.SendTransfer(payerName, sender, amount)
+	require.NoError(t, err)
+	require.Equal(t, uint32(0), res.Code, res.RawLog)
+
+	fee, _ := new(big.Int).SetString(n.Config.Fee, 10)
+	charge := new(big.Int).Mul(fee, big.NewInt(2))
+	limit := new(big.Int).Mul(fee, big.NewInt(10))
+	grant := types.NewMsgGrantFeeAllowance(payer, sender, limit.String(), time.Now().Add(time.Hour).UTC())
+	res, err = n.BroadcastMsgs(payerName, grant)
+	require.NoError(t, err)
+	require.Equal(t, uint32(0), res.Code, res.RawLog)
+
+	payerBalanceStr, err := n.QueryBalance(payer)
+	require.NoError(t, err)
+	payerBalance, _ := new(big.Int).SetString(payerBalanceStr, 10)
+
+	header := types.NewDeployHeader(time.Now().Add(-time.Minute).UnixNano()/int64(time.Millisecond), 0, nil)
+	header.FeePayer = payer
+	transfer := types.NewMsgTransfer("transfer", sender, recipient, amount, n.Config.Fee).WithDeployHeader(header)
+	res, err = n.BroadcastMsgsSignedBy([]string{"sender", payerName}, transfer, types.NewMsgAuthorize(payer))
+	require.NoError(t, err)
+	require.Equal(t, uint32(0), res.Code, res.RawLog)
+
+	// the payer pays the fee transferred to the sender and the fee of the transfer
+	balance, err := n.QueryBalance(payer)
+	require.NoError(t, err)
+	require.Equal(t, new(big.Int).Sub(payerBalance, charge).String(), balance)
+	balance, err = n.QueryBalance(sender)
+	require.NoError(t, err)
+	require.Equal(t, "0", balance)
+	balance, err = n.QueryBalance(recipient)
+	require.NoError(t, err)
+	require.Equal(t, amount, balance)
+
+	allowance, err := n.QueryFeeAllowance(payer, sender)
+	require.NoError(t, err)
+	require.Equal(t, new(big.Int).Sub(limit, charge).String(), allowance.SpendLimit)
+}
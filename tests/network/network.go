@@ -0,0 +1,271 @@
+// Package network starts in-process networks of FRIDAY validators, each running Tendermint and
+// the app, for end-to-end tests of the modules against real consensus.
+//
+// The validators share one execution engine, the stand-in of this package unless a socket of an
+// EE is configured. A network sets the global socket of the app and reserves the RPC server of
+// Tendermint, so networks must not run in parallel; create one per test and clean it up. Use
+// VotingPowers rather than the validators of the RPC to assert the validator set, since
+// Tendermint caches validator sets by height for the whole process.
+//
+//	n := network.New(t, network.DefaultConfig())
+//	defer n.Cleanup()
+//
+//	_, err := n.WaitForHeight(2)
+//	require.NoError(t, err)
+//	res, err := n.SendTransfer(n.Validators[0].Moniker, recipient, "1000000000000000000")
+package network
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+
+	tmconfig "github.com/hdac-io/tendermint/config"
+	"github.com/hdac-io/tendermint/crypto"
+	cmn "github.com/hdac-io/tendermint/libs/common"
+	"github.com/hdac-io/tendermint/libs/log"
+	"github.com/hdac-io/tendermint/node"
+	tmclient "github.com/hdac-io/tendermint/rpc/client"
+
+	"github.com/hdac-io/friday/app"
+	"github.com/hdac-io/friday/client"
+	"github.com/hdac-io/friday/client/context"
+	"github.com/hdac-io/friday/client/flags"
+	"github.com/hdac-io/friday/client/keys"
+	"github.com/hdac-io/friday/codec"
+	crkeys "github.com/hdac-io/friday/crypto/keys"
+	sdk "github.com/hdac-io/friday/types"
+	"github.com/hdac-io/friday/x/executionlayer/types"
+)
+
+// Config defines the validators, the genesis and the consensus of a network
+type Config struct {
+	NumValidators   int    // number of validators
+	ChainID         string // chain ID, random if empty
+	ConsensusModule string // consensus module of Tendermint, "tendermint" or "friday"
+
+	// EESocket is the unix socket of the execution engine of the validators. If empty, a
+	// StandInEE is started on a socket in the directory of the network.
+	EESocket string
+	// ChainSpecPath is the chain spec of the genesis of the execution layer. If empty, the
+	// default configuration without system contract installers is used, which the stand-in
+	// EE does not need.
+	ChainSpecPath string
+
+	// GenesisState replaces the default genesis of the modules it has, other than genaccounts,
+	// genutil and executionlayer, whose genesis the network builds
+	GenesisState map[string]json.RawMessage
+
+	AccountTokens string // initial balance of the account of each validator, in bigsun
+	BondedTokens  string // initial stake of each validator, in bigsun
+	Fee           string // fee of the txs sent by the helpers, in bigsun
+
+	GasLimit      uint64        // gas limit of the txs sent by the helpers
+	Passphrase    string        // passphrase of the keys in the keybase
+	TimeoutCommit time.Duration // time between the blocks
+}
+
+// DefaultConfig returns a config of a network of two validators
+func DefaultConfig() Config {
+	return Config{
+		NumValidators:   2,
+		ChainID:         "chain-" + cmn.RandStr(6),
+		ConsensusModule: "tendermint",
+		AccountTokens:   "1000000000000000000000000000",
+		BondedTokens:    "100000000000000000000",
+		Fee:             types.BASIC_FEE,
+		GasLimit:        flags.DefaultGasLimit,
+		Passphrase:      client.DefaultKeyPass,
+		TimeoutCommit:   500 * time.Millisecond,
+	}
+}
+
+// Validator is a validator of a network. Only the first validator serves the RPC and the LCD,
+// which the other validators cannot share in one process.
+type Validator struct {
+	Moniker    string
+	Dir        string
+	Address    sdk.AccAddress
+	ConsPubKey crypto.PubKey
+	NodeID     string
+	P2PAddress string // host:port of the p2p listener
+
+	RPCAddress string             // tcp://host:port of the Tendermint RPC, only on the first validator
+	APIAddress string             // http://host:port of the LCD, only on the first validator
+	ClientCtx  context.CLIContext // context querying and broadcasting through the RPC, only on the first validator
+
+	tmConfig *tmconfig.Config
+	tmNode   *node.Node
+	api      func()
+}
+
+// Network is a running in-process network of validators
+type Network struct {
+	Config     Config
+	Dir        string
+	Codec      *codec.Codec
+	Keybase    crkeys.Keybase // holds the keys of the validators and of the accounts created by NewAccount
+	Validators []*Validator
+
+	ee       *StandInEE
+	eeSocket string
+	prevHome string
+}
+
+// New starts a network of cfg, failing t if any validator cannot start. The network is running
+// once the first block is committed.
+func New(t *testing.T, cfg Config) *Network {
+	require.True(t, cfg.NumValidators > 0, "a network needs a validator")
+	if cfg.ChainID == "" {
+		cfg.ChainID = "chain-" + cmn.RandStr(6)
+	}
+	if cfg.ConsensusModule == "" {
+		cfg.ConsensusModule = "tendermint"
+	}
+
+	dir, err := ioutil.TempDir("", "network_")
+	require.NoError(t, err)
+
+	n := &Network{
+		Config:   cfg,
+		Dir:      dir,
+		Codec:    app.MakeCodec(),
+		Keybase:  keys.NewInMemoryKeyBase(),
+		eeSocket: cfg.EESocket,
+		prevHome: viper.GetString(flags.FlagHome),
+	}
+
+	// the client contexts open the keybase of the home, which is empty in tests
+	viper.Set(flags.FlagHome, filepath.Join(dir, "clif"))
+
+	if cfg.EESocket == "" {
+		n.ee, err = NewStandInEE(filepath.Join(dir, "ee.sock"))
+		if err != nil {
+			n.Cleanup()
+			require.NoError(t, err)
+		}
+		n.eeSocket = filepath.Join(dir, "ee.sock")
+	}
+
+	if err := n.initValidators(); err != nil {
+		n.Cleanup()
+		require.NoError(t, err)
+	}
+	if err := n.writeGenesis(); err != nil {
+		n.Cleanup()
+		require.NoError(t, err)
+	}
+	for _, val := range n.Validators {
+		if err := n.startValidator(val); err != nil {
+			n.Cleanup()
+			require.NoError(t, err)
+		}
+	}
+
+	if _, err := n.WaitForHeight(1); err != nil {
+		n.Cleanup()
+		require.NoError(t, err)
+	}
+	return n
+}
+
+// LatestHeight returns the latest height committed by the network
+func (n *Network) LatestHeight() (int64, error) {
+	status, err := n.rpcClient().Status()
+	if err != nil {
+		return 0, err
+	}
+	return status.SyncInfo.LatestBlockHeight, nil
+}
+
+// WaitForHeight waits until the network commits the height, allowing 10 seconds per block
+func (n *Network) WaitForHeight(height int64) (int64, error) {
+	latest, _ := n.LatestHeight()
+	timeout := 10 * time.Second
+	if latest < height {
+		timeout *= time.Duration(height - latest)
+	}
+	return n.WaitForHeightWithTimeout(height, timeout)
+}
+
+// WaitForHeightWithTimeout waits until the network commits the height, returning the latest
+// height, or an error after the timeout
+func (n *Network) WaitForHeightWithTimeout(height int64, timeout time.Duration) (int64, error) {
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	deadline := time.After(timeout)
+
+	var latest int64
+	for {
+		select {
+		case <-deadline:
+			return latest, fmt.Errorf("timeout exceeded waiting for height %d, latest height %d", height, latest)
+		case <-ticker.C:
+			if h, err := n.LatestHeight(); err == nil {
+				latest = h
+				if latest >= height {
+					return latest, nil
+				}
+			}
+		}
+	}
+}
+
+// WaitForNextBlock waits until the network commits the block after the latest one
+func (n *Network) WaitForNextBlock() error {
+	latest, err := n.LatestHeight()
+	if err != nil {
+		return err
+	}
+	_, err = n.WaitForHeight(latest + 1)
+	return err
+}
+
+// VotingPowers returns the voting power of each validator of the network in the validator set
+// the first validator currently runs consensus with, by moniker. Prefer it to the validators of
+// the RPC, which Tendermint caches by height across all the networks of the process.
+func (n *Network) VotingPowers() map[string]int64 {
+	validators := n.Validators[0].tmNode.ConsensusState().GetState().Validators
+	powers := make(map[string]int64, len(n.Validators))
+	for _, val := range n.Validators {
+		powers[val.Moniker] = 0
+		if _, tmVal := validators.GetByAddress(val.ConsPubKey.Address()); tmVal != nil {
+			powers[val.Moniker] = tmVal.VotingPower
+		}
+	}
+	return powers
+}
+
+// Cleanup stops the validators and the stand-in EE, removes the directory of the network and
+// restores the socket of the app and the home of the client. It is safe to call on a network
+// failed to start.
+func (n *Network) Cleanup() {
+	for _, val := range n.Validators {
+		if val.api != nil {
+			val.api()
+		}
+		if val.tmNode != nil && val.tmNode.IsRunning() {
+			_ = val.tmNode.Stop()
+			val.tmNode.Wait()
+		}
+	}
+	if n.ee != nil {
+		n.ee.Stop()
+	}
+	viper.Set(flags.FlagHome, n.prevHome)
+	_ = os.RemoveAll(n.Dir)
+}
+
+func (n *Network) rpcClient() tmclient.Client {
+	return n.Validators[0].ClientCtx.Client
+}
+
+// logger is the logger of the validators, which would drown the output of the tests
+var logger = log.NewNopLogger()
@@ -0,0 +1,52 @@
+package network
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/hdac-io/friday/types"
+)
+
+func TestNetwork(t *testing.T) {
+	n := New(t, DefaultConfig())
+	defer n.Cleanup()
+
+	_, err := n.WaitForHeight(2)
+	require.NoError(t, err)
+
+	require.Equal(t, map[string]int64{"node0": 100, "node1": 100}, n.VotingPowers())
+
+	// transfer
+	recipient, err := n.NewAccount("recipient")
+	require.NoError(t, err)
+	res, err := n.SendTransfer(n.Validators[0].Moniker, recipient, "1000000000000000000")
+	require.NoError(t, err)
+	require.Equal(t, uint32(0), res.Code, res.RawLog)
+
+	balance, err := n.QueryBalance(recipient)
+	require.NoError(t, err)
+	require.Equal(t, "1000000000000000000", balance)
+
+	// bond, raising the power of the validator once the update is applied
+	res, err = n.SendBond(n.Validators[1].Moniker, "10000000000000000000")
+	require.NoError(t, err)
+	require.Equal(t, uint32(0), res.Code, res.RawLog)
+
+	_, err = n.WaitForHeight(res.Height + 2)
+	require.NoError(t, err)
+	require.Equal(t, map[string]int64{"node0": 100, "node1": 110}, n.VotingPowers())
+
+	// vote
+	contract := sdk.ContractHashAddress(make([]byte, 32))
+	res, err = n.SendVote(n.Validators[0].Moniker, contract, "1000000000000000000")
+	require.NoError(t, err)
+	require.Equal(t, uint32(0), res.Code, res.RawLog)
+
+	// nickname
+	res, err = n.SendSetNickname(n.Validators[0].Moniker, "node0")
+	require.NoError(t, err)
+	require.Equal(t, uint32(0), res.Code, res.RawLog)
+
+	require.NoError(t, n.WaitForNextBlock())
+}
@@ -0,0 +1,164 @@
+package network
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/casper/consensus/state"
+
+	crkeys "github.com/hdac-io/friday/crypto/keys"
+	sdk "github.com/hdac-io/friday/types"
+	"github.com/hdac-io/friday/x/auth"
+	"github.com/hdac-io/friday/x/auth/client/utils"
+	authtypes "github.com/hdac-io/friday/x/auth/types"
+	"github.com/hdac-io/friday/x/executionlayer/types"
+	nicknametypes "github.com/hdac-io/friday/x/nickname/types"
+)
+
+// NewAccount creates a key of the name in the keybase of the network and returns its address
+func (n *Network) NewAccount(name string) (sdk.AccAddress, error) {
+	info, _, err := n.Keybase.CreateMnemonic(name, crkeys.English, n.Config.Passphrase, crkeys.Secp256k1)
+	if err != nil {
+		return nil, err
+	}
+	return info.GetAddress(), nil
+}
+
+// BroadcastMsgs signs the msgs with the key of the name and broadcasts them in a tx, returning
+// once the tx is committed in a block
+func (n *Network) BroadcastMsgs(name string, msgs ...sdk.Msg) (sdk.TxResponse, error) {
+	return n.BroadcastMsgsSignedBy([]string{name}, msgs...)
+}
+
+// BroadcastMsgsSignedBy signs the msgs with the keys of the names, in the order of the signers of
+// the msgs, and broadcasts them in a tx, returning once the tx is committed in a block
+func (n *Network) BroadcastMsgsSignedBy(names []string, msgs ...sdk.Msg) (sdk.TxResponse, error) {
+	cliCtx := n.Validators[0].ClientCtx
+	tx := auth.NewStdTx(msgs, auth.NewStdFee(n.Config.GasLimit, nil), []auth.StdSignature{}, "")
+	for _, name := range names {
+		info, err := n.Keybase.Get(name)
+		if err != nil {
+			return sdk.TxResponse{}, err
+		}
+		accNum, seq, err := authtypes.NewAccountRetriever(cliCtx).GetAccountNumberSequence(info.GetAddress())
+		if err != nil {
+			return sdk.TxResponse{}, err
+		}
+
+		txBldr := auth.NewTxBuilder(
+			utils.GetTxEncoder(n.Codec), accNum, seq, n.Config.GasLimit, 0, false, n.Config.ChainID, "", nil, nil,
+		).WithKeybase(n.Keybase)
+		if tx, err = txBldr.SignStdTx(name, n.Config.Passphrase, tx, true); err != nil {
+			return sdk.TxResponse{}, err
+		}
+	}
+
+	txBytes, err := utils.GetTxEncoder(n.Codec)(tx)
+	if err != nil {
+		return sdk.TxResponse{}, err
+	}
+	return cliCtx.BroadcastTxCommit(txBytes)
+}
+
+// SendTransfer transfers the amount from the account of the name to the recipient
+func (n *Network) SendTransfer(name string, recipient sdk.AccAddress, amount string) (sdk.TxResponse, error) {
+	from, err := n.address(name)
+	if err != nil {
+		return sdk.TxResponse{}, err
+	}
+	msg := types.NewMsgTransfer("transfer", from, recipient, amount, n.Config.Fee)
+	return n.BroadcastMsgs(name, msg)
+}
+
+// SendBond bonds the amount of the account of the name
+func (n *Network) SendBond(name string, amount string) (sdk.TxResponse, error) {
+	from, err := n.address(name)
+	if err != nil {
+		return sdk.TxResponse{}, err
+	}
+	msg := types.NewMsgBond("system:bond", from, amount, n.Config.Fee)
+	return n.BroadcastMsgs(name, msg)
+}
+
+// SendVote votes the amount of the account of the name for the contract
+func (n *Network) SendVote(name string, contract sdk.ContractAddress, amount string) (sdk.TxResponse, error) {
+	from, err := n.address(name)
+	if err != nil {
+		return sdk.TxResponse{}, err
+	}
+	msg := types.NewMsgVote("system:vote", from, contract, amount, n.Config.Fee)
+	return n.BroadcastMsgs(name, msg)
+}
+
+// SendSetNickname sets the nickname of the account of the name
+func (n *Network) SendSetNickname(name string, nickname string) (sdk.TxResponse, error) {
+	from, err := n.address(name)
+	if err != nil {
+		return sdk.TxResponse{}, err
+	}
+	msg := nicknametypes.NewMsgSetNickname(nicknametypes.NewName(nickname), from)
+	return n.BroadcastMsgs(name, msg)
+}
+
+// QueryBalance returns the balance of the address in the execution layer, in bigsun
+func (n *Network) QueryBalance(address sdk.AccAddress) (string, error) {
+	cliCtx := n.Validators[0].ClientCtx
+	bz := n.Codec.MustMarshalJSON(types.QueryGetBalanceDetail{Address: address})
+	res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/querybalancedetail", types.ModuleName), bz)
+	if err != nil {
+		return "", err
+	}
+
+	out := &state.Value{}
+	if err := jsonpb.Unmarshal(bytes.NewReader(res), out); err != nil {
+		return "", err
+	}
+	return out.GetStringValue(), nil
+}
+
+// QueryGrant returns the grant of the granter to the grantee
+func (n *Network) QueryGrant(granter, grantee sdk.AccAddress) (types.Grant, error) {
+	cliCtx := n.Validators[0].ClientCtx
+	bz := n.Codec.MustMarshalJSON(types.NewQueryGrantsParams(granter, grantee))
+	res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/querygrants", types.ModuleName), bz)
+	if err != nil {
+		return types.Grant{}, err
+	}
+
+	var grants types.Grants
+	if err := n.Codec.UnmarshalJSON(res, &grants); err != nil {
+		return types.Grant{}, err
+	}
+	if len(grants) == 0 {
+		return types.Grant{}, fmt.Errorf("%s has no grant to %s", granter, grantee)
+	}
+	return grants[0], nil
+}
+
+// QueryFeeAllowance returns the fee allowance of the payer to the grantee
+func (n *Network) QueryFeeAllowance(payer, grantee sdk.AccAddress) (types.FeeAllowance, error) {
+	cliCtx := n.Validators[0].ClientCtx
+	bz := n.Codec.MustMarshalJSON(types.NewQueryFeeAllowancesParams(payer, grantee))
+	res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/queryfeeallowances", types.ModuleName), bz)
+	if err != nil {
+		return types.FeeAllowance{}, err
+	}
+
+	var allowances types.FeeAllowances
+	if err := n.Codec.UnmarshalJSON(res, &allowances); err != nil {
+		return types.FeeAllowance{}, err
+	}
+	if len(allowances) == 0 {
+		return types.FeeAllowance{}, fmt.Errorf("%s has no fee allowance to %s", payer, grantee)
+	}
+	return allowances[0], nil
+}
+
+func (n *Network) address(name string) (sdk.AccAddress, error) {
+	info, err := n.Keybase.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return info.GetAddress(), nil
+}
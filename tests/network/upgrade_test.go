@@ -0,0 +1,67 @@
+package network
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/hdac-io/friday/types"
+	"github.com/hdac-io/friday/x/gov"
+	"github.com/hdac-io/friday/x/upgrade"
+)
+
+// TestUpgradeHalt checks a software upgrade proposal passed by the votes of the validators
+// schedules the plan, and the network, whose binary lacks the handler, halts at its height
+func TestUpgradeHalt(t *testing.T) {
+	cfg := DefaultConfig()
+	govGenesis := gov.DefaultGenesisState()
+	govGenesis.DepositParams.MinDeposit = sdk.Coins{}
+	govGenesis.VotingParams.VotingPeriod = 3 * time.Second
+	cfg.GenesisState = map[string]json.RawMessage{gov.ModuleName: gov.ModuleCdc.MustMarshalJSON(govGenesis)}
+
+	n := New(t, cfg)
+	defer n.Cleanup()
+
+	proposer := n.Validators[0]
+	height, err := n.LatestHeight()
+	require.NoError(t, err)
+	plan := upgrade.Plan{Name: "v2", Height: height + 20, Info: "v2 binary"}
+
+	proposal := upgrade.NewSoftwareUpgradeProposal("upgrade", "upgrade to v2", plan)
+	res, err := n.BroadcastMsgs(proposer.Moniker, gov.NewMsgSubmitProposal(proposal, sdk.Coins{}, proposer.Address))
+	require.NoError(t, err)
+	require.Equal(t, uint32(0), res.Code, res.RawLog)
+	for _, val := range n.Validators {
+		res, err = n.BroadcastMsgs(val.Moniker, gov.NewMsgVote(val.Address, 1, gov.OptionYes))
+		require.NoError(t, err)
+		require.Equal(t, uint32(0), res.Code, res.RawLog)
+	}
+
+	// the gov EndBlocker tallies the votes at the end of the voting period and schedules the plan
+	cliCtx := proposer.ClientCtx
+	var current upgrade.Plan
+	for deadline := time.Now().Add(20 * time.Second); ; {
+		bz, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", upgrade.QuerierRoute, upgrade.QueryCurrent), nil)
+		require.NoError(t, err)
+		if len(bz) != 0 {
+			n.Codec.MustUnmarshalJSON(bz, &current)
+			break
+		}
+		require.True(t, time.Now().Before(deadline), "the plan is not scheduled")
+		require.NoError(t, n.WaitForNextBlock())
+	}
+	require.Equal(t, plan.Name, current.Name)
+	require.Equal(t, plan.Height, current.Height)
+
+	// the block of the plan height is never committed
+	_, err = n.WaitForHeight(plan.Height - 1)
+	require.NoError(t, err)
+	_, err = n.WaitForHeightWithTimeout(plan.Height, 5*time.Second)
+	require.Error(t, err)
+	latest, err := n.LatestHeight()
+	require.NoError(t, err)
+	require.Equal(t, plan.Height-1, latest)
+}
@@ -0,0 +1,290 @@
+package network
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"strings"
+
+	tmconfig "github.com/hdac-io/tendermint/config"
+	"github.com/hdac-io/tendermint/node"
+	"github.com/hdac-io/tendermint/p2p"
+	pvm "github.com/hdac-io/tendermint/privval"
+	"github.com/hdac-io/tendermint/proxy"
+	rpcserver "github.com/hdac-io/tendermint/rpc/lib/server"
+	tmtypes "github.com/hdac-io/tendermint/types"
+	tmtime "github.com/hdac-io/tendermint/types/time"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/hdac-io/friday/app"
+	"github.com/hdac-io/friday/client"
+	"github.com/hdac-io/friday/client/context"
+	"github.com/hdac-io/friday/client/flags"
+	"github.com/hdac-io/friday/client/lcd"
+	"github.com/hdac-io/friday/codec"
+	crkeys "github.com/hdac-io/friday/crypto/keys"
+	"github.com/hdac-io/friday/server"
+	sdk "github.com/hdac-io/friday/types"
+	"github.com/hdac-io/friday/x/auth"
+	authrest "github.com/hdac-io/friday/x/auth/client/rest"
+	"github.com/hdac-io/friday/x/auth/client/utils"
+	"github.com/hdac-io/friday/x/executionlayer"
+	elconfig "github.com/hdac-io/friday/x/executionlayer/configuration"
+	"github.com/hdac-io/friday/x/executionlayer/types"
+	"github.com/hdac-io/friday/x/genaccounts"
+	"github.com/hdac-io/friday/x/genutil"
+	"github.com/hdac-io/friday/x/staking"
+)
+
+// initValidators creates the directories, the node and validator keys and the account keys of
+// the validators, with the ports of their listeners
+func (n *Network) initValidators() error {
+	for i := 0; i < n.Config.NumValidators; i++ {
+		moniker := fmt.Sprintf("node%d", i)
+		dir := filepath.Join(n.Dir, moniker)
+		tmconfig.EnsureRoot(dir)
+
+		cfg := tmconfig.DefaultConfig()
+		if n.Config.ConsensusModule == "friday" {
+			cfg.Consensus = tmconfig.DefaultFridayConsensusConfig()
+		}
+		cfg.SetRoot(dir)
+		cfg.Moniker = moniker
+		cfg.Consensus.Module = n.Config.ConsensusModule
+		cfg.Consensus.TimeoutCommit = n.Config.TimeoutCommit
+		cfg.P2P.AddrBookStrict = false
+		cfg.P2P.AllowDuplicateIP = true
+		cfg.RPC.ListenAddress = ""
+		cfg.Instrumentation.Prometheus = false
+
+		_, p2pPort, err := server.FreeTCPAddr()
+		if err != nil {
+			return err
+		}
+		cfg.P2P.ListenAddress = "tcp://127.0.0.1:" + p2pPort
+
+		nodeID, consPubKey, err := genutil.InitializeNodeValidatorFiles(cfg)
+		if err != nil {
+			return err
+		}
+		info, _, err := n.Keybase.CreateMnemonic(moniker, crkeys.English, n.Config.Passphrase, crkeys.Secp256k1)
+		if err != nil {
+			return err
+		}
+
+		val := &Validator{
+			Moniker:    moniker,
+			Dir:        dir,
+			Address:    info.GetAddress(),
+			ConsPubKey: consPubKey,
+			NodeID:     nodeID,
+			P2PAddress: "127.0.0.1:" + p2pPort,
+			tmConfig:   cfg,
+		}
+
+		// rpc/core keeps the environment of a single node, so only the first one serves the RPC and the LCD
+		if i == 0 {
+			_, rpcPort, err := server.FreeTCPAddr()
+			if err != nil {
+				return err
+			}
+			_, apiPort, err := server.FreeTCPAddr()
+			if err != nil {
+				return err
+			}
+			cfg.RPC.ListenAddress = "tcp://127.0.0.1:" + rpcPort
+			val.RPCAddress = cfg.RPC.ListenAddress
+			val.APIAddress = "http://127.0.0.1:" + apiPort
+			val.ClientCtx = context.NewCLIContext().
+				WithCodec(n.Codec).
+				WithNodeURI(val.RPCAddress).
+				WithTrustNode(true).
+				WithBroadcastMode(flags.BroadcastBlock)
+		}
+
+		n.Validators = append(n.Validators, val)
+	}
+
+	peers := make([]string, len(n.Validators))
+	for i, val := range n.Validators {
+		peers[i] = fmt.Sprintf("%s@%s", val.NodeID, val.P2PAddress)
+	}
+	for i, val := range n.Validators {
+		others := append(append([]string{}, peers[:i]...), peers[i+1:]...)
+		val.tmConfig.P2P.PersistentPeers = strings.Join(others, ",")
+	}
+	return nil
+}
+
+// writeGenesis writes the genesis of the network, funding and bonding the accounts of the
+// validators in the execution layer, to the config of every validator
+func (n *Network) writeGenesis() error {
+	cdc := n.Codec
+
+	bonded, ok := new(big.Int).SetString(n.Config.BondedTokens, 10)
+	if !ok {
+		return fmt.Errorf("invalid bonded tokens %s", n.Config.BondedTokens)
+	}
+	// the power of a validator is its stake in hdac
+	power := new(big.Int).Quo(bonded, new(big.Int).Exp(big.NewInt(10), big.NewInt(types.DECIMAL_POINT_POS), nil)).Int64()
+	stakingTokens := sdk.TokensFromConsensusPower(power)
+
+	genesisConf := types.NewGenesisConf(nil, nil, nil)
+	if n.Config.ChainSpecPath != "" {
+		conf, err := elconfig.ParseGenesisChainSpec(n.Config.ChainSpecPath)
+		if err != nil {
+			return err
+		}
+		genesisConf = *conf
+	}
+
+	var (
+		genAccounts []genaccounts.GenesisAccount
+		elAccounts  []types.Account
+		stateInfos  []string
+		genTxs      []auth.StdTx
+	)
+	for _, val := range n.Validators {
+		genAccounts = append(genAccounts, genaccounts.GenesisAccount{
+			Address: val.Address,
+			Coins:   sdk.NewCoins(sdk.NewCoin(sdk.DefaultBondDenom, stakingTokens)),
+		})
+		elAccounts = append(elAccounts, types.Account{
+			Address:             val.Address,
+			InitialBalance:      n.Config.AccountTokens,
+			InitialBondedAmount: n.Config.BondedTokens,
+		})
+		address := hex.EncodeToString(val.Address)
+		stateInfos = append(stateInfos, strings.Join([]string{"d", address, address, n.Config.BondedTokens}, "_"))
+
+		genTx, err := n.genTx(val, stakingTokens)
+		if err != nil {
+			return err
+		}
+		genTxs = append(genTxs, genTx)
+	}
+
+	// the default genesis of the execution layer loads the installers of the system contracts
+	appState := map[string]json.RawMessage{}
+	for name, module := range app.ModuleBasics {
+		if name != executionlayer.ModuleName {
+			appState[name] = module.DefaultGenesis()
+		}
+	}
+	for name, state := range n.Config.GenesisState {
+		appState[name] = state
+	}
+	appState = genaccounts.SetGenesisStateInAppState(cdc, appState, genAccounts)
+
+	elGenesisState := types.NewGenesisState(genesisConf, elAccounts, n.Config.ChainID, nil, stateInfos)
+	elGenesisState.Params = types.DefaultParams()
+	appState[executionlayer.ModuleName] = cdc.MustMarshalJSON(elGenesisState)
+
+	appState, err := genutil.SetGenTxsInAppGenesisState(cdc, appState, genTxs)
+	if err != nil {
+		return err
+	}
+	appStateJSON, err := codec.MarshalJSONIndent(cdc, appState)
+	if err != nil {
+		return err
+	}
+
+	genTime := tmtime.Now()
+	for _, val := range n.Validators {
+		err := genutil.ExportGenesisFileWithTime(
+			val.tmConfig.GenesisFile(), n.Config.ChainID, nil, appStateJSON, genTime, n.Config.ConsensusModule,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// genTx returns the signed genesis tx creating the validator in staking and in the execution layer
+func (n *Network) genTx(val *Validator, stakingTokens sdk.Int) (auth.StdTx, error) {
+	msgs := []sdk.Msg{
+		staking.NewMsgCreateValidator(
+			sdk.ValAddress(val.Address),
+			val.ConsPubKey,
+			sdk.NewCoin(sdk.DefaultBondDenom, stakingTokens),
+			staking.NewDescription(val.Moniker, "", "", ""),
+			staking.NewCommissionRates(sdk.ZeroDec(), sdk.ZeroDec(), sdk.ZeroDec()),
+			sdk.OneInt(),
+		),
+		types.NewMsgCreateValidator(
+			"system:create_validator",
+			val.Address,
+			val.ConsPubKey,
+			types.NewDescription(val.Moniker, "", "", ""),
+			types.NewCommissionRates(sdk.ZeroDec(), sdk.ZeroDec(), sdk.ZeroDec()),
+			types.BASIC_FEE,
+		),
+	}
+
+	memo := fmt.Sprintf("%s@%s", val.NodeID, val.P2PAddress)
+	tx := auth.NewStdTx(msgs, auth.StdFee{}, []auth.StdSignature{}, memo)
+	txBldr := auth.NewTxBuilder(utils.GetTxEncoder(n.Codec), 0, 0, 0, 0, false, n.Config.ChainID, memo, nil, nil).
+		WithKeybase(n.Keybase)
+	return txBldr.SignStdTx(val.Moniker, n.Config.Passphrase, tx, false)
+}
+
+// startValidator starts the app and Tendermint of the validator, and the LCD of the first one
+func (n *Network) startValidator(val *Validator) error {
+	cfg := val.tmConfig
+	fridayApp := app.NewFridayApp(logger, dbm.NewMemDB(), nil, true, 0, n.eeSocket, nil)
+
+	nodeKey, err := p2p.LoadOrGenNodeKey(cfg.NodeKeyFile())
+	if err != nil {
+		return err
+	}
+	var privVal tmtypes.PrivValidator
+	switch cfg.Consensus.Module {
+	case "friday":
+		privVal = pvm.LoadOrGenFridayFilePV(cfg.PrivValidatorKeyFile(), cfg.PrivValidatorStateFile())
+	case "tendermint":
+		privVal = pvm.LoadOrGenFilePV(cfg.PrivValidatorKeyFile(), cfg.PrivValidatorStateFile())
+	}
+
+	val.tmNode, err = node.NewNode(
+		cfg,
+		privVal,
+		nodeKey,
+		proxy.NewLocalClientCreator(fridayApp),
+		node.DefaultGenesisDocProviderFunc(cfg),
+		node.DefaultDBProvider,
+		node.DefaultMetricsProvider(cfg.Instrumentation),
+		logger.With("module", "node"),
+	)
+	if err != nil {
+		return err
+	}
+	if err := val.tmNode.Start(); err != nil {
+		return err
+	}
+
+	if val.APIAddress != "" {
+		return n.startAPI(val)
+	}
+	return nil
+}
+
+// startAPI serves the LCD with the routes of clif on the API address of the validator
+func (n *Network) startAPI(val *Validator) error {
+	rs := lcd.NewRestServer(n.Codec)
+	rs.CliCtx = val.ClientCtx
+	client.RegisterRoutes(rs.CliCtx, rs.Mux)
+	authrest.RegisterTxRoutes(rs.CliCtx, rs.Mux)
+	app.ModuleBasics.RegisterRESTRoutes(rs.CliCtx, rs.Mux)
+
+	cfg := rpcserver.DefaultConfig()
+	listener, err := rpcserver.Listen(strings.Replace(val.APIAddress, "http://", "tcp://", 1), cfg)
+	if err != nil {
+		return err
+	}
+	val.api = func() { _ = listener.Close() }
+	go rpcserver.StartHTTPServer(listener, rs.Mux, logger, cfg) // nolint: errcheck
+	return nil
+}
@@ -10,4 +10,21 @@ type CandidateBlock struct {
 	State           []byte                 `json:"state"`
 	Bonds           []*ipc.Bond            `json:"bonds"`
 	ProtocolVersion *state.ProtocolVersion `json:"protocol_version"`
+
+	// accumulated by the deploys of the block, bounded by the chainspec deploy config
+	Cost uint64 `json:"cost"`
+	Size uint64 `json:"size"`
+
+	// number of the deploys executed in the block
+	Deploys uint64 `json:"deploys"`
+
+	// deploys executed in the block, failed or not. They're recorded at the end of the block, as
+	// the state changes of a failed tx are reverted while its deploys are committed to the EE.
+	ExecutedDeploys []ExecutedDeploy `json:"executed_deploys"`
+}
+
+// ExecutedDeploy - hash of a deploy executed in the block, and of the tx which carried it
+type ExecutedDeploy struct {
+	DeployHash []byte `json:"deploy_hash"`
+	TxHash     []byte `json:"tx_hash"` // empty for a deploy out of a tx
 }
@@ -35,6 +35,7 @@ type Context struct {
 	consParams     *abci.ConsensusParams
 	eventManager   *EventManager
 	candidateBlock *CandidateBlock
+	msgIndex       int
 }
 
 // Proposed rename, not done to avoid API breakage
@@ -55,6 +56,7 @@ func (c Context) IsCheckTx() bool                 { return c.checkTx }
 func (c Context) MinGasPrices() DecCoins          { return c.minGasPrice }
 func (c Context) EventManager() *EventManager     { return c.eventManager }
 func (c Context) CandidateBlock() *CandidateBlock { return c.candidateBlock }
+func (c Context) MsgIndex() int                   { return c.msgIndex }
 func (c Context) UBlockHeight() uint64 {
        if c.header.Height < 0 {
                return 0
@@ -181,6 +183,12 @@ func (c Context) WithCandidateBlock(cb *CandidateBlock) Context {
 	return c
 }
 
+// WithMsgIndex sets the index of the msg being handled in its tx
+func (c Context) WithMsgIndex(msgIndex int) Context {
+	c.msgIndex = msgIndex
+	return c
+}
+
 // TODO: remove???
 func (c Context) IsZero() bool {
 	return c.ms == nil
@@ -22,6 +22,7 @@ type (
 	CommitMultiStore = types.CommitMultiStore
 	KVStore          = types.KVStore
 	Iterator         = types.Iterator
+	WriteListener    = types.WriteListener
 )
 
 // Iterator over all the keys with a certain prefix in ascending order
@@ -52,6 +52,10 @@ var (
 	NewTxBuilderFromCLI            = types.NewTxBuilderFromCLI
 	MakeSignature                  = types.MakeSignature
 	NewAccountRetriever            = types.NewAccountRetriever
+	WithTxSigners                  = types.WithTxSigners
+	GetTxSigners                   = types.GetTxSigners
+	WithTxSequences                = types.WithTxSequences
+	GetTxSequences                 = types.GetTxSequences
 
 	// variable aliases
 	ModuleCdc                 = types.ModuleCdc
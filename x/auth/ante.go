@@ -130,6 +130,7 @@ func NewAnteHandler(ak AccountKeeper, supplyKeeper types.SupplyKeeper, sigGasCon
 		// stdSigs contains the sequence number, account number, and signatures.
 		// When simulating, this would just be a 0-length slice.
 		stdSigs := stdTx.GetSignatures()
+		signerSeqs := make([]uint64, 0, len(stdSigs))
 
 		for i := 0; i < len(stdSigs); i++ {
 			// skip the fee payer, account is cached and fees were deducted already
@@ -140,6 +141,8 @@ func NewAnteHandler(ak AccountKeeper, supplyKeeper types.SupplyKeeper, sigGasCon
 				}
 			}
 
+			signerSeqs = append(signerSeqs, signerAccs[i].GetSequence())
+
 			// check signature, return account with incremented nonce
 			signBytes := GetSignBytes(newCtx.ChainID(), stdTx, signerAccs[i], isGenesis)
 			signerAccs[i], res = processSig(newCtx, signerAccs[i], stdSigs[i], signBytes, simulate, params, sigGasConsumer)
@@ -150,6 +153,9 @@ func NewAnteHandler(ak AccountKeeper, supplyKeeper types.SupplyKeeper, sigGasCon
 			ak.SetAccount(newCtx, signerAccs[i])
 		}
 
+		newCtx = WithTxSigners(newCtx, signerAddrs)
+		newCtx = WithTxSequences(newCtx, signerSeqs)
+
 		// TODO: tx tags (?)
 		return newCtx, sdk.Result{GasWanted: stdTx.Fee.Gas}, false // continue...
 	}
@@ -414,6 +414,37 @@ func TestAnteHandlerMultiSigner(t *testing.T) {
 	checkValidTx(t, anteHandler, ctx, tx, false)
 }
 
+// Test that the verified signers of the tx are passed to the msg handlers
+func TestAnteHandlerTxSigners(t *testing.T) {
+	// setup
+	input := setupTestInput()
+	anteHandler := NewAnteHandler(input.ak, input.sk, DefaultSigVerificationGasConsumer)
+	ctx := input.ctx.WithBlockHeight(1)
+
+	// keys and addresses
+	priv1, _, addr1 := types.KeyTestPubAddr()
+	priv2, _, addr2 := types.KeyTestPubAddr()
+
+	// set the accounts
+	acc1 := input.ak.NewAccountWithAddress(ctx, addr1)
+	acc1.SetCoins(types.NewTestCoins())
+	input.ak.SetAccount(ctx, acc1)
+	acc2 := input.ak.NewAccountWithAddress(ctx, addr2)
+	acc2.SetCoins(types.NewTestCoins())
+	input.ak.SetAccount(ctx, acc2)
+
+	require.Nil(t, GetTxSigners(ctx))
+
+	msgs := []sdk.Msg{types.NewTestMsg(addr1), types.NewTestMsg(addr2)}
+	privs, accnums, seqs := []crypto.PrivKey{priv1, priv2}, []uint64{0, 1}, []uint64{0, 0}
+	tx := types.NewTestTx(ctx, msgs, privs, accnums, seqs, types.NewTestStdFee())
+
+	newCtx, result, abort := anteHandler(ctx, tx, false)
+	require.False(t, abort)
+	require.True(t, result.IsOK())
+	require.Equal(t, []sdk.AccAddress{addr1, addr2}, GetTxSigners(newCtx))
+}
+
 func TestAnteHandlerBadSignBytes(t *testing.T) {
 	// setup
 	input := setupTestInput()
@@ -0,0 +1,39 @@
+package types
+
+import (
+	sdk "github.com/hdac-io/friday/types"
+)
+
+type txSignersKey struct{}
+type txSequencesKey struct{}
+
+// WithTxSigners returns a context holding the verified signers of the transaction,
+// so that msg handlers can see every signer and not only the ones of their own msg.
+func WithTxSigners(ctx sdk.Context, signers []sdk.AccAddress) sdk.Context {
+	return ctx.WithValue(txSignersKey{}, signers)
+}
+
+// GetTxSigners returns the verified signers of the transaction set by the ante handler
+func GetTxSigners(ctx sdk.Context) []sdk.AccAddress {
+	signers, ok := ctx.Value(txSignersKey{}).([]sdk.AccAddress)
+	if !ok {
+		return nil
+	}
+	return signers
+}
+
+// WithTxSequences returns a context holding the sequences the signers of the transaction signed
+// with, in the order of the signers
+func WithTxSequences(ctx sdk.Context, sequences []uint64) sdk.Context {
+	return ctx.WithValue(txSequencesKey{}, sequences)
+}
+
+// GetTxSequences returns the signed sequences of the transaction set by the ante handler.
+// A signer not signing in simulation has no sequence.
+func GetTxSequences(ctx sdk.Context) []uint64 {
+	sequences, ok := ctx.Value(txSequencesKey{}).([]uint64)
+	if !ok {
+		return nil
+	}
+	return sequences
+}
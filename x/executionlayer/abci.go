@@ -3,13 +3,17 @@ package executionlayer
 import (
 	"encoding/hex"
 	"fmt"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/ipc"
 	"github.com/hdac-io/casperlabs-ee-grpc-go-util/storedvalue"
 	sdk "github.com/hdac-io/friday/types"
 	"github.com/hdac-io/friday/x/executionlayer/types"
 	abci "github.com/hdac-io/tendermint/abci/types"
+	"github.com/hdac-io/tendermint/crypto"
 	tmtypes "github.com/hdac-io/tendermint/types"
 )
 
@@ -21,11 +25,21 @@ func BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock, elk ExecutionLaye
 	candidateBlock.State = unitHash.EEState
 	protocolVersion := elk.GetProtocolVersion(ctx)
 	candidateBlock.ProtocolVersion = &protocolVersion
+	candidateBlock.Cost = 0
+	candidateBlock.Size = 0
+	candidateBlock.Deploys = 0
+	candidateBlock.ExecutedDeploys = nil
 }
 
 func EndBlocker(ctx sdk.Context, req abci.RequestEndBlock, k ExecutionLayerKeeper) []abci.ValidatorUpdate {
+	defer func(start time.Time) {
+		k.metrics.EndBlockerDuration.Observe(time.Since(start).Seconds())
+	}(time.Now())
+
 	var validatorUpdates []abci.ValidatorUpdate
 
+	executeScheduledTransfers(ctx, k)
+
 	// step
 	stepRequest := &ipc.StepRequest{
 		ParentStateHash: ctx.CandidateBlock().State,
@@ -60,13 +74,28 @@ func EndBlocker(ctx sdk.Context, req abci.RequestEndBlock, k ExecutionLayerKeepe
 	}
 	nextStakeInfos := posInfos.Contract.NamedKeys.GetAllValidators()
 
+	completeStakeRequests(ctx, k)
+	recordRewardHistory(ctx, k, posInfos.Contract.NamedKeys)
+
 	// calculate and set voting power
 	validators := k.GetAllValidators(ctx)
+	rotatedConsPubKeys := make(map[string]crypto.PubKey)
+	for _, rotation := range k.GetPendingConsKeyRotations(ctx) {
+		rotatedConsPubKeys[rotation.OperatorAddress.String()] = rotation.OldConsPubKey
+	}
 
 	if len(nextStakeInfos) > 0 {
 		for _, validator := range validators {
 			var power string
 			stake, found := nextStakeInfos[hex.EncodeToString(validator.OperatorAddress)]
+			// tendermint still holds the old key of a validator rotated in the block
+			if oldConsPubKey, rotated := rotatedConsPubKeys[validator.OperatorAddress.String()]; rotated {
+				validatorUpdates = append(validatorUpdates, getRotationUpdates(oldConsPubKey, validator.ConsPubKey, validator.Stake, stake)...)
+				k.DeletePendingConsKeyRotation(ctx, validator.OperatorAddress)
+				validator.Stake = stake
+				k.SetValidator(ctx, validator.OperatorAddress, validator)
+				continue
+			}
 			if found {
 				if validator.Stake == stake {
 					continue
@@ -82,13 +111,7 @@ func EndBlocker(ctx sdk.Context, req abci.RequestEndBlock, k ExecutionLayerKeepe
 				}
 			}
 
-			if len(power) <= types.DECIMAL_POINT_POS {
-				power = "0"
-			} else {
-				power = power[:len(power)-types.DECIMAL_POINT_POS]
-			}
-
-			coin, err := strconv.ParseInt(power, 10, 64)
+			coin, err := getVotingPower(power)
 			if err != nil {
 				continue
 			}
@@ -101,9 +124,257 @@ func EndBlocker(ctx sdk.Context, req abci.RequestEndBlock, k ExecutionLayerKeepe
 		}
 	}
 
+	k.metrics.BlockDeploys.Set(float64(ctx.CandidateBlock().Deploys))
+	k.metrics.BlockCost.Set(float64(ctx.CandidateBlock().Cost))
+	k.metrics.Validators.Set(float64(len(nextStakeInfos)))
+	k.metrics.ValidatorUpdates.Add(float64(len(validatorUpdates)))
+
 	unitHash := NewUnitHashMap(ctx.CandidateBlock().State)
 
 	k.SetUnitHashMap(ctx, unitHash)
 
+	k.PruneUnitHashMap(ctx)
+	recordExecutedDeploys(ctx, k)
+	pruneDeployRecords(ctx, k)
+	k.PruneExpiredGrants(ctx, ctx.BlockTime())
+	k.PruneExpiredFeeAllowances(ctx, ctx.BlockTime())
+
 	return validatorUpdates
 }
+
+// getVotingPower converts the stake of a validator to its tendermint voting power
+func getVotingPower(stake string) (int64, error) {
+	if len(stake) <= types.DECIMAL_POINT_POS {
+		return 0, nil
+	}
+	return strconv.ParseInt(stake[:len(stake)-types.DECIMAL_POINT_POS], 10, 64)
+}
+
+// getRotationUpdates returns the validator updates of a validator which rotated its consensus pubkey
+// in the block. Tendermint holds the old key with the power of the previous stake, which is removed,
+// and the new key is added with the power of the next stake.
+func getRotationUpdates(oldConsPubKey, newConsPubKey crypto.PubKey, prevStake, nextStake string) (updates []abci.ValidatorUpdate) {
+	if prevPower, err := getVotingPower(prevStake); err == nil && prevPower > 0 {
+		updates = append(updates, abci.ValidatorUpdate{
+			PubKey: tmtypes.TM2PB.PubKey(oldConsPubKey),
+			Power:  0,
+		})
+	}
+	if nextPower, err := getVotingPower(nextStake); err == nil && nextPower > 0 {
+		updates = append(updates, abci.ValidatorUpdate{
+			PubKey: tmtypes.TM2PB.PubKey(newConsPubKey),
+			Power:  nextPower,
+		})
+	}
+	return updates
+}
+
+// recordExecutedDeploys records the deploys executed in the block in the index of the recent
+// deploys, including the failed ones whose txs reverted their records
+func recordExecutedDeploys(ctx sdk.Context, k ExecutionLayerKeeper) {
+	for _, deploy := range ctx.CandidateBlock().ExecutedDeploys {
+		if _, found := k.GetDeployRecord(ctx, deploy.DeployHash); found {
+			continue
+		}
+		k.SetDeployRecord(ctx, types.NewDeployRecord(deploy.DeployHash, ctx.BlockHeight(), deploy.TxHash))
+	}
+}
+
+// pruneDeployRecords deletes the records of the deploys older than the retention of the params,
+// which the duplicate check and the deploy query stop covering
+func pruneDeployRecords(ctx sdk.Context, k ExecutionLayerKeeper) {
+	retention := k.GetParams(ctx).DeployIndexRetention
+	if retention <= 0 {
+		return
+	}
+	k.PruneDeployRecords(ctx, ctx.BlockHeight()-retention+1)
+}
+
+// completeStakeRequests deletes the unbondings, undelegations and redelegations completed by the
+// step of the block, at their completion heights, and emits their completion events
+func completeStakeRequests(ctx sdk.Context, k ExecutionLayerKeeper) {
+	requests := k.GetMatureStakeRequests(ctx, ctx.BlockHeight())
+	for _, request := range requests {
+		k.DeleteStakeRequest(ctx, request.ID)
+	}
+	emitCompletionEvents(ctx, requests)
+}
+
+// emitCompletionEvents emits an event for each completed unbonding, undelegation and redelegation
+func emitCompletionEvents(ctx sdk.Context, requests types.StakeRequests) {
+	completionTime := ctx.BlockTime().UTC().Format(time.RFC3339)
+	for _, request := range requests {
+		if request.IsRedelegation() {
+			ctx.EventManager().EmitEvent(
+				sdk.NewEvent(
+					types.EventTypeCompleteRedelegation,
+					sdk.NewAttribute(types.AttributeKeyDelegator, request.DelegatorAddress.String()),
+					sdk.NewAttribute(types.AttributeKeySrcValidator, request.ValidatorAddress.String()),
+					sdk.NewAttribute(types.AttributeKeyDstValidator, request.ValidatorDstAddress.String()),
+					sdk.NewAttribute(types.AttributeKeyAmount, request.Amount),
+					sdk.NewAttribute(types.AttributeKeyCompletionTime, completionTime),
+				),
+			)
+			continue
+		}
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				types.EventTypeCompleteUnbonding,
+				sdk.NewAttribute(types.AttributeKeyDelegator, request.DelegatorAddress.String()),
+				sdk.NewAttribute(types.AttributeKeyValidator, request.ValidatorAddress.String()),
+				sdk.NewAttribute(types.AttributeKeyAmount, request.Amount),
+				sdk.NewAttribute(types.AttributeKeyCompletionTime, completionTime),
+			),
+		)
+	}
+}
+
+// recordRewardHistory records the rewards and commissions accrued by the step and the ones claimed
+// by the txs of the block, against the balances recorded after the step of the previous block.
+// A claim takes the whole balance, so the claimed amount is the previous balance and the step
+// earned the balance after it. The entries older than the retention of the params are pruned.
+func recordRewardHistory(ctx sdk.Context, k ExecutionLayerKeeper, namedKeys storedvalue.NamedKeys) {
+	rewardClaims := k.TakeClaims(ctx, types.RewardClaimKey)
+	commissionClaims := k.TakeClaims(ctx, types.CommissionClaimKey)
+
+	retention := k.GetParams(ctx).RewardHistoryRetention
+	if retention <= 0 {
+		return
+	}
+
+	// without the balances of the previous block, only the balances are recorded to compare with
+	recorded := k.GetPosBalancesHeight(ctx) == ctx.BlockHeight()-1
+	rewards := getPosAmounts(namedKeys, storedvalue.REWARD_PREFIX)
+	commissions := getPosAmounts(namedKeys, storedvalue.COMMISSION_PREFIX)
+	prevRewards := k.SetPosBalances(ctx, types.RewardBalanceKey, rewards)
+	prevCommissions := k.SetPosBalances(ctx, types.CommissionBalanceKey, commissions)
+	k.SetPosBalancesHeight(ctx, ctx.BlockHeight())
+	if !recorded {
+		return
+	}
+
+	for _, accrual := range getPosAccruals(prevRewards, rewards, rewardClaims) {
+		delegator, err := hex.DecodeString(accrual.address)
+		if err != nil {
+			continue
+		}
+		k.SetRewardHistoryEntry(ctx, delegator, types.RewardHistoryEntry{
+			Height:      ctx.BlockHeight(),
+			Time:        ctx.BlockTime(),
+			Earned:      accrual.earned.String(),
+			Claimed:     accrual.claimed.String(),
+			Balance:     amountOf(rewards, accrual.address).String(),
+			Delegations: getDelegationStakes(namedKeys, delegator),
+		})
+	}
+
+	stakes := namedKeys.GetAllValidators()
+	for _, accrual := range getPosAccruals(prevCommissions, commissions, commissionClaims) {
+		validator, err := hex.DecodeString(accrual.address)
+		if err != nil {
+			continue
+		}
+		k.SetCommissionHistoryEntry(ctx, validator, types.CommissionHistoryEntry{
+			Height:  ctx.BlockHeight(),
+			Time:    ctx.BlockTime(),
+			Earned:  accrual.earned.String(),
+			Claimed: accrual.claimed.String(),
+			Balance: amountOf(commissions, accrual.address).String(),
+			Stake:   stakes[accrual.address],
+		})
+	}
+
+	k.PruneRewardHistory(ctx, ctx.BlockHeight()-retention+1)
+}
+
+type posAccrual struct {
+	address         string
+	earned, claimed sdk.Int
+}
+
+// getPosAccruals returns the amounts earned and claimed in a block by the addresses whose balances
+// changed, from the balances after the steps of the previous block and of the block, and the
+// addresses which claimed in the block. A decrease without a claim is taken as one, made by the
+// deploy of a tx failed after it, whose claim record is reverted while the deploy is not.
+func getPosAccruals(prevBalances, balances map[string]sdk.Int, claims map[string]bool) []posAccrual {
+	var accruals []posAccrual
+	for _, address := range sortedAmountAddresses(prevBalances, balances) {
+		prevBalance, balance := amountOf(prevBalances, address), amountOf(balances, address)
+		claimed := sdk.ZeroInt()
+		if claims[address] || balance.LT(prevBalance) {
+			claimed, prevBalance = prevBalance, sdk.ZeroInt()
+		}
+		earned := positiveDiff(balance, prevBalance)
+		if earned.IsZero() && claimed.IsZero() {
+			continue
+		}
+		accruals = append(accruals, posAccrual{address: address, earned: earned, claimed: claimed})
+	}
+	return accruals
+}
+
+// getPosAmounts returns the amounts of the named keys of the PoS contract with the prefix,
+// the rewards or the commissions, by the hex encoded addresses
+func getPosAmounts(namedKeys storedvalue.NamedKeys, prefix string) map[string]sdk.Int {
+	amounts := map[string]sdk.Int{}
+	for _, namedKey := range namedKeys {
+		values := strings.Split(namedKey.Name, "_")
+		if len(values) != 3 || values[0] != prefix {
+			continue
+		}
+		if amount, ok := sdk.NewIntFromString(values[2]); ok {
+			amounts[values[1]] = amount
+		}
+	}
+	return amounts
+}
+
+// getDelegationStakes returns the delegations of the delegator in the order of the validators
+func getDelegationStakes(namedKeys storedvalue.NamedKeys, delegator sdk.AccAddress) []types.DelegationStake {
+	delegations := namedKeys.GetDelegateFromDelegator(delegator)
+	validators := make([]string, 0, len(delegations))
+	for validator := range delegations {
+		validators = append(validators, validator)
+	}
+	sort.Strings(validators)
+
+	stakes := make([]types.DelegationStake, 0, len(validators))
+	for _, validator := range validators {
+		validatorAddr, err := hex.DecodeString(validator)
+		if err != nil {
+			continue
+		}
+		stakes = append(stakes, types.DelegationStake{Validator: validatorAddr, Amount: delegations[validator]})
+	}
+	return stakes
+}
+
+func sortedAmountAddresses(amounts ...map[string]sdk.Int) []string {
+	seen := map[string]bool{}
+	var addresses []string
+	for _, m := range amounts {
+		for address := range m {
+			if !seen[address] {
+				seen[address] = true
+				addresses = append(addresses, address)
+			}
+		}
+	}
+	sort.Strings(addresses)
+	return addresses
+}
+
+func amountOf(amounts map[string]sdk.Int, address string) sdk.Int {
+	if amount, ok := amounts[address]; ok {
+		return amount
+	}
+	return sdk.ZeroInt()
+}
+
+// positiveDiff returns a - b, or zero if a is not greater than b
+func positiveDiff(a, b sdk.Int) sdk.Int {
+	if a.LTE(b) {
+		return sdk.ZeroInt()
+	}
+	return a.Sub(b)
+}
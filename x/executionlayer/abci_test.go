@@ -0,0 +1,96 @@
+package executionlayer
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/storedvalue"
+	sdk "github.com/hdac-io/friday/types"
+	"github.com/hdac-io/friday/x/executionlayer/types"
+	"github.com/hdac-io/tendermint/crypto/ed25519"
+	tmtypes "github.com/hdac-io/tendermint/types"
+)
+
+// posContractHex is the PoS contract of the EE after the genesis of a validator, bonded and
+// delegating to itself, as read by the tests of the EE client
+const posContractHex = "01000000000500000097000000645f643730323433646439643064363436666436646632383261386637613866613035613636323962656330316438303234633336313165623163316662396638345f643730323433646439643064363436666436646632383261386637613866613035613636323962656330316438303234633336313165623163316662396638345f3130303030303030303030303030303030303001000000000000000000000000000000000000000000000000000000000000000011000000706f735f626f6e64696e675f7075727365027cdb081c47a129b41273a1d2830f7f8481eae8380978e17cec5b4e4f9e1d0b680711000000706f735f7061796d656e745f70757273650251f1ddda0933696150cf78fe7a2141653e6a841d2f4ecaaa915a299cb7a4d19c0711000000706f735f726577617264735f707572736502c32d411249f72f9da9d61c8e0d115f3000ce00d6889b8195b94bc020ba522b1b0756000000765f643730323433646439643064363436666436646632383261386637613866613035613636323962656330316438303234633336313165623163316662396638345f31303030303030303030303030303030303030010000000000000000000000000000000000000000000000000000000000000000010000000000000000000000"
+
+// TestPosContractNamedKeys checks the layout of the named keys of the PoS contract, which has
+// the delegations, the validators and the purses, and no unbonding or redelegation queue
+func TestPosContractNamedKeys(t *testing.T) {
+	bz, err := hex.DecodeString(posContractHex)
+	require.NoError(t, err)
+	var contract storedvalue.Contract
+	contract, err, _ = contract.FromBytes(bz)
+	require.NoError(t, err)
+
+	var prefixes []string
+	for _, namedKey := range contract.NamedKeys {
+		prefixes = append(prefixes, strings.Split(namedKey.Name, "_")[0])
+	}
+	require.Equal(t, []string{"d", "pos", "pos", "pos", "v"}, prefixes)
+
+	validatorHex := "d70243dd9d0d646fd6df282a8f7a8fa05a6629bec01d8024c3611eb1c1fb9f84"
+	require.Equal(t, map[string]string{validatorHex: "1000000000000000000"}, contract.NamedKeys.GetAllValidators())
+	validator, _ := hex.DecodeString(validatorHex)
+	require.Equal(t, []types.DelegationStake{{Validator: validator, Amount: "1000000000000000000"}},
+		getDelegationStakes(contract.NamedKeys, validator))
+	require.Equal(t, 0, len(getPosAmounts(contract.NamedKeys, storedvalue.REWARD_PREFIX)))
+}
+
+func TestEmitCompletionEvents(t *testing.T) {
+	delegator := sdk.AccAddress(make([]byte, 32))
+	validator := sdk.AccAddress(append([]byte{1}, make([]byte, 31)...))
+
+	ctx := sdk.Context{}.WithEventManager(sdk.NewEventManager()).WithBlockTime(time.Unix(1600000000, 0))
+	emitCompletionEvents(ctx, types.StakeRequests{
+		types.NewStakeRequest(1, types.StakeRequestUndelegate, delegator, validator, nil, "100", 1, 3),
+		types.NewStakeRequest(2, types.StakeRequestRedelegate, delegator, validator, delegator, "300", 1, 3),
+	})
+
+	events := ctx.EventManager().Events()
+	require.Equal(t, 2, len(events))
+	require.Equal(t, types.EventTypeCompleteUnbonding, events[0].Type)
+	require.Equal(t, types.EventTypeCompleteRedelegation, events[1].Type)
+	require.Contains(t, events[0].Attributes, sdk.NewAttribute(types.AttributeKeyAmount, "100").ToKVPair())
+	require.Contains(t, events[1].Attributes, sdk.NewAttribute(types.AttributeKeyDstValidator, delegator.String()).ToKVPair())
+	require.Contains(t, events[1].Attributes, sdk.NewAttribute(types.AttributeKeyCompletionTime, "2020-09-13T12:26:40Z").ToKVPair())
+}
+
+func TestGetPosAccruals(t *testing.T) {
+	prevBalances := map[string]sdk.Int{"aa": sdk.NewInt(10), "bb": sdk.NewInt(10), "cc": sdk.NewInt(10), "dd": sdk.NewInt(10)}
+	balances := map[string]sdk.Int{"aa": sdk.NewInt(15), "bb": sdk.NewInt(3), "cc": sdk.NewInt(12), "dd": sdk.NewInt(10), "ee": sdk.NewInt(1)}
+
+	accruals := getPosAccruals(prevBalances, balances, map[string]bool{"cc": true})
+	require.Equal(t, []posAccrual{
+		{address: "aa", earned: sdk.NewInt(5), claimed: sdk.ZeroInt()},
+		// a decrease without a claim record is a claim of a deploy of a failed tx
+		{address: "bb", earned: sdk.NewInt(3), claimed: sdk.NewInt(10)},
+		{address: "cc", earned: sdk.NewInt(12), claimed: sdk.NewInt(10)},
+		{address: "ee", earned: sdk.NewInt(1), claimed: sdk.ZeroInt()},
+	}, accruals)
+}
+
+func TestGetRotationUpdates(t *testing.T) {
+	oldConsPubKey, newConsPubKey := ed25519.GenPrivKey().PubKey(), ed25519.GenPrivKey().PubKey()
+	stake := "3" + strings.Repeat("0", types.DECIMAL_POINT_POS)
+
+	// same power moves to the new key
+	updates := getRotationUpdates(oldConsPubKey, newConsPubKey, stake, stake)
+	require.Equal(t, 2, len(updates))
+	require.Equal(t, tmtypes.TM2PB.PubKey(oldConsPubKey), updates[0].PubKey)
+	require.Equal(t, int64(0), updates[0].Power)
+	require.Equal(t, tmtypes.TM2PB.PubKey(newConsPubKey), updates[1].PubKey)
+	require.Equal(t, int64(3), updates[1].Power)
+
+	// a validator without power is not known to tendermint
+	require.Equal(t, 1, len(getRotationUpdates(oldConsPubKey, newConsPubKey, "", stake)))
+	updates = getRotationUpdates(oldConsPubKey, newConsPubKey, stake, "")
+	require.Equal(t, 1, len(updates))
+	require.Equal(t, tmtypes.TM2PB.PubKey(oldConsPubKey), updates[0].PubKey)
+	require.Equal(t, 0, len(getRotationUpdates(oldConsPubKey, newConsPubKey, "", "")))
+}
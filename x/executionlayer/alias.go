@@ -8,16 +8,39 @@ const (
 	ModuleName      = types.ModuleName
 	RouterKey       = types.RouterKey
 	HashMapStoreKey = types.HashMapStoreKey
+
+	DefaultParamspace    = types.DefaultParamspace
+	MigrationUpgradeName = types.MigrationUpgradeName
 )
 
 var (
 	// function aliases
-	NewMsgExecute  = types.NewMsgExecute
-	NewMsgTransfer = types.NewMsgTransfer
-	NewMsgBond     = types.NewMsgBond
-	NewMsgUnBond   = types.NewMsgUnBond
-	RegisterCodec  = types.RegisterCodec
-	NewUnitHashMap = types.NewUnitHashMap
+	NewMsgExecute             = types.NewMsgExecute
+	NewMsgTransfer            = types.NewMsgTransfer
+	NewMsgBond                = types.NewMsgBond
+	NewMsgUnBond              = types.NewMsgUnBond
+	NewMsgRotateConsPubKey    = types.NewMsgRotateConsPubKey
+	NewMsgDeployContract      = types.NewMsgDeployContract
+	NewMsgSetContractSchema   = types.NewMsgSetContractSchema
+	NewMsgAddAssociatedKey    = types.NewMsgAddAssociatedKey
+	NewMsgRemoveAssociatedKey = types.NewMsgRemoveAssociatedKey
+	NewMsgUpdateAssociatedKey = types.NewMsgUpdateAssociatedKey
+	NewMsgSetActionThreshold  = types.NewMsgSetActionThreshold
+	NewMsgAuthorize           = types.NewMsgAuthorize
+	NewMsgGrant               = types.NewMsgGrant
+	NewMsgRevoke              = types.NewMsgRevoke
+	NewMsgExec                = types.NewMsgExec
+	NewGrant                  = types.NewGrant
+	NewMsgGrantFeeAllowance   = types.NewMsgGrantFeeAllowance
+	NewMsgRevokeFeeAllowance  = types.NewMsgRevokeFeeAllowance
+	NewFeeAllowance           = types.NewFeeAllowance
+	NewMsgScheduleTransfer    = types.NewMsgScheduleTransfer
+	NewMsgCancelSchedule      = types.NewMsgCancelSchedule
+	NewSchedule               = types.NewSchedule
+	RegisterCodec             = types.RegisterCodec
+	NewUnitHashMap            = types.NewUnitHashMap
+	NewParams                 = types.NewParams
+	DefaultParams             = types.DefaultParams
 
 	// variable aliases
 	ModuleCdc               = types.ModuleCdc
@@ -28,6 +51,8 @@ var (
 	ErrValidatorOwnerExists            = types.ErrValidatorOwnerExists
 	ErrValidatorPubKeyExists           = types.ErrValidatorPubKeyExists
 	ErrValidatorPubKeyTypeNotSupported = types.ErrValidatorPubKeyTypeNotSupported
+	ErrNoValidatorFound                = types.ErrNoValidatorFound
+	ErrConsKeyRotationCooldown         = types.ErrConsKeyRotationCooldown
 )
 
 type (
@@ -36,6 +61,29 @@ type (
 	MsgUnBond                 = types.MsgUnBond
 	MsgCreateValidator        = types.MsgCreateValidator
 	MsgEditValidator          = types.MsgEditValidator
+	MsgRotateConsPubKey       = types.MsgRotateConsPubKey
+	MsgDeployContract         = types.MsgDeployContract
+	MsgSetContractSchema      = types.MsgSetContractSchema
+	MsgAddAssociatedKey       = types.MsgAddAssociatedKey
+	MsgRemoveAssociatedKey    = types.MsgRemoveAssociatedKey
+	MsgUpdateAssociatedKey    = types.MsgUpdateAssociatedKey
+	MsgSetActionThreshold     = types.MsgSetActionThreshold
+	MsgAuthorize              = types.MsgAuthorize
+	MsgGrant                  = types.MsgGrant
+	MsgRevoke                 = types.MsgRevoke
+	MsgExec                   = types.MsgExec
+	Grant                     = types.Grant
+	Grants                    = types.Grants
+	MsgGrantFeeAllowance      = types.MsgGrantFeeAllowance
+	MsgRevokeFeeAllowance     = types.MsgRevokeFeeAllowance
+	FeeAllowance              = types.FeeAllowance
+	FeeAllowances             = types.FeeAllowances
+	MsgScheduleTransfer       = types.MsgScheduleTransfer
+	MsgCancelSchedule         = types.MsgCancelSchedule
+	Schedule                  = types.Schedule
+	Schedules                 = types.Schedules
+	ContractInfo              = types.ContractInfo
+	ContractSchema            = types.ContractSchema
 	UnitHashMap               = types.UnitHashMap
 	QueryExecutionLayerDetail = types.QueryExecutionLayerDetail
 	QueryGetBalanceDetail     = types.QueryGetBalanceDetail
@@ -46,4 +94,15 @@ type (
 	QueryVoterParams          = types.QueryVoterParams
 	QueryVoterParamsUref      = types.QueryVoterParamsUref
 	QueryVoterParamsHash      = types.QueryVoterParamsHash
+	QueryContractParams       = types.QueryContractParams
+	QueryDryRunParams         = types.QueryDryRunParams
+	QueryGrantsParams         = types.QueryGrantsParams
+	QueryFeeAllowancesParams  = types.QueryFeeAllowancesParams
+	QuerySchedulesParams      = types.QuerySchedulesParams
+	DryRunResult              = types.DryRunResult
+	StakeRequest              = types.StakeRequest
+	StakeRequests             = types.StakeRequests
+	Params                    = types.Params
+	RewardHistory             = types.RewardHistory
+	CommissionHistory         = types.CommissionHistory
 )
@@ -0,0 +1,224 @@
+package executionlayer
+
+import (
+	sdk "github.com/hdac-io/friday/types"
+	"github.com/hdac-io/friday/x/auth"
+	"github.com/hdac-io/friday/x/executionlayer/types"
+)
+
+// NewAnteHandler returns an AnteHandler running the ante handler of auth, then checking a schedule
+// transfer has its own tx, and charging the grants used by the exec of the tx and the fee allowances
+// used by its sponsored deploys. Like the fees and the sequences of auth, the charges are written
+// even if the msgs fail, as the deploys committed to the EE before the failure are not reverted.
+func NewAnteHandler(k ExecutionLayerKeeper, anteHandler sdk.AnteHandler) sdk.AnteHandler {
+	return func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, sdk.Result, bool) {
+		newCtx, res, abort := anteHandler(ctx, tx, simulate)
+		if abort {
+			return newCtx, res, abort
+		}
+
+		err := checkScheduleTransfers(tx.GetMsgs())
+		if err == nil {
+			err = chargeGrants(newCtx, k, tx.GetMsgs())
+		}
+		if err == nil {
+			err = chargeFeeAllowances(newCtx, k, tx.GetMsgs())
+		}
+		if err != nil {
+			result := err.Result()
+			result.GasWanted = res.GasWanted
+			return newCtx, result, true
+		}
+		return newCtx.WithValue(chargedKey{}, true), res, false
+	}
+}
+
+// chargedKey is the context key marking the grants and the fee allowances of the tx charged by the
+// ante handler
+type chargedKey struct{}
+
+// isCharged returns whether the ante handler charged the grants and the fee allowances of the tx
+func isCharged(ctx sdk.Context) bool {
+	charged, _ := ctx.Value(chargedKey{}).(bool)
+	return charged
+}
+
+// checkScheduleTransfers checks a schedule transfer only shares its tx with the MsgAuthorize of
+// co-signers. The escrow of the schedule is funded by a deploy committed to the EE, so another msg
+// failing the tx would revert the schedule and leave its escrow funded with no schedule to pay
+// out or refund it.
+func checkScheduleTransfers(msgs []sdk.Msg) sdk.Error {
+	schedules := 0
+	others := 0
+	for _, msg := range msgs {
+		switch msg.(type) {
+		case types.MsgScheduleTransfer:
+			schedules++
+		case types.MsgAuthorize:
+		default:
+			others++
+		}
+	}
+	if schedules > 1 || (schedules == 1 && others > 0) {
+		return types.ErrInvalidSchedule(types.DefaultCodespace, "a schedule transfer can only share its tx with authorizations")
+	}
+	return nil
+}
+
+// chargeGrants checks the grants of the granters to the grantee of the exec allow the msgs of the
+// exec, and deducts the total spending of the msgs from the grants. An exec can only share its tx
+// with the MsgAuthorize of co-signers, so that no other msg can fail the tx after the deploys of
+// the exec are committed.
+func chargeGrants(ctx sdk.Context, k ExecutionLayerKeeper, msgs []sdk.Msg) sdk.Error {
+	var execs []types.MsgExec
+	others := 0
+	for _, msg := range msgs {
+		switch msg := msg.(type) {
+		case types.MsgExec:
+			execs = append(execs, msg)
+		case types.MsgAuthorize:
+		default:
+			others++
+		}
+	}
+	if len(execs) == 0 {
+		return nil
+	}
+	if len(execs) > 1 || others > 0 {
+		return types.ErrInvalidExec(types.DefaultCodespace, "an exec can only share its tx with authorizations")
+	}
+
+	exec := execs[0]
+	grants := map[string]types.Grant{}
+	var granters []string
+	for _, msg := range exec.Msgs {
+		granter := msg.GetSigners()[0]
+		grant, found := grants[granter.String()]
+		if !found {
+			var err sdk.Error
+			if grant, err = getGrant(ctx, k, granter, exec.Grantee); err != nil {
+				return err
+			}
+			granters = append(granters, granter.String())
+		}
+
+		grant, err := useGrant(grant, msg)
+		if err != nil {
+			return err
+		}
+		grants[granter.String()] = grant
+	}
+
+	for _, granter := range granters {
+		k.SetGrant(ctx, grants[granter])
+	}
+	return nil
+}
+
+// getGrant returns the unexpired grant of the granter to the grantee
+func getGrant(ctx sdk.Context, k ExecutionLayerKeeper, granter, grantee sdk.AccAddress) (types.Grant, sdk.Error) {
+	grant, found := k.GetGrant(ctx, granter, grantee)
+	if !found {
+		return grant, types.ErrGrantNotFound(types.DefaultCodespace, granter, grantee)
+	}
+	if grant.IsExpired(ctx.BlockTime()) {
+		return grant, types.ErrGrantExpired(types.DefaultCodespace, granter, grantee, grant.Expiration)
+	}
+	return grant, nil
+}
+
+// useGrant checks the grant allows the msg, and returns the grant with the spending of the msg deducted
+func useGrant(grant types.Grant, msg sdk.Msg) (types.Grant, sdk.Error) {
+	msgType, ok := types.GetGrantMsgType(msg)
+	if !ok {
+		return grant, types.ErrMsgNotGrantable(types.DefaultCodespace, msg)
+	}
+	if !grant.Allows(msgType) {
+		return grant, types.ErrMsgNotGranted(types.DefaultCodespace, msgType, grant.Granter, grant.Grantee)
+	}
+
+	amount, err := types.GetSpendAmount(msg)
+	if err != nil {
+		return grant, err
+	}
+	return grant.Spend(amount)
+}
+
+// chargeFeeAllowances checks the fee payers of the sponsored deploys of the msgs, including the msgs
+// of an exec, co-signed the tx and allow the senders to spend the fees, and deducts the charges of
+// the deploys from the allowances. The handler then funds the fees from the payers' accounts.
+func chargeFeeAllowances(ctx sdk.Context, k ExecutionLayerKeeper, msgs []sdk.Msg) sdk.Error {
+	var deployMsgs []types.DeployMsg
+	for _, msg := range msgs {
+		deployed := []sdk.Msg{msg}
+		if exec, ok := msg.(types.MsgExec); ok {
+			deployed = exec.Msgs
+		}
+		for _, msg := range deployed {
+			if deployMsg, ok := msg.(types.DeployMsg); ok {
+				deployMsgs = append(deployMsgs, deployMsg)
+			}
+		}
+	}
+
+	allowances := map[string]types.FeeAllowance{}
+	var keys []string
+	for _, msg := range deployMsgs {
+		header := msg.GetDeployHeader()
+		if header == nil || header.FeePayer.Empty() {
+			continue
+		}
+		payer, sender := header.FeePayer, msg.GetSigners()[0]
+		key := string(types.GetFeeAllowanceKey(payer, sender))
+		allowance, found := allowances[key]
+		if !found {
+			var err sdk.Error
+			if allowance, err = getFeeAllowance(ctx, k, payer, sender); err != nil {
+				return err
+			}
+			keys = append(keys, key)
+		}
+
+		charge, err := types.GetSponsoredCharge(types.GetDeployFee(msg))
+		if err != nil {
+			return err
+		}
+		if allowance, err = allowance.Spend(charge); err != nil {
+			return err
+		}
+		allowances[key] = allowance
+	}
+
+	for _, key := range keys {
+		k.SetFeeAllowance(ctx, allowances[key])
+	}
+	return nil
+}
+
+// getFeeAllowance returns the unexpired fee allowance of the payer to the sender, checking the payer
+// co-signed the tx
+func getFeeAllowance(ctx sdk.Context, k ExecutionLayerKeeper, payer, sender sdk.AccAddress) (types.FeeAllowance, sdk.Error) {
+	if payer.Equals(sender) {
+		return types.FeeAllowance{}, types.ErrInvalidDeployHeader(types.DefaultCodespace, "fee payer must be different from the sender")
+	}
+
+	signed := false
+	for _, signer := range auth.GetTxSigners(ctx) {
+		if signer.Equals(payer) {
+			signed = true
+			break
+		}
+	}
+	if !signed {
+		return types.FeeAllowance{}, types.ErrFeePayerNotSigned(types.DefaultCodespace, payer)
+	}
+
+	allowance, found := k.GetFeeAllowance(ctx, payer, sender)
+	if !found {
+		return allowance, types.ErrFeeAllowanceNotFound(types.DefaultCodespace, payer, sender)
+	}
+	if allowance.IsExpired(ctx.BlockTime()) {
+		return allowance, types.ErrFeeAllowanceExpired(types.DefaultCodespace, payer, sender, allowance.Expiration)
+	}
+	return allowance, nil
+}
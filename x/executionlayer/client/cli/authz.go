@@ -0,0 +1,228 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/hdac-io/friday/client"
+	"github.com/hdac-io/friday/client/context"
+	"github.com/hdac-io/friday/codec"
+	sdk "github.com/hdac-io/friday/types"
+	"github.com/hdac-io/friday/x/auth"
+	"github.com/hdac-io/friday/x/auth/client/utils"
+	cliutil "github.com/hdac-io/friday/x/executionlayer/client/util"
+	"github.com/hdac-io/friday/x/executionlayer/types"
+)
+
+// GetCmdGrant is the CLI command for authorizing a grantee to execute msgs on behalf of the sender
+func GetCmdGrant(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "grant <grantee_nickname>|<address> <msg-types> <duration> --from <from> [--spend-limit <amount>]",
+		Short: "Authorize a grantee to execute msgs on your behalf",
+		Long: "Authorize a grantee to execute msgs on your behalf, e.g. to claim and delegate the rewards periodically\n" +
+			fmt.Sprintf("<msg-types> is a comma separated list of %s.\n", strings.Join(types.GrantableMsgTypes, ",")) +
+			"The grant expires after <duration> from now, e.g. 720h, and replaces the previous grant to the grantee.\n" +
+			"With --spend-limit, the fees and the amounts of the executed msgs are limited to the amount in total.",
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			kb, err := client.NewKeyBaseFromDir(viper.GetString(client.FlagHome))
+			if err != nil {
+				return err
+			}
+
+			valueFromFromFlag := viper.GetString(client.FlagFrom)
+			keyInfo, err := cliutil.GetLocalWalletInfo(valueFromFromFlag, kb, cdc, cliCtx)
+			if err != nil {
+				return err
+			}
+
+			cliCtx = cliCtx.WithFromAddress(keyInfo.GetAddress()).WithFromName(keyInfo.GetName())
+			granter := keyInfo.GetAddress()
+
+			grantee, err := cliutil.GetAddress(cliCtx.Codec, cliCtx, args[0])
+			if err != nil {
+				return fmt.Errorf("no nickname mapping of %s", args[0])
+			}
+
+			var msgTypes []string
+			for _, msgType := range strings.Split(args[1], ",") {
+				msgTypes = append(msgTypes, strings.TrimSpace(msgType))
+			}
+
+			duration, err := time.ParseDuration(args[2])
+			if err != nil {
+				return err
+			}
+			if duration <= 0 {
+				return fmt.Errorf("duration must be positive")
+			}
+
+			spendLimit := ""
+			if spendLimitStr := viper.GetString(FlagSpendLimit); spendLimitStr != "" {
+				limit, err := cliutil.ToBigsun(cliutil.Hdac(spendLimitStr))
+				if err != nil {
+					return err
+				}
+				spendLimit = string(limit)
+			}
+
+			// build and sign the transaction, then broadcast to Tendermint
+			msg := types.NewMsgGrant(granter, grantee, msgTypes, spendLimit, time.Now().Add(duration).UTC())
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(client.FlagHome, DefaultClientHome, "Custom local path of client's home dir")
+	cmd.Flags().String(client.FlagFrom, "", "Granter's identity (one of wallet alias, address, nickname)")
+	cmd.Flags().String(FlagSpendLimit, "", "Total amount of the fees and the amounts the grantee can spend")
+
+	return cmd
+}
+
+// GetCmdRevoke is the CLI command for revoking a grant
+func GetCmdRevoke(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "revoke <grantee_nickname>|<address> --from <from>",
+		Short: "Revoke the grant to a grantee",
+		Long:  "Revoke the grant to a grantee",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			kb, err := client.NewKeyBaseFromDir(viper.GetString(client.FlagHome))
+			if err != nil {
+				return err
+			}
+
+			valueFromFromFlag := viper.GetString(client.FlagFrom)
+			keyInfo, err := cliutil.GetLocalWalletInfo(valueFromFromFlag, kb, cdc, cliCtx)
+			if err != nil {
+				return err
+			}
+
+			cliCtx = cliCtx.WithFromAddress(keyInfo.GetAddress()).WithFromName(keyInfo.GetName())
+			granter := keyInfo.GetAddress()
+
+			grantee, err := cliutil.GetAddress(cliCtx.Codec, cliCtx, args[0])
+			if err != nil {
+				return fmt.Errorf("no nickname mapping of %s", args[0])
+			}
+
+			// build and sign the transaction, then broadcast to Tendermint
+			msg := types.NewMsgRevoke(granter, grantee)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(client.FlagHome, DefaultClientHome, "Custom local path of client's home dir")
+	cmd.Flags().String(client.FlagFrom, "", "Granter's identity (one of wallet alias, address, nickname)")
+
+	return cmd
+}
+
+// GetCmdExec is the CLI command for executing the msgs of a generated tx on behalf of their signers
+func GetCmdExec(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "exec <tx-file> --from <from>",
+		Short: "Execute the msgs of a tx on behalf of the granters",
+		Long: "Execute the msgs of a tx on behalf of the granters\n" +
+			"<tx-file> is a tx generated with --generate-only by the granters, e.g. a claim and a delegate.\n" +
+			"Only the grantee signs, and the grants of the signers of the msgs must allow them.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			kb, err := client.NewKeyBaseFromDir(viper.GetString(client.FlagHome))
+			if err != nil {
+				return err
+			}
+
+			valueFromFromFlag := viper.GetString(client.FlagFrom)
+			keyInfo, err := cliutil.GetLocalWalletInfo(valueFromFromFlag, kb, cdc, cliCtx)
+			if err != nil {
+				return err
+			}
+
+			cliCtx = cliCtx.WithFromAddress(keyInfo.GetAddress()).WithFromName(keyInfo.GetName())
+			grantee := keyInfo.GetAddress()
+
+			stdTx, err := utils.ReadStdTxFromFile(cdc, args[0])
+			if err != nil {
+				return err
+			}
+
+			// build and sign the transaction, then broadcast to Tendermint
+			msg := types.NewMsgExec(grantee, stdTx.GetMsgs())
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(client.FlagHome, DefaultClientHome, "Custom local path of client's home dir")
+	cmd.Flags().String(client.FlagFrom, "", "Grantee's identity (one of wallet alias, address, nickname)")
+
+	return cmd
+}
+
+// GetCmdQueryGrants implements the grant query command.
+func GetCmdQueryGrants(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "grants [--granter <granter>] [--grantee <grantee>]",
+		Short: "Query the grants of a granter or to a grantee",
+		Long: "Query the grants of a granter or to a grantee\n" +
+			"With both, the grant of the granter to the grantee is queried.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			var granter, grantee sdk.AccAddress
+			var err error
+			if granterStr := viper.GetString(FlagGranter); granterStr != "" {
+				granter, err = cliutil.GetAddress(cdc, cliCtx, granterStr)
+				if err != nil {
+					return fmt.Errorf("no nickname mapping of %s", granterStr)
+				}
+			}
+			if granteeStr := viper.GetString(FlagGrantee); granteeStr != "" {
+				grantee, err = cliutil.GetAddress(cdc, cliCtx, granteeStr)
+				if err != nil {
+					return fmt.Errorf("no nickname mapping of %s", granteeStr)
+				}
+			}
+			if granter.Empty() && grantee.Empty() {
+				return fmt.Errorf("--%s or --%s is required", FlagGranter, FlagGrantee)
+			}
+
+			queryData := types.NewQueryGrantsParams(granter, grantee)
+			bz := cdc.MustMarshalJSON(queryData)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/querygrants", types.ModuleName), bz)
+			if err != nil {
+				return err
+			}
+
+			var out types.Grants
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+
+	cmd.Flags().String(FlagGranter, "", "Granter's identity (one of address, nickname)")
+	cmd.Flags().String(FlagGrantee, "", "Grantee's identity (one of address, nickname)")
+
+	return cmd
+}
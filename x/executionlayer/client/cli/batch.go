@@ -0,0 +1,286 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/hdac-io/friday/client"
+	"github.com/hdac-io/friday/client/context"
+	"github.com/hdac-io/friday/client/flags"
+	"github.com/hdac-io/friday/client/input"
+	"github.com/hdac-io/friday/client/keys"
+	"github.com/hdac-io/friday/codec"
+	sdk "github.com/hdac-io/friday/types"
+	"github.com/hdac-io/friday/x/auth"
+	"github.com/hdac-io/friday/x/auth/client/utils"
+	cliutil "github.com/hdac-io/friday/x/executionlayer/client/util"
+)
+
+// nolint
+const (
+	FlagReport     = "report"
+	FlagResume     = "resume"
+	FlagMaxMsgs    = "max-msgs"
+	FlagMaxTxBytes = "max-tx-bytes"
+	FlagMaxTxGas   = "max-tx-gas"
+)
+
+// GetCmdTransferBatch is the CLI command for transferring to many recipients with few txs
+func GetCmdTransferBatch(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "transfer-batch <file.csv>|<file.json> <fee> --from <from> [--report <report.json>] [--resume]",
+		Short: "Transfer Hdac token to many recipients",
+		Long: "Transfer Hdac token to many recipients\n" +
+			"The file has lines of '<recipient_nickname>|<address>,<amount>' in CSV, or a JSON array of\n" +
+			"{\"recipient\": ..., \"amount\": ...}. The fee is paid for each transfer.\n" +
+			"The transfers are packed into as few txs as fit --max-msgs and --max-tx-bytes, and --max-tx-gas\n" +
+			"with --gas=auto, which must be within the block size and gas limits of the chain.\n" +
+			"The outcome of each transfer is written to the report after each tx. With --resume, the\n" +
+			"delivered transfers of the report are skipped and the failed ones are sent again. Transfers\n" +
+			"broadcast but not in a block yet are confirmed by querying their txs, and are left as they are\n" +
+			"until they're found in a block. The transfers of a tx failed in a block before the failing one\n" +
+			"are committed and delivered; if the logs of the tx don't tell which, they're marked unknown and\n" +
+			"not sent again, so check the balances of their recipients.\n" +
+			"Resuming is not idempotent: every transfer the report has as pending or failed is sent again, so\n" +
+			"resume only with the report of the last run, and don't edit it.",
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			kb, err := client.NewKeyBaseFromDir(viper.GetString(client.FlagHome))
+			if err != nil {
+				return err
+			}
+
+			valueFromFromFlag := viper.GetString(client.FlagFrom)
+			keyInfo, err := cliutil.GetLocalWalletInfo(valueFromFromFlag, kb, cdc, cliCtx)
+			if err != nil {
+				return err
+			}
+			cliCtx = cliCtx.WithFromAddress(keyInfo.GetAddress()).WithFromName(keyInfo.GetName())
+			txBldr = txBldr.WithKeybase(kb)
+
+			fee, err := cliutil.ToBigsun(cliutil.Hdac(args[1]))
+			if err != nil {
+				return err
+			}
+
+			entries, err := cliutil.ReadTransferBatch(args[0])
+			if err != nil {
+				return err
+			}
+			if len(entries) == 0 {
+				return fmt.Errorf("no transfer in %s", args[0])
+			}
+
+			reportFile := viper.GetString(FlagReport)
+			if reportFile == "" {
+				reportFile = args[0] + ".report.json"
+			}
+
+			results := cliutil.NewTransferBatchResults(entries)
+			if viper.GetBool(FlagResume) {
+				previous, err := cliutil.ReadTransferBatchReport(reportFile)
+				if err != nil {
+					return err
+				}
+				confirmTransferBatch(cliCtx, previous)
+
+				results, err = cliutil.ResumeTransferBatch(entries, previous)
+				if err != nil {
+					return err
+				}
+			}
+
+			msgs, indices := cliutil.NewTransferBatchMsgs(cliCtx, keyInfo.GetAddress(), results, fee)
+			if len(msgs) == 0 {
+				printTransferBatchSummary(results)
+				return cliutil.WriteTransferBatchReport(reportFile, results)
+			}
+
+			txBldr, err = utils.PrepareTxBuilder(txBldr, cliCtx)
+			if err != nil {
+				return err
+			}
+
+			stdSignMsg, err := txBldr.BuildSignMsg(nil)
+			if err != nil {
+				return err
+			}
+			txSize := cliutil.SignedTxSize(txBldr.TxEncoder(), stdSignMsg.Fee, stdSignMsg.Memo)
+			packs, err := cliutil.PackMsgs(msgs, viper.GetInt(FlagMaxMsgs), viper.GetInt(FlagMaxTxBytes), txSize)
+			if err != nil {
+				return err
+			}
+
+			_, _ = fmt.Fprintf(os.Stderr, "%d transfers in %d txs from %s, %d invalid\n",
+				len(msgs), len(packs), keyInfo.GetAddress(), countTransferBatch(results, cliutil.TransferInvalid))
+			if !cliCtx.SkipConfirm {
+				ok, err := input.GetConfirmation("confirm transfers before signing and broadcasting", bufio.NewReader(os.Stdin))
+				if err != nil || !ok {
+					_, _ = fmt.Fprintf(os.Stderr, "%s\n", "cancelled transfers")
+					return err
+				}
+			}
+
+			passphrase, err := keys.GetPassphrase(keyInfo.GetName())
+			if err != nil {
+				return err
+			}
+
+			maxTxGas := viper.GetUint64(FlagMaxTxGas)
+			sequence := txBldr.Sequence()
+			pending := make([]transferBatchTx, 0, len(packs))
+			next := 0
+			for _, pack := range packs {
+				pending = append(pending, transferBatchTx{pack, indices[next : next+len(pack)]})
+				next += len(pack)
+			}
+
+			for tx := 0; len(pending) != 0; {
+				batchTx := pending[0]
+				pending = pending[1:]
+
+				bldr, txErr := txBldr.WithSequence(sequence), error(nil)
+				if bldr.SimulateAndExecute() {
+					bldr, txErr = utils.EnrichWithGas(bldr, cliCtx, batchTx.msgs)
+					if txErr == nil && maxTxGas != 0 && bldr.Gas() > maxTxGas && len(batchTx.msgs) > 1 {
+						// split the tx until it fits the gas limit
+						half := len(batchTx.msgs) / 2
+						pending = append([]transferBatchTx{
+							{batchTx.msgs[:half], batchTx.indices[:half]},
+							{batchTx.msgs[half:], batchTx.indices[half:]},
+						}, pending...)
+						continue
+					}
+				}
+
+				var res sdk.TxResponse
+				if txErr == nil {
+					res, txErr = broadcastTransferBatchTx(cliCtx, bldr, keyInfo.GetName(), passphrase, batchTx.msgs)
+				}
+				sequence += markTransferBatch(results, batchTx.indices, tx, cliCtx.BroadcastMode, res, txErr)
+				tx++
+
+				if err := cliutil.WriteTransferBatchReport(reportFile, results); err != nil {
+					return err
+				}
+			}
+
+			printTransferBatchSummary(results)
+			_, _ = fmt.Fprintf(os.Stderr, "report is written to %s\n", reportFile)
+			return nil
+		},
+	}
+
+	cmd.Flags().String(client.FlagHome, DefaultClientHome, "Custom local path of client's home dir")
+	cmd.Flags().String(client.FlagFrom, "", "Executor's identity (one of wallet alias, address, nickname)")
+	cmd.Flags().String(FlagReport, "", "File of the outcome of each transfer, <file>.report.json by default")
+	cmd.Flags().Bool(FlagResume, false, "Resume the batch from the outcomes of the report")
+	cmd.Flags().Int(FlagMaxMsgs, cliutil.DefaultBatchMaxMsgs, "Max number of the transfers of a tx")
+	cmd.Flags().Int(FlagMaxTxBytes, cliutil.DefaultBatchMaxTxBytes, "Max size of a tx in bytes")
+	cmd.Flags().Uint64(FlagMaxTxGas, 0, "Max gas of a tx estimated with --gas=auto, unlimited if 0")
+
+	return cmd
+}
+
+type transferBatchTx struct {
+	msgs    []sdk.Msg
+	indices []int
+}
+
+func broadcastTransferBatchTx(cliCtx context.CLIContext, txBldr auth.TxBuilder, name, passphrase string,
+	msgs []sdk.Msg) (sdk.TxResponse, error) {
+	txBytes, err := txBldr.BuildAndSign(name, passphrase, msgs)
+	if err != nil {
+		return sdk.TxResponse{}, err
+	}
+	return cliCtx.BroadcastTx(txBytes)
+}
+
+// markTransferBatch marks the results of a broadcast tx, and returns the number of the
+// sequences used by the tx
+func markTransferBatch(results []cliutil.TransferBatchResult, indices []int, tx int, mode string,
+	res sdk.TxResponse, err error) uint64 {
+	for _, i := range indices {
+		results[i].Tx = tx
+	}
+
+	status, errMsg := cliutil.TransferBroadcast, ""
+	switch {
+	case err != nil:
+		status, errMsg = cliutil.TransferFailed, err.Error()
+	case res.Code != 0 && res.Height == 0:
+		// a tx rejected by CheckTx used neither the sequence nor the balance
+		status, errMsg = cliutil.TransferFailed, res.RawLog
+	case mode == flags.BroadcastBlock:
+		cliutil.MarkTransferBatchTx(results, indices, res)
+		return 1
+	}
+
+	for _, i := range indices {
+		results[i].Status = status
+		results[i].TxHash = res.TxHash
+		results[i].Error = errMsg
+	}
+	if status == cliutil.TransferFailed {
+		return 0
+	}
+	return 1
+}
+
+// confirmTransferBatch marks the broadcast transfers of the results by their txs in the blocks
+func confirmTransferBatch(cliCtx context.CLIContext, results []cliutil.TransferBatchResult) {
+	var txHashes []string
+	txIndices := make(map[string][]int)
+	for i, result := range results {
+		if result.Status != cliutil.TransferBroadcast {
+			continue
+		}
+		if _, ok := txIndices[result.TxHash]; !ok {
+			txHashes = append(txHashes, result.TxHash)
+		}
+		txIndices[result.TxHash] = append(txIndices[result.TxHash], i)
+	}
+
+	for _, txHash := range txHashes {
+		res, err := utils.QueryTx(cliCtx, txHash)
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "tx %s is not found in a block yet: %s\n", txHash, err.Error())
+			continue
+		}
+		cliutil.MarkTransferBatchTx(results, txIndices[txHash], res)
+	}
+}
+
+func countTransferBatch(results []cliutil.TransferBatchResult, status string) (count int) {
+	for _, result := range results {
+		if result.Status == status {
+			count++
+		}
+	}
+	return count
+}
+
+func printTransferBatchSummary(results []cliutil.TransferBatchResult) {
+	for _, status := range []string{
+		cliutil.TransferDelivered, cliutil.TransferBroadcast, cliutil.TransferFailed, cliutil.TransferUnknown,
+		cliutil.TransferInvalid,
+	} {
+		if count := countTransferBatch(results, status); count != 0 {
+			_, _ = fmt.Fprintf(os.Stderr, "%s: %d\n", status, count)
+		}
+	}
+	for _, result := range results {
+		if result.Status == cliutil.TransferFailed || result.Status == cliutil.TransferUnknown ||
+			result.Status == cliutil.TransferInvalid {
+			_, _ = fmt.Fprintf(os.Stderr, "#%d %s %s: %s %s\n",
+				result.Index, result.Recipient, result.Amount, result.Status, result.Error)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/hdac-io/friday/client"
+	"github.com/hdac-io/friday/client/context"
+	"github.com/hdac-io/friday/codec"
+	sdk "github.com/hdac-io/friday/types"
+	"github.com/hdac-io/friday/x/auth"
+	"github.com/hdac-io/friday/x/auth/client/utils"
+	cliutil "github.com/hdac-io/friday/x/executionlayer/client/util"
+	"github.com/hdac-io/friday/x/executionlayer/types"
+)
+
+// GetCmdGrantFeeAllowance is the CLI command for allowing a grantee to have the sender pay its fees
+func GetCmdGrantFeeAllowance(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "grant-fee-allowance <grantee_nickname>|<address> <duration> --from <from> [--spend-limit <amount>]",
+		Short: "Allow a grantee to have you pay the fees of its deploys",
+		Long: "Allow a grantee to have you pay the fees of its deploys\n" +
+			"The grantee sets you as the fee payer with --fee-payer, and you co-sign its txs.\n" +
+			"The allowance expires after <duration> from now, e.g. 720h, and replaces the previous allowance to the grantee.\n" +
+			"Before a deploy of the grantee runs, its fee is transferred from your account in a deploy paying the same fee,\n" +
+			"so each deploy is charged twice its fee. With --spend-limit, the charges are limited to the amount in total.",
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			kb, err := client.NewKeyBaseFromDir(viper.GetString(client.FlagHome))
+			if err != nil {
+				return err
+			}
+
+			valueFromFromFlag := viper.GetString(client.FlagFrom)
+			keyInfo, err := cliutil.GetLocalWalletInfo(valueFromFromFlag, kb, cdc, cliCtx)
+			if err != nil {
+				return err
+			}
+
+			cliCtx = cliCtx.WithFromAddress(keyInfo.GetAddress()).WithFromName(keyInfo.GetName())
+			payer := keyInfo.GetAddress()
+
+			grantee, err := cliutil.GetAddress(cliCtx.Codec, cliCtx, args[0])
+			if err != nil {
+				return fmt.Errorf("no nickname mapping of %s", args[0])
+			}
+
+			duration, err := time.ParseDuration(args[1])
+			if err != nil {
+				return err
+			}
+			if duration <= 0 {
+				return fmt.Errorf("duration must be positive")
+			}
+
+			spendLimit := ""
+			if spendLimitStr := viper.GetString(FlagSpendLimit); spendLimitStr != "" {
+				limit, err := cliutil.ToBigsun(cliutil.Hdac(spendLimitStr))
+				if err != nil {
+					return err
+				}
+				spendLimit = string(limit)
+			}
+
+			// build and sign the transaction, then broadcast to Tendermint
+			msg := types.NewMsgGrantFeeAllowance(payer, grantee, spendLimit, time.Now().Add(duration).UTC())
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(client.FlagHome, DefaultClientHome, "Custom local path of client's home dir")
+	cmd.Flags().String(client.FlagFrom, "", "Payer's identity (one of wallet alias, address, nickname)")
+	cmd.Flags().String(FlagSpendLimit, "", "Total amount of the fees paid for the grantee")
+
+	return cmd
+}
+
+// GetCmdRevokeFeeAllowance is the CLI command for revoking a fee allowance
+func GetCmdRevokeFeeAllowance(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "revoke-fee-allowance <grantee_nickname>|<address> --from <from>",
+		Short: "Revoke the fee allowance to a grantee",
+		Long:  "Revoke the fee allowance to a grantee",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			kb, err := client.NewKeyBaseFromDir(viper.GetString(client.FlagHome))
+			if err != nil {
+				return err
+			}
+
+			valueFromFromFlag := viper.GetString(client.FlagFrom)
+			keyInfo, err := cliutil.GetLocalWalletInfo(valueFromFromFlag, kb, cdc, cliCtx)
+			if err != nil {
+				return err
+			}
+
+			cliCtx = cliCtx.WithFromAddress(keyInfo.GetAddress()).WithFromName(keyInfo.GetName())
+			payer := keyInfo.GetAddress()
+
+			grantee, err := cliutil.GetAddress(cliCtx.Codec, cliCtx, args[0])
+			if err != nil {
+				return fmt.Errorf("no nickname mapping of %s", args[0])
+			}
+
+			// build and sign the transaction, then broadcast to Tendermint
+			msg := types.NewMsgRevokeFeeAllowance(payer, grantee)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(client.FlagHome, DefaultClientHome, "Custom local path of client's home dir")
+	cmd.Flags().String(client.FlagFrom, "", "Payer's identity (one of wallet alias, address, nickname)")
+
+	return cmd
+}
+
+// GetCmdQueryFeeAllowances implements the fee allowance query command.
+func GetCmdQueryFeeAllowances(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "fee-allowances [--payer <payer>] [--grantee <grantee>]",
+		Short: "Query the fee allowances of a payer or to a grantee",
+		Long: "Query the fee allowances of a payer or to a grantee\n" +
+			"With both, the fee allowance of the payer to the grantee is queried.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			var payer, grantee sdk.AccAddress
+			var err error
+			if payerStr := viper.GetString(FlagPayer); payerStr != "" {
+				payer, err = cliutil.GetAddress(cdc, cliCtx, payerStr)
+				if err != nil {
+					return fmt.Errorf("no nickname mapping of %s", payerStr)
+				}
+			}
+			if granteeStr := viper.GetString(FlagGrantee); granteeStr != "" {
+				grantee, err = cliutil.GetAddress(cdc, cliCtx, granteeStr)
+				if err != nil {
+					return fmt.Errorf("no nickname mapping of %s", granteeStr)
+				}
+			}
+			if payer.Empty() && grantee.Empty() {
+				return fmt.Errorf("--%s or --%s is required", FlagPayer, FlagGrantee)
+			}
+
+			queryData := types.NewQueryFeeAllowancesParams(payer, grantee)
+			bz := cdc.MustMarshalJSON(queryData)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/queryfeeallowances", types.ModuleName), bz)
+			if err != nil {
+				return err
+			}
+
+			var out types.FeeAllowances
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+
+	cmd.Flags().String(FlagPayer, "", "Payer's identity (one of address, nickname)")
+	cmd.Flags().String(FlagGrantee, "", "Grantee's identity (one of address, nickname)")
+
+	return cmd
+}
@@ -24,6 +24,37 @@ const (
 
 	FlagMinSelfDelegation = "min-self-delegation"
 
+	FlagCommissionRate          = "commission-rate"
+	FlagCommissionMaxRate       = "commission-max-rate"
+	FlagCommissionMaxChangeRate = "commission-max-change-rate"
+	FlagCommissionHistory       = "commission-history"
+
+	FlagDeployer     = "deployer"
+	FlagContractName = "name"
+	FlagCodeHash     = "code-hash"
+	FlagSchema       = "schema"
+
+	FlagRaw = "raw"
+
+	FlagAuthorizers = "authorizers"
+
+	FlagSpendLimit = "spend-limit"
+	FlagGranter    = "granter"
+	FlagGrantee    = "grantee"
+	FlagPayer      = "payer"
+
+	FlagInterval  = "interval"
+	FlagCount     = "count"
+	FlagSender    = "sender"
+	FlagRecipient = "recipient"
+
+	FlagTTL          = "ttl"
+	FlagDependencies = "dependencies"
+	FlagFeePayer     = "fee-payer"
+
+	FlagFromHeight = "from-height"
+	FlagToHeight   = "to-height"
+
 	FlagGenesisFormat = "genesis-format"
 	FlagNodeID        = "node-id"
 	FlagIP            = "ip"
@@ -36,6 +67,9 @@ var (
 	fsDescriptionCreate = flag.NewFlagSet("", flag.ContinueOnError)
 	fsDescriptionEdit   = flag.NewFlagSet("", flag.ContinueOnError)
 	fsValidator         = flag.NewFlagSet("", flag.ContinueOnError)
+	fsCommissionCreate  = flag.NewFlagSet("", flag.ContinueOnError)
+	fsCommissionUpdate  = flag.NewFlagSet("", flag.ContinueOnError)
+	fsDeployHeader      = flag.NewFlagSet("", flag.ContinueOnError)
 
 	DefaultClientHome = os.ExpandEnv("$HOME/.clif")
 )
@@ -51,4 +85,11 @@ func init() {
 	fsDescriptionEdit.String(FlagWebsite, types.DoNotModifyDesc, "The validator's (optional) website")
 	fsDescriptionEdit.String(FlagDetails, types.DoNotModifyDesc, "The validator's (optional) details")
 	fsValidator.String(FlagAddressValidator, "", "The Bech32 address of the validator")
+	fsCommissionCreate.String(FlagCommissionRate, "", "The initial commission rate, as a fraction")
+	fsCommissionCreate.String(FlagCommissionMaxRate, "", "The maximum commission rate, as a fraction")
+	fsCommissionCreate.String(FlagCommissionMaxChangeRate, "", "The maximum commission rate change per day, as a fraction")
+	fsCommissionUpdate.String(FlagCommissionRate, "", "The new commission rate, as a fraction")
+	fsDeployHeader.Duration(FlagTTL, 0, "Time to live of the deploy from now, e.g. 30m (default the max TTL of the chainspec with --dependencies)")
+	fsDeployHeader.String(FlagDependencies, "", "Comma separated hex encoded hashes of the deploys to be executed before this deploy")
+	fsDeployHeader.String(FlagFeePayer, "", "Address or nickname of the account paying the fee, which must co-sign the tx. Requires --generate-only")
 }
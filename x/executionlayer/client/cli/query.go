@@ -2,6 +2,7 @@ package cli
 
 import (
 	"bytes"
+	"encoding/hex"
 	"fmt"
 	"strings"
 
@@ -41,7 +42,10 @@ func GetCmdQueryBalance(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "getbalance --from <from> [--height <block_height>]",
 		Short: "Get balance of address",
-		Args:  cobra.NoArgs,
+		Long: "Get balance of address\n" +
+			"The balance is not proven, as the execution engine does not return merkle proofs of query\n" +
+			"results. Only the EE state root of a height can be verified, with geteestate.",
+		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
 
@@ -92,12 +96,37 @@ func GetCmdQueryBalance(cdc *codec.Codec) *cobra.Command {
 	return cmd
 }
 
+// GetCmdQueryEEState is a getter of the EE state root of a height
+func GetCmdQueryEEState(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "geteestate [--height <block_height>]",
+		Short: "Get EE state root of a height",
+		Long: "Get the EE state root of a height, the latest provable height by default\n" +
+			"With --trust-node=false, the root is verified against the signed header.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			eeState, height, err := cliutil.QueryEEState(cliCtx)
+			if err != nil {
+				return err
+			}
+
+			return cliCtx.PrintOutput(types.NewQueryEEStateResponse(height, eeState))
+		},
+	}
+
+	return cmd
+}
+
 // GetCmdQueryStake is a getter of the stake amount of the address
 func GetCmdQueryStake(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "getstake --from <from> [--height <block_height>]",
 		Short: "Get stake amount of address",
-		Args:  cobra.NoArgs,
+		Long: "Get stake amount of address\n" +
+			"The pending unbondings and redelegations are shown by unbonding and redelegation.",
+		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
 
@@ -212,7 +241,7 @@ func GetCmdQueryVote(cdc *codec.Codec) *cobra.Command {
 // GetCmdQuery is a EE query getter
 func GetCmdQuery(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "query address|uref|hash|local <data> <path> [--height <block_height>]",
+		Use:   "query address|uref|hash|local <data> <path> [--height <block_height>] [--raw]",
 		Short: "Get query of the data",
 		Args:  cobra.MaximumNArgs(3),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -231,6 +260,17 @@ func GetCmdQuery(cdc *codec.Codec) *cobra.Command {
 			}
 			bz := cdc.MustMarshalJSON(queryData)
 
+			if !viper.GetBool(FlagRaw) {
+				res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/querydecodeddetail", types.ModuleName), bz)
+				if err != nil {
+					fmt.Printf("could not resolve data - %s %s %s\nerr : %s\n", dataType, data, path, err.Error())
+					return nil
+				}
+
+				_, err = fmt.Println(string(res))
+				return err
+			}
+
 			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/querydetail", types.ModuleName), bz)
 			if err != nil {
 				fmt.Printf("could not resolve data - %s %s %s\nerr : %s\n", dataType, data, path, err.Error())
@@ -243,42 +283,164 @@ func GetCmdQuery(cdc *codec.Codec) *cobra.Command {
 				return nil
 			}
 
-			marshaler := jsonpb.Marshaler{Indent: "  "}
+			valueStr, err := cliutil.StoredValueToRawJSON(storedValue)
+			if err != nil {
+				fmt.Printf("could not resolve data - %s %s %s\nerr : %s\n", dataType, data, path, err.Error())
+				return nil
+			}
 
-			valueStr := ""
+			_, err = fmt.Println(valueStr)
+			return err
+		},
+	}
+
+	cmd.Flags().Bool(FlagRaw, false, "Print the stored value as the EE returns it, without decoding CLValues and keys")
 
-			switch storedValue.Type {
-			case storedvalue.TYPE_ACCOUNT:
-				value := &state.Value{Value: &state.Value_Account{Account: storedValue.Account.ToStateValue()}}
-				valueStr, err = marshaler.MarshalToString(value)
-			case storedvalue.TYPE_CONTRACT:
-				value := &state.Value{Value: &state.Value_Contract{Contract: storedValue.Contract.ToStateValue()}}
-				valueStr, err = marshaler.MarshalToString(value)
-			case storedvalue.TYPE_CL_VALUE:
-				value := storedValue.ClValue.ToCLInstanceValue()
-				valueStr, err = marshaler.MarshalToString(value)
+	return cmd
+}
+
+// GetCmdQueryDeploy implements the command looking up an executed deploy by its hash.
+func GetCmdQueryDeploy(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "deploy-info <deploy-hash>",
+		Short: "Query the tx and the height of an executed deploy",
+		Long: "Query the tx and the height of an executed deploy\n" +
+			"Only the deploys within the index retention of the params are kept.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			deployHash, err := hex.DecodeString(args[0])
+			if err != nil {
+				return fmt.Errorf("deploy hash must be hex encoded: %s", err.Error())
 			}
 
+			queryData := types.NewQueryDeployParams(deployHash)
+			bz := cdc.MustMarshalJSON(queryData)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/querydeploy", types.ModuleName), bz)
 			if err != nil {
-				fmt.Printf("could not resolve data - %s %s %s\nerr : %s\n", dataType, data, path, err.Error())
-				return nil
+				return err
 			}
 
-			valueStr = cliutil.ReplaceBase64HashToBech32(path, valueStr)
+			var out types.DeployRecord
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+
+	return cmd
+}
 
-			_, err = fmt.Println(valueStr)
-			return err
+// GetCmdQueryContractRegistry implements the contract registry query command.
+func GetCmdQueryContractRegistry(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "registry [--deployer <deployer>] [--name <name>] [--code-hash <code-hash>]",
+		Short: "Query contracts in the contract registry",
+		Long: "Query contracts in the contract registry\n" +
+			"Given flags are combined as filters. Without any flag, all registered contracts are listed.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			var deployer sdk.AccAddress
+			var err error
+			if deployerStr := viper.GetString(FlagDeployer); deployerStr != "" {
+				deployer, err = cliutil.GetAddress(cdc, cliCtx, deployerStr)
+				if err != nil {
+					return err
+				}
+			}
+
+			var codeHash []byte
+			if codeHashStr := viper.GetString(FlagCodeHash); codeHashStr != "" {
+				codeHash, err = hex.DecodeString(codeHashStr)
+				if err != nil {
+					return fmt.Errorf("code hash must be hex encoded: %s", err.Error())
+				}
+			}
+
+			queryData := types.NewQueryContractParams(deployer, viper.GetString(FlagContractName), codeHash)
+			bz := cdc.MustMarshalJSON(queryData)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/querycontract", types.ModuleName), bz)
+			if err != nil {
+				return err
+			}
+
+			var out types.ContractInfos
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
 		},
 	}
+
+	cmd.Flags().String(FlagDeployer, "", "Deployer of the contract (address or nickname)")
+	cmd.Flags().String(FlagContractName, "", "Registered name of the contract")
+	cmd.Flags().String(FlagCodeHash, "", "Hex encoded blake2b256 hash of the contract WASM code")
+
+	return cmd
+}
+
+// GetCmdQueryContractDescribe implements the command describing the entry points of a contract.
+func GetCmdQueryContractDescribe(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "describe <contract> [--from <from>]",
+		Short: "Describe a contract and its entry points",
+		Long: "Describe a contract and its entry points\n" +
+			"The contract is a bech32 contract hash or uref address, <deployer>:<name> of the contract registry,\n" +
+			"or the name of a contract registered by --from.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			valueFromFromFlag := viper.GetString(client.FlagFrom)
+			var addr sdk.AccAddress
+			var err error
+			if valueFromFromFlag != "" {
+				addr, err = cliutil.GetAddress(cdc, cliCtx, valueFromFromFlag)
+				if err != nil {
+					kb, err := client.NewKeyBaseFromDir(viper.GetString(client.FlagHome))
+					if err != nil {
+						return err
+					}
+
+					keyInfo, err := kb.Get(valueFromFromFlag)
+					if err != nil {
+						return err
+					}
+
+					addr = keyInfo.GetAddress()
+				}
+			}
+
+			contract, err := cliutil.ResolveContract(cliCtx, addr, args[0])
+			if err != nil {
+				return err
+			}
+			if contract.Info == nil {
+				return fmt.Errorf("%s is not in the contract registry, so it has no published schema", args[0])
+			}
+			if contract.Info.Schema == nil {
+				fmt.Printf("contract %s publishes no schema; its arguments aren't checked before sending\n", args[0])
+			}
+			return cliCtx.PrintOutput(*contract.Info)
+		},
+	}
+
+	cmd.Flags().String(client.FlagHome, DefaultClientHome, "Custom local path of client's home dir")
+	cmd.Flags().String(client.FlagFrom, "", "Deployer of the contract given by name (one of wallet alias, address, nickname)")
+
 	return cmd
 }
 
 // GetCmdQueryValidator implements the validator query command.
 func GetCmdQueryValidator(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "validator [--from <from>]",
+		Use:   "validator [--from <from>] [--commission-history]",
 		Short: "Query a validator",
-		Args:  cobra.NoArgs,
+		Long: "Query a validator\n" +
+			"With --commission-history, the commission of the validator and the changes of its rate are queried.",
+		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
 
@@ -302,6 +464,24 @@ func GetCmdQueryValidator(cdc *codec.Codec) *cobra.Command {
 				}
 			}
 
+			if viper.GetBool(FlagCommissionHistory) {
+				if addr.Empty() {
+					return fmt.Errorf("--%s requires the validator given by --%s", FlagCommissionHistory, client.FlagFrom)
+				}
+
+				queryData := types.NewQueryValidatorParams(addr)
+				bz := cdc.MustMarshalJSON(queryData)
+
+				res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/querycommissionrates", types.ModuleName), bz)
+				if err != nil {
+					return err
+				}
+
+				var out types.CommissionRateHistory
+				cdc.MustUnmarshalJSON(res, &out)
+				return cliCtx.PrintOutput(out)
+			}
+
 			if addr.Empty() {
 				res, _, err := cliCtx.Query(fmt.Sprintf("custom/%s/queryallvalidator", types.ModuleName))
 				if err != nil {
@@ -337,6 +517,7 @@ func GetCmdQueryValidator(cdc *codec.Codec) *cobra.Command {
 
 	cmd.Flags().String(client.FlagHome, DefaultClientHome, "Custom local path of client's home dir")
 	cmd.Flags().String(client.FlagFrom, "", "Executor's identity (one of wallet alias, address, nickname)")
+	cmd.Flags().Bool(FlagCommissionHistory, false, "Query the commission and its rate changes of the validator")
 
 	return cmd
 }
@@ -346,7 +527,9 @@ func GetCmdQueryDelegator(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "delegator [<vaidator-address>] [--from <from>]",
 		Short: "Query a validator",
-		Args:  cobra.MaximumNArgs(1),
+		Long: "Query the delegations of a delegator or to a validator\n" +
+			"The pending unbondings and redelegations are shown by unbonding and redelegation.",
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
 
@@ -620,3 +803,211 @@ func GetCmdQueryCommission(cdc *codec.Codec) *cobra.Command {
 
 	return cmd
 }
+
+// GetCmdQueryUnbonding implements the pending unbonding query command.
+func GetCmdQueryUnbonding(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "unbonding [<validator-address>] [--from <from>]",
+		Short: "Query pending unbondings and undelegations",
+		Long: "Query pending unbondings and undelegations with the heights they complete at\n" +
+			"Without validator and --from, every pending entry is shown.",
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			addr, validator, err := getDelegatorAndValidatorAddress(cdc, cliCtx, args)
+			if err != nil {
+				return err
+			}
+
+			queryData := types.NewQueryDelegatorParams(addr, validator)
+			bz := cdc.MustMarshalJSON(queryData)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/queryunbonding", types.ModuleName), bz)
+			if err != nil {
+				return err
+			}
+
+			var out types.StakeRequests
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+
+	cmd.Flags().String(client.FlagHome, DefaultClientHome, "Custom local path of client's home dir")
+	cmd.Flags().String(client.FlagFrom, "", "Delegator's identity (one of wallet alias, address, nickname)")
+
+	return cmd
+}
+
+// GetCmdQueryRedelegation implements the pending redelegation query command.
+func GetCmdQueryRedelegation(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "redelegation [<validator-address>] [--from <from>]",
+		Short: "Query pending redelegations",
+		Long: "Query pending redelegations from or to the validator with the heights they complete at\n" +
+			"Without validator and --from, every pending entry is shown.",
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			addr, validator, err := getDelegatorAndValidatorAddress(cdc, cliCtx, args)
+			if err != nil {
+				return err
+			}
+
+			queryData := types.NewQueryDelegatorParams(addr, validator)
+			bz := cdc.MustMarshalJSON(queryData)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/queryredelegation", types.ModuleName), bz)
+			if err != nil {
+				return err
+			}
+
+			var out types.StakeRequests
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+
+	cmd.Flags().String(client.FlagHome, DefaultClientHome, "Custom local path of client's home dir")
+	cmd.Flags().String(client.FlagFrom, "", "Delegator's identity (one of wallet alias, address, nickname)")
+
+	return cmd
+}
+
+// getDelegatorAndValidatorAddress resolves the delegator from --from and the validator from the first argument
+func getDelegatorAndValidatorAddress(cdc *codec.Codec, cliCtx context.CLIContext, args []string) (sdk.AccAddress, sdk.AccAddress, error) {
+	var addr sdk.AccAddress
+	var err error
+	valueFromFromFlag := viper.GetString(client.FlagFrom)
+	if valueFromFromFlag != "" {
+		addr, err = cliutil.GetAddress(cdc, cliCtx, valueFromFromFlag)
+		if err != nil {
+			kb, err := client.NewKeyBaseFromDir(viper.GetString(client.FlagHome))
+			if err != nil {
+				return nil, nil, err
+			}
+
+			keyInfo, err := kb.Get(valueFromFromFlag)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			addr = keyInfo.GetAddress()
+		}
+	}
+
+	var validator sdk.AccAddress
+	if len(args) > 0 {
+		validator, err = cliutil.GetAddress(cdc, cliCtx, args[0])
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return addr, validator, nil
+}
+
+// GetCmdQueryRewardHistory implements the reward history query command.
+func GetCmdQueryRewardHistory(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reward-history [<validator-address>] --from <from> [--from-height <height>] [--to-height <height>]",
+		Short: "Query the reward history of a delegator",
+		Long: "Query the reward earned and claimed by the delegator per block, with the totals and the annualized yield\n" +
+			"With a validator, the share of the reward by the stake delegated to it is shown. Amounts are in bigsun.",
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			addr, validator, err := getDelegatorAndValidatorAddress(cdc, cliCtx, args)
+			if err != nil {
+				return err
+			}
+			if addr.Empty() {
+				return fmt.Errorf("--from is required")
+			}
+
+			queryData := types.NewQueryRewardHistoryParams(addr, validator, viper.GetInt64(FlagFromHeight), viper.GetInt64(FlagToHeight))
+			bz := cdc.MustMarshalJSON(queryData)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/queryrewardhistory", types.ModuleName), bz)
+			if err != nil {
+				return err
+			}
+
+			var out types.RewardHistory
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+
+	cmd.Flags().String(client.FlagHome, DefaultClientHome, "Custom local path of client's home dir")
+	cmd.Flags().String(client.FlagFrom, "", "Delegator's identity (one of wallet alias, address, nickname)")
+	cmd.Flags().Int64(FlagFromHeight, 0, "First height of the history (default the oldest recorded)")
+	cmd.Flags().Int64(FlagToHeight, 0, "Last height of the history (default the latest)")
+
+	return cmd
+}
+
+// GetCmdQueryCommissionHistory implements the commission history query command.
+func GetCmdQueryCommissionHistory(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "commission-history --from <from> [--from-height <height>] [--to-height <height>]",
+		Short: "Query the commission history of a validator",
+		Long: "Query the commission earned and claimed by the validator per block, with the totals and the annualized yield\n" +
+			"Amounts are in bigsun.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			addr, _, err := getDelegatorAndValidatorAddress(cdc, cliCtx, args)
+			if err != nil {
+				return err
+			}
+			if addr.Empty() {
+				return fmt.Errorf("--from is required")
+			}
+
+			queryData := types.NewQueryCommissionHistoryParams(addr, viper.GetInt64(FlagFromHeight), viper.GetInt64(FlagToHeight))
+			bz := cdc.MustMarshalJSON(queryData)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/querycommissionhistory", types.ModuleName), bz)
+			if err != nil {
+				return err
+			}
+
+			var out types.CommissionHistory
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+
+	cmd.Flags().String(client.FlagHome, DefaultClientHome, "Custom local path of client's home dir")
+	cmd.Flags().String(client.FlagFrom, "", "Validator's identity (one of wallet alias, address, nickname)")
+	cmd.Flags().Int64(FlagFromHeight, 0, "First height of the history (default the oldest recorded)")
+	cmd.Flags().Int64(FlagToHeight, 0, "Last height of the history (default the latest)")
+
+	return cmd
+}
+
+// GetCmdQueryParams implements the params query command.
+func GetCmdQueryParams(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "params",
+		Short: "Query the current executionlayer parameters",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/queryparams", types.ModuleName), nil)
+			if err != nil {
+				return err
+			}
+
+			var out types.Params
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
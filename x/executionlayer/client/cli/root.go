@@ -19,6 +19,7 @@ func GetHdacCustomCmd(cdc *codec.Codec) *cobra.Command {
 	hdacCustomTxCmd.AddCommand(client.GetCommands(
 		// Tx
 		GetCmdTransfer(cdc),
+		GetCmdTransferBatch(cdc),
 		GetCmdBonding(cdc),
 		GetCmdUnbonding(cdc),
 		GetCmdDelegate(cdc),
@@ -26,9 +27,21 @@ func GetHdacCustomCmd(cdc *codec.Codec) *cobra.Command {
 		GetCmdRedelegate(cdc),
 		GetCmdCreateValidator(cdc),
 		GetCmdEditValidator(cdc),
+		GetCmdRotateConsPubKey(cdc),
 		GetCmdVote(cdc),
 		GetCmdUnvote(cdc),
 		GetCmdClaimReward(cdc),
+		GetCmdAddAssociatedKey(cdc),
+		GetCmdRemoveAssociatedKey(cdc),
+		GetCmdUpdateAssociatedKey(cdc),
+		GetCmdSetActionThreshold(cdc),
+		GetCmdGrant(cdc),
+		GetCmdRevoke(cdc),
+		GetCmdExec(cdc),
+		GetCmdGrantFeeAllowance(cdc),
+		GetCmdRevokeFeeAllowance(cdc),
+		GetCmdScheduleTransfer(cdc),
+		GetCmdCancelSchedule(cdc),
 
 		// Query
 		GetCmdQueryBalance(cdc),
@@ -36,8 +49,17 @@ func GetHdacCustomCmd(cdc *codec.Codec) *cobra.Command {
 		GetCmdQueryVote(cdc),
 		GetCmdQueryValidator(cdc),
 		GetCmdQueryDelegator(cdc),
+		GetCmdQueryUnbonding(cdc),
+		GetCmdQueryRedelegation(cdc),
 		GetCmdQueryReward(cdc),
 		GetCmdQueryCommission(cdc),
+		GetCmdQueryRewardHistory(cdc),
+		GetCmdQueryCommissionHistory(cdc),
+		GetCmdQueryParams(cdc),
+		GetCmdQueryGrants(cdc),
+		GetCmdQueryFeeAllowances(cdc),
+		GetCmdQuerySchedules(cdc),
+		GetCmdQueryEEState(cdc),
 	)...)
 	return hdacCustomTxCmd
 }
@@ -54,7 +76,13 @@ func GetContractCmd(cdc *codec.Codec) *cobra.Command {
 	contractTxCmd.AddCommand(client.GetCommands(
 		// Tx
 		GetCmdQuery(cdc),
+		GetCmdQueryContractRegistry(cdc),
+		GetCmdQueryContractDescribe(cdc),
+		GetCmdQueryDeploy(cdc),
 		GetCmdContractRun(cdc),
+		GetCmdContractCall(cdc),
+		GetCmdContractDeploy(cdc),
+		GetCmdSetContractSchema(cdc),
 	)...)
 
 	return contractTxCmd
@@ -0,0 +1,185 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/hdac-io/friday/client"
+	"github.com/hdac-io/friday/client/context"
+	"github.com/hdac-io/friday/codec"
+	sdk "github.com/hdac-io/friday/types"
+	"github.com/hdac-io/friday/x/auth"
+	"github.com/hdac-io/friday/x/auth/client/utils"
+	cliutil "github.com/hdac-io/friday/x/executionlayer/client/util"
+	"github.com/hdac-io/friday/x/executionlayer/types"
+)
+
+// GetCmdScheduleTransfer is the CLI command for scheduling transfers executed by the chain
+func GetCmdScheduleTransfer(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schedule-transfer <recipient_nickname>|<address> <amount> <payout-fee> <start-height> <fee> --from <from> [--interval <blocks> --count <count>]",
+		Short: "Schedule transfers of Hdac token",
+		Long: "Schedule transfers of Hdac token\n" +
+			"The <amount> is transferred at <start-height>, and then every --interval blocks until --count transfers are made.\n" +
+			"The amounts and the <payout-fee> of every transfer are held by the escrow of the schedule until transferred, " +
+			"and the remaining ones are refunded by 'cancel-schedule'. <fee> is the fee of scheduling.",
+		Args: cobra.ExactArgs(5),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			// Parse nickname of address
+			var recipentAddr sdk.AccAddress
+			recipentAddr, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				recipentAddr, err = cliutil.GetAddress(cliCtx.Codec, cliCtx, args[0])
+				if err != nil {
+					return fmt.Errorf("no nickname mapping of %s", args[0])
+				}
+			}
+
+			amount, err := cliutil.ToBigsun(cliutil.Hdac(args[1]))
+			if err != nil {
+				return err
+			}
+
+			payoutFee, err := cliutil.ToBigsun(cliutil.Hdac(args[2]))
+			if err != nil {
+				return err
+			}
+
+			startHeight, err := strconv.ParseInt(args[3], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			fee, err := cliutil.ToBigsun(cliutil.Hdac(args[4]))
+			if err != nil {
+				return err
+			}
+
+			kb, err := client.NewKeyBaseFromDir(viper.GetString(client.FlagHome))
+			if err != nil {
+				return err
+			}
+
+			valueFromFromFlag := viper.GetString(client.FlagFrom)
+			keyInfo, err := cliutil.GetLocalWalletInfo(valueFromFromFlag, kb, cdc, cliCtx)
+			if err != nil {
+				return err
+			}
+			fromAddr := keyInfo.GetAddress()
+
+			cliCtx = cliCtx.WithFromAddress(keyInfo.GetAddress()).WithFromName(keyInfo.GetName())
+
+			// build and sign the transaction, then broadcast to Tendermint
+			msg := types.NewMsgScheduleTransfer("transfer", fromAddr, recipentAddr, string(amount), string(payoutFee),
+				startHeight, viper.GetInt64(FlagInterval), viper.GetUint64(FlagCount), string(fee))
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return generateOrBroadcastAuthorizedMsgs(cliCtx, txBldr, msg)
+		},
+	}
+
+	cmd.Flags().String(client.FlagHome, DefaultClientHome, "Custom local path of client's home dir")
+	cmd.Flags().String(client.FlagFrom, "", "Executor's identity (one of wallet alias, address, nickname)")
+	cmd.Flags().Int64(FlagInterval, 0, "Number of blocks between the transfers")
+	cmd.Flags().Uint64(FlagCount, 1, "Number of the transfers")
+	cmd.Flags().String(FlagAuthorizers, "", authorizersFlagUsage)
+
+	cmd.Flags().AddFlagSet(fsDeployHeader)
+	return cmd
+}
+
+// GetCmdCancelSchedule is the CLI command for cancelling a schedule
+func GetCmdCancelSchedule(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cancel-schedule <schedule-id> --from <from>",
+		Short: "Cancel a schedule of transfers",
+		Long:  "Cancel a schedule of transfers. The remaining transfers are refunded at the end of the block, less the fee of the refund",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			scheduleID, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			kb, err := client.NewKeyBaseFromDir(viper.GetString(client.FlagHome))
+			if err != nil {
+				return err
+			}
+
+			valueFromFromFlag := viper.GetString(client.FlagFrom)
+			keyInfo, err := cliutil.GetLocalWalletInfo(valueFromFromFlag, kb, cdc, cliCtx)
+			if err != nil {
+				return err
+			}
+
+			cliCtx = cliCtx.WithFromAddress(keyInfo.GetAddress()).WithFromName(keyInfo.GetName())
+
+			// build and sign the transaction, then broadcast to Tendermint
+			msg := types.NewMsgCancelSchedule(keyInfo.GetAddress(), scheduleID)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(client.FlagHome, DefaultClientHome, "Custom local path of client's home dir")
+	cmd.Flags().String(client.FlagFrom, "", "Sender's identity (one of wallet alias, address, nickname)")
+
+	return cmd
+}
+
+// GetCmdQuerySchedules implements the schedule query command.
+func GetCmdQuerySchedules(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schedules [--sender <sender>] [--recipient <recipient>]",
+		Short: "Query the schedules of transfers of a sender or to a recipient",
+		Long:  "Query the pending and failed schedules of transfers of a sender or to a recipient",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			var sender, recipient sdk.AccAddress
+			var err error
+			if senderStr := viper.GetString(FlagSender); senderStr != "" {
+				sender, err = cliutil.GetAddress(cdc, cliCtx, senderStr)
+				if err != nil {
+					return fmt.Errorf("no nickname mapping of %s", senderStr)
+				}
+			}
+			if recipientStr := viper.GetString(FlagRecipient); recipientStr != "" {
+				recipient, err = cliutil.GetAddress(cdc, cliCtx, recipientStr)
+				if err != nil {
+					return fmt.Errorf("no nickname mapping of %s", recipientStr)
+				}
+			}
+			if sender.Empty() && recipient.Empty() {
+				return fmt.Errorf("--%s or --%s is required", FlagSender, FlagRecipient)
+			}
+
+			queryData := types.NewQuerySchedulesParams(sender, recipient)
+			bz := cdc.MustMarshalJSON(queryData)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/queryschedules", types.ModuleName), bz)
+			if err != nil {
+				return err
+			}
+
+			var out types.Schedules
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+
+	cmd.Flags().String(FlagSender, "", "Sender's identity (one of address, nickname)")
+	cmd.Flags().String(FlagRecipient, "", "Recipient's identity (one of address, nickname)")
+
+	return cmd
+}
@@ -0,0 +1,365 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/hdac-io/friday/client"
+	"github.com/hdac-io/friday/client/context"
+	"github.com/hdac-io/friday/codec"
+	sdk "github.com/hdac-io/friday/types"
+	"github.com/hdac-io/friday/x/auth"
+	"github.com/hdac-io/friday/x/auth/client/utils"
+	cliutil "github.com/hdac-io/friday/x/executionlayer/client/util"
+	"github.com/hdac-io/friday/x/executionlayer/types"
+)
+
+const tokenRefUsage = "The token is a bech32 contract hash or uref address, <deployer>:<name> of the contract registry\n" +
+	"with the deployer being an address or nickname, or the name of a token of --from.\n" +
+	"Amounts are in the decimals of the token."
+
+// GetTokenCmd implements the commands of the fungible-token contracts
+func GetTokenCmd(cdc *codec.Codec) *cobra.Command {
+	tokenCmd := &cobra.Command{
+		Use:                        "token",
+		Short:                      "Commands for fungible-token contracts",
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+	tokenCmd.AddCommand(client.GetCommands(
+		// Tx
+		GetCmdTokenTransfer(cdc),
+		GetCmdTokenApprove(cdc),
+		GetCmdTokenTransferFrom(cdc),
+
+		// Query
+		GetCmdQueryTokenBalance(cdc),
+		GetCmdQueryTokenAllowance(cdc),
+		GetCmdQueryTokenTotalSupply(cdc),
+		GetCmdQueryTokenMetadata(cdc),
+	)...)
+	return tokenCmd
+}
+
+// GetCmdTokenTransfer is the CLI command for transferring a token
+func GetCmdTokenTransfer(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "transfer <token> <recipient_nickname>|<address> <amount> <fee> --from <from> [--dry-run]",
+		Short: "Transfer the token to the recipient",
+		Long:  "Transfer the token to the recipient\n" + tokenRefUsage,
+		Args:  cobra.ExactArgs(4),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx, fromAddr, err := getTokenSender(cdc)
+			if err != nil {
+				return err
+			}
+
+			token, err := cliutil.ResolveToken(cliCtx, fromAddr, args[0])
+			if err != nil {
+				return err
+			}
+			recipient, err := cliutil.GetAddress(cdc, cliCtx, args[1])
+			if err != nil {
+				return fmt.Errorf("no nickname mapping of %s", args[1])
+			}
+			amount, err := cliutil.ParseTokenAmount(cliCtx, token, args[2])
+			if err != nil {
+				return err
+			}
+
+			sessionArgs, err := types.NewTokenTransferArgs(recipient, amount)
+			if err != nil {
+				return err
+			}
+			return callToken(cdc, cliCtx, fromAddr, token, sessionArgs, args[3])
+		},
+	}
+
+	return addTokenTxFlags(cmd)
+}
+
+// GetCmdTokenApprove is the CLI command for allowing a spender to transfer the token of the sender
+func GetCmdTokenApprove(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "approve <token> <spender_nickname>|<address> <amount> <fee> --from <from> [--dry-run]",
+		Short: "Allow the spender to transfer up to the amount of your token",
+		Long: "Allow the spender to transfer up to the amount of your token\n" +
+			"The amount replaces the previous allowance to the spender.\n" + tokenRefUsage,
+		Args: cobra.ExactArgs(4),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx, fromAddr, err := getTokenSender(cdc)
+			if err != nil {
+				return err
+			}
+
+			token, err := cliutil.ResolveToken(cliCtx, fromAddr, args[0])
+			if err != nil {
+				return err
+			}
+			spender, err := cliutil.GetAddress(cdc, cliCtx, args[1])
+			if err != nil {
+				return fmt.Errorf("no nickname mapping of %s", args[1])
+			}
+			amount, err := cliutil.ParseTokenAmount(cliCtx, token, args[2])
+			if err != nil {
+				return err
+			}
+
+			sessionArgs, err := types.NewTokenApproveArgs(spender, amount)
+			if err != nil {
+				return err
+			}
+			return callToken(cdc, cliCtx, fromAddr, token, sessionArgs, args[3])
+		},
+	}
+
+	return addTokenTxFlags(cmd)
+}
+
+// GetCmdTokenTransferFrom is the CLI command for transferring the token of an owner within the allowance
+func GetCmdTokenTransferFrom(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "transfer-from <token> <owner_nickname>|<address> <recipient_nickname>|<address> <amount> <fee> --from <from> [--dry-run]",
+		Short: "Transfer the token of the owner to the recipient within the allowance of the owner to you",
+		Long:  "Transfer the token of the owner to the recipient within the allowance of the owner to you\n" + tokenRefUsage,
+		Args:  cobra.ExactArgs(5),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx, fromAddr, err := getTokenSender(cdc)
+			if err != nil {
+				return err
+			}
+
+			token, err := cliutil.ResolveToken(cliCtx, fromAddr, args[0])
+			if err != nil {
+				return err
+			}
+			owner, err := cliutil.GetAddress(cdc, cliCtx, args[1])
+			if err != nil {
+				return fmt.Errorf("no nickname mapping of %s", args[1])
+			}
+			recipient, err := cliutil.GetAddress(cdc, cliCtx, args[2])
+			if err != nil {
+				return fmt.Errorf("no nickname mapping of %s", args[2])
+			}
+			amount, err := cliutil.ParseTokenAmount(cliCtx, token, args[3])
+			if err != nil {
+				return err
+			}
+
+			sessionArgs, err := types.NewTokenTransferFromArgs(owner, recipient, amount)
+			if err != nil {
+				return err
+			}
+			return callToken(cdc, cliCtx, fromAddr, token, sessionArgs, args[4])
+		},
+	}
+
+	return addTokenTxFlags(cmd)
+}
+
+// GetCmdQueryTokenBalance implements the token balance query command.
+func GetCmdQueryTokenBalance(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "balance-of <token> <owner_nickname>|<address> [--from <from>]",
+		Short: "Query the token balance of the owner",
+		Long:  "Query the token balance of the owner\n" + tokenRefUsage,
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			token, err := resolveQueriedToken(cdc, cliCtx, args[0])
+			if err != nil {
+				return err
+			}
+			owner, err := cliutil.GetAddress(cdc, cliCtx, args[1])
+			if err != nil {
+				return fmt.Errorf("no nickname mapping of %s", args[1])
+			}
+
+			out, err := cliutil.QueryTokenBalance(cliCtx, token, owner)
+			if err != nil {
+				return err
+			}
+			return cliCtx.PrintOutput(out)
+		},
+	}
+
+	return addTokenQueryFlags(cmd)
+}
+
+// GetCmdQueryTokenAllowance implements the token allowance query command.
+func GetCmdQueryTokenAllowance(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "allowance <token> <owner_nickname>|<address> <spender_nickname>|<address> [--from <from>]",
+		Short: "Query the amount the spender may transfer of the owner's token",
+		Long:  "Query the amount the spender may transfer of the owner's token\n" + tokenRefUsage,
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			token, err := resolveQueriedToken(cdc, cliCtx, args[0])
+			if err != nil {
+				return err
+			}
+			owner, err := cliutil.GetAddress(cdc, cliCtx, args[1])
+			if err != nil {
+				return fmt.Errorf("no nickname mapping of %s", args[1])
+			}
+			spender, err := cliutil.GetAddress(cdc, cliCtx, args[2])
+			if err != nil {
+				return fmt.Errorf("no nickname mapping of %s", args[2])
+			}
+
+			out, err := cliutil.QueryTokenAllowance(cliCtx, token, owner, spender)
+			if err != nil {
+				return err
+			}
+			return cliCtx.PrintOutput(out)
+		},
+	}
+
+	return addTokenQueryFlags(cmd)
+}
+
+// GetCmdQueryTokenTotalSupply implements the token total supply query command.
+func GetCmdQueryTokenTotalSupply(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "total-supply <token> [--from <from>]",
+		Short: "Query the total supply of the token",
+		Long:  "Query the total supply of the token\n" + tokenRefUsage,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			token, err := resolveQueriedToken(cdc, cliCtx, args[0])
+			if err != nil {
+				return err
+			}
+
+			metadata, err := cliutil.QueryTokenMetadata(cliCtx, token)
+			if err != nil {
+				return err
+			}
+
+			_, err = fmt.Printf("%s %s\n", metadata.TotalSupply, metadata.Symbol)
+			return err
+		},
+	}
+
+	return addTokenQueryFlags(cmd)
+}
+
+// GetCmdQueryTokenMetadata implements the token metadata query command.
+func GetCmdQueryTokenMetadata(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "metadata <token> [--from <from>]",
+		Short: "Query the name, symbol, decimals and total supply of the token",
+		Long:  "Query the name, symbol, decimals and total supply of the token\n" + tokenRefUsage,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			token, err := resolveQueriedToken(cdc, cliCtx, args[0])
+			if err != nil {
+				return err
+			}
+
+			out, err := cliutil.QueryTokenMetadata(cliCtx, token)
+			if err != nil {
+				return err
+			}
+			return cliCtx.PrintOutput(out)
+		},
+	}
+
+	return addTokenQueryFlags(cmd)
+}
+
+func addTokenTxFlags(cmd *cobra.Command) *cobra.Command {
+	cmd.Flags().String(client.FlagHome, DefaultClientHome, "Custom local path of client's home dir")
+	cmd.Flags().String(client.FlagFrom, "", "Executor's identity (one of wallet alias, address, nickname)")
+	cmd.Flags().Bool(client.FlagDryRun, false, "Run the contract on the state of the given height and show its cost and effects, without broadcasting")
+	cmd.Flags().String(FlagAuthorizers, "", authorizersFlagUsage)
+
+	cmd.Flags().AddFlagSet(fsDeployHeader)
+	return cmd
+}
+
+func addTokenQueryFlags(cmd *cobra.Command) *cobra.Command {
+	cmd.Flags().String(client.FlagHome, DefaultClientHome, "Custom local path of client's home dir")
+	cmd.Flags().String(client.FlagFrom, "", "Deployer of the token given by name (one of wallet alias, address, nickname)")
+	return cmd
+}
+
+// getTokenSender returns the context of the sender of --from
+func getTokenSender(cdc *codec.Codec) (context.CLIContext, sdk.AccAddress, error) {
+	cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+	kb, err := client.NewKeyBaseFromDir(viper.GetString(client.FlagHome))
+	if err != nil {
+		return cliCtx, nil, err
+	}
+
+	valueFromFromFlag := viper.GetString(client.FlagFrom)
+	keyInfo, err := cliutil.GetLocalWalletInfo(valueFromFromFlag, kb, cdc, cliCtx)
+	if err != nil {
+		return cliCtx, nil, err
+	}
+
+	cliCtx = cliCtx.WithFromAddress(keyInfo.GetAddress()).WithFromName(keyInfo.GetName())
+	return cliCtx, keyInfo.GetAddress(), nil
+}
+
+// resolveQueriedToken resolves the token, a token given by name being one of the optional --from
+func resolveQueriedToken(cdc *codec.Codec, cliCtx context.CLIContext, ref string) (cliutil.Token, error) {
+	valueFromFromFlag := viper.GetString(client.FlagFrom)
+	var addr sdk.AccAddress
+	var err error
+	if valueFromFromFlag != "" {
+		addr, err = cliutil.GetAddress(cdc, cliCtx, valueFromFromFlag)
+		if err != nil {
+			kb, err := client.NewKeyBaseFromDir(viper.GetString(client.FlagHome))
+			if err != nil {
+				return cliutil.Token{}, err
+			}
+
+			keyInfo, err := kb.Get(valueFromFromFlag)
+			if err != nil {
+				return cliutil.Token{}, err
+			}
+
+			addr = keyInfo.GetAddress()
+		}
+	}
+
+	return cliutil.ResolveToken(cliCtx, addr, ref)
+}
+
+// callToken calls the entry point of the token with the session args
+func callToken(cdc *codec.Codec, cliCtx context.CLIContext, fromAddr sdk.AccAddress, token cliutil.Token, sessionArgs string, feeStr string) error {
+	txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+
+	fee, err := cliutil.ToBigsun(cliutil.Hdac(feeStr))
+	if err != nil {
+		return err
+	}
+
+	// build and sign the transaction, then broadcast to Tendermint
+	msg := types.NewMsgExecute(
+		token.ContractAddress,
+		fromAddr,
+		token.SessionType,
+		token.SessionCode,
+		sessionArgs,
+		string(fee),
+	)
+
+	if viper.GetBool(client.FlagDryRun) {
+		return dryRunMsgExecute(cliCtx, cdc, msg)
+	}
+
+	return generateOrBroadcastAuthorizedMsgs(cliCtx, txBldr, msg)
+}
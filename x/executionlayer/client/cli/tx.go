@@ -2,8 +2,13 @@ package cli
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io/ioutil"
+	"math"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hdac-io/casperlabs-ee-grpc-go-util/util"
 	"github.com/hdac-io/friday/client"
@@ -21,10 +26,12 @@ import (
 
 func GetCmdContractRun(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "run <type> <wasm-path>|<uref>|<name>|<hash> <argument> <fee> --from <from>",
+		Use:   "run <type> <wasm-path>|<uref>|<name>|<hash> <argument> <fee> --from <from> [--dry-run]",
 		Short: "Run contract",
 		Long: "Run contract\n" +
-			"There are 4 types of contract run. ('wasm', 'uref', 'name', 'hash)",
+			"There are 4 types of contract run. ('wasm', 'uref', 'name', 'hash)\n" +
+			"The arguments of a registered contract publishing its schema are checked against it before sending.\n" +
+			"With --dry-run, the contract is not broadcasted and its cost and effects are printed instead.",
 		Args: cobra.ExactArgs(4),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
@@ -46,6 +53,7 @@ func GetCmdContractRun(cdc *codec.Codec) *cobra.Command {
 			sessionType := cliutil.GetContractType(args[0])
 			var sessionCode []byte
 			var contractAddress string
+			var contractInfo *types.ContractInfo
 
 			switch sessionType {
 			case util.WASM:
@@ -58,6 +66,10 @@ func GetCmdContractRun(cdc *codec.Codec) *cobra.Command {
 					return err
 				}
 				sessionCode = contractHashAddr.Bytes()
+				contractInfo, err = cliutil.FindContractInfo(cliCtx, args[1])
+				if err != nil {
+					return err
+				}
 			case util.UREF:
 				contractAddress = args[1]
 				contractUrefAddr, err := sdk.ContractUrefAddressFromBech32(args[1])
@@ -65,9 +77,17 @@ func GetCmdContractRun(cdc *codec.Codec) *cobra.Command {
 					return err
 				}
 				sessionCode = contractUrefAddr.Bytes()
+				contractInfo, err = cliutil.FindContractInfo(cliCtx, args[1])
+				if err != nil {
+					return err
+				}
 			case util.NAME:
 				contractAddress = fmt.Sprintf("%s:%s", fromAddr.String(), args[1])
 				sessionCode = []byte(args[1])
+				contractInfo, err = cliutil.FindRegisteredContract(cliCtx, fromAddr, args[1])
+				if err != nil {
+					return err
+				}
 			default:
 				return fmt.Errorf("type must be one of wasm, name, uref, or hash")
 			}
@@ -77,14 +97,12 @@ func GetCmdContractRun(cdc *codec.Codec) *cobra.Command {
 				return err
 			}
 
-			prettyJSON := []byte("")
-			var jsonData []map[string]interface{}
-			if len(args[2]) > 0 {
-				err = json.Unmarshal([]byte(args[2]), &jsonData)
-				if err != nil {
-					return err
-				}
-				prettyJSON, err = json.Marshal(jsonData)
+			prettyJSON, err := prettySessionArgs(args[2])
+			if err != nil {
+				return err
+			}
+			if err := cliutil.ValidateContractArgs(contractInfo, string(prettyJSON)); err != nil {
+				return err
 			}
 
 			// build and sign the transaction, then broadcast to Tendermint
@@ -97,6 +115,321 @@ func GetCmdContractRun(cdc *codec.Codec) *cobra.Command {
 				string(fee),
 			)
 
+			if viper.GetBool(client.FlagDryRun) {
+				return dryRunMsgExecute(cliCtx, cdc, msg)
+			}
+
+			return generateOrBroadcastAuthorizedMsgs(cliCtx, txBldr, msg)
+		},
+	}
+
+	cmd.Flags().String(client.FlagHome, DefaultClientHome, "Custom local path of client's home dir")
+	cmd.Flags().String(client.FlagFrom, "", "Executor's identity (one of wallet alias, address, nickname)")
+	cmd.Flags().Bool(client.FlagDryRun, false, "Run the contract on the state of the given height and show its cost and effects, without broadcasting")
+	cmd.Flags().String(FlagAuthorizers, "", authorizersFlagUsage)
+
+	cmd.Flags().AddFlagSet(fsDeployHeader)
+	return cmd
+}
+
+// dryRunMsgExecute runs the message through the dry run query and prints the result as a diff,
+// or as it is with the json output format
+func dryRunMsgExecute(cliCtx context.CLIContext, cdc *codec.Codec, msg types.MsgExecute) error {
+	bz := cdc.MustMarshalJSON(types.NewQueryDryRunParams(msg))
+	res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/querydryrun", types.ModuleName), bz)
+	if err != nil {
+		return err
+	}
+
+	if cliCtx.OutputFormat == "json" {
+		_, err = fmt.Println(string(res))
+		return err
+	}
+
+	var result types.DryRunResult
+	err = json.Unmarshal(res, &result)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Println(result.String())
+	return err
+}
+
+const authorizersFlagUsage = "Comma separated addresses or nicknames of associated keys co-signing the tx. " +
+	"Requires --generate-only, and the generated tx must be signed by the sender and every authorizer"
+
+// generateOrBroadcastAuthorizedMsgs appends a MsgAuthorize of each of the --authorizers to the msg,
+// so that their weights count toward the action thresholds of the sender's account
+func generateOrBroadcastAuthorizedMsgs(cliCtx context.CLIContext, txBldr auth.TxBuilder, msg sdk.Msg) error {
+	msgs := []sdk.Msg{msg}
+
+	authorizers := viper.GetString(FlagAuthorizers)
+	if authorizers == "" {
+		return generateOrBroadcastDeployMsgs(cliCtx, txBldr, msgs)
+	}
+	if !cliCtx.GenerateOnly {
+		return fmt.Errorf("--%s requires --%s, as the tx has to be signed by every authorizer", FlagAuthorizers, client.FlagGenerateOnly)
+	}
+
+	for _, authorizer := range strings.Split(authorizers, ",") {
+		addr, err := cliutil.GetAddress(cliCtx.Codec, cliCtx, strings.TrimSpace(authorizer))
+		if err != nil {
+			return fmt.Errorf("no nickname mapping of %s", authorizer)
+		}
+		msgs = append(msgs, types.NewMsgAuthorize(addr))
+	}
+
+	return generateOrBroadcastDeployMsgs(cliCtx, txBldr, msgs)
+}
+
+// generateOrBroadcastDeployMsgs sets the deploy header of the --ttl, --dependencies and --fee-payer
+// flags to the deploy msgs, then generates or broadcasts the tx. With --fee-payer, a MsgAuthorize of
+// the payer is appended, as the payer co-signs the tx.
+func generateOrBroadcastDeployMsgs(cliCtx context.CLIContext, txBldr auth.TxBuilder, msgs []sdk.Msg) error {
+	header, err := getDeployHeader(cliCtx)
+	if err != nil {
+		return err
+	}
+	if header != nil {
+		for i, msg := range msgs {
+			if deployMsg, ok := msg.(types.DeployMsg); ok {
+				msgs[i] = deployMsg.WithDeployHeader(header)
+			}
+		}
+		if !header.FeePayer.Empty() {
+			if !cliCtx.GenerateOnly {
+				return fmt.Errorf("--%s requires --%s, as the tx has to be signed by the fee payer", FlagFeePayer, client.FlagGenerateOnly)
+			}
+			msgs = append(msgs, types.NewMsgAuthorize(header.FeePayer))
+		}
+	}
+
+	return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, msgs)
+}
+
+// getDeployHeader returns the deploy header of the --ttl, --dependencies and --fee-payer flags
+// created now, or nil without the flags
+func getDeployHeader(cliCtx context.CLIContext) (*types.DeployHeader, error) {
+	ttl := viper.GetDuration(FlagTTL)
+	var dependencies []string
+	if dependenciesStr := viper.GetString(FlagDependencies); dependenciesStr != "" {
+		for _, dependency := range strings.Split(dependenciesStr, ",") {
+			dependencies = append(dependencies, strings.TrimSpace(dependency))
+		}
+	}
+	var feePayer sdk.AccAddress
+	if feePayerStr := viper.GetString(FlagFeePayer); feePayerStr != "" {
+		addr, err := cliutil.GetAddress(cliCtx.Codec, cliCtx, feePayerStr)
+		if err != nil {
+			return nil, fmt.Errorf("no nickname mapping of %s", feePayerStr)
+		}
+		feePayer = addr
+	}
+	if ttl == 0 && len(dependencies) == 0 && feePayer.Empty() {
+		return nil, nil
+	}
+
+	ttlMillis := int64(ttl / time.Millisecond)
+	if ttlMillis < 0 || ttlMillis > math.MaxUint32 {
+		return nil, fmt.Errorf("--%s %s is out of range", FlagTTL, ttl)
+	}
+
+	header := types.NewDeployHeader(time.Now().UnixNano()/int64(time.Millisecond), uint32(ttlMillis), dependencies)
+	header.FeePayer = feePayer
+	if err := header.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	return header, nil
+}
+
+// GetCmdContractDeploy is the CLI command for deploying a contract into the contract registry
+func GetCmdContractDeploy(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "deploy <wasm-path> <name> <argument> <fee> --from <from>",
+		Short: "Deploy contract",
+		Long: "Deploy contract\n" +
+			"Runs the WASM installer and registers the created contract under the given name.\n" +
+			"The registry can be looked up by 'contract registry'.\n" +
+			"With --schema, the entry points of the contract in the JSON file are published in the registry.",
+		Args: cobra.ExactArgs(4),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			kb, err := client.NewKeyBaseFromDir(viper.GetString(client.FlagHome))
+			if err != nil {
+				return err
+			}
+
+			valueFromFromFlag := viper.GetString(client.FlagFrom)
+			keyInfo, err := cliutil.GetLocalWalletInfo(valueFromFromFlag, kb, cdc, cliCtx)
+			if err != nil {
+				return err
+			}
+			cliCtx = cliCtx.WithFromAddress(keyInfo.GetAddress()).WithFromName(keyInfo.GetName())
+			fromAddr := keyInfo.GetAddress()
+
+			code := util.LoadWasmFile(args[0])
+
+			fee, err := cliutil.ToBigsun(cliutil.Hdac(args[3]))
+			if err != nil {
+				return err
+			}
+
+			prettyJSON, err := prettySessionArgs(args[2])
+			if err != nil {
+				return err
+			}
+
+			var schema *types.ContractSchema
+			if schemaFile := viper.GetString(FlagSchema); schemaFile != "" {
+				if schema, err = readContractSchema(schemaFile); err != nil {
+					return err
+				}
+			}
+
+			// build and sign the transaction, then broadcast to Tendermint
+			msg := types.NewMsgDeployContract(
+				"wasm_file_direct_execution",
+				fromAddr,
+				args[1],
+				code,
+				string(prettyJSON),
+				string(fee),
+				schema,
+			)
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return generateOrBroadcastDeployMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(client.FlagHome, DefaultClientHome, "Custom local path of client's home dir")
+	cmd.Flags().String(client.FlagFrom, "", "Executor's identity (one of wallet alias, address, nickname)")
+	cmd.Flags().String(FlagSchema, "", "JSON file of the entry points of the contract to publish in the registry")
+
+	cmd.Flags().AddFlagSet(fsDeployHeader)
+	return cmd
+}
+
+// GetCmdContractCall is the CLI command for calling a stored contract by its name, hash or uref
+func GetCmdContractCall(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "call <contract> <argument> <fee> --from <from> [--dry-run]",
+		Short: "Call stored contract",
+		Long: "Call stored contract\n" +
+			"The contract is one of:\n" +
+			"  (1) Bech32 contract hash or uref address\n" +
+			"  (2) <deployer>:<name> of the contract registry\n" +
+			"  (3) Name of a contract of the sender in the registry, or else a named key of the sender\n" +
+			"The arguments are checked against the schema of the contract when it publishes one.\n" +
+			"The entry points of a contract are shown by 'contract describe'.",
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			kb, err := client.NewKeyBaseFromDir(viper.GetString(client.FlagHome))
+			if err != nil {
+				return err
+			}
+
+			valueFromFromFlag := viper.GetString(client.FlagFrom)
+			keyInfo, err := cliutil.GetLocalWalletInfo(valueFromFromFlag, kb, cdc, cliCtx)
+			if err != nil {
+				return err
+			}
+			cliCtx = cliCtx.WithFromAddress(keyInfo.GetAddress()).WithFromName(keyInfo.GetName())
+			fromAddr := keyInfo.GetAddress()
+
+			contract, err := cliutil.ResolveContract(cliCtx, fromAddr, args[0])
+			if err != nil {
+				return err
+			}
+
+			fee, err := cliutil.ToBigsun(cliutil.Hdac(args[2]))
+			if err != nil {
+				return err
+			}
+
+			prettyJSON, err := prettySessionArgs(args[1])
+			if err != nil {
+				return err
+			}
+			if err := cliutil.ValidateContractArgs(contract.Info, string(prettyJSON)); err != nil {
+				return err
+			}
+
+			// build and sign the transaction, then broadcast to Tendermint
+			msg := types.NewMsgExecute(
+				contract.ContractAddress,
+				fromAddr,
+				contract.SessionType,
+				contract.SessionCode,
+				string(prettyJSON),
+				string(fee),
+			)
+
+			if viper.GetBool(client.FlagDryRun) {
+				return dryRunMsgExecute(cliCtx, cdc, msg)
+			}
+
+			return generateOrBroadcastAuthorizedMsgs(cliCtx, txBldr, msg)
+		},
+	}
+
+	cmd.Flags().String(client.FlagHome, DefaultClientHome, "Custom local path of client's home dir")
+	cmd.Flags().String(client.FlagFrom, "", "Executor's identity (one of wallet alias, address, nickname)")
+	cmd.Flags().Bool(client.FlagDryRun, false, "Run the contract on the state of the given height and show its cost and effects, without broadcasting")
+	cmd.Flags().String(FlagAuthorizers, "", authorizersFlagUsage)
+
+	cmd.Flags().AddFlagSet(fsDeployHeader)
+	return cmd
+}
+
+// GetCmdSetContractSchema is the CLI command for publishing the schema of a registered contract
+func GetCmdSetContractSchema(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-schema <name> <schema-path> --from <from>",
+		Short: "Publish the entry points of a registered contract",
+		Long: "Publish the entry points of a registered contract\n" +
+			"The schema replaces the one of the contract deployed by the sender under the name.\n" +
+			"An empty schema path removes the schema.",
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			kb, err := client.NewKeyBaseFromDir(viper.GetString(client.FlagHome))
+			if err != nil {
+				return err
+			}
+
+			valueFromFromFlag := viper.GetString(client.FlagFrom)
+			keyInfo, err := cliutil.GetLocalWalletInfo(valueFromFromFlag, kb, cdc, cliCtx)
+			if err != nil {
+				return err
+			}
+			cliCtx = cliCtx.WithFromAddress(keyInfo.GetAddress()).WithFromName(keyInfo.GetName())
+
+			var schema *types.ContractSchema
+			if args[1] != "" {
+				if schema, err = readContractSchema(args[1]); err != nil {
+					return err
+				}
+			}
+
+			// build and sign the transaction, then broadcast to Tendermint
+			msg := types.NewMsgSetContractSchema(keyInfo.GetAddress(), args[0], schema)
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
 			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
 		},
 	}
@@ -107,6 +440,33 @@ func GetCmdContractRun(cdc *codec.Codec) *cobra.Command {
 	return cmd
 }
 
+// prettySessionArgs compacts the session arguments in JSON, checking they're an array of arguments
+func prettySessionArgs(sessionArgs string) ([]byte, error) {
+	if len(sessionArgs) == 0 {
+		return []byte(""), nil
+	}
+
+	var jsonData []map[string]interface{}
+	if err := json.Unmarshal([]byte(sessionArgs), &jsonData); err != nil {
+		return nil, err
+	}
+	return json.Marshal(jsonData)
+}
+
+// readContractSchema reads and validates the schema of a contract in the JSON file
+func readContractSchema(filename string) (*types.ContractSchema, error) {
+	bz, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	schema, err := types.ParseContractSchema(bz)
+	if err != nil {
+		return nil, err
+	}
+	return &schema, nil
+}
+
 // GetCmdTransfer is the CLI command for transfer
 func GetCmdTransfer(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
@@ -154,13 +514,15 @@ func GetCmdTransfer(cdc *codec.Codec) *cobra.Command {
 
 			// build and sign the transaction, then broadcast to Tendermint
 			msg := types.NewMsgTransfer("transfer", fromAddr, recipentAddr, string(amount), string(fee))
-			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+			return generateOrBroadcastAuthorizedMsgs(cliCtx, txBldr, msg)
 		},
 	}
 
 	cmd.Flags().String(client.FlagHome, DefaultClientHome, "Custom local path of client's home dir")
 	cmd.Flags().String(client.FlagFrom, "", "Executor's identity (one of wallet alias, address, nickname)")
+	cmd.Flags().String(FlagAuthorizers, "", authorizersFlagUsage)
 
+	cmd.Flags().AddFlagSet(fsDeployHeader)
 	return cmd
 }
 
@@ -201,13 +563,14 @@ func GetCmdBonding(cdc *codec.Codec) *cobra.Command {
 
 			// build and sign the transaction, then broadcast to Tendermint
 			msg := types.NewMsgBond("system:bond", addr, string(amount), string(fee))
-			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+			return generateOrBroadcastDeployMsgs(cliCtx, txBldr, []sdk.Msg{msg})
 		},
 	}
 
 	cmd.Flags().String(client.FlagHome, DefaultClientHome, "Custom local path of client's home dir")
 	cmd.Flags().String(client.FlagFrom, "", "Executor's identity (one of wallet alias, address, nickname)")
 
+	cmd.Flags().AddFlagSet(fsDeployHeader)
 	return cmd
 }
 
@@ -248,13 +611,14 @@ func GetCmdUnbonding(cdc *codec.Codec) *cobra.Command {
 
 			// build and sign the transaction, then broadcast to Tendermint
 			msg := types.NewMsgUnBond("system:unbond", addr, string(amount), string(fee))
-			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+			return generateOrBroadcastDeployMsgs(cliCtx, txBldr, []sdk.Msg{msg})
 		},
 	}
 
 	cmd.Flags().String(client.FlagHome, DefaultClientHome, "Custom local path of client's home dir")
 	cmd.Flags().String(client.FlagFrom, "", "Executor's identity (one of wallet alias, address, nickname)")
 
+	cmd.Flags().AddFlagSet(fsDeployHeader)
 	return cmd
 }
 
@@ -302,13 +666,14 @@ func GetCmdDelegate(cdc *codec.Codec) *cobra.Command {
 
 			// build and sign the transaction, then broadcast to Tendermint
 			msg := types.NewMsgDelegate("system:delegate", addr, valAddress, string(amount), string(fee))
-			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+			return generateOrBroadcastDeployMsgs(cliCtx, txBldr, []sdk.Msg{msg})
 		},
 	}
 
 	cmd.Flags().String(client.FlagHome, DefaultClientHome, "Custom local path of client's home dir")
 	cmd.Flags().String(client.FlagFrom, "", "Executor's identity (one of wallet alias, address, nickname)")
 
+	cmd.Flags().AddFlagSet(fsDeployHeader)
 	return cmd
 }
 
@@ -356,13 +721,14 @@ func GetCmdUndelegate(cdc *codec.Codec) *cobra.Command {
 
 			// build and sign the transaction, then broadcast to Tendermint
 			msg := types.NewMsgUndelegate("system:undelegate", addr, valAddress, string(amount), string(fee))
-			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+			return generateOrBroadcastDeployMsgs(cliCtx, txBldr, []sdk.Msg{msg})
 		},
 	}
 
 	cmd.Flags().String(client.FlagHome, DefaultClientHome, "Custom local path of client's home dir")
 	cmd.Flags().String(client.FlagFrom, "", "Executor's identity (one of wallet alias, address, nickname)")
 
+	cmd.Flags().AddFlagSet(fsDeployHeader)
 	return cmd
 }
 
@@ -418,13 +784,14 @@ func GetCmdRedelegate(cdc *codec.Codec) *cobra.Command {
 
 			// build and sign the transaction, then broadcast to Tendermint
 			msg := types.NewMsgRedelegate("system:redelegate", addr, srcValAddress, destValAddress, string(amount), string(fee))
-			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+			return generateOrBroadcastDeployMsgs(cliCtx, txBldr, []sdk.Msg{msg})
 		},
 	}
 
 	cmd.Flags().String(client.FlagHome, DefaultClientHome, "Custom local path of client's home dir")
 	cmd.Flags().String(client.FlagFrom, "", "Executor's identity (one of wallet alias, address, nickname)")
 
+	cmd.Flags().AddFlagSet(fsDeployHeader)
 	return cmd
 }
 
@@ -477,13 +844,14 @@ func GetCmdVote(cdc *codec.Codec) *cobra.Command {
 
 			// build and sign the transaction, then broadcast to Tendermint
 			msg := types.NewMsgVote("system:vote", addr, contractAddress, string(amount), string(fee))
-			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+			return generateOrBroadcastDeployMsgs(cliCtx, txBldr, []sdk.Msg{msg})
 		},
 	}
 
 	cmd.Flags().String(client.FlagHome, DefaultClientHome, "Custom local path of client's home dir")
 	cmd.Flags().String(client.FlagFrom, "", "Executor's identity (one of wallet alias, address, nickname)")
 
+	cmd.Flags().AddFlagSet(fsDeployHeader)
 	return cmd
 }
 
@@ -532,13 +900,14 @@ func GetCmdUnvote(cdc *codec.Codec) *cobra.Command {
 
 			// build and sign the transaction, then broadcast to Tendermint
 			msg := types.NewMsgUnvote("system:unvote", addr, contractAddress, string(amount), string(fee))
-			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+			return generateOrBroadcastDeployMsgs(cliCtx, txBldr, []sdk.Msg{msg})
 		},
 	}
 
 	cmd.Flags().String(client.FlagHome, DefaultClientHome, "Custom local path of client's home dir")
 	cmd.Flags().String(client.FlagFrom, "", "Executor's identity (one of wallet alias, address, nickname)")
 
+	cmd.Flags().AddFlagSet(fsDeployHeader)
 	return cmd
 }
 
@@ -581,13 +950,226 @@ func GetCmdClaimReward(cdc *codec.Codec) *cobra.Command {
 
 			// build and sign the transaction, then broadcast to Tendermint
 			msg := types.NewMsgClaim(fmt.Sprintf("system:claim_%s", args[0]), addr, isRewardOrCommission, string(fee))
-			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+			return generateOrBroadcastDeployMsgs(cliCtx, txBldr, []sdk.Msg{msg})
 		},
 	}
 
 	cmd.Flags().String(client.FlagHome, DefaultClientHome, "Custom local path of client's home dir")
 	cmd.Flags().String(client.FlagFrom, "", "Executor's identity (one of wallet alias, address, nickname)")
 
+	cmd.Flags().AddFlagSet(fsDeployHeader)
+	return cmd
+}
+
+// GetCmdAddAssociatedKey is the CLI command for adding an associated key to the account
+func GetCmdAddAssociatedKey(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add-associated-key <associated_nickname>|<address> <weight> <fee> --from <from>",
+		Short: "Add an associated key to the account",
+		Long: "Add an associated key to the account\n" +
+			"Signatures of associated keys are summed by weight and checked against the action thresholds of the account.",
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			kb, err := client.NewKeyBaseFromDir(viper.GetString(client.FlagHome))
+			if err != nil {
+				return err
+			}
+
+			valueFromFromFlag := viper.GetString(client.FlagFrom)
+			keyInfo, err := cliutil.GetLocalWalletInfo(valueFromFromFlag, kb, cdc, cliCtx)
+			if err != nil {
+				return err
+			}
+
+			cliCtx = cliCtx.WithFromAddress(keyInfo.GetAddress()).WithFromName(keyInfo.GetName())
+			addr := keyInfo.GetAddress()
+
+			associatedAddr, err := cliutil.GetAddress(cliCtx.Codec, cliCtx, args[0])
+			if err != nil {
+				return fmt.Errorf("no nickname mapping of %s", args[0])
+			}
+
+			weight, err := strconv.ParseUint(args[1], 10, 8)
+			if err != nil {
+				return fmt.Errorf("weight must be between 1 and %d", types.MaxKeyWeight)
+			}
+
+			fee, err := cliutil.ToBigsun(cliutil.Hdac(args[2]))
+			if err != nil {
+				return err
+			}
+
+			// build and sign the transaction, then broadcast to Tendermint
+			msg := types.NewMsgAddAssociatedKey("system:add_associated_key", addr, associatedAddr, uint32(weight), string(fee))
+			return generateOrBroadcastAuthorizedMsgs(cliCtx, txBldr, msg)
+		},
+	}
+
+	cmd.Flags().String(client.FlagHome, DefaultClientHome, "Custom local path of client's home dir")
+	cmd.Flags().String(client.FlagFrom, "", "Executor's identity (one of wallet alias, address, nickname)")
+	cmd.Flags().String(FlagAuthorizers, "", authorizersFlagUsage)
+
+	cmd.Flags().AddFlagSet(fsDeployHeader)
+	return cmd
+}
+
+// GetCmdRemoveAssociatedKey is the CLI command for removing an associated key from the account
+func GetCmdRemoveAssociatedKey(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "remove-associated-key <associated_nickname>|<address> <fee> --from <from>",
+		Short: "Remove an associated key from the account",
+		Long:  "Remove an associated key from the account",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			kb, err := client.NewKeyBaseFromDir(viper.GetString(client.FlagHome))
+			if err != nil {
+				return err
+			}
+
+			valueFromFromFlag := viper.GetString(client.FlagFrom)
+			keyInfo, err := cliutil.GetLocalWalletInfo(valueFromFromFlag, kb, cdc, cliCtx)
+			if err != nil {
+				return err
+			}
+
+			cliCtx = cliCtx.WithFromAddress(keyInfo.GetAddress()).WithFromName(keyInfo.GetName())
+			addr := keyInfo.GetAddress()
+
+			associatedAddr, err := cliutil.GetAddress(cliCtx.Codec, cliCtx, args[0])
+			if err != nil {
+				return fmt.Errorf("no nickname mapping of %s", args[0])
+			}
+
+			fee, err := cliutil.ToBigsun(cliutil.Hdac(args[1]))
+			if err != nil {
+				return err
+			}
+
+			// build and sign the transaction, then broadcast to Tendermint
+			msg := types.NewMsgRemoveAssociatedKey("system:remove_associated_key", addr, associatedAddr, string(fee))
+			return generateOrBroadcastAuthorizedMsgs(cliCtx, txBldr, msg)
+		},
+	}
+
+	cmd.Flags().String(client.FlagHome, DefaultClientHome, "Custom local path of client's home dir")
+	cmd.Flags().String(client.FlagFrom, "", "Executor's identity (one of wallet alias, address, nickname)")
+	cmd.Flags().String(FlagAuthorizers, "", authorizersFlagUsage)
+
+	cmd.Flags().AddFlagSet(fsDeployHeader)
+	return cmd
+}
+
+// GetCmdUpdateAssociatedKey is the CLI command for changing the weight of an associated key
+func GetCmdUpdateAssociatedKey(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update-associated-key <associated_nickname>|<address> <weight> <fee> --from <from>",
+		Short: "Change the weight of an associated key of the account",
+		Long:  "Change the weight of an associated key of the account",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			kb, err := client.NewKeyBaseFromDir(viper.GetString(client.FlagHome))
+			if err != nil {
+				return err
+			}
+
+			valueFromFromFlag := viper.GetString(client.FlagFrom)
+			keyInfo, err := cliutil.GetLocalWalletInfo(valueFromFromFlag, kb, cdc, cliCtx)
+			if err != nil {
+				return err
+			}
+
+			cliCtx = cliCtx.WithFromAddress(keyInfo.GetAddress()).WithFromName(keyInfo.GetName())
+			addr := keyInfo.GetAddress()
+
+			associatedAddr, err := cliutil.GetAddress(cliCtx.Codec, cliCtx, args[0])
+			if err != nil {
+				return fmt.Errorf("no nickname mapping of %s", args[0])
+			}
+
+			weight, err := strconv.ParseUint(args[1], 10, 8)
+			if err != nil {
+				return fmt.Errorf("weight must be between 1 and %d", types.MaxKeyWeight)
+			}
+
+			fee, err := cliutil.ToBigsun(cliutil.Hdac(args[2]))
+			if err != nil {
+				return err
+			}
+
+			// build and sign the transaction, then broadcast to Tendermint
+			msg := types.NewMsgUpdateAssociatedKey("system:update_associated_key", addr, associatedAddr, uint32(weight), string(fee))
+			return generateOrBroadcastAuthorizedMsgs(cliCtx, txBldr, msg)
+		},
+	}
+
+	cmd.Flags().String(client.FlagHome, DefaultClientHome, "Custom local path of client's home dir")
+	cmd.Flags().String(client.FlagFrom, "", "Executor's identity (one of wallet alias, address, nickname)")
+	cmd.Flags().String(FlagAuthorizers, "", authorizersFlagUsage)
+
+	cmd.Flags().AddFlagSet(fsDeployHeader)
+	return cmd
+}
+
+// GetCmdSetActionThreshold is the CLI command for setting an action threshold of the account
+func GetCmdSetActionThreshold(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-threshold deployment|key_management <threshold> <fee> --from <from>",
+		Short: "Set an action threshold of the account",
+		Long: "Set an action threshold of the account\n" +
+			"A deploy is accepted only when the total weight of its authorizing keys reaches the threshold of the action.",
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			kb, err := client.NewKeyBaseFromDir(viper.GetString(client.FlagHome))
+			if err != nil {
+				return err
+			}
+
+			valueFromFromFlag := viper.GetString(client.FlagFrom)
+			keyInfo, err := cliutil.GetLocalWalletInfo(valueFromFromFlag, kb, cdc, cliCtx)
+			if err != nil {
+				return err
+			}
+
+			cliCtx = cliCtx.WithFromAddress(keyInfo.GetAddress()).WithFromName(keyInfo.GetName())
+			addr := keyInfo.GetAddress()
+
+			if _, ok := types.ActionTypeToEE(args[0]); !ok {
+				return fmt.Errorf("action type must be one of %s, %s", types.ActionTypeDeployment, types.ActionTypeKeyManagement)
+			}
+
+			threshold, err := strconv.ParseUint(args[1], 10, 8)
+			if err != nil {
+				return fmt.Errorf("threshold must be between 1 and %d", types.MaxKeyWeight)
+			}
+
+			fee, err := cliutil.ToBigsun(cliutil.Hdac(args[2]))
+			if err != nil {
+				return err
+			}
+
+			// build and sign the transaction, then broadcast to Tendermint
+			msg := types.NewMsgSetActionThreshold("system:set_action_threshold", addr, args[0], uint32(threshold), string(fee))
+			return generateOrBroadcastAuthorizedMsgs(cliCtx, txBldr, msg)
+		},
+	}
+
+	cmd.Flags().String(client.FlagHome, DefaultClientHome, "Custom local path of client's home dir")
+	cmd.Flags().String(client.FlagFrom, "", "Executor's identity (one of wallet alias, address, nickname)")
+	cmd.Flags().String(FlagAuthorizers, "", authorizersFlagUsage)
+
+	cmd.Flags().AddFlagSet(fsDeployHeader)
 	return cmd
 }
 
@@ -595,6 +1177,7 @@ func GetCmdClaimReward(cdc *codec.Codec) *cobra.Command {
 func GetCmdCreateValidator(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
 		Use: "create-validator <fee> --from <from> --pubkey <validator_cons_pubkey> " +
+			"--commission-rate <rate> --commission-max-rate <max_rate> --commission-max-change-rate <max_change_rate> " +
 			"[--moniker <moniker>] [--identity <identity>] [--website <site_address>] [--details <detail_description>]",
 		Short: "create new validator initialized with a self-delegation to it",
 		Args:  cobra.ExactArgs(1),
@@ -629,18 +1212,27 @@ func GetCmdCreateValidator(cdc *codec.Codec) *cobra.Command {
 				viper.GetString(FlagDetails),
 			)
 
+			commission, err := buildCommissionRates(
+				viper.GetString(FlagCommissionRate),
+				viper.GetString(FlagCommissionMaxRate),
+				viper.GetString(FlagCommissionMaxChangeRate),
+			)
+			if err != nil {
+				return err
+			}
+
 			fee, err := cliutil.ToBigsun(cliutil.Hdac(args[0]))
 			if err != nil {
 				return err
 			}
 
-			msg := types.NewMsgCreateValidator("system:create_validator", valAddr, consPubKey, description, string(fee))
+			msg := types.NewMsgCreateValidator("system:create_validator", valAddr, consPubKey, description, commission, string(fee))
 
 			if err != nil {
 				return err
 			}
 
-			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+			return generateOrBroadcastDeployMsgs(cliCtx, txBldr, []sdk.Msg{msg})
 		},
 	}
 
@@ -648,10 +1240,12 @@ func GetCmdCreateValidator(cdc *codec.Codec) *cobra.Command {
 	cmd.Flags().String(client.FlagFrom, "", "Executor's identity (one of wallet alias, address, nickname)")
 	cmd.Flags().AddFlagSet(fsDescriptionCreate)
 	cmd.Flags().AddFlagSet(FsPk)
+	cmd.Flags().AddFlagSet(fsCommissionCreate)
 
 	cmd.MarkFlagRequired(FlagPubKey)
 	cmd.MarkFlagRequired(FlagMoniker)
 
+	cmd.Flags().AddFlagSet(fsDeployHeader)
 	return cmd
 }
 
@@ -659,7 +1253,8 @@ func GetCmdCreateValidator(cdc *codec.Codec) *cobra.Command {
 func GetCmdEditValidator(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
 		Use: "edit-validator <fee> --from <from> " +
-			"[--moniker <moniker>] [--identity <identity>] [--website <site_address>] [--details <detail_description>]",
+			"[--moniker <moniker>] [--identity <identity>] [--website <site_address>] [--details <detail_description>] " +
+			"[--commission-rate <rate>]",
 		Short: "edit an existing validator account",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -686,15 +1281,24 @@ func GetCmdEditValidator(cdc *codec.Codec) *cobra.Command {
 				Details:  viper.GetString(FlagDetails),
 			}
 
+			var newRate *sdk.Dec
+			if commissionRate := viper.GetString(FlagCommissionRate); commissionRate != "" {
+				rate, err := sdk.NewDecFromStr(commissionRate)
+				if err != nil {
+					return fmt.Errorf("invalid new commission rate: %v", err)
+				}
+				newRate = &rate
+			}
+
 			fee, err := cliutil.ToBigsun(cliutil.Hdac(args[0]))
 			if err != nil {
 				return err
 			}
 
-			msg := types.NewMsgEditValidator("system:edit_validator", valAddr, description, string(fee))
+			msg := types.NewMsgEditValidator("system:edit_validator", valAddr, description, newRate, string(fee))
 
 			// build and sign the transaction, then broadcast to Tendermint
-			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+			return generateOrBroadcastDeployMsgs(cliCtx, txBldr, []sdk.Msg{msg})
 		},
 	}
 
@@ -702,9 +1306,66 @@ func GetCmdEditValidator(cdc *codec.Codec) *cobra.Command {
 	cmd.Flags().String(client.FlagFrom, "", "Executor's identity (one of wallet alias, address, nickname)")
 
 	cmd.Flags().AddFlagSet(fsDescriptionEdit)
+	cmd.Flags().AddFlagSet(fsCommissionUpdate)
+
+	cmd.MarkFlagRequired(client.FlagFrom)
+
+	cmd.Flags().AddFlagSet(fsDeployHeader)
+	return cmd
+}
+
+// GetCmdRotateConsPubKey implements the command replacing the consensus pubkey of a validator.
+func GetCmdRotateConsPubKey(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rotate-cons-pubkey <fee> --from <from> --pubkey <new-cons-pubkey>",
+		Short: "replace the consensus pubkey of an existing validator",
+		Long: "replace the consensus pubkey of an existing validator\n" +
+			"The validator keeps its stake and delegations, and the new key signs from the second block after the tx.\n" +
+			"Rotated again only after the cooldown of the params.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(auth.DefaultTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			kb, err := client.NewKeyBaseFromDir(viper.GetString(client.FlagHome))
+			if err != nil {
+				return err
+			}
+
+			valueFromFromFlag := viper.GetString(client.FlagFrom)
+			keyInfo, err := cliutil.GetLocalWalletInfo(valueFromFromFlag, kb, cdc, cliCtx)
+			if err != nil {
+				return err
+			}
+			cliCtx = cliCtx.WithFromAddress(keyInfo.GetAddress()).WithFromName(keyInfo.GetName())
+
+			valAddr := cliCtx.GetFromAddress()
+
+			consPubKey, err := sdk.GetConsPubKeyBech32(viper.GetString(FlagPubKey))
+			if err != nil {
+				return err
+			}
+
+			fee, err := cliutil.ToBigsun(cliutil.Hdac(args[0]))
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgRotateConsPubKey("system:rotate_cons_pubkey", valAddr, consPubKey, string(fee))
+
+			// build and sign the transaction, then broadcast to Tendermint
+			return generateOrBroadcastDeployMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(client.FlagHome, DefaultClientHome, "Custom local path of client's home dir")
+	cmd.Flags().String(client.FlagFrom, "", "Executor's identity (one of wallet alias, address, nickname)")
+	cmd.Flags().AddFlagSet(FsPk)
 
 	cmd.MarkFlagRequired(client.FlagFrom)
+	cmd.MarkFlagRequired(FlagPubKey)
 
+	cmd.Flags().AddFlagSet(fsDeployHeader)
 	return cmd
 }
 
@@ -725,7 +1386,41 @@ func BuildCreateValidatorMsg(cliCtx context.CLIContext) (sdk.Msg, error) {
 		viper.GetString(FlagDetails),
 	)
 
-	msg := types.NewMsgCreateValidator("system:create_validator", valAddr, consPubKey, description, types.BASIC_FEE)
+	commission, err := buildCommissionRates(
+		viper.GetString(FlagCommissionRate),
+		viper.GetString(FlagCommissionMaxRate),
+		viper.GetString(FlagCommissionMaxChangeRate),
+	)
+	if err != nil {
+		return types.MsgCreateValidator{}, err
+	}
+
+	msg := types.NewMsgCreateValidator("system:create_validator", valAddr, consPubKey, description, commission, types.BASIC_FEE)
 
 	return msg, nil
 }
+
+// buildCommissionRates parses the commission rates of a new validator
+func buildCommissionRates(rateStr, maxRateStr, maxChangeRateStr string) (commission types.CommissionRates, err error) {
+	if rateStr == "" || maxRateStr == "" || maxChangeRateStr == "" {
+		return commission, errors.New("must specify all validator commission parameters")
+	}
+
+	rate, err := sdk.NewDecFromStr(rateStr)
+	if err != nil {
+		return commission, err
+	}
+
+	maxRate, err := sdk.NewDecFromStr(maxRateStr)
+	if err != nil {
+		return commission, err
+	}
+
+	maxChangeRate, err := sdk.NewDecFromStr(maxChangeRateStr)
+	if err != nil {
+		return commission, err
+	}
+
+	commission = types.NewCommissionRates(rate, maxRate, maxChangeRate)
+	return commission, nil
+}
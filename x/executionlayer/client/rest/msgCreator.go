@@ -2,14 +2,19 @@ package rest
 
 import (
 	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hdac-io/friday/client/context"
+	"github.com/hdac-io/friday/client/flags"
 	sdk "github.com/hdac-io/friday/types"
 	"github.com/hdac-io/friday/types/rest"
+	"github.com/hdac-io/friday/x/auth"
+	"github.com/hdac-io/friday/x/auth/client/utils"
 	cliutil "github.com/hdac-io/friday/x/executionlayer/client/util"
 	"github.com/hdac-io/friday/x/executionlayer/types"
 
@@ -17,12 +22,14 @@ import (
 )
 
 type contractRunReq struct {
-	BaseReq                       rest.BaseReq `json:"base_req"`
-	ExecutionType                 string       `json:"type"`
-	TokenContractAddressOrKeyName string       `json:"token_contract_address_or_key_name"`
-	Base64EncodedBinary           string       `json:"base64_encoded_binary"`
-	Args                          string       `json:"args"`
-	Fee                           string       `json:"fee"`
+	BaseReq                       rest.BaseReq        `json:"base_req"`
+	ExecutionType                 string              `json:"type"`
+	TokenContractAddressOrKeyName string              `json:"token_contract_address_or_key_name"`
+	Base64EncodedBinary           string              `json:"base64_encoded_binary"`
+	Args                          string              `json:"args"`
+	Fee                           string              `json:"fee"`
+	Authorizers                   []string            `json:"authorizers"`
+	DeployHeader                  *types.DeployHeader `json:"deploy_header"`
 }
 
 func contractRunMsgCreator(w http.ResponseWriter, cliCtx context.CLIContext, r *http.Request) (rest.BaseReq, []sdk.Msg, error) {
@@ -99,15 +106,42 @@ func contractRunMsgCreator(w http.ResponseWriter, cliCtx context.CLIContext, r *
 		return rest.BaseReq{}, nil, err
 	}
 
-	return req.BaseReq, []sdk.Msg{msg}, nil
+	msgs, err := appendAuthorizeMsgs(cliCtx, withDeployHeader(msg, req.DeployHeader), req.Authorizers)
+	if err != nil {
+		return rest.BaseReq{}, nil, err
+	}
+
+	return req.BaseReq, msgs, nil
+}
+
+// getContractDryRunQuerying takes the same body as the contract run request
+func getContractDryRunQuerying(w http.ResponseWriter, cliCtx context.CLIContext, r *http.Request) ([]byte, error) {
+	_, msgs, err := contractRunMsgCreator(w, cliCtx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	queryData := types.NewQueryDryRunParams(msgs[0].(types.MsgExecute))
+	bz := cliCtx.Codec.MustMarshalJSON(queryData)
+
+	return bz, nil
 }
 
-func getContractQuerying(w http.ResponseWriter, cliCtx context.CLIContext, r *http.Request) ([]byte, string, error) {
+func getContractQuerying(w http.ResponseWriter, cliCtx context.CLIContext, r *http.Request) ([]byte, bool, error) {
 	vars := r.URL.Query()
 	dataType := vars.Get("data_type")
 	data := vars.Get("data")
 	path := vars.Get("path")
 
+	raw := false
+	if rawStr := vars.Get("raw"); rawStr != "" {
+		var err error
+		raw, err = strconv.ParseBool(rawStr)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to parse raw: %s", rawStr)
+		}
+	}
+
 	queryData := types.QueryExecutionLayerDetail{
 		KeyType: dataType,
 		KeyData: data,
@@ -115,14 +149,178 @@ func getContractQuerying(w http.ResponseWriter, cliCtx context.CLIContext, r *ht
 	}
 	bz := cliCtx.Codec.MustMarshalJSON(queryData)
 
-	return bz, path, nil
+	return bz, raw, nil
+}
+
+type contractDeployReq struct {
+	BaseReq             rest.BaseReq          `json:"base_req"`
+	Name                string                `json:"name"`
+	Base64EncodedBinary string                `json:"base64_encoded_binary"`
+	Args                string                `json:"args"`
+	Fee                 string                `json:"fee"`
+	Schema              *types.ContractSchema `json:"schema"`
+	DeployHeader        *types.DeployHeader   `json:"deploy_header"`
+}
+
+func contractDeployMsgCreator(w http.ResponseWriter, cliCtx context.CLIContext, r *http.Request) (rest.BaseReq, []sdk.Msg, error) {
+	var req contractDeployReq
+
+	// Get body parameters
+	if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+		return rest.BaseReq{}, nil, fmt.Errorf("failed to parse request")
+	}
+
+	var senderAddr sdk.AccAddress
+	senderAddr, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+	if err != nil {
+		senderAddr, err = cliutil.GetAddress(cliCtx.Codec, cliCtx, req.BaseReq.From)
+		if err != nil {
+			return rest.BaseReq{}, nil, fmt.Errorf("failed to parse sender address or name: %s", req.BaseReq.From)
+		}
+	}
+
+	req.BaseReq.From = senderAddr.String()
+	if !req.BaseReq.ValidateBasic(w) {
+		return rest.BaseReq{}, nil, fmt.Errorf("failed to parse base request")
+	}
+
+	code, err := base64.StdEncoding.DecodeString(req.Base64EncodedBinary)
+	if err != nil {
+		return rest.BaseReq{}, nil, fmt.Errorf("failed to decode WASM binary")
+	}
+
+	fee, err := cliutil.ToBigsun(cliutil.Hdac(req.Fee))
+	if err != nil {
+		return rest.BaseReq{}, nil, fmt.Errorf("error on conversion from bigsun to token")
+	}
+
+	// build and sign the transaction, then broadcast to Tendermint
+	msg := types.NewMsgDeployContract(
+		"wasm_file_direct_execution",
+		senderAddr,
+		req.Name,
+		code,
+		req.Args,
+		string(fee),
+		req.Schema,
+	)
+
+	err = msg.ValidateBasic()
+	if err != nil {
+		return rest.BaseReq{}, nil, err
+	}
+
+	return req.BaseReq, withDeployHeader(msg, req.DeployHeader), nil
+}
+
+type setContractSchemaReq struct {
+	BaseReq rest.BaseReq          `json:"base_req"`
+	Name    string                `json:"name"`
+	Schema  *types.ContractSchema `json:"schema"`
+}
+
+func setContractSchemaMsgCreator(w http.ResponseWriter, cliCtx context.CLIContext, r *http.Request) (rest.BaseReq, []sdk.Msg, error) {
+	var req setContractSchemaReq
+
+	// Get body parameters
+	if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+		return rest.BaseReq{}, nil, fmt.Errorf("failed to parse request")
+	}
+
+	var senderAddr sdk.AccAddress
+	senderAddr, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+	if err != nil {
+		senderAddr, err = cliutil.GetAddress(cliCtx.Codec, cliCtx, req.BaseReq.From)
+		if err != nil {
+			return rest.BaseReq{}, nil, fmt.Errorf("failed to parse sender address or name: %s", req.BaseReq.From)
+		}
+	}
+
+	req.BaseReq.From = senderAddr.String()
+	if !req.BaseReq.ValidateBasic(w) {
+		return rest.BaseReq{}, nil, fmt.Errorf("failed to parse base request")
+	}
+
+	msg := types.NewMsgSetContractSchema(senderAddr, req.Name, req.Schema)
+	err = msg.ValidateBasic()
+	if err != nil {
+		return rest.BaseReq{}, nil, err
+	}
+
+	return req.BaseReq, []sdk.Msg{msg}, nil
+}
+
+func getContractDescribeQuerying(w http.ResponseWriter, cliCtx context.CLIContext, r *http.Request) (*types.ContractInfo, error) {
+	vars := r.URL.Query()
+
+	var from sdk.AccAddress
+	var err error
+	if fromStr := vars.Get("from"); fromStr != "" {
+		from, err = cliutil.GetAddress(cliCtx.Codec, cliCtx, fromStr)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	contract := vars.Get("contract")
+	if contract == "" {
+		return nil, fmt.Errorf("contract is required")
+	}
+	resolved, err := cliutil.ResolveContract(cliCtx, from, contract)
+	if err != nil {
+		return nil, err
+	}
+	if resolved.Info == nil {
+		return nil, fmt.Errorf("%s is not in the contract registry", contract)
+	}
+	return resolved.Info, nil
+}
+
+func getDeployQuerying(w http.ResponseWriter, cliCtx context.CLIContext, r *http.Request) ([]byte, error) {
+	deployHash, err := hex.DecodeString(r.URL.Query().Get("hash"))
+	if err != nil {
+		return nil, fmt.Errorf("deploy hash must be hex encoded: %s", err.Error())
+	}
+
+	queryData := types.NewQueryDeployParams(deployHash)
+	bz := cliCtx.Codec.MustMarshalJSON(queryData)
+
+	return bz, nil
+}
+
+func getContractRegistryQuerying(w http.ResponseWriter, cliCtx context.CLIContext, r *http.Request) ([]byte, error) {
+	vars := r.URL.Query()
+
+	var deployer sdk.AccAddress
+	var err error
+	if deployerStr := vars.Get("deployer"); deployerStr != "" {
+		deployer, err = cliutil.GetAddress(cliCtx.Codec, cliCtx, deployerStr)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var codeHash []byte
+	if codeHashStr := vars.Get("code_hash"); codeHashStr != "" {
+		codeHash, err = hex.DecodeString(codeHashStr)
+		if err != nil {
+			return nil, fmt.Errorf("code hash must be hex encoded")
+		}
+	}
+
+	queryData := types.NewQueryContractParams(deployer, vars.Get("name"), codeHash)
+	bz := cliCtx.Codec.MustMarshalJSON(queryData)
+
+	return bz, nil
 }
 
 type transferReq struct {
-	BaseReq                    rest.BaseReq `json:"base_req"`
-	RecipientAddressOrNickname string       `json:"recipient_address_or_nickname"`
-	Amount                     string       `json:"amount"`
-	Fee                        string       `json:"fee"`
+	BaseReq                    rest.BaseReq        `json:"base_req"`
+	RecipientAddressOrNickname string              `json:"recipient_address_or_nickname"`
+	Amount                     string              `json:"amount"`
+	Fee                        string              `json:"fee"`
+	Authorizers                []string            `json:"authorizers"`
+	DeployHeader               *types.DeployHeader `json:"deploy_header"`
 }
 
 func transferMsgCreator(w http.ResponseWriter, cliCtx context.CLIContext, r *http.Request) (rest.BaseReq, []sdk.Msg, error) {
@@ -174,13 +372,81 @@ func transferMsgCreator(w http.ResponseWriter, cliCtx context.CLIContext, r *htt
 		return rest.BaseReq{}, nil, err
 	}
 
-	return req.BaseReq, []sdk.Msg{eeMsg}, nil
+	msgs, err := appendAuthorizeMsgs(cliCtx, withDeployHeader(eeMsg, req.DeployHeader), req.Authorizers)
+	if err != nil {
+		return rest.BaseReq{}, nil, err
+	}
+
+	return req.BaseReq, msgs, nil
+}
+
+type transferBatchReq struct {
+	BaseReq    rest.BaseReq                 `json:"base_req"`
+	Transfers  []cliutil.TransferBatchEntry `json:"transfers"`
+	Fee        string                       `json:"fee"`
+	MaxMsgs    int                          `json:"max_msgs"`
+	MaxTxBytes int                          `json:"max_tx_bytes"`
+}
+
+// transferBatchMsgCreator resolves the recipients and validates the amounts of the transfers,
+// and packs the valid transfers into txs
+func transferBatchMsgCreator(w http.ResponseWriter, cliCtx context.CLIContext, r *http.Request) (
+	rest.BaseReq, [][]sdk.Msg, []cliutil.TransferBatchResult, []int, error) {
+	var req transferBatchReq
+
+	// Get body parameters
+	if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+		return rest.BaseReq{}, nil, nil, nil, fmt.Errorf("failed to parse request")
+	}
+
+	var senderAddr sdk.AccAddress
+	senderAddr, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+	if err != nil {
+		senderAddr, err = cliutil.GetAddress(cliCtx.Codec, cliCtx, req.BaseReq.From)
+		if err != nil {
+			return rest.BaseReq{}, nil, nil, nil, fmt.Errorf("failed to parse sender address or name: %s", req.BaseReq.From)
+		}
+	}
+
+	req.BaseReq.From = senderAddr.String()
+	if !req.BaseReq.ValidateBasic(w) {
+		return rest.BaseReq{}, nil, nil, nil, fmt.Errorf("failed to parse base request")
+	}
+	if len(req.Transfers) == 0 {
+		return rest.BaseReq{}, nil, nil, nil, fmt.Errorf("no transfer in the request")
+	}
+
+	fee, err := cliutil.ToBigsun(cliutil.Hdac(req.Fee))
+	if err != nil {
+		return rest.BaseReq{}, nil, nil, nil, err
+	}
+
+	maxMsgs, maxTxBytes := req.MaxMsgs, req.MaxTxBytes
+	if maxMsgs == 0 {
+		maxMsgs = cliutil.DefaultBatchMaxMsgs
+	}
+	if maxTxBytes == 0 {
+		maxTxBytes = cliutil.DefaultBatchMaxTxBytes
+	}
+
+	results := cliutil.NewTransferBatchResults(req.Transfers)
+	msgs, indices := cliutil.NewTransferBatchMsgs(cliCtx, senderAddr, results, fee)
+
+	txSize := cliutil.SignedTxSize(utils.GetTxEncoder(cliCtx.Codec),
+		auth.NewStdFee(flags.DefaultGasLimit, req.BaseReq.Fees), req.BaseReq.Memo)
+	packs, err := cliutil.PackMsgs(msgs, maxMsgs, maxTxBytes, txSize)
+	if err != nil {
+		return rest.BaseReq{}, nil, nil, nil, err
+	}
+
+	return req.BaseReq, packs, results, indices, nil
 }
 
 type bondReq struct {
-	BaseReq rest.BaseReq `json:"base_req"`
-	Amount  string       `json:"amount"`
-	Fee     string       `json:"fee"`
+	BaseReq      rest.BaseReq        `json:"base_req"`
+	Amount       string              `json:"amount"`
+	Fee          string              `json:"fee"`
+	DeployHeader *types.DeployHeader `json:"deploy_header"`
 }
 
 func bondUnbondMsgCreator(bondIsTrue bool, w http.ResponseWriter, cliCtx context.CLIContext, r *http.Request) (rest.BaseReq, []sdk.Msg, error) {
@@ -229,14 +495,15 @@ func bondUnbondMsgCreator(bondIsTrue bool, w http.ResponseWriter, cliCtx context
 		return rest.BaseReq{}, nil, err
 	}
 
-	return req.BaseReq, []sdk.Msg{msg}, nil
+	return req.BaseReq, withDeployHeader(msg, req.DeployHeader), nil
 }
 
 type delegateReq struct {
-	BaseReq          rest.BaseReq `json:"base_req"`
-	ValidatorAddress string       `json:"validator_address"`
-	Amount           string       `json:"amount"`
-	Fee              string       `json:"fee"`
+	BaseReq          rest.BaseReq        `json:"base_req"`
+	ValidatorAddress string              `json:"validator_address"`
+	Amount           string              `json:"amount"`
+	Fee              string              `json:"fee"`
+	DeployHeader     *types.DeployHeader `json:"deploy_header"`
 }
 
 func delegateUndelegateMsgCreator(delegateIsTrue bool, w http.ResponseWriter, cliCtx context.CLIContext, r *http.Request) (rest.BaseReq, []sdk.Msg, error) {
@@ -294,15 +561,16 @@ func delegateUndelegateMsgCreator(delegateIsTrue bool, w http.ResponseWriter, cl
 		return rest.BaseReq{}, nil, err
 	}
 
-	return req.BaseReq, []sdk.Msg{msg}, nil
+	return req.BaseReq, withDeployHeader(msg, req.DeployHeader), nil
 }
 
 type redelegateReq struct {
-	BaseReq              rest.BaseReq `json:"base_req"`
-	SrcValidatorAddress  string       `json:"src_validator_address"`
-	DestValidatorAddress string       `json:"dest_validator_address"`
-	Amount               string       `json:"amount"`
-	Fee                  string       `json:"fee"`
+	BaseReq              rest.BaseReq        `json:"base_req"`
+	SrcValidatorAddress  string              `json:"src_validator_address"`
+	DestValidatorAddress string              `json:"dest_validator_address"`
+	Amount               string              `json:"amount"`
+	Fee                  string              `json:"fee"`
+	DeployHeader         *types.DeployHeader `json:"deploy_header"`
 }
 
 func redelegateMsgCreator(w http.ResponseWriter, cliCtx context.CLIContext, r *http.Request) (rest.BaseReq, []sdk.Msg, error) {
@@ -363,14 +631,15 @@ func redelegateMsgCreator(w http.ResponseWriter, cliCtx context.CLIContext, r *h
 		return rest.BaseReq{}, nil, err
 	}
 
-	return req.BaseReq, []sdk.Msg{msg}, nil
+	return req.BaseReq, withDeployHeader(msg, req.DeployHeader), nil
 }
 
 type voteReq struct {
-	BaseReq                rest.BaseReq `json:"base_req"`
-	TargetContrractAddress string       `json:"target_contract_address"`
-	Amount                 string       `json:"amount"`
-	Fee                    string       `json:"fee"`
+	BaseReq                rest.BaseReq        `json:"base_req"`
+	TargetContrractAddress string              `json:"target_contract_address"`
+	Amount                 string              `json:"amount"`
+	Fee                    string              `json:"fee"`
+	DeployHeader           *types.DeployHeader `json:"deploy_header"`
 }
 
 func voteUnvoteMsgCreator(voteIsTrue bool, w http.ResponseWriter, cliCtx context.CLIContext, r *http.Request) (rest.BaseReq, []sdk.Msg, error) {
@@ -433,13 +702,14 @@ func voteUnvoteMsgCreator(voteIsTrue bool, w http.ResponseWriter, cliCtx context
 		return rest.BaseReq{}, nil, err
 	}
 
-	return req.BaseReq, []sdk.Msg{msg}, nil
+	return req.BaseReq, withDeployHeader(msg, req.DeployHeader), nil
 }
 
 type claimReq struct {
-	BaseReq            rest.BaseReq `json:"base_req"`
-	RewardOrCommission bool         `json:"reward_or_commission"`
-	Fee                string       `json:"fee"`
+	BaseReq            rest.BaseReq        `json:"base_req"`
+	RewardOrCommission bool                `json:"reward_or_commission"`
+	Fee                string              `json:"fee"`
+	DeployHeader       *types.DeployHeader `json:"deploy_header"`
 }
 
 func claimMsgCreator(w http.ResponseWriter, cliCtx context.CLIContext, r *http.Request) (rest.BaseReq, []sdk.Msg, error) {
@@ -487,7 +757,7 @@ func claimMsgCreator(w http.ResponseWriter, cliCtx context.CLIContext, r *http.R
 		return rest.BaseReq{}, nil, err
 	}
 
-	return req.BaseReq, []sdk.Msg{msg}, nil
+	return req.BaseReq, withDeployHeader(msg, req.DeployHeader), nil
 }
 
 func getRewardQuerying(w http.ResponseWriter, cliCtx context.CLIContext, r *http.Request, storeName string) ([]byte, error) {
@@ -526,6 +796,65 @@ func getCommissionQuerying(w http.ResponseWriter, cliCtx context.CLIContext, r *
 	return bz, nil
 }
 
+func getRewardHistoryQuerying(w http.ResponseWriter, cliCtx context.CLIContext, r *http.Request) ([]byte, error) {
+	vars := r.URL.Query()
+	addr, err := cliutil.GetAddress(cliCtx.Codec, cliCtx, vars.Get("address"))
+	if err != nil {
+		return nil, err
+	}
+
+	var validatorAddress sdk.AccAddress
+	if validatorAddressStr := vars.Get("validator"); validatorAddressStr != "" {
+		validatorAddress, err = cliutil.GetAddress(cliCtx.Codec, cliCtx, validatorAddressStr)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	fromHeight, toHeight, err := getHeightRange(vars.Get("from_height"), vars.Get("to_height"))
+	if err != nil {
+		return nil, err
+	}
+
+	queryData := types.NewQueryRewardHistoryParams(addr, validatorAddress, fromHeight, toHeight)
+	bz := cliCtx.Codec.MustMarshalJSON(queryData)
+
+	return bz, nil
+}
+
+func getCommissionHistoryQuerying(w http.ResponseWriter, cliCtx context.CLIContext, r *http.Request) ([]byte, error) {
+	vars := r.URL.Query()
+	addr, err := cliutil.GetAddress(cliCtx.Codec, cliCtx, vars.Get("address"))
+	if err != nil {
+		return nil, err
+	}
+
+	fromHeight, toHeight, err := getHeightRange(vars.Get("from_height"), vars.Get("to_height"))
+	if err != nil {
+		return nil, err
+	}
+
+	queryData := types.NewQueryCommissionHistoryParams(addr, fromHeight, toHeight)
+	bz := cliCtx.Codec.MustMarshalJSON(queryData)
+
+	return bz, nil
+}
+
+// getHeightRange parses the optional heights of a history range, an empty one being zero
+func getHeightRange(fromHeightStr, toHeightStr string) (fromHeight, toHeight int64, err error) {
+	if fromHeightStr != "" {
+		if fromHeight, err = strconv.ParseInt(fromHeightStr, 10, 64); err != nil {
+			return 0, 0, fmt.Errorf("invalid from_height %s", fromHeightStr)
+		}
+	}
+	if toHeightStr != "" {
+		if toHeight, err = strconv.ParseInt(toHeightStr, 10, 64); err != nil {
+			return 0, 0, fmt.Errorf("invalid to_height %s", toHeightStr)
+		}
+	}
+	return fromHeight, toHeight, nil
+}
+
 func getBalanceQuerying(w http.ResponseWriter, cliCtx context.CLIContext, r *http.Request, storeName string) ([]byte, error) {
 	vars := r.URL.Query()
 	straddr := vars.Get("address")
@@ -621,10 +950,12 @@ func getVoteQuerying(w http.ResponseWriter, cliCtx context.CLIContext, r *http.R
 }
 
 type createValidatorReq struct {
-	BaseReq     rest.BaseReq      `json:"base_req"`
-	ConsPubKey  string            `json:"cons_pub_key"`
-	Description types.Description `json:"description"`
-	Fee         string            `json:"fee"`
+	BaseReq      rest.BaseReq          `json:"base_req"`
+	ConsPubKey   string                `json:"cons_pub_key"`
+	Description  types.Description     `json:"description"`
+	Commission   types.CommissionRates `json:"commission"`
+	Fee          string                `json:"fee"`
+	DeployHeader *types.DeployHeader   `json:"deploy_header"`
 }
 
 func createValidatorMsgCreator(w http.ResponseWriter, cliCtx context.CLIContext, r *http.Request) (rest.BaseReq, []sdk.Msg, error) {
@@ -661,19 +992,21 @@ func createValidatorMsgCreator(w http.ResponseWriter, cliCtx context.CLIContext,
 	}
 
 	// create the message
-	msg := types.NewMsgCreateValidator("system:create_validator", valAddr, consPubKey, req.Description, string(fee))
+	msg := types.NewMsgCreateValidator("system:create_validator", valAddr, consPubKey, req.Description, req.Commission, string(fee))
 	err = msg.ValidateBasic()
 	if err != nil {
 		return rest.BaseReq{}, nil, err
 	}
 
-	return req.BaseReq, []sdk.Msg{msg}, nil
+	return req.BaseReq, withDeployHeader(msg, req.DeployHeader), nil
 }
 
 type editValidatorReq struct {
-	BaseReq     rest.BaseReq      `json:"base_req"`
-	Description types.Description `json:"description"`
-	Fee         string            `json:"fee"`
+	BaseReq        rest.BaseReq        `json:"base_req"`
+	Description    types.Description   `json:"description"`
+	CommissionRate *sdk.Dec            `json:"commission_rate"`
+	Fee            string              `json:"fee"`
+	DeployHeader   *types.DeployHeader `json:"deploy_header"`
 }
 
 func editValidatorMsgCreator(w http.ResponseWriter, cliCtx context.CLIContext, r *http.Request) (rest.BaseReq, []sdk.Msg, error) {
@@ -704,13 +1037,63 @@ func editValidatorMsgCreator(w http.ResponseWriter, cliCtx context.CLIContext, r
 	}
 
 	// create the message
-	msg := types.NewMsgEditValidator("system:edit_validator", valAddr, req.Description, string(fee))
+	msg := types.NewMsgEditValidator("system:edit_validator", valAddr, req.Description, req.CommissionRate, string(fee))
 	err = msg.ValidateBasic()
 	if err != nil {
 		return rest.BaseReq{}, nil, err
 	}
 
-	return req.BaseReq, []sdk.Msg{msg}, nil
+	return req.BaseReq, withDeployHeader(msg, req.DeployHeader), nil
+}
+
+type rotateConsPubKeyReq struct {
+	BaseReq      rest.BaseReq        `json:"base_req"`
+	ConsPubKey   string              `json:"cons_pub_key"`
+	Fee          string              `json:"fee"`
+	DeployHeader *types.DeployHeader `json:"deploy_header"`
+}
+
+func rotateConsPubKeyMsgCreator(w http.ResponseWriter, cliCtx context.CLIContext, r *http.Request) (rest.BaseReq, []sdk.Msg, error) {
+	var req rotateConsPubKeyReq
+
+	// Get body parameters
+	if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+		return rest.BaseReq{}, nil, fmt.Errorf("failed to parse request")
+	}
+
+	var valAddr sdk.AccAddress
+	valAddr, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+	if err != nil {
+		valAddr, err = cliutil.GetAddress(cliCtx.Codec, cliCtx, req.BaseReq.From)
+		if err != nil {
+			return rest.BaseReq{}, nil, fmt.Errorf("failed to parse sender address or name: %s", req.BaseReq.From)
+		}
+	}
+
+	req.BaseReq.From = valAddr.String()
+	if !req.BaseReq.ValidateBasic(w) {
+		return rest.BaseReq{}, nil, fmt.Errorf("failed to parse base request")
+	}
+
+	// Parameter touching
+	consPubKey, err := sdk.GetConsPubKeyBech32(req.ConsPubKey)
+	if err != nil {
+		return rest.BaseReq{}, nil, err
+	}
+
+	fee, err := cliutil.ToBigsun(cliutil.Hdac(req.Fee))
+	if err != nil {
+		return rest.BaseReq{}, nil, err
+	}
+
+	// create the message
+	msg := types.NewMsgRotateConsPubKey("system:rotate_cons_pubkey", valAddr, consPubKey, string(fee))
+	err = msg.ValidateBasic()
+	if err != nil {
+		return rest.BaseReq{}, nil, err
+	}
+
+	return req.BaseReq, withDeployHeader(msg, req.DeployHeader), nil
 }
 
 func getValidatorQuerying(w http.ResponseWriter, cliCtx context.CLIContext, r *http.Request) ([]byte, error) {
@@ -763,6 +1146,33 @@ func getDelegatorQuerying(w http.ResponseWriter, cliCtx context.CLIContext, r *h
 	return bz, nil
 }
 
+// getUnbondingQuerying takes the optional delegator and validator filters of the pending unbondings and redelegations
+func getUnbondingQuerying(w http.ResponseWriter, cliCtx context.CLIContext, r *http.Request) ([]byte, error) {
+	vars := r.URL.Query()
+	var validatorAddress sdk.AccAddress
+	if validatorAddressStr := vars.Get("validator"); validatorAddressStr != "" {
+		addr, err := cliutil.GetAddress(cliCtx.Codec, cliCtx, validatorAddressStr)
+		if err != nil {
+			return nil, err
+		}
+		validatorAddress = addr
+	}
+
+	var delegatorAddress sdk.AccAddress
+	if delegatorAddressStr := vars.Get("delegator"); delegatorAddressStr != "" {
+		addr, err := cliutil.GetAddress(cliCtx.Codec, cliCtx, delegatorAddressStr)
+		if err != nil {
+			return nil, err
+		}
+		delegatorAddress = addr
+	}
+
+	queryData := types.NewQueryDelegatorParams(delegatorAddress, validatorAddress)
+	bz := cliCtx.Codec.MustMarshalJSON(queryData)
+
+	return bz, nil
+}
+
 func getVoterQuerying(w http.ResponseWriter, cliCtx context.CLIContext, r *http.Request) ([]byte, error) {
 	vars := r.URL.Query()
 
@@ -814,3 +1224,853 @@ func getVoterQuerying(w http.ResponseWriter, cliCtx context.CLIContext, r *http.
 
 	return bz, nil
 }
+
+type associatedKeyReq struct {
+	BaseReq                     rest.BaseReq        `json:"base_req"`
+	AssociatedAddressOrNickname string              `json:"associated_address_or_nickname"`
+	Weight                      string              `json:"weight"`
+	Fee                         string              `json:"fee"`
+	Authorizers                 []string            `json:"authorizers"`
+	DeployHeader                *types.DeployHeader `json:"deploy_header"`
+}
+
+func associatedKeyMsgCreator(isAdd bool, w http.ResponseWriter, cliCtx context.CLIContext, r *http.Request) (rest.BaseReq, []sdk.Msg, error) {
+	var req associatedKeyReq
+
+	// Get body parameters
+	if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+		return rest.BaseReq{}, nil, fmt.Errorf("failed to parse request")
+	}
+
+	var addr sdk.AccAddress
+	addr, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+	if err != nil {
+		addr, err = cliutil.GetAddress(cliCtx.Codec, cliCtx, req.BaseReq.From)
+		if err != nil {
+			return rest.BaseReq{}, nil, fmt.Errorf("failed to parse sender address or name: %s", req.BaseReq.From)
+		}
+	}
+
+	req.BaseReq.From = addr.String()
+	if !req.BaseReq.ValidateBasic(w) {
+		return rest.BaseReq{}, nil, fmt.Errorf("failed to parse base request")
+	}
+
+	// Parameter touching
+	associatedAddr, err := cliutil.GetAddress(cliCtx.Codec, cliCtx, req.AssociatedAddressOrNickname)
+	if err != nil {
+		return rest.BaseReq{}, nil, fmt.Errorf("failed to parse associated address or name: %s", req.AssociatedAddressOrNickname)
+	}
+
+	weight, err := strconv.ParseUint(req.Weight, 10, 8)
+	if err != nil {
+		return rest.BaseReq{}, nil, fmt.Errorf("weight must be between 1 and %d", types.MaxKeyWeight)
+	}
+
+	fee, err := cliutil.ToBigsun(cliutil.Hdac(req.Fee))
+	if err != nil {
+		return rest.BaseReq{}, nil, err
+	}
+
+	var msg sdk.Msg
+	if isAdd == true {
+		msg = types.NewMsgAddAssociatedKey("system:add_associated_key", addr, associatedAddr, uint32(weight), string(fee))
+	} else {
+		msg = types.NewMsgUpdateAssociatedKey("system:update_associated_key", addr, associatedAddr, uint32(weight), string(fee))
+	}
+
+	// create the message
+	err = msg.ValidateBasic()
+	if err != nil {
+		return rest.BaseReq{}, nil, err
+	}
+
+	msgs, err := appendAuthorizeMsgs(cliCtx, withDeployHeader(msg, req.DeployHeader), req.Authorizers)
+	if err != nil {
+		return rest.BaseReq{}, nil, err
+	}
+
+	return req.BaseReq, msgs, nil
+}
+
+type removeAssociatedKeyReq struct {
+	BaseReq                     rest.BaseReq        `json:"base_req"`
+	AssociatedAddressOrNickname string              `json:"associated_address_or_nickname"`
+	Fee                         string              `json:"fee"`
+	Authorizers                 []string            `json:"authorizers"`
+	DeployHeader                *types.DeployHeader `json:"deploy_header"`
+}
+
+func removeAssociatedKeyMsgCreator(w http.ResponseWriter, cliCtx context.CLIContext, r *http.Request) (rest.BaseReq, []sdk.Msg, error) {
+	var req removeAssociatedKeyReq
+
+	// Get body parameters
+	if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+		return rest.BaseReq{}, nil, fmt.Errorf("failed to parse request")
+	}
+
+	var addr sdk.AccAddress
+	addr, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+	if err != nil {
+		addr, err = cliutil.GetAddress(cliCtx.Codec, cliCtx, req.BaseReq.From)
+		if err != nil {
+			return rest.BaseReq{}, nil, fmt.Errorf("failed to parse sender address or name: %s", req.BaseReq.From)
+		}
+	}
+
+	req.BaseReq.From = addr.String()
+	if !req.BaseReq.ValidateBasic(w) {
+		return rest.BaseReq{}, nil, fmt.Errorf("failed to parse base request")
+	}
+
+	// Parameter touching
+	associatedAddr, err := cliutil.GetAddress(cliCtx.Codec, cliCtx, req.AssociatedAddressOrNickname)
+	if err != nil {
+		return rest.BaseReq{}, nil, fmt.Errorf("failed to parse associated address or name: %s", req.AssociatedAddressOrNickname)
+	}
+
+	fee, err := cliutil.ToBigsun(cliutil.Hdac(req.Fee))
+	if err != nil {
+		return rest.BaseReq{}, nil, err
+	}
+
+	// create the message
+	msg := types.NewMsgRemoveAssociatedKey("system:remove_associated_key", addr, associatedAddr, string(fee))
+	err = msg.ValidateBasic()
+	if err != nil {
+		return rest.BaseReq{}, nil, err
+	}
+
+	msgs, err := appendAuthorizeMsgs(cliCtx, withDeployHeader(msg, req.DeployHeader), req.Authorizers)
+	if err != nil {
+		return rest.BaseReq{}, nil, err
+	}
+
+	return req.BaseReq, msgs, nil
+}
+
+type actionThresholdReq struct {
+	BaseReq      rest.BaseReq        `json:"base_req"`
+	ActionType   string              `json:"action_type"`
+	Threshold    string              `json:"threshold"`
+	Fee          string              `json:"fee"`
+	Authorizers  []string            `json:"authorizers"`
+	DeployHeader *types.DeployHeader `json:"deploy_header"`
+}
+
+func actionThresholdMsgCreator(w http.ResponseWriter, cliCtx context.CLIContext, r *http.Request) (rest.BaseReq, []sdk.Msg, error) {
+	var req actionThresholdReq
+
+	// Get body parameters
+	if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+		return rest.BaseReq{}, nil, fmt.Errorf("failed to parse request")
+	}
+
+	var addr sdk.AccAddress
+	addr, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+	if err != nil {
+		addr, err = cliutil.GetAddress(cliCtx.Codec, cliCtx, req.BaseReq.From)
+		if err != nil {
+			return rest.BaseReq{}, nil, fmt.Errorf("failed to parse sender address or name: %s", req.BaseReq.From)
+		}
+	}
+
+	req.BaseReq.From = addr.String()
+	if !req.BaseReq.ValidateBasic(w) {
+		return rest.BaseReq{}, nil, fmt.Errorf("failed to parse base request")
+	}
+
+	// Parameter touching
+	threshold, err := strconv.ParseUint(req.Threshold, 10, 8)
+	if err != nil {
+		return rest.BaseReq{}, nil, fmt.Errorf("threshold must be between 1 and %d", types.MaxKeyWeight)
+	}
+
+	fee, err := cliutil.ToBigsun(cliutil.Hdac(req.Fee))
+	if err != nil {
+		return rest.BaseReq{}, nil, err
+	}
+
+	// create the message
+	msg := types.NewMsgSetActionThreshold("system:set_action_threshold", addr, req.ActionType, uint32(threshold), string(fee))
+	err = msg.ValidateBasic()
+	if err != nil {
+		return rest.BaseReq{}, nil, err
+	}
+
+	msgs, err := appendAuthorizeMsgs(cliCtx, withDeployHeader(msg, req.DeployHeader), req.Authorizers)
+	if err != nil {
+		return rest.BaseReq{}, nil, err
+	}
+
+	return req.BaseReq, msgs, nil
+}
+
+type grantReq struct {
+	BaseReq                  rest.BaseReq `json:"base_req"`
+	GranteeAddressOrNickname string       `json:"grantee_address_or_nickname"`
+	MsgTypes                 []string     `json:"msg_types"`
+	SpendLimit               string       `json:"spend_limit"`
+	Expiration               time.Time    `json:"expiration"`
+}
+
+func grantMsgCreator(w http.ResponseWriter, cliCtx context.CLIContext, r *http.Request) (rest.BaseReq, []sdk.Msg, error) {
+	var req grantReq
+
+	// Get body parameters
+	if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+		return rest.BaseReq{}, nil, fmt.Errorf("failed to parse request")
+	}
+
+	var addr sdk.AccAddress
+	addr, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+	if err != nil {
+		addr, err = cliutil.GetAddress(cliCtx.Codec, cliCtx, req.BaseReq.From)
+		if err != nil {
+			return rest.BaseReq{}, nil, fmt.Errorf("failed to parse granter address or name: %s", req.BaseReq.From)
+		}
+	}
+
+	req.BaseReq.From = addr.String()
+	if !req.BaseReq.ValidateBasic(w) {
+		return rest.BaseReq{}, nil, fmt.Errorf("failed to parse base request")
+	}
+
+	// Parameter touching
+	granteeAddr, err := cliutil.GetAddress(cliCtx.Codec, cliCtx, req.GranteeAddressOrNickname)
+	if err != nil {
+		return rest.BaseReq{}, nil, fmt.Errorf("failed to parse grantee address or name: %s", req.GranteeAddressOrNickname)
+	}
+
+	spendLimit := ""
+	if req.SpendLimit != "" {
+		limit, err := cliutil.ToBigsun(cliutil.Hdac(req.SpendLimit))
+		if err != nil {
+			return rest.BaseReq{}, nil, err
+		}
+		spendLimit = string(limit)
+	}
+
+	// create the message
+	msg := types.NewMsgGrant(addr, granteeAddr, req.MsgTypes, spendLimit, req.Expiration)
+	err = msg.ValidateBasic()
+	if err != nil {
+		return rest.BaseReq{}, nil, err
+	}
+
+	return req.BaseReq, []sdk.Msg{msg}, nil
+}
+
+type revokeReq struct {
+	BaseReq                  rest.BaseReq `json:"base_req"`
+	GranteeAddressOrNickname string       `json:"grantee_address_or_nickname"`
+}
+
+func revokeMsgCreator(w http.ResponseWriter, cliCtx context.CLIContext, r *http.Request) (rest.BaseReq, []sdk.Msg, error) {
+	var req revokeReq
+
+	// Get body parameters
+	if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+		return rest.BaseReq{}, nil, fmt.Errorf("failed to parse request")
+	}
+
+	var addr sdk.AccAddress
+	addr, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+	if err != nil {
+		addr, err = cliutil.GetAddress(cliCtx.Codec, cliCtx, req.BaseReq.From)
+		if err != nil {
+			return rest.BaseReq{}, nil, fmt.Errorf("failed to parse granter address or name: %s", req.BaseReq.From)
+		}
+	}
+
+	req.BaseReq.From = addr.String()
+	if !req.BaseReq.ValidateBasic(w) {
+		return rest.BaseReq{}, nil, fmt.Errorf("failed to parse base request")
+	}
+
+	// Parameter touching
+	granteeAddr, err := cliutil.GetAddress(cliCtx.Codec, cliCtx, req.GranteeAddressOrNickname)
+	if err != nil {
+		return rest.BaseReq{}, nil, fmt.Errorf("failed to parse grantee address or name: %s", req.GranteeAddressOrNickname)
+	}
+
+	// create the message
+	msg := types.NewMsgRevoke(addr, granteeAddr)
+	err = msg.ValidateBasic()
+	if err != nil {
+		return rest.BaseReq{}, nil, err
+	}
+
+	return req.BaseReq, []sdk.Msg{msg}, nil
+}
+
+type execReq struct {
+	BaseReq rest.BaseReq `json:"base_req"`
+	Msgs    []sdk.Msg    `json:"msgs"`
+}
+
+func execMsgCreator(w http.ResponseWriter, cliCtx context.CLIContext, r *http.Request) (rest.BaseReq, []sdk.Msg, error) {
+	var req execReq
+
+	// Get body parameters
+	if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+		return rest.BaseReq{}, nil, fmt.Errorf("failed to parse request")
+	}
+
+	var addr sdk.AccAddress
+	addr, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+	if err != nil {
+		addr, err = cliutil.GetAddress(cliCtx.Codec, cliCtx, req.BaseReq.From)
+		if err != nil {
+			return rest.BaseReq{}, nil, fmt.Errorf("failed to parse grantee address or name: %s", req.BaseReq.From)
+		}
+	}
+
+	req.BaseReq.From = addr.String()
+	if !req.BaseReq.ValidateBasic(w) {
+		return rest.BaseReq{}, nil, fmt.Errorf("failed to parse base request")
+	}
+
+	// create the message
+	msg := types.NewMsgExec(addr, req.Msgs)
+	err = msg.ValidateBasic()
+	if err != nil {
+		return rest.BaseReq{}, nil, err
+	}
+
+	return req.BaseReq, []sdk.Msg{msg}, nil
+}
+
+type grantFeeAllowanceReq struct {
+	BaseReq                  rest.BaseReq `json:"base_req"`
+	GranteeAddressOrNickname string       `json:"grantee_address_or_nickname"`
+	SpendLimit               string       `json:"spend_limit"`
+	Expiration               time.Time    `json:"expiration"`
+}
+
+func grantFeeAllowanceMsgCreator(w http.ResponseWriter, cliCtx context.CLIContext, r *http.Request) (rest.BaseReq, []sdk.Msg, error) {
+	var req grantFeeAllowanceReq
+
+	// Get body parameters
+	if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+		return rest.BaseReq{}, nil, fmt.Errorf("failed to parse request")
+	}
+
+	var addr sdk.AccAddress
+	addr, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+	if err != nil {
+		addr, err = cliutil.GetAddress(cliCtx.Codec, cliCtx, req.BaseReq.From)
+		if err != nil {
+			return rest.BaseReq{}, nil, fmt.Errorf("failed to parse payer address or name: %s", req.BaseReq.From)
+		}
+	}
+
+	req.BaseReq.From = addr.String()
+	if !req.BaseReq.ValidateBasic(w) {
+		return rest.BaseReq{}, nil, fmt.Errorf("failed to parse base request")
+	}
+
+	// Parameter touching
+	granteeAddr, err := cliutil.GetAddress(cliCtx.Codec, cliCtx, req.GranteeAddressOrNickname)
+	if err != nil {
+		return rest.BaseReq{}, nil, fmt.Errorf("failed to parse grantee address or name: %s", req.GranteeAddressOrNickname)
+	}
+
+	spendLimit := ""
+	if req.SpendLimit != "" {
+		limit, err := cliutil.ToBigsun(cliutil.Hdac(req.SpendLimit))
+		if err != nil {
+			return rest.BaseReq{}, nil, err
+		}
+		spendLimit = string(limit)
+	}
+
+	// create the message
+	msg := types.NewMsgGrantFeeAllowance(addr, granteeAddr, spendLimit, req.Expiration)
+	err = msg.ValidateBasic()
+	if err != nil {
+		return rest.BaseReq{}, nil, err
+	}
+
+	return req.BaseReq, []sdk.Msg{msg}, nil
+}
+
+type revokeFeeAllowanceReq struct {
+	BaseReq                  rest.BaseReq `json:"base_req"`
+	GranteeAddressOrNickname string       `json:"grantee_address_or_nickname"`
+}
+
+func revokeFeeAllowanceMsgCreator(w http.ResponseWriter, cliCtx context.CLIContext, r *http.Request) (rest.BaseReq, []sdk.Msg, error) {
+	var req revokeFeeAllowanceReq
+
+	// Get body parameters
+	if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+		return rest.BaseReq{}, nil, fmt.Errorf("failed to parse request")
+	}
+
+	var addr sdk.AccAddress
+	addr, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+	if err != nil {
+		addr, err = cliutil.GetAddress(cliCtx.Codec, cliCtx, req.BaseReq.From)
+		if err != nil {
+			return rest.BaseReq{}, nil, fmt.Errorf("failed to parse payer address or name: %s", req.BaseReq.From)
+		}
+	}
+
+	req.BaseReq.From = addr.String()
+	if !req.BaseReq.ValidateBasic(w) {
+		return rest.BaseReq{}, nil, fmt.Errorf("failed to parse base request")
+	}
+
+	// Parameter touching
+	granteeAddr, err := cliutil.GetAddress(cliCtx.Codec, cliCtx, req.GranteeAddressOrNickname)
+	if err != nil {
+		return rest.BaseReq{}, nil, fmt.Errorf("failed to parse grantee address or name: %s", req.GranteeAddressOrNickname)
+	}
+
+	// create the message
+	msg := types.NewMsgRevokeFeeAllowance(addr, granteeAddr)
+	err = msg.ValidateBasic()
+	if err != nil {
+		return rest.BaseReq{}, nil, err
+	}
+
+	return req.BaseReq, []sdk.Msg{msg}, nil
+}
+
+type tokenTransferReq struct {
+	BaseReq                    rest.BaseReq        `json:"base_req"`
+	Token                      string              `json:"token"`
+	RecipientAddressOrNickname string              `json:"recipient_address_or_nickname"`
+	Amount                     string              `json:"amount"`
+	Fee                        string              `json:"fee"`
+	Authorizers                []string            `json:"authorizers"`
+	DeployHeader               *types.DeployHeader `json:"deploy_header"`
+}
+
+func tokenTransferMsgCreator(w http.ResponseWriter, cliCtx context.CLIContext, r *http.Request) (rest.BaseReq, []sdk.Msg, error) {
+	var req tokenTransferReq
+
+	// Get body parameters
+	if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+		return rest.BaseReq{}, nil, fmt.Errorf("failed to parse request")
+	}
+
+	senderAddr, token, err := getTokenSender(w, cliCtx, &req.BaseReq, req.Token)
+	if err != nil {
+		return rest.BaseReq{}, nil, err
+	}
+
+	// Parameter touching
+	recipientAddr, err := cliutil.GetAddress(cliCtx.Codec, cliCtx, req.RecipientAddressOrNickname)
+	if err != nil {
+		return rest.BaseReq{}, nil, fmt.Errorf("failed to parse recipient address or name: %s", req.RecipientAddressOrNickname)
+	}
+
+	amount, err := cliutil.ParseTokenAmount(cliCtx, token, req.Amount)
+	if err != nil {
+		return rest.BaseReq{}, nil, err
+	}
+
+	sessionArgs, err := types.NewTokenTransferArgs(recipientAddr, amount)
+	if err != nil {
+		return rest.BaseReq{}, nil, err
+	}
+
+	msgs, err := tokenCallMsgs(cliCtx, senderAddr, token, sessionArgs, req.Fee, req.DeployHeader, req.Authorizers)
+	if err != nil {
+		return rest.BaseReq{}, nil, err
+	}
+
+	return req.BaseReq, msgs, nil
+}
+
+type tokenApproveReq struct {
+	BaseReq                  rest.BaseReq        `json:"base_req"`
+	Token                    string              `json:"token"`
+	SpenderAddressOrNickname string              `json:"spender_address_or_nickname"`
+	Amount                   string              `json:"amount"`
+	Fee                      string              `json:"fee"`
+	Authorizers              []string            `json:"authorizers"`
+	DeployHeader             *types.DeployHeader `json:"deploy_header"`
+}
+
+func tokenApproveMsgCreator(w http.ResponseWriter, cliCtx context.CLIContext, r *http.Request) (rest.BaseReq, []sdk.Msg, error) {
+	var req tokenApproveReq
+
+	// Get body parameters
+	if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+		return rest.BaseReq{}, nil, fmt.Errorf("failed to parse request")
+	}
+
+	senderAddr, token, err := getTokenSender(w, cliCtx, &req.BaseReq, req.Token)
+	if err != nil {
+		return rest.BaseReq{}, nil, err
+	}
+
+	// Parameter touching
+	spenderAddr, err := cliutil.GetAddress(cliCtx.Codec, cliCtx, req.SpenderAddressOrNickname)
+	if err != nil {
+		return rest.BaseReq{}, nil, fmt.Errorf("failed to parse spender address or name: %s", req.SpenderAddressOrNickname)
+	}
+
+	amount, err := cliutil.ParseTokenAmount(cliCtx, token, req.Amount)
+	if err != nil {
+		return rest.BaseReq{}, nil, err
+	}
+
+	sessionArgs, err := types.NewTokenApproveArgs(spenderAddr, amount)
+	if err != nil {
+		return rest.BaseReq{}, nil, err
+	}
+
+	msgs, err := tokenCallMsgs(cliCtx, senderAddr, token, sessionArgs, req.Fee, req.DeployHeader, req.Authorizers)
+	if err != nil {
+		return rest.BaseReq{}, nil, err
+	}
+
+	return req.BaseReq, msgs, nil
+}
+
+type tokenTransferFromReq struct {
+	BaseReq                    rest.BaseReq        `json:"base_req"`
+	Token                      string              `json:"token"`
+	OwnerAddressOrNickname     string              `json:"owner_address_or_nickname"`
+	RecipientAddressOrNickname string              `json:"recipient_address_or_nickname"`
+	Amount                     string              `json:"amount"`
+	Fee                        string              `json:"fee"`
+	Authorizers                []string            `json:"authorizers"`
+	DeployHeader               *types.DeployHeader `json:"deploy_header"`
+}
+
+func tokenTransferFromMsgCreator(w http.ResponseWriter, cliCtx context.CLIContext, r *http.Request) (rest.BaseReq, []sdk.Msg, error) {
+	var req tokenTransferFromReq
+
+	// Get body parameters
+	if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+		return rest.BaseReq{}, nil, fmt.Errorf("failed to parse request")
+	}
+
+	senderAddr, token, err := getTokenSender(w, cliCtx, &req.BaseReq, req.Token)
+	if err != nil {
+		return rest.BaseReq{}, nil, err
+	}
+
+	// Parameter touching
+	ownerAddr, err := cliutil.GetAddress(cliCtx.Codec, cliCtx, req.OwnerAddressOrNickname)
+	if err != nil {
+		return rest.BaseReq{}, nil, fmt.Errorf("failed to parse owner address or name: %s", req.OwnerAddressOrNickname)
+	}
+
+	recipientAddr, err := cliutil.GetAddress(cliCtx.Codec, cliCtx, req.RecipientAddressOrNickname)
+	if err != nil {
+		return rest.BaseReq{}, nil, fmt.Errorf("failed to parse recipient address or name: %s", req.RecipientAddressOrNickname)
+	}
+
+	amount, err := cliutil.ParseTokenAmount(cliCtx, token, req.Amount)
+	if err != nil {
+		return rest.BaseReq{}, nil, err
+	}
+
+	sessionArgs, err := types.NewTokenTransferFromArgs(ownerAddr, recipientAddr, amount)
+	if err != nil {
+		return rest.BaseReq{}, nil, err
+	}
+
+	msgs, err := tokenCallMsgs(cliCtx, senderAddr, token, sessionArgs, req.Fee, req.DeployHeader, req.Authorizers)
+	if err != nil {
+		return rest.BaseReq{}, nil, err
+	}
+
+	return req.BaseReq, msgs, nil
+}
+
+// getTokenSender resolves the sender of the base request and the token the sender calls
+func getTokenSender(w http.ResponseWriter, cliCtx context.CLIContext, baseReq *rest.BaseReq, ref string) (sdk.AccAddress, cliutil.Token, error) {
+	var senderAddr sdk.AccAddress
+	senderAddr, err := sdk.AccAddressFromBech32(baseReq.From)
+	if err != nil {
+		senderAddr, err = cliutil.GetAddress(cliCtx.Codec, cliCtx, baseReq.From)
+		if err != nil {
+			return nil, cliutil.Token{}, fmt.Errorf("failed to parse sender address or name: %s", baseReq.From)
+		}
+	}
+
+	baseReq.From = senderAddr.String()
+	if !baseReq.ValidateBasic(w) {
+		return nil, cliutil.Token{}, fmt.Errorf("failed to parse base request")
+	}
+
+	token, err := cliutil.ResolveToken(cliCtx, senderAddr, ref)
+	if err != nil {
+		return nil, cliutil.Token{}, err
+	}
+	return senderAddr, token, nil
+}
+
+// tokenCallMsgs returns the msgs calling the token with the session args
+func tokenCallMsgs(cliCtx context.CLIContext, senderAddr sdk.AccAddress, token cliutil.Token, sessionArgs, feeStr string,
+	header *types.DeployHeader, authorizers []string) ([]sdk.Msg, error) {
+	fee, err := cliutil.ToBigsun(cliutil.Hdac(feeStr))
+	if err != nil {
+		return nil, err
+	}
+
+	// create the message
+	eeMsg := types.NewMsgExecute(token.ContractAddress, senderAddr, token.SessionType, token.SessionCode, sessionArgs, string(fee))
+	err = eeMsg.ValidateBasic()
+	if err != nil {
+		return nil, err
+	}
+
+	return appendAuthorizeMsgs(cliCtx, withDeployHeader(eeMsg, header), authorizers)
+}
+
+// getQueriedToken resolves the token of the query, a token given by name being one of the
+// optional from
+func getQueriedToken(cliCtx context.CLIContext, r *http.Request) (cliutil.Token, error) {
+	vars := r.URL.Query()
+
+	var from sdk.AccAddress
+	var err error
+	if fromStr := vars.Get("from"); fromStr != "" {
+		from, err = cliutil.GetAddress(cliCtx.Codec, cliCtx, fromStr)
+		if err != nil {
+			return cliutil.Token{}, err
+		}
+	}
+
+	token := vars.Get("token")
+	if token == "" {
+		return cliutil.Token{}, fmt.Errorf("token is required")
+	}
+	return cliutil.ResolveToken(cliCtx, from, token)
+}
+
+type scheduleTransferReq struct {
+	BaseReq                    rest.BaseReq        `json:"base_req"`
+	RecipientAddressOrNickname string              `json:"recipient_address_or_nickname"`
+	Amount                     string              `json:"amount"`
+	PayoutFee                  string              `json:"payout_fee"`
+	StartHeight                int64               `json:"start_height"`
+	Interval                   int64               `json:"interval"`
+	Count                      uint64              `json:"count"`
+	Fee                        string              `json:"fee"`
+	Authorizers                []string            `json:"authorizers"`
+	DeployHeader               *types.DeployHeader `json:"deploy_header"`
+}
+
+func scheduleTransferMsgCreator(w http.ResponseWriter, cliCtx context.CLIContext, r *http.Request) (rest.BaseReq, []sdk.Msg, error) {
+	var req scheduleTransferReq
+
+	// Get body parameters
+	if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+		return rest.BaseReq{}, nil, fmt.Errorf("failed to parse request")
+	}
+
+	var senderAddr sdk.AccAddress
+	senderAddr, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+	if err != nil {
+		senderAddr, err = cliutil.GetAddress(cliCtx.Codec, cliCtx, req.BaseReq.From)
+		if err != nil {
+			return rest.BaseReq{}, nil, fmt.Errorf("failed to parse sender address or name: %s", req.BaseReq.From)
+		}
+	}
+
+	req.BaseReq.From = senderAddr.String()
+	if !req.BaseReq.ValidateBasic(w) {
+		return rest.BaseReq{}, nil, fmt.Errorf("failed to parse base request")
+	}
+
+	// Parameter touching
+	var recipientAddr sdk.AccAddress
+	recipientAddr, err = sdk.AccAddressFromBech32(req.RecipientAddressOrNickname)
+	if err != nil {
+		recipientAddr, err = cliutil.GetAddress(cliCtx.Codec, cliCtx, req.RecipientAddressOrNickname)
+		if err != nil {
+			return rest.BaseReq{}, nil, fmt.Errorf("failed to parse recipient address or name: %s", req.RecipientAddressOrNickname)
+		}
+	}
+
+	amount, err := cliutil.ToBigsun(cliutil.Hdac(req.Amount))
+	if err != nil {
+		return rest.BaseReq{}, nil, err
+	}
+
+	payoutFee, err := cliutil.ToBigsun(cliutil.Hdac(req.PayoutFee))
+	if err != nil {
+		return rest.BaseReq{}, nil, err
+	}
+
+	fee, err := cliutil.ToBigsun(cliutil.Hdac(req.Fee))
+	if err != nil {
+		return rest.BaseReq{}, nil, err
+	}
+
+	count := req.Count
+	if count == 0 {
+		count = 1
+	}
+
+	// create the message
+	eeMsg := types.NewMsgScheduleTransfer("transfer", senderAddr, recipientAddr, string(amount), string(payoutFee),
+		req.StartHeight, req.Interval, count, string(fee))
+	err = eeMsg.ValidateBasic()
+	if err != nil {
+		return rest.BaseReq{}, nil, err
+	}
+
+	msgs, err := appendAuthorizeMsgs(cliCtx, withDeployHeader(eeMsg, req.DeployHeader), req.Authorizers)
+	if err != nil {
+		return rest.BaseReq{}, nil, err
+	}
+
+	return req.BaseReq, msgs, nil
+}
+
+type cancelScheduleReq struct {
+	BaseReq    rest.BaseReq `json:"base_req"`
+	ScheduleID uint64       `json:"schedule_id"`
+}
+
+func cancelScheduleMsgCreator(w http.ResponseWriter, cliCtx context.CLIContext, r *http.Request) (rest.BaseReq, []sdk.Msg, error) {
+	var req cancelScheduleReq
+
+	// Get body parameters
+	if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+		return rest.BaseReq{}, nil, fmt.Errorf("failed to parse request")
+	}
+
+	var addr sdk.AccAddress
+	addr, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+	if err != nil {
+		addr, err = cliutil.GetAddress(cliCtx.Codec, cliCtx, req.BaseReq.From)
+		if err != nil {
+			return rest.BaseReq{}, nil, fmt.Errorf("failed to parse sender address or name: %s", req.BaseReq.From)
+		}
+	}
+
+	req.BaseReq.From = addr.String()
+	if !req.BaseReq.ValidateBasic(w) {
+		return rest.BaseReq{}, nil, fmt.Errorf("failed to parse base request")
+	}
+
+	// create the message
+	msg := types.NewMsgCancelSchedule(addr, req.ScheduleID)
+	err = msg.ValidateBasic()
+	if err != nil {
+		return rest.BaseReq{}, nil, err
+	}
+
+	return req.BaseReq, []sdk.Msg{msg}, nil
+}
+
+func getGrantsQuerying(w http.ResponseWriter, cliCtx context.CLIContext, r *http.Request) ([]byte, error) {
+	vars := r.URL.Query()
+
+	var granter, grantee sdk.AccAddress
+	var err error
+	if granterStr := vars.Get("granter"); granterStr != "" {
+		granter, err = cliutil.GetAddress(cliCtx.Codec, cliCtx, granterStr)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if granteeStr := vars.Get("grantee"); granteeStr != "" {
+		grantee, err = cliutil.GetAddress(cliCtx.Codec, cliCtx, granteeStr)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if granter.Empty() && grantee.Empty() {
+		return nil, fmt.Errorf("granter or grantee is required")
+	}
+
+	queryData := types.NewQueryGrantsParams(granter, grantee)
+	bz := cliCtx.Codec.MustMarshalJSON(queryData)
+
+	return bz, nil
+}
+
+func getFeeAllowancesQuerying(w http.ResponseWriter, cliCtx context.CLIContext, r *http.Request) ([]byte, error) {
+	vars := r.URL.Query()
+
+	var payer, grantee sdk.AccAddress
+	var err error
+	if payerStr := vars.Get("payer"); payerStr != "" {
+		payer, err = cliutil.GetAddress(cliCtx.Codec, cliCtx, payerStr)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if granteeStr := vars.Get("grantee"); granteeStr != "" {
+		grantee, err = cliutil.GetAddress(cliCtx.Codec, cliCtx, granteeStr)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if payer.Empty() && grantee.Empty() {
+		return nil, fmt.Errorf("payer or grantee is required")
+	}
+
+	queryData := types.NewQueryFeeAllowancesParams(payer, grantee)
+	bz := cliCtx.Codec.MustMarshalJSON(queryData)
+
+	return bz, nil
+}
+
+func getSchedulesQuerying(w http.ResponseWriter, cliCtx context.CLIContext, r *http.Request) ([]byte, error) {
+	vars := r.URL.Query()
+
+	var sender, recipient sdk.AccAddress
+	var err error
+	if senderStr := vars.Get("sender"); senderStr != "" {
+		sender, err = cliutil.GetAddress(cliCtx.Codec, cliCtx, senderStr)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if recipientStr := vars.Get("recipient"); recipientStr != "" {
+		recipient, err = cliutil.GetAddress(cliCtx.Codec, cliCtx, recipientStr)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if sender.Empty() && recipient.Empty() {
+		return nil, fmt.Errorf("sender or recipient is required")
+	}
+
+	queryData := types.NewQuerySchedulesParams(sender, recipient)
+	bz := cliCtx.Codec.MustMarshalJSON(queryData)
+
+	return bz, nil
+}
+
+// withDeployHeader sets the deploy header of the request to the deploy msg. With a fee payer in the
+// header, a MsgAuthorize of the payer is appended, and the generated tx has to be signed by the payer.
+func withDeployHeader(msg sdk.Msg, header *types.DeployHeader) []sdk.Msg {
+	deployMsg, ok := msg.(types.DeployMsg)
+	if !ok || header == nil {
+		return []sdk.Msg{msg}
+	}
+
+	msgs := []sdk.Msg{deployMsg.WithDeployHeader(header)}
+	if !header.FeePayer.Empty() {
+		msgs = append(msgs, types.NewMsgAuthorize(header.FeePayer))
+	}
+	return msgs
+}
+
+// appendAuthorizeMsgs appends a MsgAuthorize of each authorizer to the msgs.
+// The generated tx has to be signed by every authorizer as well as the sender.
+func appendAuthorizeMsgs(cliCtx context.CLIContext, msgs []sdk.Msg, authorizers []string) ([]sdk.Msg, error) {
+	for _, authorizer := range authorizers {
+		addr, err := cliutil.GetAddress(cliCtx.Codec, cliCtx, authorizer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse authorizer address or name: %s", authorizer)
+		}
+		msgs = append(msgs, types.NewMsgAuthorize(addr))
+	}
+
+	return msgs, nil
+}
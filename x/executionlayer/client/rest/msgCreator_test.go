@@ -10,6 +10,7 @@ import (
 	"os"
 	"path"
 	"testing"
+	"time"
 
 	"github.com/hdac-io/friday/client/context"
 	"github.com/hdac-io/friday/codec"
@@ -95,15 +96,86 @@ func TestRESTContractRunContractAddress(t *testing.T) {
 	require.NotNil(t, msgs)
 }
 
+func TestRESTContractDryRun(t *testing.T) {
+	_, _, writer, clictx, basereq := prepare()
+
+	contractReq := contractRunReq{
+		BaseReq:                       basereq,
+		ExecutionType:                 "uref",
+		TokenContractAddressOrKeyName: "fridaycontracturef1v4xev2kdy8hkzvwcadk4a3872lzcyyz8t44du5z2jhz636qduz3sf9mf96",
+		Base64EncodedBinary:           "",
+		Args:                          `[{"name": "method", "value": {"string_value": "mint"}}]`,
+		Fee:                           "10000000",
+	}
+
+	body := clictx.Codec.MustMarshalJSON(contractReq)
+	req := mustNewRequest(t, "POST", "/contract/dry-run", bytes.NewReader(body))
+
+	res, err := getContractDryRunQuerying(writer, clictx, req)
+	require.NoError(t, err)
+
+	var params types.QueryDryRunParams
+	clictx.Codec.MustUnmarshalJSON(res, &params)
+	require.EqualValues(t, eeutil.UREF, params.Msg.SessionType)
+	require.Equal(t, contractReq.Args, params.Msg.SessionArgs)
+}
+
 func TestRESTContractQuery(t *testing.T) {
 	_, _, writer, clictx, _ := prepare()
 
 	req := mustNewRequest(t, "GET", fmt.Sprintf("/contract?data_type=%s&data=%s&path=", "address", "system"), nil)
-	res, path, err := getContractQuerying(writer, clictx, req)
+	res, raw, err := getContractQuerying(writer, clictx, req)
 
 	require.NoError(t, err)
 	require.NotNil(t, res)
-	require.NotNil(t, path)
+	require.False(t, raw)
+
+	req = mustNewRequest(t, "GET", fmt.Sprintf("/contract?data_type=%s&data=%s&path=&raw=true", "address", "system"), nil)
+	_, raw, err = getContractQuerying(writer, clictx, req)
+
+	require.NoError(t, err)
+	require.True(t, raw)
+
+	req = mustNewRequest(t, "GET", fmt.Sprintf("/contract?data_type=%s&data=%s&path=&raw=maybe", "address", "system"), nil)
+	_, _, err = getContractQuerying(writer, clictx, req)
+
+	require.Error(t, err)
+}
+
+func TestRESTContractDeploy(t *testing.T) {
+	_, _, writer, clictx, basereq := prepare()
+	counterBinary := eeutil.LoadWasmFile(path.Join(contractPath, counterDefineWasm))
+
+	deployReq := contractDeployReq{
+		BaseReq:             basereq,
+		Name:                "counter",
+		Base64EncodedBinary: base64.StdEncoding.EncodeToString(counterBinary),
+		Args:                "",
+		Fee:                 "10000000",
+	}
+
+	body := clictx.Codec.MustMarshalJSON(deployReq)
+	req := mustNewRequest(t, "POST", fmt.Sprintf("/%s/deploy", general), bytes.NewReader(body))
+
+	outputBasereq, msgs, err := contractDeployMsgCreator(writer, clictx, req)
+
+	require.NoError(t, err)
+	require.Equal(t, outputBasereq, basereq)
+	require.NotNil(t, msgs)
+}
+
+func TestRESTContractRegistry(t *testing.T) {
+	fromAddr, _, writer, clictx, _ := prepare()
+
+	req := mustNewRequest(t, "GET", fmt.Sprintf("/%s/registry?deployer=%s&name=%s&code_hash=%s", general, fromAddr, "counter", "0a0b"), nil)
+	res, err := getContractRegistryQuerying(writer, clictx, req)
+
+	require.NoError(t, err)
+	require.NotNil(t, res)
+
+	req = mustNewRequest(t, "GET", fmt.Sprintf("/%s/registry?code_hash=%s", general, "not-hex"), nil)
+	_, err = getContractRegistryQuerying(writer, clictx, req)
+	require.Error(t, err)
 }
 
 func TestRESTTransfer(t *testing.T) {
@@ -128,6 +200,118 @@ func TestRESTTransfer(t *testing.T) {
 	require.NotNil(t, msgs)
 }
 
+func TestRESTTransferWithAuthorizers(t *testing.T) {
+	fromAddr, receipAddr, writer, clictx, basereq := prepare()
+
+	// Body
+	transReq := transferReq{
+		BaseReq:                    basereq,
+		RecipientAddressOrNickname: receipAddr,
+		Amount:                     "20000000",
+		Fee:                        "10000000",
+		Authorizers:                []string{receipAddr},
+	}
+
+	// http.request
+	body := clictx.Codec.MustMarshalJSON(transReq)
+	req := mustNewRequest(t, "POST", fmt.Sprintf("/%s/transfer", hdacSpecific), bytes.NewReader(body))
+
+	outputBasereq, msgs, err := transferMsgCreator(writer, clictx, req)
+
+	require.NoError(t, err)
+	require.Equal(t, outputBasereq, basereq)
+	require.Equal(t, 2, len(msgs))
+	require.Equal(t, fromAddr, msgs[0].GetSigners()[0].String())
+	require.Equal(t, receipAddr, msgs[1].(types.MsgAuthorize).Authorizer.String())
+}
+
+func TestRESTAddAssociatedKey(t *testing.T) {
+	_, receipAddr, writer, clictx, basereq := prepare()
+
+	keyReq := associatedKeyReq{
+		BaseReq:                     basereq,
+		AssociatedAddressOrNickname: receipAddr,
+		Weight:                      "1",
+		Fee:                         "10000000",
+	}
+
+	body := clictx.Codec.MustMarshalJSON(keyReq)
+	req := mustNewRequest(t, "POST", fmt.Sprintf("/%s/associated-keys", hdacSpecific), bytes.NewReader(body))
+
+	outputBasereq, msgs, err := associatedKeyMsgCreator(true, writer, clictx, req)
+
+	require.NoError(t, err)
+	require.Equal(t, outputBasereq, basereq)
+	require.Equal(t, uint32(1), msgs[0].(types.MsgAddAssociatedKey).Weight)
+}
+
+func TestRESTUpdateAssociatedKey(t *testing.T) {
+	_, receipAddr, writer, clictx, basereq := prepare()
+
+	keyReq := associatedKeyReq{
+		BaseReq:                     basereq,
+		AssociatedAddressOrNickname: receipAddr,
+		Weight:                      "300",
+		Fee:                         "10000000",
+	}
+
+	body := clictx.Codec.MustMarshalJSON(keyReq)
+	req := mustNewRequest(t, "PUT", fmt.Sprintf("/%s/associated-keys", hdacSpecific), bytes.NewReader(body))
+
+	_, _, err := associatedKeyMsgCreator(false, writer, clictx, req)
+	require.Error(t, err)
+
+	keyReq.Weight = "2"
+	body = clictx.Codec.MustMarshalJSON(keyReq)
+	req = mustNewRequest(t, "PUT", fmt.Sprintf("/%s/associated-keys", hdacSpecific), bytes.NewReader(body))
+
+	outputBasereq, msgs, err := associatedKeyMsgCreator(false, writer, clictx, req)
+
+	require.NoError(t, err)
+	require.Equal(t, outputBasereq, basereq)
+	require.Equal(t, uint32(2), msgs[0].(types.MsgUpdateAssociatedKey).Weight)
+}
+
+func TestRESTRemoveAssociatedKey(t *testing.T) {
+	_, receipAddr, writer, clictx, basereq := prepare()
+
+	keyReq := removeAssociatedKeyReq{
+		BaseReq:                     basereq,
+		AssociatedAddressOrNickname: receipAddr,
+		Fee:                         "10000000",
+	}
+
+	body := clictx.Codec.MustMarshalJSON(keyReq)
+	req := mustNewRequest(t, "DELETE", fmt.Sprintf("/%s/associated-keys", hdacSpecific), bytes.NewReader(body))
+
+	outputBasereq, msgs, err := removeAssociatedKeyMsgCreator(writer, clictx, req)
+
+	require.NoError(t, err)
+	require.Equal(t, outputBasereq, basereq)
+	require.NotNil(t, msgs)
+}
+
+func TestRESTActionThreshold(t *testing.T) {
+	_, receipAddr, writer, clictx, basereq := prepare()
+
+	thresholdReq := actionThresholdReq{
+		BaseReq:     basereq,
+		ActionType:  types.ActionTypeDeployment,
+		Threshold:   "2",
+		Fee:         "10000000",
+		Authorizers: []string{receipAddr},
+	}
+
+	body := clictx.Codec.MustMarshalJSON(thresholdReq)
+	req := mustNewRequest(t, "PUT", fmt.Sprintf("/%s/action-threshold", hdacSpecific), bytes.NewReader(body))
+
+	outputBasereq, msgs, err := actionThresholdMsgCreator(writer, clictx, req)
+
+	require.NoError(t, err)
+	require.Equal(t, outputBasereq, basereq)
+	require.Equal(t, 2, len(msgs))
+}
+
 func TestRESTBond(t *testing.T) {
 	_, _, writer, clictx, basereq := prepare()
 
@@ -416,6 +600,31 @@ func TestRESTGetDelegatorFromAll(t *testing.T) {
 	require.NotNil(t, res)
 }
 
+func TestRESTGetUnbonding(t *testing.T) {
+	fromAddr, receipAddr, writer, clictx, _ := prepare()
+
+	req := mustNewRequest(t, "GET", fmt.Sprintf("/%s/unbonding?delegator=%s", hdacSpecific, fromAddr), nil)
+	res, err := getUnbondingQuerying(writer, clictx, req)
+	require.NoError(t, err)
+
+	var params types.QueryDelegatorParams
+	clictx.Codec.MustUnmarshalJSON(res, &params)
+	require.Equal(t, fromAddr, params.DelegatorAddr.String())
+	require.True(t, params.ValidatorAddr.Empty())
+
+	req = mustNewRequest(t, "GET", fmt.Sprintf("/%s/redelegation?validator=%s", hdacSpecific, receipAddr), nil)
+	res, err = getUnbondingQuerying(writer, clictx, req)
+	require.NoError(t, err)
+
+	clictx.Codec.MustUnmarshalJSON(res, &params)
+	require.Equal(t, receipAddr, params.ValidatorAddr.String())
+
+	req = mustNewRequest(t, "GET", fmt.Sprintf("/%s/unbonding", hdacSpecific), nil)
+	res, err = getUnbondingQuerying(writer, clictx, req)
+	require.NoError(t, err)
+	require.NotNil(t, res)
+}
+
 func TestRESTGetVoterFromAddress(t *testing.T) {
 	fromAddr, _, writer, clictx, _ := prepare()
 
@@ -475,6 +684,7 @@ func TestRESTCreateValidator(t *testing.T) {
 		BaseReq:     basereq,
 		ConsPubKey:  "fridayvalconspub16jrl8jvqq9k957nfd43n2dnyxc6nsazpgf5yuwtzfe6kku63ga6nvtmcdeg92vj4gy4kkd62vd69vvnhx935w5zpw9ex7733tft8we6evemzke66xv4ks56gfdvx66ndfye5x5z9fs6j74z6g3u4zdzd0p8hw6mr24k8wjzx0ghhz5z8vdm92vjs2e8xwdn5xpvxu56fvejnj7t6wsens5gwxlen9",
 		Description: types.NewDescription("moniker", "identity", "https://test.io", "details"),
+		Commission:  types.NewCommissionRates(sdk.NewDecWithPrec(1, 1), sdk.NewDecWithPrec(2, 1), sdk.NewDecWithPrec(1, 2)),
 		Fee:         "10000000",
 	}
 
@@ -507,6 +717,77 @@ func TestRESTEditValidator(t *testing.T) {
 	require.NotNil(t, msgs)
 }
 
+func TestRESTRotateConsPubKey(t *testing.T) {
+	_, _, writer, clictx, basereq := prepare()
+
+	rotateConsPubKeyReq := rotateConsPubKeyReq{
+		BaseReq:    basereq,
+		ConsPubKey: "fridayvalconspub16jrl8jvqq9k957nfd43n2dnyxc6nsazpgf5yuwtzfe6kku63ga6nvtmcdeg92vj4gy4kkd62vd69vvnhx935w5zpw9ex7733tft8we6evemzke66xv4ks56gfdvx66ndfye5x5z9fs6j74z6g3u4zdzd0p8hw6mr24k8wjzx0ghhz5z8vdm92vjs2e8xwdn5xpvxu56fvejnj7t6wsens5gwxlen9",
+		Fee:        "10000000",
+	}
+
+	body := clictx.Codec.MustMarshalJSON(rotateConsPubKeyReq)
+	req := mustNewRequest(t, "PUT", fmt.Sprintf("/%s/validators/cons-pubkey", types.ModuleName), bytes.NewReader((body)))
+
+	outputRotateConsPubKeyReq, msgs, err := rotateConsPubKeyMsgCreator(writer, clictx, req)
+
+	require.NoError(t, err)
+	require.Equal(t, outputRotateConsPubKeyReq, basereq)
+	require.NotNil(t, msgs)
+}
+
+func TestRESTGrant(t *testing.T) {
+	_, receipAddr, writer, clictx, basereq := prepare()
+
+	grantReq := grantReq{
+		BaseReq:                  basereq,
+		GranteeAddressOrNickname: receipAddr,
+		MsgTypes:                 []string{types.GrantMsgTypeClaim, types.GrantMsgTypeDelegate},
+		SpendLimit:               "100",
+		Expiration:               time.Now().Add(time.Hour).UTC(),
+	}
+
+	body := clictx.Codec.MustMarshalJSON(grantReq)
+	req := mustNewRequest(t, "POST", fmt.Sprintf("/%s/grants", hdacSpecific), bytes.NewReader(body))
+
+	outputBasereq, msgs, err := grantMsgCreator(writer, clictx, req)
+
+	require.NoError(t, err)
+	require.Equal(t, outputBasereq, basereq)
+	require.Equal(t, 1, len(msgs))
+	require.Equal(t, []string{types.GrantMsgTypeClaim, types.GrantMsgTypeDelegate}, msgs[0].(types.MsgGrant).MsgTypes)
+}
+
+func TestRESTExec(t *testing.T) {
+	fromAddr, receipAddr, writer, clictx, basereq := prepare()
+	sdk.RegisterCodec(clictx.Codec)
+	types.RegisterCodec(clictx.Codec)
+
+	granter, err := sdk.AccAddressFromBech32(receipAddr)
+	require.NoError(t, err)
+	grantee, err := sdk.AccAddressFromBech32(fromAddr)
+	require.NoError(t, err)
+
+	execReq := execReq{
+		BaseReq: basereq,
+		Msgs: []sdk.Msg{
+			types.NewMsgClaim("system:claim_reward", granter, types.RewardValue, "10000000"),
+			types.NewMsgDelegate("system:delegate", granter, grantee, "1000000000", "10000000"),
+		},
+	}
+
+	body := clictx.Codec.MustMarshalJSON(execReq)
+	req := mustNewRequest(t, "POST", fmt.Sprintf("/%s/exec", hdacSpecific), bytes.NewReader(body))
+
+	outputBasereq, msgs, err := execMsgCreator(writer, clictx, req)
+
+	require.NoError(t, err)
+	require.Equal(t, outputBasereq, basereq)
+	require.Equal(t, 1, len(msgs))
+	require.Equal(t, []sdk.AccAddress{grantee}, msgs[0].GetSigners())
+	require.Equal(t, 2, len(msgs[0].(types.MsgExec).Msgs))
+}
+
 func mustNewRequest(t *testing.T, method, url string, body io.Reader) *http.Request {
 	req, err := http.NewRequest(method, url, body)
 	require.NoError(t, err)
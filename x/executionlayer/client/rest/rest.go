@@ -4,13 +4,13 @@ import (
 	"fmt"
 	"net/http"
 
-	"github.com/gogo/protobuf/jsonpb"
 	"github.com/gorilla/mux"
 
-	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/casper/consensus/state"
 	"github.com/hdac-io/casperlabs-ee-grpc-go-util/storedvalue"
 	"github.com/hdac-io/friday/client/context"
+	"github.com/hdac-io/friday/client/flags"
 	"github.com/hdac-io/friday/types/rest"
+	"github.com/hdac-io/friday/x/auth"
 	"github.com/hdac-io/friday/x/auth/client/utils"
 	cliutil "github.com/hdac-io/friday/x/executionlayer/client/util"
 	"github.com/hdac-io/friday/x/executionlayer/types"
@@ -21,29 +21,65 @@ func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router, storeName string)
 	const (
 		hdacSpecific = "hdac"
 		general      = "contract"
+		token        = "token"
 	)
 
 	r.HandleFunc(fmt.Sprintf("/%s", general), contractRunHandler(cliCtx)).Methods("POST")
 	r.HandleFunc(fmt.Sprintf("/%s", general), contractQueryHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/dry-run", general), contractDryRunHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/deploy", general), contractDeployHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/deploy", general), getDeployHandler(cliCtx)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/registry", general), getContractRegistryHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/schema", general), setContractSchemaHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/describe", general), getContractDescribeHandler(cliCtx)).Methods("GET")
+
+	r.HandleFunc(fmt.Sprintf("/%s/transfer", token), tokenTransferHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/approve", token), tokenApproveHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/transfer_from", token), tokenTransferFromHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/balance", token), getTokenBalanceHandler(cliCtx)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/allowance", token), getTokenAllowanceHandler(cliCtx)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/total_supply", token), getTokenTotalSupplyHandler(cliCtx)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/metadata", token), getTokenMetadataHandler(cliCtx)).Methods("GET")
 
 	r.HandleFunc(fmt.Sprintf("/%s/transfer", hdacSpecific), transferHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/transfer-batch", hdacSpecific), transferBatchHandler(cliCtx)).Methods("POST")
 	r.HandleFunc(fmt.Sprintf("/%s/bond", hdacSpecific), bondHandler(cliCtx)).Methods("POST")
 	r.HandleFunc(fmt.Sprintf("/%s/unbond", hdacSpecific), unbondHandler(cliCtx)).Methods("POST")
 	r.HandleFunc(fmt.Sprintf("/%s/delegate", hdacSpecific), delegateHandler(cliCtx)).Methods("POST")
 	r.HandleFunc(fmt.Sprintf("/%s/undelegate", hdacSpecific), undelegateHandler(cliCtx)).Methods("POST")
 	r.HandleFunc(fmt.Sprintf("/%s/redelegate", hdacSpecific), redelegateHandler(cliCtx)).Methods("POST")
 	r.HandleFunc(fmt.Sprintf("/%s/delegator", hdacSpecific), getDelegatorHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/unbonding", hdacSpecific), getUnbondingHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/redelegation", hdacSpecific), getRedelegationHandler(cliCtx, storeName)).Methods("GET")
 	r.HandleFunc(fmt.Sprintf("/%s/vote", hdacSpecific), voteHandler(cliCtx)).Methods("POST")
 	r.HandleFunc(fmt.Sprintf("/%s/vote", hdacSpecific), getVoteHandler(cliCtx, storeName)).Methods("GET")
 	r.HandleFunc(fmt.Sprintf("/%s/unvote", hdacSpecific), unvoteHandler(cliCtx)).Methods("POST")
 	r.HandleFunc(fmt.Sprintf("/%s/claim", hdacSpecific), claimHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/associated-keys", hdacSpecific), addAssociatedKeyHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/associated-keys", hdacSpecific), updateAssociatedKeyHandler(cliCtx)).Methods("PUT")
+	r.HandleFunc(fmt.Sprintf("/%s/associated-keys", hdacSpecific), removeAssociatedKeyHandler(cliCtx)).Methods("DELETE")
+	r.HandleFunc(fmt.Sprintf("/%s/action-threshold", hdacSpecific), actionThresholdHandler(cliCtx)).Methods("PUT")
+	r.HandleFunc(fmt.Sprintf("/%s/grants", hdacSpecific), grantHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/grants", hdacSpecific), revokeHandler(cliCtx)).Methods("DELETE")
+	r.HandleFunc(fmt.Sprintf("/%s/grants", hdacSpecific), getGrantsHandler(cliCtx)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/exec", hdacSpecific), execHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/fee_allowances", hdacSpecific), grantFeeAllowanceHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/fee_allowances", hdacSpecific), revokeFeeAllowanceHandler(cliCtx)).Methods("DELETE")
+	r.HandleFunc(fmt.Sprintf("/%s/fee_allowances", hdacSpecific), getFeeAllowancesHandler(cliCtx)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/schedules", hdacSpecific), scheduleTransferHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/schedules", hdacSpecific), cancelScheduleHandler(cliCtx)).Methods("DELETE")
+	r.HandleFunc(fmt.Sprintf("/%s/schedules", hdacSpecific), getSchedulesHandler(cliCtx)).Methods("GET")
 	r.HandleFunc(fmt.Sprintf("/%s/reward", hdacSpecific), getRewardHandler(cliCtx, storeName)).Methods("GET")
 	r.HandleFunc(fmt.Sprintf("/%s/commission", hdacSpecific), getCommissionHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/reward/history", hdacSpecific), getRewardHistoryHandler(cliCtx)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/commission/history", hdacSpecific), getCommissionHistoryHandler(cliCtx)).Methods("GET")
 	r.HandleFunc(fmt.Sprintf("/%s/balance", hdacSpecific), getBalanceHandler(cliCtx, storeName)).Methods("GET")
 	r.HandleFunc(fmt.Sprintf("/%s/stake", hdacSpecific), getStakeHandler(cliCtx, storeName)).Methods("GET")
 	r.HandleFunc(fmt.Sprintf("/%s/validators", hdacSpecific), getValidatorHandler(cliCtx, storeName)).Methods("GET")
 	r.HandleFunc(fmt.Sprintf("/%s/validators", hdacSpecific), createValidatorHandler(cliCtx)).Methods("POST")
 	r.HandleFunc(fmt.Sprintf("/%s/validators", hdacSpecific), editValidatorHandler(cliCtx)).Methods("PUT")
+	r.HandleFunc(fmt.Sprintf("/%s/validators/cons-pubkey", hdacSpecific), rotateConsPubKeyHandler(cliCtx)).Methods("PUT")
+	r.HandleFunc(fmt.Sprintf("/%s/validators/commission-rates", hdacSpecific), getCommissionRatesHandler(cliCtx)).Methods("GET")
 }
 
 func contractRunHandler(cliCtx context.CLIContext) http.HandlerFunc {
@@ -59,43 +95,260 @@ func contractRunHandler(cliCtx context.CLIContext) http.HandlerFunc {
 
 func contractQueryHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		bz, path, err := getContractQuerying(w, cliCtx, r)
+		bz, raw, err := getContractQuerying(w, cliCtx, r)
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
 		}
 
+		if !raw {
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/querydecodeddetail", types.ModuleName), bz)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+				return
+			}
+
+			rest.PostProcessResponseBare(w, cliCtx, res)
+			return
+		}
+
 		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/querydetail", types.ModuleName), bz)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
 		var storedValue storedvalue.StoredValue
 		storedValue, err, _ = storedValue.FromBytes(res)
 		if err != nil {
-			fmt.Printf("could not resolve data - %s\nerr : %s\n", path, err.Error())
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
 			return
 		}
-		marshaler := jsonpb.Marshaler{Indent: "  "}
 
-		valueStr := ""
+		valueStr, err := cliutil.StoredValueToRawJSON(storedValue)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
 
-		switch storedValue.Type {
-		case storedvalue.TYPE_ACCOUNT:
-			value := &state.Value{Value: &state.Value_Account{Account: storedValue.Account.ToStateValue()}}
-			valueStr, err = marshaler.MarshalToString(value)
-		case storedvalue.TYPE_CONTRACT:
-			value := &state.Value{Value: &state.Value_Contract{Contract: storedValue.Contract.ToStateValue()}}
-			valueStr, err = marshaler.MarshalToString(value)
-		case storedvalue.TYPE_CL_VALUE:
-			value := storedValue.ClValue.ToCLInstanceValue()
-			valueStr, err = marshaler.MarshalToString(value)
+		rest.PostProcessResponseBare(w, cliCtx, []byte(valueStr))
+	}
+}
+
+func contractDryRunHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
 		}
 
+		bz, err := getContractDryRunQuerying(w, cliCtx, r)
 		if err != nil {
-			fmt.Printf("could not resolve data - %s %s %s\nerr : %s\n", path, err.Error())
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
 		}
 
-		valueStr = cliutil.ReplaceBase64HashToBech32(path, valueStr)
+		res, height, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/querydryrun", types.ModuleName), bz)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
 
-		rest.PostProcessResponseBare(w, cliCtx, []byte(valueStr))
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponseBare(w, cliCtx, res)
+	}
+}
+
+func contractDeployHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		baseReq, msgs, err := contractDeployMsgCreator(w, cliCtx, r)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, msgs)
+	}
+}
+
+func getDeployHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		bz, err := getDeployQuerying(w, cliCtx, r)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/querydeploy", types.ModuleName), bz)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponseBare(w, cliCtx, res)
+	}
+}
+
+func getContractRegistryHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		bz, err := getContractRegistryQuerying(w, cliCtx, r)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/querycontract", types.ModuleName), bz)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		rest.PostProcessResponseBare(w, cliCtx, res)
+	}
+}
+
+func setContractSchemaHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		baseReq, msgs, err := setContractSchemaMsgCreator(w, cliCtx, r)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, msgs)
+	}
+}
+
+func getContractDescribeHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		info, err := getContractDescribeQuerying(w, cliCtx, r)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		rest.PostProcessResponseBare(w, cliCtx, info)
+	}
+}
+
+func tokenTransferHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		baseReq, msgs, err := tokenTransferMsgCreator(w, cliCtx, r)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, msgs)
+	}
+}
+
+func tokenApproveHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		baseReq, msgs, err := tokenApproveMsgCreator(w, cliCtx, r)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, msgs)
+	}
+}
+
+func tokenTransferFromHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		baseReq, msgs, err := tokenTransferFromMsgCreator(w, cliCtx, r)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, msgs)
+	}
+}
+
+func getTokenBalanceHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token, err := getQueriedToken(cliCtx, r)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		owner, err := cliutil.GetAddress(cliCtx.Codec, cliCtx, r.URL.Query().Get("owner"))
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, err := cliutil.QueryTokenBalance(cliCtx, token, owner)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		rest.PostProcessResponseBare(w, cliCtx, res)
+	}
+}
+
+func getTokenAllowanceHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token, err := getQueriedToken(cliCtx, r)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		owner, err := cliutil.GetAddress(cliCtx.Codec, cliCtx, r.URL.Query().Get("owner"))
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		spender, err := cliutil.GetAddress(cliCtx.Codec, cliCtx, r.URL.Query().Get("spender"))
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, err := cliutil.QueryTokenAllowance(cliCtx, token, owner, spender)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		rest.PostProcessResponseBare(w, cliCtx, res)
+	}
+}
+
+// tokenTotalSupplyRes - total supply of a token in its decimals
+type tokenTotalSupplyRes struct {
+	Contract    string `json:"contract"`
+	TotalSupply string `json:"total_supply"`
+	Symbol      string `json:"symbol"`
+}
+
+func getTokenTotalSupplyHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token, err := getQueriedToken(cliCtx, r)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		metadata, err := cliutil.QueryTokenMetadata(cliCtx, token)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		rest.PostProcessResponseBare(w, cliCtx, tokenTotalSupplyRes{metadata.Contract, metadata.TotalSupply, metadata.Symbol})
+	}
+}
+
+func getTokenMetadataHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token, err := getQueriedToken(cliCtx, r)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, err := cliutil.QueryTokenMetadata(cliCtx, token)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		rest.PostProcessResponseBare(w, cliCtx, res)
 	}
 }
 
@@ -110,6 +363,74 @@ func transferHandler(cliCtx context.CLIContext) http.HandlerFunc {
 	}
 }
 
+// transferBatchTx is an unsigned tx of a batch, to be signed with the sequence
+type transferBatchTx struct {
+	Sequence  uint64     `json:"sequence"`
+	Transfers []int      `json:"transfers"`
+	Tx        auth.StdTx `json:"tx"`
+}
+
+type transferBatchRes struct {
+	Txs     []transferBatchTx             `json:"txs"`
+	Results []cliutil.TransferBatchResult `json:"results"`
+}
+
+// transferBatchHandler generates the unsigned txs of the transfers of a batch, with the
+// sequences increasing from the sequence of the base request
+func transferBatchHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		br, packs, results, indices, err := transferBatchMsgCreator(w, cliCtx, r)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		gasAdj, ok := rest.ParseFloat64OrReturnBadRequest(w, br.GasAdjustment, flags.DefaultGasAdjustment)
+		if !ok {
+			return
+		}
+		simAndExec, gas, err := flags.ParseGas(br.Gas)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res := transferBatchRes{Txs: make([]transferBatchTx, len(packs)), Results: results}
+		next := 0
+		for i, msgs := range packs {
+			txBldr := auth.NewTxBuilder(
+				utils.GetTxEncoder(cliCtx.Codec), br.AccountNumber, br.Sequence+uint64(i), gas, gasAdj,
+				false, br.ChainID, br.Memo, br.Fees, br.GasPrices,
+			)
+			if simAndExec {
+				txBldr, err = utils.EnrichWithGas(txBldr, cliCtx, msgs)
+				if err != nil {
+					rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+					return
+				}
+			}
+
+			stdMsg, err := txBldr.BuildSignMsg(msgs)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+
+			res.Txs[i] = transferBatchTx{
+				Sequence:  txBldr.Sequence(),
+				Transfers: indices[next : next+len(msgs)],
+				Tx:        auth.NewStdTx(stdMsg.Msgs, stdMsg.Fee, nil, stdMsg.Memo),
+			}
+			for _, index := range res.Txs[i].Transfers {
+				results[index].Tx = i
+			}
+			next += len(msgs)
+		}
+
+		rest.PostProcessResponseBare(w, cliCtx, res)
+	}
+}
+
 func bondHandler(cliCtx context.CLIContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		baseReq, msgs, err := bondUnbondMsgCreator(true, w, cliCtx, r)
@@ -198,6 +519,178 @@ func claimHandler(cliCtx context.CLIContext) http.HandlerFunc {
 	}
 }
 
+func addAssociatedKeyHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		baseReq, msgs, err := associatedKeyMsgCreator(true, w, cliCtx, r)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, msgs)
+	}
+}
+
+func updateAssociatedKeyHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		baseReq, msgs, err := associatedKeyMsgCreator(false, w, cliCtx, r)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, msgs)
+	}
+}
+
+func removeAssociatedKeyHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		baseReq, msgs, err := removeAssociatedKeyMsgCreator(w, cliCtx, r)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, msgs)
+	}
+}
+
+func actionThresholdHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		baseReq, msgs, err := actionThresholdMsgCreator(w, cliCtx, r)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, msgs)
+	}
+}
+
+func grantHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		baseReq, msgs, err := grantMsgCreator(w, cliCtx, r)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, msgs)
+	}
+}
+
+func revokeHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		baseReq, msgs, err := revokeMsgCreator(w, cliCtx, r)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, msgs)
+	}
+}
+
+func execHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		baseReq, msgs, err := execMsgCreator(w, cliCtx, r)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, msgs)
+	}
+}
+
+func getGrantsHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		bz, err := getGrantsQuerying(w, cliCtx, r)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/querygrants", types.ModuleName), bz)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		rest.PostProcessResponseBare(w, cliCtx, res)
+	}
+}
+
+func grantFeeAllowanceHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		baseReq, msgs, err := grantFeeAllowanceMsgCreator(w, cliCtx, r)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, msgs)
+	}
+}
+
+func revokeFeeAllowanceHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		baseReq, msgs, err := revokeFeeAllowanceMsgCreator(w, cliCtx, r)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, msgs)
+	}
+}
+
+func getFeeAllowancesHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		bz, err := getFeeAllowancesQuerying(w, cliCtx, r)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/queryfeeallowances", types.ModuleName), bz)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		rest.PostProcessResponseBare(w, cliCtx, res)
+	}
+}
+
+func scheduleTransferHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		baseReq, msgs, err := scheduleTransferMsgCreator(w, cliCtx, r)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, msgs)
+	}
+}
+
+func cancelScheduleHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		baseReq, msgs, err := cancelScheduleMsgCreator(w, cliCtx, r)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, msgs)
+	}
+}
+
+func getSchedulesHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		bz, err := getSchedulesQuerying(w, cliCtx, r)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/queryschedules", types.ModuleName), bz)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		rest.PostProcessResponseBare(w, cliCtx, res)
+	}
+}
+
 func getBalanceHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		bz, err := getBalanceQuerying(w, cliCtx, r, storeName)
@@ -271,6 +764,17 @@ func editValidatorHandler(cliCtx context.CLIContext) http.HandlerFunc {
 	}
 }
 
+func rotateConsPubKeyHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		baseReq, msgs, err := rotateConsPubKeyMsgCreator(w, cliCtx, r)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, msgs)
+	}
+}
+
 func getValidatorHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		bz, err := getValidatorQuerying(w, cliCtx, r)
@@ -294,6 +798,27 @@ func getValidatorHandler(cliCtx context.CLIContext, storeName string) http.Handl
 	}
 }
 
+func getCommissionRatesHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		bz, err := getValidatorQuerying(w, cliCtx, r)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if len(bz) == 0 {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "address of the validator is required")
+			return
+		}
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/querycommissionrates", types.ModuleName), bz)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		rest.PostProcessResponseBare(w, cliCtx, res)
+	}
+}
+
 func getDelegatorHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		bz, err := getDelegatorQuerying(w, cliCtx, r)
@@ -365,3 +890,75 @@ func getCommissionHandler(cliCtx context.CLIContext, storeName string) http.Hand
 		rest.PostProcessResponseBare(w, cliCtx, res)
 	}
 }
+
+func getRewardHistoryHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		bz, err := getRewardHistoryQuerying(w, cliCtx, r)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/queryrewardhistory", types.ModuleName), bz)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		rest.PostProcessResponseBare(w, cliCtx, res)
+	}
+}
+
+func getCommissionHistoryHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		bz, err := getCommissionHistoryQuerying(w, cliCtx, r)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/querycommissionhistory", types.ModuleName), bz)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		rest.PostProcessResponseBare(w, cliCtx, res)
+	}
+}
+
+func getUnbondingHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		bz, err := getUnbondingQuerying(w, cliCtx, r)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/queryunbonding", types.ModuleName), bz)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		rest.PostProcessResponseBare(w, cliCtx, res)
+	}
+}
+
+func getRedelegationHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		bz, err := getUnbondingQuerying(w, cliCtx, r)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/queryredelegation", types.ModuleName), bz)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		rest.PostProcessResponseBare(w, cliCtx, res)
+	}
+}
@@ -0,0 +1,315 @@
+package util
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hdac-io/tendermint/crypto/secp256k1"
+
+	"github.com/hdac-io/friday/client/context"
+	sdk "github.com/hdac-io/friday/types"
+	authtypes "github.com/hdac-io/friday/x/auth/types"
+	"github.com/hdac-io/friday/x/executionlayer/types"
+)
+
+// Statuses of the transfers of a batch
+const (
+	// TransferPending is not sent yet
+	TransferPending = "pending"
+	// TransferInvalid has a recipient or amount which can't be sent
+	TransferInvalid = "invalid"
+	// TransferBroadcast is accepted into the mempool, and not confirmed in a block yet
+	TransferBroadcast = "broadcast"
+	// TransferDelivered is executed successfully in a block
+	TransferDelivered = "delivered"
+	// TransferFailed is rejected by the node or failed in a block, and can be sent again
+	TransferFailed = "failed"
+	// TransferUnknown failed in a block with its tx, without the logs of the tx telling whether the
+	// transfer was committed before the failure. It isn't sent again, to be checked by the sender.
+	TransferUnknown = "unknown"
+)
+
+// Defaults of the packing of the transfers of a batch into txs
+const (
+	// DefaultBatchMaxMsgs is the default number of the transfers of a tx
+	DefaultBatchMaxMsgs = 100
+	// DefaultBatchMaxTxBytes is the default size of a tx, the default max_tx_bytes of the mempool
+	DefaultBatchMaxTxBytes = 1024 * 1024
+)
+
+// TransferBatchEntry is a transfer of a batch file
+type TransferBatchEntry struct {
+	Recipient string `json:"recipient"`
+	Amount    string `json:"amount"`
+}
+
+// TransferBatchResult is the outcome of a transfer of a batch
+type TransferBatchResult struct {
+	Index     int    `json:"index"`
+	Recipient string `json:"recipient"`
+	Amount    string `json:"amount"`
+	Address   string `json:"address,omitempty"`
+	Status    string `json:"status"`
+	Tx        int    `json:"tx"`
+	TxHash    string `json:"txhash,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// Sendable reports whether the transfer is to be sent, being neither invalid nor sent already
+func (r TransferBatchResult) Sendable() bool {
+	return r.Status == TransferPending || r.Status == TransferFailed
+}
+
+// ReadTransferBatch reads the transfers of a batch file, a JSON array of transfers if the
+// extension is .json and CSV lines of recipient and amount otherwise.
+// A CSV header line of "recipient,amount" is skipped.
+func ReadTransferBatch(filename string) ([]TransferBatchEntry, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	if strings.ToLower(filepath.Ext(filename)) == ".json" {
+		var entries []TransferBatchEntry
+		bz, err := ioutil.ReadAll(file)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(bz, &entries); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %s", filename, err.Error())
+		}
+		return entries, nil
+	}
+
+	return readTransferBatchCSV(file)
+}
+
+func readTransferBatchCSV(r io.Reader) ([]TransferBatchEntry, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 2
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	var entries []TransferBatchEntry
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if line == 1 && strings.EqualFold(record[0], "recipient") {
+			continue
+		}
+		entries = append(entries, TransferBatchEntry{
+			Recipient: strings.TrimSpace(record[0]),
+			Amount:    strings.TrimSpace(record[1]),
+		})
+	}
+	return entries, nil
+}
+
+// NewTransferBatchResults returns the pending results of the entries
+func NewTransferBatchResults(entries []TransferBatchEntry) []TransferBatchResult {
+	results := make([]TransferBatchResult, len(entries))
+	for i, entry := range entries {
+		results[i] = TransferBatchResult{
+			Index:     i,
+			Recipient: entry.Recipient,
+			Amount:    entry.Amount,
+			Status:    TransferPending,
+			Tx:        -1,
+		}
+	}
+	return results
+}
+
+// NewTransferBatchMsgs resolves the recipients of the sendable results, bech32 addresses or
+// nicknames, and returns the transfers of them with the indices of the results.
+// The results of the recipients or amounts which can't be sent are marked invalid.
+func NewTransferBatchMsgs(cliCtx context.CLIContext, fromAddr sdk.AccAddress, results []TransferBatchResult,
+	fee Bigsun) ([]sdk.Msg, []int) {
+	var msgs []sdk.Msg
+	var indices []int
+	for i := range results {
+		result := &results[i]
+		if !result.Sendable() {
+			continue
+		}
+
+		msg, err := newTransferBatchMsg(cliCtx, fromAddr, result, fee)
+		if err != nil {
+			result.Status = TransferInvalid
+			result.Error = err.Error()
+			continue
+		}
+		msgs = append(msgs, msg)
+		indices = append(indices, i)
+	}
+	return msgs, indices
+}
+
+func newTransferBatchMsg(cliCtx context.CLIContext, fromAddr sdk.AccAddress, result *TransferBatchResult,
+	fee Bigsun) (sdk.Msg, error) {
+	toAddr, err := sdk.AccAddressFromBech32(result.Recipient)
+	if err != nil {
+		toAddr, err = GetAddress(cliCtx.Codec, cliCtx, result.Recipient)
+		if err != nil || toAddr.Empty() {
+			return nil, fmt.Errorf("no nickname mapping of %s", result.Recipient)
+		}
+	}
+	result.Address = toAddr.String()
+
+	amount, err := ToBigsun(Hdac(result.Amount))
+	if err != nil {
+		return nil, err
+	}
+	if amount == "0" {
+		return nil, fmt.Errorf("amount must be positive")
+	}
+
+	msg := types.NewMsgTransfer("transfer", fromAddr, toAddr, string(amount), string(fee))
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// PackMsgs splits the messages into packs of up to maxMsgs messages whose txs are up to
+// maxTxBytes, in order. txSize returns the size of the tx of the messages.
+func PackMsgs(msgs []sdk.Msg, maxMsgs, maxTxBytes int, txSize func([]sdk.Msg) (int, error)) ([][]sdk.Msg, error) {
+	if maxMsgs <= 0 {
+		return nil, fmt.Errorf("max messages of a tx must be positive")
+	}
+
+	var packs [][]sdk.Msg
+	var pack []sdk.Msg
+	for _, msg := range msgs {
+		candidate := append(pack[:len(pack):len(pack)], msg)
+		size, err := txSize(candidate)
+		if err != nil {
+			return nil, err
+		}
+
+		if size <= maxTxBytes && len(candidate) <= maxMsgs {
+			pack = candidate
+			continue
+		}
+		if len(pack) == 0 {
+			return nil, fmt.Errorf("tx of a single transfer is %d bytes, larger than %d bytes", size, maxTxBytes)
+		}
+
+		packs = append(packs, pack)
+		pack = []sdk.Msg{msg}
+		if size, err = txSize(pack); err != nil {
+			return nil, err
+		} else if size > maxTxBytes {
+			return nil, fmt.Errorf("tx of a single transfer is %d bytes, larger than %d bytes", size, maxTxBytes)
+		}
+	}
+	if len(pack) != 0 {
+		packs = append(packs, pack)
+	}
+	return packs, nil
+}
+
+// SignedTxSize returns the function of the size of the tx of messages signed by a secp256k1 key
+func SignedTxSize(encoder sdk.TxEncoder, fee authtypes.StdFee, memo string) func([]sdk.Msg) (int, error) {
+	sig := authtypes.StdSignature{
+		PubKey:    secp256k1.PubKeySecp256k1{},
+		Signature: make([]byte, 64),
+	}
+	return func(msgs []sdk.Msg) (int, error) {
+		txBytes, err := encoder(authtypes.NewStdTx(msgs, fee, []authtypes.StdSignature{sig}, memo))
+		return len(txBytes), err
+	}
+}
+
+// MarkTransferBatchTx marks the results at indices by the outcomes of the transfers of their tx in a
+// block. The deploys of the transfers of a tx are committed to the EE one by one, and aren't reverted
+// when a later transfer fails the tx, so the transfers succeeding in the logs of a failed tx are
+// delivered and only the failing one and the ones after it have failed. Without the logs, the
+// transfers of a failed tx with more than one transfer are unknown.
+func MarkTransferBatchTx(results []TransferBatchResult, indices []int, res sdk.TxResponse) {
+	succeeded := make(map[int]bool)
+	for _, log := range res.Logs {
+		if log.Success {
+			succeeded[int(log.MsgIndex)] = true
+		}
+	}
+
+	for i, index := range indices {
+		result := &results[index]
+		result.TxHash = res.TxHash
+		result.Error = ""
+		switch {
+		case res.Code == 0 || succeeded[i]:
+			result.Status = TransferDelivered
+		case len(res.Logs) != 0 || len(indices) == 1:
+			result.Status, result.Error = TransferFailed, res.RawLog
+		default:
+			result.Status, result.Error = TransferUnknown, res.RawLog
+		}
+	}
+}
+
+// ReadTransferBatchReport reads the results written by WriteTransferBatchReport
+func ReadTransferBatchReport(filename string) ([]TransferBatchResult, error) {
+	bz, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []TransferBatchResult
+	if err := json.Unmarshal(bz, &results); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %s", filename, err.Error())
+	}
+	return results, nil
+}
+
+// WriteTransferBatchReport writes the results to the file, replacing it at once
+func WriteTransferBatchReport(filename string, results []TransferBatchResult) error {
+	bz, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := filename + ".tmp"
+	if err := ioutil.WriteFile(tmp, bz, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, filename)
+}
+
+// ResumeTransferBatch takes over the outcomes of a previous run of the entries from its
+// results. Failed transfers are sent again, while the transfers of the previous run not
+// confirmed yet are left as broadcast, to be resumed again once they're in a block, and the
+// unknown ones are left to be checked by the sender. Resuming is not idempotent: a transfer is
+// sent again whenever the report says it's pending or failed.
+func ResumeTransferBatch(entries []TransferBatchEntry, previous []TransferBatchResult) ([]TransferBatchResult, error) {
+	if len(previous) != len(entries) {
+		return nil, fmt.Errorf("report has %d transfers while the batch has %d", len(previous), len(entries))
+	}
+
+	results := NewTransferBatchResults(entries)
+	for i, result := range previous {
+		if result.Recipient != entries[i].Recipient || result.Amount != entries[i].Amount {
+			return nil, fmt.Errorf("transfer %d of the report is %s to %s, but %s to %s in the batch",
+				i, result.Amount, result.Recipient, entries[i].Amount, entries[i].Recipient)
+		}
+
+		switch result.Status {
+		case TransferDelivered, TransferBroadcast, TransferUnknown:
+			results[i] = result
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,181 @@
+package util
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hdac-io/friday/client/context"
+	"github.com/hdac-io/friday/codec"
+	"github.com/hdac-io/friday/crypto/keys"
+	sdk "github.com/hdac-io/friday/types"
+	authtypes "github.com/hdac-io/friday/x/auth/types"
+	"github.com/hdac-io/friday/x/executionlayer/types"
+)
+
+func TestReadTransferBatch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "transfer-batch")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	csvFile := filepath.Join(dir, "batch.csv")
+	require.NoError(t, ioutil.WriteFile(csvFile, []byte("recipient,amount\n# comment\nalice, 1.5\nbob,2\n"), 0644))
+	entries, err := ReadTransferBatch(csvFile)
+	require.NoError(t, err)
+	require.Equal(t, []TransferBatchEntry{{"alice", "1.5"}, {"bob", "2"}}, entries)
+
+	jsonFile := filepath.Join(dir, "batch.json")
+	require.NoError(t, ioutil.WriteFile(jsonFile, []byte(`[{"recipient": "alice", "amount": "1.5"}]`), 0644))
+	entries, err = ReadTransferBatch(jsonFile)
+	require.NoError(t, err)
+	require.Equal(t, []TransferBatchEntry{{"alice", "1.5"}}, entries)
+
+	require.NoError(t, ioutil.WriteFile(csvFile, []byte("alice,1.5,extra\n"), 0644))
+	_, err = ReadTransferBatch(csvFile)
+	require.Error(t, err)
+}
+
+func TestNewTransferBatchMsgs(t *testing.T) {
+	fromAddr := sdk.AccAddress([]byte("from____________________________"))
+	toAddr := sdk.AccAddress([]byte("to______________________________"))
+	cliCtx := context.CLIContext{Codec: codec.New()}
+
+	results := NewTransferBatchResults([]TransferBatchEntry{
+		{toAddr.String(), "1.5"},
+		{"nickname-without-node", "1"},
+		{toAddr.String(), "0"},
+		{toAddr.String(), "1.x"},
+		{toAddr.String(), "2"},
+	})
+	results[4].Status = TransferDelivered
+
+	msgs, indices := NewTransferBatchMsgs(cliCtx, fromAddr, results, Bigsun("1000"))
+	require.Equal(t, []int{0}, indices)
+	require.Equal(t, []sdk.Msg{types.NewMsgTransfer("transfer", fromAddr, toAddr, "1500000000000000000", "1000")}, msgs)
+
+	require.Equal(t, TransferPending, results[0].Status)
+	require.Equal(t, toAddr.String(), results[0].Address)
+	for _, i := range []int{1, 2, 3} {
+		require.Equal(t, TransferInvalid, results[i].Status, "transfer %d", i)
+		require.NotEmpty(t, results[i].Error)
+	}
+	require.Equal(t, TransferDelivered, results[4].Status)
+}
+
+func TestPackMsgs(t *testing.T) {
+	fromAddr := sdk.AccAddress([]byte("from____________________________"))
+	msgs := make([]sdk.Msg, 7)
+	for i := range msgs {
+		msgs[i] = types.NewMsgTransfer("transfer", fromAddr, fromAddr, "1", "1")
+	}
+	// each message is 10 bytes over the 5 bytes of the tx
+	txSize := func(msgs []sdk.Msg) (int, error) { return 5 + 10*len(msgs), nil }
+
+	packs, err := PackMsgs(msgs, 3, 1000, txSize)
+	require.NoError(t, err)
+	require.Equal(t, [][]sdk.Msg{msgs[0:3], msgs[3:6], msgs[6:7]}, packs)
+
+	packs, err = PackMsgs(msgs, 10, 25, txSize)
+	require.NoError(t, err)
+	require.Equal(t, [][]sdk.Msg{msgs[0:2], msgs[2:4], msgs[4:6], msgs[6:7]}, packs)
+
+	_, err = PackMsgs(msgs, 10, 10, txSize)
+	require.Error(t, err)
+	_, err = PackMsgs(msgs, 0, 1000, txSize)
+	require.Error(t, err)
+}
+
+func TestSignedTxSize(t *testing.T) {
+	cdc := codec.New()
+	sdk.RegisterCodec(cdc)
+	codec.RegisterCrypto(cdc)
+	authtypes.RegisterCodec(cdc)
+	types.RegisterCodec(cdc)
+
+	kb := keys.NewInMemory()
+	info, _, err := kb.CreateMnemonic("alice", keys.English, "12345678", keys.Secp256k1)
+	require.NoError(t, err)
+
+	msgs := []sdk.Msg{types.NewMsgTransfer("transfer", info.GetAddress(), info.GetAddress(), "1", "1")}
+	fee := authtypes.NewStdFee(100000, sdk.NewCoins(sdk.NewInt64Coin("stake", 10)))
+	txBldr := authtypes.NewTxBuilder(authtypes.DefaultTxEncoder(cdc), 1, 1, fee.Gas, 1, false, "test", "memo", fee.Amount, nil).
+		WithKeybase(kb)
+	txBytes, err := txBldr.BuildAndSign("alice", "12345678", msgs)
+	require.NoError(t, err)
+
+	size, err := SignedTxSize(authtypes.DefaultTxEncoder(cdc), fee, "memo")(msgs)
+	require.NoError(t, err)
+	require.Equal(t, len(txBytes), size)
+}
+
+func TestResumeTransferBatch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "transfer-batch")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	entries := []TransferBatchEntry{{"alice", "1"}, {"bob", "2"}, {"carol", "3"}, {"dave", "4"}}
+	results := NewTransferBatchResults(entries)
+	results[0].Status, results[0].TxHash = TransferDelivered, "AA"
+	results[1].Status, results[1].TxHash = TransferBroadcast, "BB"
+	results[2].Status, results[2].Error = TransferFailed, "out of gas"
+	results[3].Status, results[3].Error = TransferInvalid, "no nickname mapping of dave"
+	results = append(results, TransferBatchResult{Index: 4, Recipient: "erin", Amount: "5", Status: TransferUnknown, Tx: 2})
+	entries = append(entries, TransferBatchEntry{"erin", "5"})
+
+	reportFile := filepath.Join(dir, "report.json")
+	require.NoError(t, WriteTransferBatchReport(reportFile, results))
+	previous, err := ReadTransferBatchReport(reportFile)
+	require.NoError(t, err)
+	require.Equal(t, results, previous)
+
+	resumed, err := ResumeTransferBatch(entries, previous)
+	require.NoError(t, err)
+	require.Equal(t, results[0], resumed[0])
+	require.Equal(t, results[1], resumed[1])
+	// the failed and invalid transfers are sent again
+	require.Equal(t, TransferPending, resumed[2].Status)
+	require.Equal(t, TransferPending, resumed[3].Status)
+	require.True(t, resumed[3].Sendable())
+	// the unknown transfers are left to be checked
+	require.Equal(t, results[4], resumed[4])
+	require.False(t, resumed[4].Sendable())
+
+	_, err = ResumeTransferBatch(entries[:4], previous)
+	require.Error(t, err)
+	changed := append([]TransferBatchEntry{{"alice", "10"}}, entries[1:]...)
+	_, err = ResumeTransferBatch(changed, previous)
+	require.Error(t, err)
+}
+
+func TestMarkTransferBatchTx(t *testing.T) {
+	entries := []TransferBatchEntry{{"alice", "1"}, {"bob", "2"}, {"carol", "3"}, {"dave", "4"}}
+
+	// the transfers succeeding before the failing one are committed with a failed tx
+	results := NewTransferBatchResults(entries)
+	MarkTransferBatchTx(results, []int{1, 2, 3}, sdk.TxResponse{
+		TxHash: "AA",
+		Height: 10,
+		Code:   1,
+		RawLog: "insufficient balance",
+		Logs:   sdk.ABCIMessageLogs{{MsgIndex: 0, Success: true}, {MsgIndex: 1, Success: false}},
+	})
+	require.Equal(t, TransferPending, results[0].Status)
+	require.Equal(t, TransferDelivered, results[1].Status)
+	require.Equal(t, "", results[1].Error)
+	require.Equal(t, TransferFailed, results[2].Status)
+	require.Equal(t, TransferFailed, results[3].Status)
+	require.Equal(t, "AA", results[3].TxHash)
+
+	// without the logs, only the transfer of a tx of its own is known to have failed
+	results = NewTransferBatchResults(entries)
+	MarkTransferBatchTx(results, []int{0, 1}, sdk.TxResponse{TxHash: "BB", Height: 10, Code: 1})
+	MarkTransferBatchTx(results, []int{2}, sdk.TxResponse{TxHash: "CC", Height: 10, Code: 1})
+	MarkTransferBatchTx(results, []int{3}, sdk.TxResponse{TxHash: "DD", Height: 10})
+	require.Equal(t, TransferUnknown, results[0].Status)
+	require.Equal(t, TransferUnknown, results[1].Status)
+	require.Equal(t, TransferFailed, results[2].Status)
+	require.Equal(t, TransferDelivered, results[3].Status)
+}
@@ -0,0 +1,132 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/util"
+	"github.com/hdac-io/friday/client/context"
+	sdk "github.com/hdac-io/friday/types"
+	"github.com/hdac-io/friday/x/executionlayer/types"
+)
+
+// ResolvedContract is a stored contract to be called, with its registry record if it has one
+type ResolvedContract struct {
+	SessionType     util.ContractType
+	SessionCode     []byte
+	ContractAddress string
+	Info            *types.ContractInfo
+}
+
+// ResolveContract resolves the reference of a stored contract into the session of its call.
+// The reference is one of:
+//
+//	(1) Bech32 contract hash or uref address
+//	(2) <deployer>:<name> of the contract registry, the deployer being an address or nickname
+//	(3) Name of a contract of the sender in the registry, or else a named key of the sender
+func ResolveContract(cliCtx context.CLIContext, fromAddr sdk.AccAddress, ref string) (ResolvedContract, error) {
+	if contractHashAddr, err := sdk.ContractHashAddressFromBech32(ref); err == nil {
+		info, err := FindContractInfo(cliCtx, ref)
+		if err != nil {
+			return ResolvedContract{}, err
+		}
+		return ResolvedContract{util.HASH, contractHashAddr.Bytes(), ref, info}, nil
+	}
+	if contractUrefAddr, err := sdk.ContractUrefAddressFromBech32(ref); err == nil {
+		info, err := FindContractInfo(cliCtx, ref)
+		if err != nil {
+			return ResolvedContract{}, err
+		}
+		return ResolvedContract{util.UREF, contractUrefAddr.Bytes(), ref, info}, nil
+	}
+
+	deployer, name := fromAddr, ref
+	if i := strings.LastIndex(ref, ":"); i >= 0 {
+		var err error
+		deployer, err = GetAddress(cliCtx.Codec, cliCtx, ref[:i])
+		if err != nil || deployer.Empty() {
+			return ResolvedContract{}, fmt.Errorf("no nickname mapping of %s", ref[:i])
+		}
+		name = ref[i+1:]
+	}
+
+	info, err := FindRegisteredContract(cliCtx, deployer, name)
+	if err != nil {
+		return ResolvedContract{}, err
+	}
+	if info == nil {
+		if !deployer.Equals(fromAddr) {
+			return ResolvedContract{}, fmt.Errorf("no contract %s of %s in the registry", name, deployer)
+		}
+		// a named key of the sender, resolved by the EE
+		return ResolvedContract{util.NAME, []byte(name), fmt.Sprintf("%s:%s", fromAddr, name), nil}, nil
+	}
+
+	for _, prefix := range []string{sdk.Bech32PrefixContractHash, sdk.Bech32PrefixContractURef} {
+		for _, key := range info.ContractKeys {
+			if !strings.HasPrefix(key, prefix+"1") {
+				continue
+			}
+			resolved, err := ResolveContract(cliCtx, fromAddr, key)
+			if err != nil {
+				return ResolvedContract{}, err
+			}
+			resolved.Info = info
+			return resolved, nil
+		}
+	}
+	return ResolvedContract{}, fmt.Errorf("contract %s of %s has no contract key", name, deployer)
+}
+
+// QueryContractInfos queries the contract registry records matching the params
+func QueryContractInfos(cliCtx context.CLIContext, params types.QueryContractParams) (types.ContractInfos, error) {
+	bz := cliCtx.Codec.MustMarshalJSON(params)
+	res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/querycontract", types.ModuleName), bz)
+	if err != nil {
+		return nil, err
+	}
+
+	var infos types.ContractInfos
+	if err := cliCtx.Codec.UnmarshalJSON(res, &infos); err != nil {
+		return nil, err
+	}
+	return infos, nil
+}
+
+// FindContractInfo returns the registry record of the contract stored under the bech32 hash or
+// uref address, or nil if the contract isn't registered
+func FindContractInfo(cliCtx context.CLIContext, contractKey string) (*types.ContractInfo, error) {
+	bz := cliCtx.Codec.MustMarshalJSON(types.NewQueryContractByKeyParams(contractKey))
+	res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/querycontractbykey", types.ModuleName), bz)
+	if err != nil {
+		return nil, err
+	}
+
+	var info *types.ContractInfo
+	if err := cliCtx.Codec.UnmarshalJSON(res, &info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// FindRegisteredContract returns the registry record of the contract of the deployer and name,
+// or nil if there is none
+func FindRegisteredContract(cliCtx context.CLIContext, deployer sdk.AccAddress, name string) (*types.ContractInfo, error) {
+	infos, err := QueryContractInfos(cliCtx, types.NewQueryContractParams(deployer, name, nil))
+	if err != nil || len(infos) == 0 {
+		return nil, err
+	}
+	return &infos[0], nil
+}
+
+// ValidateContractArgs checks the session arguments against the schema of the contract, if it
+// publishes one. The error of mismatched arguments lists the entry points of the contract.
+func ValidateContractArgs(info *types.ContractInfo, sessionArgs string) error {
+	if info == nil || info.Schema == nil {
+		return nil
+	}
+	if _, err := info.Schema.ValidateArgs(sessionArgs); err != nil {
+		return fmt.Errorf("%s\n%s", err.Error(), info.Schema.String())
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package util
+
+import (
+	"fmt"
+
+	"github.com/hdac-io/friday/client/context"
+	"github.com/hdac-io/friday/client/rpc"
+	"github.com/hdac-io/friday/x/executionlayer/types"
+)
+
+// QueryEEState returns the EE state root of the height of the context, or of the latest
+// provable height when the context has no height.
+// The root is read from the hashmap store, so unless the node is trusted it is checked
+// with the merkle proof of the store against the app hash of the signed header.
+func QueryEEState(cliCtx context.CLIContext) ([]byte, int64, error) {
+	height := cliCtx.Height
+	if height == 0 {
+		latest, err := rpc.GetChainHeight(cliCtx)
+		if err != nil {
+			return nil, 0, err
+		}
+		// the app hash of a height is signed in the header of the next height
+		height = latest - 1
+	}
+
+	res, _, err := cliCtx.WithHeight(height).QueryStore(types.GetEEStateKey(height), types.HashMapStoreKey)
+	if err != nil {
+		return nil, height, err
+	}
+	if len(res) == 0 {
+		return nil, height, fmt.Errorf("EE state of height %d is not found, the height may have been pruned", height)
+	}
+
+	var unitHashMap types.UnitHashMap
+	if err := cliCtx.Codec.UnmarshalBinaryBare(res, &unitHashMap); err != nil {
+		return nil, height, err
+	}
+
+	return unitHashMap.EEState, height, nil
+}
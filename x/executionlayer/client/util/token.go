@@ -0,0 +1,173 @@
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/storedvalue"
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/util"
+	"github.com/hdac-io/friday/client/context"
+	sdk "github.com/hdac-io/friday/types"
+	"github.com/hdac-io/friday/x/executionlayer/types"
+)
+
+// Token is a token contract resolved for its calls and the queries of its named keys
+type Token struct {
+	ResolvedContract
+	Ref string
+
+	keyType string
+	keyData string
+	path    string // prefix of the paths of the named keys
+}
+
+// ResolveToken resolves the reference of a token contract, in any of the forms ResolveContract
+// takes. A registered token publishing a schema must publish the fungible-token interface.
+func ResolveToken(cliCtx context.CLIContext, fromAddr sdk.AccAddress, ref string) (Token, error) {
+	contract, err := ResolveContract(cliCtx, fromAddr, ref)
+	if err != nil {
+		return Token{}, err
+	}
+	if contract.Info != nil && contract.Info.Schema != nil {
+		if err := types.ValidateTokenSchema(*contract.Info.Schema); err != nil {
+			return Token{}, fmt.Errorf("%s is not a token: %s", ref, err.Error())
+		}
+	}
+
+	token := Token{ResolvedContract: contract, Ref: ref}
+	switch contract.SessionType {
+	case util.HASH:
+		token.keyType, token.keyData = types.HASH, contract.ContractAddress
+	case util.UREF:
+		token.keyType, token.keyData = types.UREF, contract.ContractAddress
+	default:
+		// a named key of the sender
+		token.keyType, token.keyData, token.path = types.ADDRESS, fromAddr.String(), string(contract.SessionCode)+"/"
+	}
+	return token, nil
+}
+
+// QueryTokenMetadata queries the metadata and the total supply of the token
+func QueryTokenMetadata(cliCtx context.CLIContext, token Token) (types.TokenMetadata, error) {
+	metadata := types.TokenMetadata{Contract: token.Ref}
+
+	decimals, err := QueryTokenDecimals(cliCtx, token)
+	if err != nil {
+		return metadata, err
+	}
+	metadata.Decimals = decimals
+
+	if metadata.Name, err = queryTokenString(cliCtx, token, types.TokenKeyName); err != nil {
+		return metadata, err
+	}
+	if metadata.Symbol, err = queryTokenString(cliCtx, token, types.TokenKeySymbol); err != nil {
+		return metadata, err
+	}
+	totalSupply, err := queryTokenValue(cliCtx, token, types.TokenKeyTotalSupply)
+	if err != nil {
+		return metadata, err
+	}
+	if metadata.TotalSupply, err = formatTokenValue(totalSupply, decimals); err != nil {
+		return metadata, err
+	}
+	return metadata, nil
+}
+
+// QueryTokenDecimals queries the decimals of the token
+func QueryTokenDecimals(cliCtx context.CLIContext, token Token) (uint8, error) {
+	value, err := queryTokenValue(cliCtx, token, types.TokenKeyDecimals)
+	if err != nil {
+		return 0, err
+	}
+	decimals, ok := value.Value.(uint8)
+	if !ok {
+		return 0, fmt.Errorf("decimals of %s must be U8, but %s", token.Ref, value.Type)
+	}
+	return decimals, nil
+}
+
+// QueryTokenBalance queries the balance of the owner in the token
+func QueryTokenBalance(cliCtx context.CLIContext, token Token, owner sdk.AccAddress) (types.TokenAmount, error) {
+	return queryTokenAmount(cliCtx, token, owner, nil, types.TokenBalanceKey(owner))
+}
+
+// QueryTokenAllowance queries the amount the spender may transfer of the owner's balance in the token
+func QueryTokenAllowance(cliCtx context.CLIContext, token Token, owner, spender sdk.AccAddress) (types.TokenAmount, error) {
+	return queryTokenAmount(cliCtx, token, owner, spender, types.TokenAllowanceKey(owner, spender))
+}
+
+// ParseTokenAmount converts the decimal amount of the token into the integer amount of the
+// token's smallest unit, by the decimals of the token
+func ParseTokenAmount(cliCtx context.CLIContext, token Token, amount string) (string, error) {
+	decimals, err := QueryTokenDecimals(cliCtx, token)
+	if err != nil {
+		return "", err
+	}
+	return types.ParseTokenAmount(amount, decimals)
+}
+
+// queryTokenAmount queries the U512 under the named key of the token. The token has no named key
+// for the balances and allowances never set, so a missing key is the zero amount.
+func queryTokenAmount(cliCtx context.CLIContext, token Token, owner, spender sdk.AccAddress, key string) (types.TokenAmount, error) {
+	amount := types.TokenAmount{Contract: token.Ref, Owner: owner, Spender: spender, Amount: "0"}
+
+	decimals, err := QueryTokenDecimals(cliCtx, token)
+	if err != nil {
+		return amount, err
+	}
+	if amount.Symbol, err = queryTokenString(cliCtx, token, types.TokenKeySymbol); err != nil {
+		return amount, err
+	}
+
+	value, err := queryTokenValue(cliCtx, token, key)
+	if err != nil {
+		return amount, nil
+	}
+	amount.Amount, err = formatTokenValue(value, decimals)
+	return amount, err
+}
+
+func queryTokenString(cliCtx context.CLIContext, token Token, key string) (string, error) {
+	value, err := queryTokenValue(cliCtx, token, key)
+	if err != nil {
+		return "", err
+	}
+	str, ok := value.Value.(string)
+	if !ok {
+		return "", fmt.Errorf("%s of %s must be String, but %s", key, token.Ref, value.Type)
+	}
+	return str, nil
+}
+
+func formatTokenValue(value types.DecodedCLValue, decimals uint8) (string, error) {
+	amount, ok := value.Value.(json.Number)
+	if !ok {
+		return "", fmt.Errorf("token amount must be U512, but %s", value.Type)
+	}
+	return types.FormatTokenAmount(amount.String(), decimals)
+}
+
+// queryTokenValue queries the CLValue under the named key of the token contract
+func queryTokenValue(cliCtx context.CLIContext, token Token, key string) (types.DecodedCLValue, error) {
+	queryData := types.QueryExecutionLayerDetail{
+		KeyType: token.keyType,
+		KeyData: token.keyData,
+		Path:    token.path + key,
+	}
+	bz := cliCtx.Codec.MustMarshalJSON(queryData)
+
+	res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/querydetail", types.ModuleName), bz)
+	if err != nil {
+		return types.DecodedCLValue{}, fmt.Errorf("failed to query %s of %s: %s", key, token.Ref, err.Error())
+	}
+
+	var storedValue storedvalue.StoredValue
+	storedValue, err, _ = storedValue.FromBytes(res)
+	if err != nil {
+		return types.DecodedCLValue{}, err
+	}
+	if storedValue.Type != storedvalue.TYPE_CL_VALUE {
+		return types.DecodedCLValue{}, fmt.Errorf("%s of %s is not a CLValue", key, token.Ref)
+	}
+	return types.DecodeCLValue(storedValue.ClValue)
+}
@@ -5,6 +5,9 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/casper/consensus/state"
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/storedvalue"
 	"github.com/hdac-io/casperlabs-ee-grpc-go-util/util"
 	"github.com/hdac-io/friday/client/context"
 	"github.com/hdac-io/friday/codec"
@@ -170,22 +173,21 @@ func ToHdac(Bigsun Bigsun) Hdac {
 	return Hdac(strings.Join(res, "."))
 }
 
-// ReplaceBase64HashToBech32 for replace hashes for empty path query
-func ReplaceBase64HashToBech32(path, valueStr string) string {
-	res := valueStr
-	if path == "" {
-		r := regexp.MustCompile(`\"uref\": \{[\s]+\"uref\": \"([a-zA-Z0-9+/]+={0,3})\"`)
-		for _, matchedGroup := range r.FindAllStringSubmatch(valueStr, -1) {
-			urefStr := matchedGroup[1]
-			res = strings.Replace(res, urefStr, sdk.MustGetBech32ContractUrefFromBase64(urefStr), -1)
-		}
-
-		r = regexp.MustCompile(`\"hash\": \{[\s]+\"hash\": \"([a-zA-Z0-9+/]+={0,3})\"`)
-		for _, matchedGroup := range r.FindAllStringSubmatch(valueStr, -1) {
-			hashStr := matchedGroup[1]
-			res = strings.Replace(res, hashStr, sdk.MustGetBech32ContractHashFromBase64(hashStr), -1)
-		}
+// StoredValueToRawJSON returns the protobuf JSON form of a stored value as the EE gives it, without decoding
+func StoredValueToRawJSON(storedValue storedvalue.StoredValue) (string, error) {
+	marshaler := jsonpb.Marshaler{Indent: "  "}
+
+	switch storedValue.Type {
+	case storedvalue.TYPE_ACCOUNT:
+		value := &state.Value{Value: &state.Value_Account{Account: storedValue.Account.ToStateValue()}}
+		return marshaler.MarshalToString(value)
+	case storedvalue.TYPE_CONTRACT:
+		value := &state.Value{Value: &state.Value_Contract{Contract: storedValue.Contract.ToStateValue()}}
+		return marshaler.MarshalToString(value)
+	case storedvalue.TYPE_CL_VALUE:
+		value := storedValue.ClValue.ToCLInstanceValue()
+		return marshaler.MarshalToString(value)
+	default:
+		return "", fmt.Errorf("unknown stored value type: %d", storedValue.Type)
 	}
-
-	return res
 }
@@ -40,6 +40,26 @@ func InitGenesis(
 
 	keeper.SetChainName(ctx, data.ChainName)
 	keeper.SetGenesisConf(ctx, data.GenesisConf)
+	keeper.SetDeployConfig(ctx, data.GenesisConf.DeployConfig)
+	keeper.SetParams(ctx, data.Params)
+	for _, grant := range data.Grants {
+		keeper.SetGrant(ctx, grant)
+	}
+	for _, allowance := range data.FeeAllowances {
+		keeper.SetFeeAllowance(ctx, allowance)
+	}
+	for _, schedule := range data.Schedules {
+		keeper.SetSchedule(ctx, schedule)
+		if schedule.ID >= keeper.GetNextScheduleID(ctx) {
+			keeper.SetNextScheduleID(ctx, schedule.ID+1)
+		}
+	}
+	for _, request := range data.StakeRequests {
+		keeper.SetStakeRequest(ctx, request)
+		if request.ID >= keeper.GetNextStakeRequestID(ctx) {
+			keeper.SetNextStakeRequestID(ctx, request.ID+1)
+		}
+	}
 	keeper.SetUnitHashMap(ctx, types.NewUnitHashMap(ctx.CandidateBlock().State))
 
 	// Query to current validator information.
@@ -162,8 +182,14 @@ func ExportGenesis(ctx sdk.Context, keeper ExecutionLayerKeeper) types.GenesisSt
 		}
 	}
 
-	return types.NewGenesisState(
+	genesisState := types.NewGenesisState(
 		keeper.GetGenesisConf(ctx), accounts, keeper.GetChainName(ctx), validators, stateInfos)
+	genesisState.Params = keeper.GetParams(ctx)
+	genesisState.Grants = keeper.GetAllGrants(ctx)
+	genesisState.FeeAllowances = keeper.GetAllFeeAllowances(ctx)
+	genesisState.Schedules = keeper.GetAllSchedules(ctx)
+	genesisState.StakeRequests = keeper.GetAllStakeRequests(ctx)
+	return genesisState
 }
 
 func WriteValidators(ctx sdk.Context, keeper ExecutionLayerKeeper) (vals []tmtypes.GenesisValidator) {
@@ -1,8 +1,11 @@
 package executionlayer
 
 import (
+	"bytes"
 	"encoding/hex"
 	"fmt"
+	"math"
+	"strconv"
 	"strings"
 
 	"github.com/hdac-io/casperlabs-ee-grpc-go-util/grpc"
@@ -10,9 +13,12 @@ import (
 	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/casper/consensus/state"
 	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/ipc"
 	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/ipc/transforms"
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/storedvalue"
 	"github.com/hdac-io/casperlabs-ee-grpc-go-util/util"
 	sdk "github.com/hdac-io/friday/types"
+	"github.com/hdac-io/friday/x/auth"
 	"github.com/hdac-io/friday/x/executionlayer/types"
+	"github.com/hdac-io/tendermint/crypto/tmhash"
 	"github.com/hdac-io/tendermint/libs/common"
 	tmtypes "github.com/hdac-io/tendermint/types"
 )
@@ -22,35 +28,85 @@ func NewHandler(k ExecutionLayerKeeper) sdk.Handler {
 	return func(ctx sdk.Context, msg sdk.Msg, simulate bool) sdk.Result {
 		ctx = ctx.WithEventManager(sdk.NewEventManager())
 
-		switch msg := msg.(type) {
-		case types.MsgExecute:
-			return handlerMsgExecute(ctx, k, msg, simulate)
-		case types.MsgTransfer:
-			return handlerMsgTransfer(ctx, k, msg, simulate)
-		case types.MsgCreateValidator:
-			return handlerMsgCreateValidator(ctx, k, msg, simulate)
-		case types.MsgEditValidator:
-			return handlerMsgEditValidator(ctx, k, msg, simulate)
-		case types.MsgBond:
-			return handlerMsgBond(ctx, k, msg, simulate)
-		case types.MsgUnBond:
-			return handlerMsgUnBond(ctx, k, msg, simulate)
-		case types.MsgDelegate:
-			return handlerMsgDelegate(ctx, k, msg, simulate)
-		case types.MsgUndelegate:
-			return handlerMsgUndelgate(ctx, k, msg, simulate)
-		case types.MsgRedelegate:
-			return handlerMsgRedelegate(ctx, k, msg, simulate)
-		case types.MsgVote:
-			return handlerMsgVote(ctx, k, msg, simulate)
-		case types.MsgUnvote:
-			return handlerMsgUnvote(ctx, k, msg, simulate)
-		case types.MsgClaim:
-			return handlerMsgClaim(ctx, k, msg, simulate)
-		default:
-			errMsg := fmt.Sprintf("unrecognized execution layer messgae type: %T", msg)
-			return sdk.ErrUnknownRequest(errMsg).Result()
+		if deployMsg, ok := msg.(types.DeployMsg); ok {
+			if err := validateDeployHeader(ctx, k, deployMsg.GetDeployHeader()); err != nil {
+				return err.Result()
+			}
+			var err sdk.Error
+			if ctx, err = withFeePayer(ctx, deployMsg); err != nil {
+				return err.Result()
+			}
+		}
+
+		res := handleMsg(ctx, k, msg, simulate)
+		res.Events = ctx.EventManager().Events()
+		if !simulate {
+			k.metrics.Msgs.With("msg_type", msg.Type(), "result", resultLabel(res.IsOK())).Add(1)
 		}
+		return res
+	}
+}
+
+// handleMsg routes the msg to its handler
+func handleMsg(ctx sdk.Context, k ExecutionLayerKeeper, msg sdk.Msg, simulate bool) sdk.Result {
+	switch msg := msg.(type) {
+	case types.MsgExecute:
+		return handlerMsgExecute(ctx, k, msg, simulate)
+	case types.MsgTransfer:
+		return handlerMsgTransfer(ctx, k, msg, simulate)
+	case types.MsgCreateValidator:
+		return handlerMsgCreateValidator(ctx, k, msg, simulate)
+	case types.MsgEditValidator:
+		return handlerMsgEditValidator(ctx, k, msg, simulate)
+	case types.MsgRotateConsPubKey:
+		return handlerMsgRotateConsPubKey(ctx, k, msg, simulate)
+	case types.MsgBond:
+		return handlerMsgBond(ctx, k, msg, simulate)
+	case types.MsgUnBond:
+		return handlerMsgUnBond(ctx, k, msg, simulate)
+	case types.MsgDelegate:
+		return handlerMsgDelegate(ctx, k, msg, simulate)
+	case types.MsgUndelegate:
+		return handlerMsgUndelgate(ctx, k, msg, simulate)
+	case types.MsgRedelegate:
+		return handlerMsgRedelegate(ctx, k, msg, simulate)
+	case types.MsgVote:
+		return handlerMsgVote(ctx, k, msg, simulate)
+	case types.MsgUnvote:
+		return handlerMsgUnvote(ctx, k, msg, simulate)
+	case types.MsgClaim:
+		return handlerMsgClaim(ctx, k, msg, simulate)
+	case types.MsgDeployContract:
+		return handlerMsgDeployContract(ctx, k, msg, simulate)
+	case types.MsgSetContractSchema:
+		return handlerMsgSetContractSchema(ctx, k, msg, simulate)
+	case types.MsgAddAssociatedKey:
+		return handlerMsgAddAssociatedKey(ctx, k, msg, simulate)
+	case types.MsgRemoveAssociatedKey:
+		return handlerMsgRemoveAssociatedKey(ctx, k, msg, simulate)
+	case types.MsgUpdateAssociatedKey:
+		return handlerMsgUpdateAssociatedKey(ctx, k, msg, simulate)
+	case types.MsgSetActionThreshold:
+		return handlerMsgSetActionThreshold(ctx, k, msg, simulate)
+	case types.MsgAuthorize:
+		return handlerMsgAuthorize(ctx, k, msg, simulate)
+	case types.MsgGrant:
+		return handlerMsgGrant(ctx, k, msg, simulate)
+	case types.MsgRevoke:
+		return handlerMsgRevoke(ctx, k, msg, simulate)
+	case types.MsgExec:
+		return handlerMsgExec(ctx, k, msg, simulate)
+	case types.MsgGrantFeeAllowance:
+		return handlerMsgGrantFeeAllowance(ctx, k, msg, simulate)
+	case types.MsgRevokeFeeAllowance:
+		return handlerMsgRevokeFeeAllowance(ctx, k, msg, simulate)
+	case types.MsgScheduleTransfer:
+		return handlerMsgScheduleTransfer(ctx, k, msg, simulate)
+	case types.MsgCancelSchedule:
+		return handlerMsgCancelSchedule(ctx, k, msg, simulate)
+	default:
+		errMsg := fmt.Sprintf("unrecognized execution layer messgae type: %T", msg)
+		return sdk.ErrUnknownRequest(errMsg).Result()
 	}
 }
 
@@ -104,7 +160,7 @@ func handlerMsgTransfer(ctx sdk.Context, k ExecutionLayerKeeper, msg types.MsgTr
 
 // Handle MsgExecute
 func handlerMsgExecute(ctx sdk.Context, k ExecutionLayerKeeper, msg types.MsgExecute, simulate bool) sdk.Result {
-	replacedSessionArgs, addrList, err := ReplaceFromBech32ToHex(msg.SessionArgs)
+	deployAbi, addrList, err := sessionArgsToAbi(msg.SessionArgs)
 	if err != nil {
 		return getResult(false, err.Error())
 	}
@@ -113,16 +169,6 @@ func handlerMsgExecute(ctx sdk.Context, k ExecutionLayerKeeper, msg types.MsgExe
 		k.SetAccountIfNotExists(ctx, unitAddr)
 	}
 
-	deployArgs, err := util.JsonStringToDeployArgs(replacedSessionArgs)
-	if err != nil {
-		return getResult(false, err.Error())
-	}
-
-	deployAbi, err := util.AbiDeployArgsTobytes(deployArgs)
-	if err != nil {
-		return getResult(false, err.Error())
-	}
-
 	msg.SessionArgs = util.EncodeToHexString(deployAbi)
 
 	result, log := execute(ctx, k, msg, simulate)
@@ -154,6 +200,11 @@ func handlerMsgCreateValidator(ctx sdk.Context, k ExecutionLayerKeeper, msg type
 
 	proxyContractHash := k.GetProxyContractHash(ctx)
 	validator := types.NewValidator(msg.ValidatorAddress, msg.ConsPubKey, msg.Description, "")
+	commission := types.NewCommissionWithTime(msg.Commission.Rate, msg.Commission.MaxRate, msg.Commission.MaxChangeRate, ctx.BlockTime())
+	validator, err := validator.SetInitialCommission(commission)
+	if err != nil {
+		return err.Result()
+	}
 
 	if proxyContractHash != nil {
 
@@ -181,6 +232,7 @@ func handlerMsgCreateValidator(ctx sdk.Context, k ExecutionLayerKeeper, msg type
 
 	k.SetValidator(ctx, msg.ValidatorAddress, validator)
 	k.SetValidatorByConsAddr(ctx, validator)
+	k.SetCommissionRateChange(ctx, msg.ValidatorAddress, types.NewCommissionRateChange(ctx.BlockHeight(), ctx.BlockTime(), commission.Rate))
 	return getResult(true, "")
 }
 
@@ -189,15 +241,30 @@ func handlerMsgEditValidator(ctx sdk.Context, k ExecutionLayerKeeper, msg types.
 	// validator must already be registered
 	validator, found := k.GetValidator(ctx, msg.ValidatorAddress)
 
+	if !found {
+		return getResult(false, "validator does not exist for that address")
+	}
+
 	// replace all editable fields (clients should autofill existing values)
 	description, err := validator.Description.UpdateDescription(msg.Description)
-
-	paymentAmount := "0"
-	if found && err == nil {
-		paymentAmount = types.BASIC_PAY_AMOUNT
+	if err != nil {
+		return getResult(false, err.Error())
 	}
 
-	sessionAbi, parseError := getPayAmountSessionArgsStr(paymentAmount)
+	// a new commission rate is forwarded to the PoS contract in place of the payment
+	var sessionAbi []byte
+	var parseError error
+	if msg.CommissionRate != nil {
+		if err := validator.Commission.ValidateNewRate(*msg.CommissionRate, ctx.BlockTime()); err != nil {
+			return err.Result()
+		}
+		sessionAbi, parseError = getCommissionRateSessionArgs(*msg.CommissionRate)
+	} else {
+		sessionAbi, parseError = getPayAmountSessionArgsStr(types.BASIC_PAY_AMOUNT)
+	}
+	if parseError != nil {
+		return getResult(false, parseError.Error())
+	}
 
 	msgExecute := NewMsgExecute(
 		msg.ContractAddress,
@@ -209,22 +276,80 @@ func handlerMsgEditValidator(ctx sdk.Context, k ExecutionLayerKeeper, msg types.
 	)
 
 	result, log := execute(ctx, k, msgExecute, simulate)
-
-	if !found {
-		return getResult(false, "validator does not exist for that address")
-	} else if err != nil {
-		return getResult(false, err.Error())
-	} else if parseError != nil {
-		return getResult(false, parseError.Error())
-	} else if log != "" || !result {
+	if log != "" || !result {
 		return getResult(false, log)
 	}
 
 	validator.Description = description
+	if msg.CommissionRate != nil {
+		validator.Commission.Rate = *msg.CommissionRate
+		validator.Commission.UpdateTime = ctx.BlockTime()
+		k.SetCommissionRateChange(ctx, msg.ValidatorAddress, types.NewCommissionRateChange(ctx.BlockHeight(), ctx.BlockTime(), *msg.CommissionRate))
+	}
 	k.SetValidator(ctx, msg.ValidatorAddress, validator)
 	return getResult(true, "")
 }
 
+func handlerMsgRotateConsPubKey(ctx sdk.Context, k ExecutionLayerKeeper, msg types.MsgRotateConsPubKey, simulate bool) sdk.Result {
+	validator, found := k.GetValidator(ctx, msg.ValidatorAddress)
+	if !found {
+		return ErrNoValidatorFound(types.DefaultCodespace).Result()
+	}
+
+	if _, found := k.GetValidatorByConsAddr(ctx, sdk.GetConsAddress(msg.NewConsPubKey)); found {
+		return ErrValidatorPubKeyExists(types.DefaultCodespace).Result()
+	}
+
+	if ctx.ConsensusParams() != nil {
+		tmPubKey := tmtypes.TM2PB.PubKey(msg.NewConsPubKey)
+		if !common.StringInSlice(tmPubKey.Type, ctx.ConsensusParams().Validator.PubKeyTypes) {
+			return ErrValidatorPubKeyTypeNotSupported(types.DefaultCodespace,
+				tmPubKey.Type,
+				ctx.ConsensusParams().Validator.PubKeyTypes).Result()
+		}
+	}
+
+	if rotatedHeight, found := k.GetConsKeyRotationHeight(ctx, msg.ValidatorAddress); found {
+		cooldown := k.GetParams(ctx).ConsKeyRotationCooldown
+		if ctx.BlockHeight() < rotatedHeight+cooldown {
+			return ErrConsKeyRotationCooldown(types.DefaultCodespace, rotatedHeight, rotatedHeight+cooldown).Result()
+		}
+	}
+
+	proxyContractHash := k.GetProxyContractHash(ctx)
+	if proxyContractHash != nil {
+		sessionAbi, err := getPayAmountSessionArgsStr(types.BASIC_PAY_AMOUNT)
+		if err != nil {
+			return getResult(false, err.Error())
+		}
+
+		msgExecute := NewMsgExecute(
+			msg.ContractAddress,
+			msg.ValidatorAddress,
+			util.HASH,
+			proxyContractHash,
+			hex.EncodeToString(sessionAbi),
+			msg.Fee,
+		)
+		result, log := execute(ctx, k, msgExecute, simulate)
+		if log != "" || !result {
+			return getResult(false, log)
+		}
+	}
+
+	oldConsAddress := validator.ConsAddress()
+	k.RotateValidatorConsPubKey(ctx, validator, msg.NewConsPubKey)
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeRotateConsPubKey,
+			sdk.NewAttribute(types.AttributeKeyValidator, msg.ValidatorAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyOldConsAddress, oldConsAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyNewConsAddress, sdk.GetConsAddress(msg.NewConsPubKey).String()),
+		),
+	)
+	return getResult(true, "")
+}
+
 func handlerMsgBond(ctx sdk.Context, k ExecutionLayerKeeper, msg types.MsgBond, simulate bool) sdk.Result {
 	proxyContractHash := k.GetProxyContractHash(ctx)
 	sessionArgs := []*consensus.Deploy_Arg{
@@ -295,6 +420,9 @@ func handlerMsgUnBond(ctx sdk.Context, k ExecutionLayerKeeper, msg types.MsgUnBo
 		msg.Fee,
 	)
 	result, log := execute(ctx, k, msgExecute, simulate)
+	if result && !simulate {
+		k.AddStakeRequest(ctx, types.StakeRequestUnbond, msg.FromAddress, msg.FromAddress, nil, msg.Amount)
+	}
 
 	return getResult(result, log)
 }
@@ -380,6 +508,9 @@ func handlerMsgUndelgate(ctx sdk.Context, k ExecutionLayerKeeper, msg types.MsgU
 		msg.Fee,
 	)
 	result, log := execute(ctx, k, msgExecute, simulate)
+	if result && !simulate {
+		k.AddStakeRequest(ctx, types.StakeRequestUndelegate, msg.FromAddress, msg.ValAddress, nil, msg.Amount)
+	}
 
 	return getResult(result, log)
 }
@@ -430,6 +561,9 @@ func handlerMsgRedelegate(ctx sdk.Context, k ExecutionLayerKeeper, msg types.Msg
 		msg.Fee,
 	)
 	result, log := execute(ctx, k, msgExecute, simulate)
+	if result && !simulate {
+		k.AddStakeRequest(ctx, types.StakeRequestRedelegate, msg.FromAddress, msg.SrcValAddress, msg.DestValAddress, msg.Amount)
+	}
 
 	return getResult(result, log)
 }
@@ -599,11 +733,12 @@ func handlerMsgUnvote(ctx sdk.Context, k ExecutionLayerKeeper, msg types.MsgUnvo
 func handlerMsgClaim(ctx sdk.Context, k ExecutionLayerKeeper, msg types.MsgClaim, simulate bool) sdk.Result {
 	proxyContractHash := k.GetProxyContractHash(ctx)
 	var methodName string
+	var claimKey []byte
 	switch msg.RewardOrCommission {
 	case types.CommissionValue:
-		methodName = types.ClaimCommissionMethodName
+		methodName, claimKey = types.ClaimCommissionMethodName, types.CommissionClaimKey
 	case types.RewardValue:
-		methodName = types.ClaimRewardMethodName
+		methodName, claimKey = types.ClaimRewardMethodName, types.RewardClaimKey
 	default:
 		return getResult(false, "Must be reward or commission")
 	}
@@ -630,115 +765,959 @@ func handlerMsgClaim(ctx sdk.Context, k ExecutionLayerKeeper, msg types.MsgClaim
 		msg.Fee,
 	)
 	result, log := execute(ctx, k, msgExecute, simulate)
+	if result {
+		// for the reward history to tell the claimed balance from the earned one
+		k.SetClaim(ctx, claimKey, msg.FromAddress)
+	}
 
 	return getResult(result, log)
 }
 
-func execute(ctx sdk.Context, k ExecutionLayerKeeper, msg types.MsgExecute, simulate bool) (bool, string) {
-	proxyContractHash := k.GetProxyContractHash(ctx)
-	// Parameter preparation
-	var stateHash []byte
-	var protocolVersion state.ProtocolVersion
+// Handle MsgDeployContract
+// Deploys WASM code like MsgExecute does, and records the deployment in the contract registry
+// with the contract keys created by the execution.
+func handlerMsgDeployContract(ctx sdk.Context, k ExecutionLayerKeeper, msg types.MsgDeployContract, simulate bool) sdk.Result {
+	sessionAbi := []byte{}
+	if msg.SessionArgs != "" {
+		replacedSessionArgs, addrList, err := ReplaceFromBech32ToHex(msg.SessionArgs)
+		if err != nil {
+			return getResult(false, err.Error())
+		}
+
+		for _, unitAddr := range addrList {
+			k.SetAccountIfNotExists(ctx, unitAddr)
+		}
+
+		deployArgs, err := util.JsonStringToDeployArgs(replacedSessionArgs)
+		if err != nil {
+			return getResult(false, err.Error())
+		}
+
+		sessionAbi, err = util.AbiDeployArgsTobytes(deployArgs)
+		if err != nil {
+			return getResult(false, err.Error())
+		}
+	}
+
+	msgExecute := NewMsgExecute(
+		msg.ContractAddress,
+		msg.FromAddress,
+		util.WASM,
+		msg.Code,
+		hex.EncodeToString(sessionAbi),
+		msg.Fee,
+	)
+	result, log, effects := executeWithEffects(ctx, k, msgExecute, simulate)
+	if !result || simulate {
+		return getResult(result, log)
+	}
+
+	contractInfo := types.NewContractInfo(
+		msg.FromAddress,
+		msg.Name,
+		util.Blake2b256(msg.Code),
+		ctx.BlockHeight(),
+		getContractKeysFromEffects(effects),
+	)
+	contractInfo.Schema = msg.Schema
+	k.SetContractInfo(ctx, contractInfo)
+
+	event := sdk.NewEvent(
+		types.EventTypeDeployContract,
+		sdk.NewAttribute(types.AttributeKeyDeployer, msg.FromAddress.String()),
+		sdk.NewAttribute(types.AttributeKeyContractName, msg.Name),
+		sdk.NewAttribute(types.AttributeKeyCodeHash, hex.EncodeToString(contractInfo.CodeHash)),
+	)
+	for _, contractKey := range contractInfo.ContractKeys {
+		event = event.AppendAttributes(sdk.NewAttribute(types.AttributeKeyContractKey, contractKey))
+	}
+	ctx.EventManager().EmitEvent(event)
+
+	res := getResult(true, "")
+	res.Events = ctx.EventManager().Events()
+	return res
+}
+
+// Handle MsgSetContractSchema
+// Nothing to execute, the schema is replaced in the registry record of the deployer.
+func handlerMsgSetContractSchema(ctx sdk.Context, k ExecutionLayerKeeper, msg types.MsgSetContractSchema, simulate bool) sdk.Result {
+	contractInfo, found := k.GetContractInfo(ctx, msg.FromAddress, msg.Name)
+	if !found {
+		return getResult(false, fmt.Sprintf("no contract %s deployed by %s", msg.Name, msg.FromAddress))
+	}
 	if simulate {
-		stateHash = k.GetUnitHashMap(ctx, ctx.BlockHeight()).EEState
-		protocolVersion = k.GetProtocolVersion(ctx)
-	} else {
-		stateHash = ctx.CandidateBlock().State
-		protocolVersion = *ctx.CandidateBlock().ProtocolVersion
+		return getResult(true, "")
 	}
-	log := ""
 
-	paymentArgs := []*consensus.Deploy_Arg{
+	contractInfo.Schema = msg.Schema
+	k.SetContractInfo(ctx, contractInfo)
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeSetContractSchema,
+		sdk.NewAttribute(types.AttributeKeyDeployer, msg.FromAddress.String()),
+		sdk.NewAttribute(types.AttributeKeyContractName, msg.Name),
+	))
+
+	res := getResult(true, "")
+	res.Events = ctx.EventManager().Events()
+	return res
+}
+
+// Handle MsgAddAssociatedKey
+// Associated keys and action thresholds are managed by the proxy contract on behalf of the account.
+func handlerMsgAddAssociatedKey(ctx sdk.Context, k ExecutionLayerKeeper, msg types.MsgAddAssociatedKey, simulate bool) sdk.Result {
+	proxyContractHash := k.GetProxyContractHash(ctx)
+	sessionArgs := []*consensus.Deploy_Arg{
 		&consensus.Deploy_Arg{
 			Value: &state.CLValueInstance{
 				ClType: &state.CLType{Variants: &state.CLType_SimpleType{SimpleType: state.CLType_STRING}},
 				Value: &state.CLValueInstance_Value{
 					Value: &state.CLValueInstance_Value_StrValue{
-						StrValue: types.PaymentMethodName}}}},
+						StrValue: types.AddAssociatedKeyMethodName}}}},
 		&consensus.Deploy_Arg{
 			Value: &state.CLValueInstance{
-				ClType: &state.CLType{Variants: &state.CLType_SimpleType{SimpleType: state.CLType_U512}},
+				ClType: &state.CLType{Variants: &state.CLType_ListType{ListType: &state.CLType_List{Inner: &state.CLType{Variants: &state.CLType_SimpleType{SimpleType: state.CLType_U8}}}}},
 				Value: &state.CLValueInstance_Value{
-					Value: &state.CLValueInstance_Value_U512{
-						U512: &state.CLValueInstance_U512{
-							Value: string(msg.Fee)}}}}}}
+					Value: &state.CLValueInstance_Value_BytesValue{
+						BytesValue: msg.AssociatedAddress}}}},
+		&consensus.Deploy_Arg{
+			Value: &state.CLValueInstance{
+				ClType: &state.CLType{Variants: &state.CLType_SimpleType{SimpleType: state.CLType_U8}},
+				Value: &state.CLValueInstance_Value{
+					Value: &state.CLValueInstance_Value_U8{
+						U8: int32(msg.Weight)}}}}}
 
-	paymentAbi, err := util.AbiDeployArgsTobytes(paymentArgs)
+	sessionAbi, err := util.AbiDeployArgsTobytes(sessionArgs)
 	if err != nil {
-		return false, err.Error()
+		return getResult(false, err.Error())
 	}
 
-	sessionAbi, err := hex.DecodeString(msg.SessionArgs)
-	if err != nil {
-		return false, err.Error()
-	}
+	msgExecute := NewMsgExecute(
+		msg.ContractAddress,
+		msg.FromAddress,
+		util.HASH,
+		proxyContractHash,
+		hex.EncodeToString(sessionAbi),
+		msg.Fee,
+	)
+	result, log := execute(ctx, k, msgExecute, simulate)
 
-	msgHash := util.Blake2b256(msg.GetSignBytes())
+	return getResult(result, log)
+}
 
-	// Execute
-	deploys := []*ipc.DeployItem{
-		&ipc.DeployItem{
-			Address:           msg.ExecAddress,
-			Session:           util.MakeDeployPayload(msg.SessionType, msg.SessionCode, sessionAbi),
-			Payment:           util.MakeDeployPayload(util.HASH, proxyContractHash, paymentAbi),
-			AuthorizationKeys: [][]byte{msg.ExecAddress},
-			DeployHash:        msgHash,
-			GasPrice:          types.BASIC_GAS,
-		},
-	}
-	reqExecute := &ipc.ExecuteRequest{
-		ParentStateHash: stateHash,
-		BlockTime:       uint64(ctx.BlockTime().Unix()),
-		Deploys:         deploys,
-		ProtocolVersion: &protocolVersion,
-	}
-	resExecute, err := k.client.Execute(ctx.Context(), reqExecute)
+func handlerMsgRemoveAssociatedKey(ctx sdk.Context, k ExecutionLayerKeeper, msg types.MsgRemoveAssociatedKey, simulate bool) sdk.Result {
+	proxyContractHash := k.GetProxyContractHash(ctx)
+	sessionArgs := []*consensus.Deploy_Arg{
+		&consensus.Deploy_Arg{
+			Value: &state.CLValueInstance{
+				ClType: &state.CLType{Variants: &state.CLType_SimpleType{SimpleType: state.CLType_STRING}},
+				Value: &state.CLValueInstance_Value{
+					Value: &state.CLValueInstance_Value_StrValue{
+						StrValue: types.RemoveAssociatedKeyMethodName}}}},
+		&consensus.Deploy_Arg{
+			Value: &state.CLValueInstance{
+				ClType: &state.CLType{Variants: &state.CLType_ListType{ListType: &state.CLType_List{Inner: &state.CLType{Variants: &state.CLType_SimpleType{SimpleType: state.CLType_U8}}}}},
+				Value: &state.CLValueInstance_Value{
+					Value: &state.CLValueInstance_Value_BytesValue{
+						BytesValue: msg.AssociatedAddress}}}}}
+
+	sessionAbi, err := util.AbiDeployArgsTobytes(sessionArgs)
 	if err != nil {
-		return false, err.Error()
+		return getResult(false, err.Error())
 	}
 
-	effects := []*transforms.TransformEntry{}
-	switch resExecute.GetResult().(type) {
-	case *ipc.ExecuteResponse_Success:
-		for _, res := range resExecute.GetSuccess().GetDeployResults() {
-			switch res.GetExecutionResult().GetError().GetValue().(type) {
-			case *ipc.DeployError_GasError:
-				err = types.ErrGRpcExecuteDeployGasError(types.DefaultCodespace)
-			case *ipc.DeployError_ExecError:
-				err = types.ErrGRpcExecuteDeployExecError(types.DefaultCodespace, res.GetExecutionResult().GetError().GetExecError().GetMessage())
-			}
+	msgExecute := NewMsgExecute(
+		msg.ContractAddress,
+		msg.FromAddress,
+		util.HASH,
+		proxyContractHash,
+		hex.EncodeToString(sessionAbi),
+		msg.Fee,
+	)
+	result, log := execute(ctx, k, msgExecute, simulate)
 
-			effects = append(effects, res.GetExecutionResult().GetEffects().GetTransformMap()...)
-			if err != nil {
-				log += fmt.Sprintf(log, err.Error())
-			}
-		}
-	case *ipc.ExecuteResponse_MissingParent:
-		err = types.ErrGRpcExecuteMissingParent(types.DefaultCodespace, util.EncodeToHexString(resExecute.GetMissingParent().GetHash()))
-		log += err.Error()
-	default:
-		err = fmt.Errorf("Unknown result : %s", resExecute.String())
-		log += err.Error()
-	}
+	return getResult(result, log)
+}
 
-	if simulate {
-		return log == "", log
+func handlerMsgUpdateAssociatedKey(ctx sdk.Context, k ExecutionLayerKeeper, msg types.MsgUpdateAssociatedKey, simulate bool) sdk.Result {
+	proxyContractHash := k.GetProxyContractHash(ctx)
+	sessionArgs := []*consensus.Deploy_Arg{
+		&consensus.Deploy_Arg{
+			Value: &state.CLValueInstance{
+				ClType: &state.CLType{Variants: &state.CLType_SimpleType{SimpleType: state.CLType_STRING}},
+				Value: &state.CLValueInstance_Value{
+					Value: &state.CLValueInstance_Value_StrValue{
+						StrValue: types.UpdateAssociatedKeyMethodName}}}},
+		&consensus.Deploy_Arg{
+			Value: &state.CLValueInstance{
+				ClType: &state.CLType{Variants: &state.CLType_ListType{ListType: &state.CLType_List{Inner: &state.CLType{Variants: &state.CLType_SimpleType{SimpleType: state.CLType_U8}}}}},
+				Value: &state.CLValueInstance_Value{
+					Value: &state.CLValueInstance_Value_BytesValue{
+						BytesValue: msg.AssociatedAddress}}}},
+		&consensus.Deploy_Arg{
+			Value: &state.CLValueInstance{
+				ClType: &state.CLType{Variants: &state.CLType_SimpleType{SimpleType: state.CLType_U8}},
+				Value: &state.CLValueInstance_Value{
+					Value: &state.CLValueInstance_Value_U8{
+						U8: int32(msg.Weight)}}}}}
+
+	sessionAbi, err := util.AbiDeployArgsTobytes(sessionArgs)
+	if err != nil {
+		return getResult(false, err.Error())
 	}
 
-	// Commit
-	postStateHash, bonds, errGrpc := grpc.Commit(k.client, stateHash, effects, &protocolVersion)
-	log += errGrpc
+	msgExecute := NewMsgExecute(
+		msg.ContractAddress,
+		msg.FromAddress,
+		util.HASH,
+		proxyContractHash,
+		hex.EncodeToString(sessionAbi),
+		msg.Fee,
+	)
+	result, log := execute(ctx, k, msgExecute, simulate)
 
-	candidateBlock := ctx.CandidateBlock()
-	candidateBlock.State = postStateHash
-	candidateBlock.Bonds = bonds
+	return getResult(result, log)
+}
 
-	result := false
+func handlerMsgSetActionThreshold(ctx sdk.Context, k ExecutionLayerKeeper, msg types.MsgSetActionThreshold, simulate bool) sdk.Result {
+	proxyContractHash := k.GetProxyContractHash(ctx)
+	actionType, ok := types.ActionTypeToEE(msg.ActionType)
+	if !ok {
+		return getResult(false, "Must be deployment or key_management")
+	}
+
+	sessionArgs := []*consensus.Deploy_Arg{
+		&consensus.Deploy_Arg{
+			Value: &state.CLValueInstance{
+				ClType: &state.CLType{Variants: &state.CLType_SimpleType{SimpleType: state.CLType_STRING}},
+				Value: &state.CLValueInstance_Value{
+					Value: &state.CLValueInstance_Value_StrValue{
+						StrValue: types.SetActionThresholdMethodName}}}},
+		&consensus.Deploy_Arg{
+			Value: &state.CLValueInstance{
+				ClType: &state.CLType{Variants: &state.CLType_SimpleType{SimpleType: state.CLType_U32}},
+				Value: &state.CLValueInstance_Value{
+					Value: &state.CLValueInstance_Value_U32{
+						U32: actionType}}}},
+		&consensus.Deploy_Arg{
+			Value: &state.CLValueInstance{
+				ClType: &state.CLType{Variants: &state.CLType_SimpleType{SimpleType: state.CLType_U8}},
+				Value: &state.CLValueInstance_Value{
+					Value: &state.CLValueInstance_Value_U8{
+						U8: int32(msg.Threshold)}}}}}
+
+	sessionAbi, err := util.AbiDeployArgsTobytes(sessionArgs)
+	if err != nil {
+		return getResult(false, err.Error())
+	}
+
+	msgExecute := NewMsgExecute(
+		msg.ContractAddress,
+		msg.FromAddress,
+		util.HASH,
+		proxyContractHash,
+		hex.EncodeToString(sessionAbi),
+		msg.Fee,
+	)
+	result, log := execute(ctx, k, msgExecute, simulate)
+
+	return getResult(result, log)
+}
+
+// Handle MsgAuthorize
+// Nothing to execute, the signer is used as an authorization key of the other msgs in the tx.
+func handlerMsgAuthorize(ctx sdk.Context, k ExecutionLayerKeeper, msg types.MsgAuthorize, simulate bool) sdk.Result {
+	return getResult(true, "")
+}
+
+// Handle MsgGrant
+// Nothing to execute, the grant replaces the previous grant of the granter to the grantee.
+func handlerMsgGrant(ctx sdk.Context, k ExecutionLayerKeeper, msg types.MsgGrant, simulate bool) sdk.Result {
+	grant := msg.Grant()
+	if grant.IsExpired(ctx.BlockTime()) {
+		return types.ErrGrantExpired(types.DefaultCodespace, grant.Granter, grant.Grantee, grant.Expiration).Result()
+	}
+
+	k.SetGrant(ctx, grant)
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeGrant,
+		sdk.NewAttribute(types.AttributeKeyGranter, msg.Granter.String()),
+		sdk.NewAttribute(types.AttributeKeyGrantee, msg.Grantee.String()),
+	))
+	return getResult(true, "")
+}
+
+// Handle MsgRevoke
+func handlerMsgRevoke(ctx sdk.Context, k ExecutionLayerKeeper, msg types.MsgRevoke, simulate bool) sdk.Result {
+	if _, found := k.GetGrant(ctx, msg.Granter, msg.Grantee); !found {
+		return types.ErrGrantNotFound(types.DefaultCodespace, msg.Granter, msg.Grantee).Result()
+	}
+
+	k.DeleteGrant(ctx, msg.Granter, msg.Grantee)
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeRevoke,
+		sdk.NewAttribute(types.AttributeKeyGranter, msg.Granter.String()),
+		sdk.NewAttribute(types.AttributeKeyGrantee, msg.Grantee.String()),
+	))
+	return getResult(true, "")
+}
+
+// Handle MsgExec
+// Each msg is handled as if its signer, the granter, sent it. The ante handler has checked the
+// grants of the granters to the grantee allow the msgs, and charged their spending to the grants.
+// The deploys of the msgs run as the granters' deploys, authorized by the grantee's signature of
+// the exec instead of the granters' ones.
+func handlerMsgExec(ctx sdk.Context, k ExecutionLayerKeeper, msg types.MsgExec, simulate bool) sdk.Result {
+	if !isCharged(ctx) {
+		return types.ErrInvalidExec(types.DefaultCodespace, "grants are not charged by the ante handler").Result()
+	}
+
+	for i, execMsg := range msg.Msgs {
+		granter := execMsg.GetSigners()[0]
+		execCtx := withExec(ctx, msg.Grantee, i)
+		if deployMsg, ok := execMsg.(types.DeployMsg); ok {
+			if err := validateDeployHeader(ctx, k, deployMsg.GetDeployHeader()); err != nil {
+				return err.Result()
+			}
+			var err sdk.Error
+			if execCtx, err = withFeePayer(execCtx, deployMsg); err != nil {
+				return err.Result()
+			}
+		}
+
+		res := handleMsg(execCtx, k, execMsg, simulate)
+		if !res.IsOK() {
+			return res
+		}
+
+		ctx.EventManager().EmitEvent(sdk.NewEvent(
+			types.EventTypeExec,
+			sdk.NewAttribute(types.AttributeKeyGranter, granter.String()),
+			sdk.NewAttribute(types.AttributeKeyGrantee, msg.Grantee.String()),
+		))
+	}
+	return getResult(true, "")
+}
+
+// Handle MsgGrantFeeAllowance
+// Nothing to execute, the allowance replaces the previous allowance of the payer to the grantee.
+func handlerMsgGrantFeeAllowance(ctx sdk.Context, k ExecutionLayerKeeper, msg types.MsgGrantFeeAllowance, simulate bool) sdk.Result {
+	allowance := msg.FeeAllowance()
+	if allowance.IsExpired(ctx.BlockTime()) {
+		return types.ErrFeeAllowanceExpired(types.DefaultCodespace, allowance.Payer, allowance.Grantee, allowance.Expiration).Result()
+	}
+
+	k.SetFeeAllowance(ctx, allowance)
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeGrantFeeAllowance,
+		sdk.NewAttribute(types.AttributeKeyFeePayer, msg.Payer.String()),
+		sdk.NewAttribute(types.AttributeKeyGrantee, msg.Grantee.String()),
+	))
+	return getResult(true, "")
+}
+
+// Handle MsgRevokeFeeAllowance
+func handlerMsgRevokeFeeAllowance(ctx sdk.Context, k ExecutionLayerKeeper, msg types.MsgRevokeFeeAllowance, simulate bool) sdk.Result {
+	if _, found := k.GetFeeAllowance(ctx, msg.Payer, msg.Grantee); !found {
+		return types.ErrFeeAllowanceNotFound(types.DefaultCodespace, msg.Payer, msg.Grantee).Result()
+	}
+
+	k.DeleteFeeAllowance(ctx, msg.Payer, msg.Grantee)
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeRevokeFeeAllowance,
+		sdk.NewAttribute(types.AttributeKeyFeePayer, msg.Payer.String()),
+		sdk.NewAttribute(types.AttributeKeyGrantee, msg.Grantee.String()),
+	))
+	return getResult(true, "")
+}
+
+// Handle MsgScheduleTransfer
+// The schedule is recorded and queued at the start height to be executed by the EndBlocker, then
+// the amounts and the fees of the payouts are transferred to its own escrow. The schedule is
+// dropped if the transfer fails. The ante handler keeps other msgs out of the tx, so that none
+// can revert the schedule after its escrow is funded.
+func handlerMsgScheduleTransfer(ctx sdk.Context, k ExecutionLayerKeeper, msg types.MsgScheduleTransfer, simulate bool) sdk.Result {
+	if msg.StartHeight < ctx.BlockHeight() {
+		return types.ErrInvalidSchedule(types.DefaultCodespace,
+			fmt.Sprintf("start height %d is before the current height %d", msg.StartHeight, ctx.BlockHeight())).Result()
+	}
+
+	id := k.GetNextScheduleID(ctx)
+	k.SetNextScheduleID(ctx, id+1)
+	schedule := types.NewSchedule(id, msg.FromAddress, msg.ToAddress, msg.Amount, msg.PayoutFee, msg.StartHeight, msg.Interval, msg.Count)
+	k.SetSchedule(ctx, schedule)
+
+	escrowMsg := types.NewMsgTransfer(msg.ContractAddress, msg.FromAddress, schedule.EscrowAddress(), schedule.Escrow, msg.Fee)
+	res := handlerMsgTransfer(ctx, k, escrowMsg, simulate)
+	if !res.IsOK() {
+		k.DeleteSchedule(ctx, id)
+		return res
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeScheduleTransfer,
+		sdk.NewAttribute(types.AttributeKeyScheduleID, strconv.FormatUint(id, 10)),
+		sdk.NewAttribute(types.AttributeKeySender, msg.FromAddress.String()),
+		sdk.NewAttribute(types.AttributeKeyRecipient, msg.ToAddress.String()),
+	))
+	return res
+}
+
+// Handle MsgCancelSchedule
+// The schedule is queued at the current height for the EndBlocker to refund the amounts and the
+// fees of the remaining payouts from its escrow, less the fee of the refund itself. The refund is
+// left to the EndBlocker, as the fee of a failed refund can't be charged to the schedule by a
+// failed tx.
+func handlerMsgCancelSchedule(ctx sdk.Context, k ExecutionLayerKeeper, msg types.MsgCancelSchedule, simulate bool) sdk.Result {
+	schedule, found := k.GetSchedule(ctx, msg.ScheduleID)
+	if !found || !schedule.Sender.Equals(msg.FromAddress) || schedule.Status == types.ScheduleStatusCancelled {
+		return types.ErrScheduleNotFound(types.DefaultCodespace, msg.ScheduleID, msg.FromAddress).Result()
+	}
+	if _, _, _, err := schedule.Amounts(); err != nil {
+		return err.Result()
+	}
+
+	schedule.Status = types.ScheduleStatusCancelled
+	schedule.NextHeight = ctx.BlockHeight()
+	k.SetSchedule(ctx, schedule)
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeCancelSchedule,
+		sdk.NewAttribute(types.AttributeKeyScheduleID, strconv.FormatUint(schedule.ID, 10)),
+		sdk.NewAttribute(types.AttributeKeySender, schedule.Sender.String()),
+	))
+	return getResult(true, "")
+}
+
+// executeScheduledTransfers executes the payouts of the schedules due at the block and the refunds
+// of the cancelled ones, up to the max of the params. The schedules over the max or over the limits
+// of the block are left to the next blocks. The fee of a failed deploy is charged to the escrow of
+// its schedule, which is marked failed, for the sender to cancel it for a refund.
+func executeScheduledTransfers(ctx sdk.Context, k ExecutionLayerKeeper) {
+	for _, schedule := range k.GetDueSchedules(ctx, ctx.BlockHeight(), k.GetParams(ctx).MaxScheduledTransfers) {
+		amount, fee, escrow, err := schedule.Amounts()
+		if err != nil {
+			failSchedule(ctx, k, schedule, err.Error())
+			continue
+		}
+		if err := checkBlockLimits(ctx, k, 0, getGasLimit(schedule.Fee), false); err != nil {
+			return
+		}
+		if schedule.Status == types.ScheduleStatusCancelled {
+			refundSchedule(ctx, k, schedule, fee, escrow)
+			continue
+		}
+
+		payout := types.NewMsgTransfer("transfer", schedule.EscrowAddress(), schedule.Recipient, schedule.Amount, schedule.Fee)
+		payoutHash := types.NewScheduleDeployHash(ctx.ChainID(), schedule.ID, schedule.Executed)
+		res := handlerMsgTransfer(withDeployHash(ctx, payoutHash), k, payout, false)
+		if !res.IsOK() {
+			failSchedule(ctx, k, schedule.Charge(escrow, fee), res.Log)
+			continue
+		}
+
+		ctx.EventManager().EmitEvent(sdk.NewEvent(
+			types.EventTypeExecuteSchedule,
+			sdk.NewAttribute(types.AttributeKeyScheduleID, strconv.FormatUint(schedule.ID, 10)),
+			sdk.NewAttribute(types.AttributeKeyRecipient, schedule.Recipient.String()),
+			sdk.NewAttribute(types.AttributeKeyAmount, schedule.Amount),
+		))
+		if schedule.Remaining == 1 {
+			k.DeleteSchedule(ctx, schedule.ID)
+		} else {
+			k.SetSchedule(ctx, schedule.Next(escrow.Sub(amount.Add(fee))))
+		}
+	}
+}
+
+// refundSchedule refunds the escrow of a cancelled schedule to its sender, less the fee of the
+// refund, and deletes the schedule. An escrow not covering the fee is left as is.
+func refundSchedule(ctx sdk.Context, k ExecutionLayerKeeper, schedule types.Schedule, fee, escrow sdk.Int) {
+	refund := escrow.Sub(fee)
+	if refund.IsPositive() {
+		refundMsg := types.NewMsgTransfer("transfer", schedule.EscrowAddress(), schedule.Sender, refund.String(), schedule.Fee)
+		refundHash := types.NewScheduleDeployHash(ctx.ChainID(), schedule.ID, schedule.Executed+schedule.Remaining)
+		res := handlerMsgTransfer(withDeployHash(ctx, refundHash), k, refundMsg, false)
+		if !res.IsOK() {
+			failSchedule(ctx, k, schedule.Charge(escrow, fee), res.Log)
+			return
+		}
+	}
+
+	k.DeleteSchedule(ctx, schedule.ID)
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeRefundSchedule,
+		sdk.NewAttribute(types.AttributeKeyScheduleID, strconv.FormatUint(schedule.ID, 10)),
+		sdk.NewAttribute(types.AttributeKeySender, schedule.Sender.String()),
+		sdk.NewAttribute(types.AttributeKeyAmount, sdk.MaxInt(refund, sdk.ZeroInt()).String()),
+	))
+}
+
+// failSchedule marks the schedule failed, out of the queue
+func failSchedule(ctx sdk.Context, k ExecutionLayerKeeper, schedule types.Schedule, log string) {
+	schedule.Status = types.ScheduleStatusFailed
+	k.SetSchedule(ctx, schedule)
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeScheduleFailed,
+		sdk.NewAttribute(types.AttributeKeyScheduleID, strconv.FormatUint(schedule.ID, 10)),
+		sdk.NewAttribute(types.AttributeKeyError, log),
+	))
+}
+
+// withFeePayer returns a context funding the fee of the deploy of msg from the fee payer of its
+// deploy header, whose allowance the ante handler charged.
+// Without a fee payer, the context is returned as is.
+func withFeePayer(ctx sdk.Context, msg types.DeployMsg) (sdk.Context, sdk.Error) {
+	header := msg.GetDeployHeader()
+	if header == nil || header.FeePayer.Empty() {
+		return ctx, nil
+	}
+	if !isCharged(ctx) {
+		return ctx, types.ErrInvalidDeployHeader(types.DefaultCodespace, "fee allowances are not charged by the ante handler")
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeUseFeeAllowance,
+		sdk.NewAttribute(types.AttributeKeyFeePayer, header.FeePayer.String()),
+		sdk.NewAttribute(types.AttributeKeyGrantee, msg.GetSigners()[0].String()),
+		sdk.NewAttribute(types.AttributeKeyFee, types.GetDeployFee(msg)),
+	))
+	return ctx.WithValue(feePayerKey{}, header.FeePayer), nil
+}
+
+// feePayerKey is the context key of the fee payer of the deploy of the handled msg
+type feePayerKey struct{}
+
+// getFeePayer returns the fee payer of the deploy of the handled msg, nil for the sender paying the fee
+func getFeePayer(ctx sdk.Context) sdk.AccAddress {
+	payer, _ := ctx.Value(feePayerKey{}).(sdk.AccAddress)
+	return payer
+}
+
+// deployHashKey is the context key of the hash of the deploy of a transfer made by the chain
+type deployHashKey struct{}
+
+// withDeployHash returns a context running the deploy of the handled msg with the hash, for the
+// deploys made by the chain which no signature derives a hash from
+func withDeployHash(ctx sdk.Context, deployHash []byte) sdk.Context {
+	return ctx.WithValue(deployHashKey{}, deployHash)
+}
+
+// execKey is the context key of the exec the handled msg is executed in
+type execKey struct{}
+
+// execInfo - grantee executing the handled msg, and the index of the msg in the exec
+type execInfo struct {
+	grantee sdk.AccAddress
+	index   int
+}
+
+// withExec returns a context handling the msg at index of the exec of the grantee
+func withExec(ctx sdk.Context, grantee sdk.AccAddress, index int) sdk.Context {
+	return ctx.WithValue(execKey{}, execInfo{grantee: grantee, index: index})
+}
+
+// validateDeployHeader checks the header of a deploy against the deploy config of the chainspec,
+// and that the deploys it depends on are executed
+func validateDeployHeader(ctx sdk.Context, k ExecutionLayerKeeper, header *types.DeployHeader) sdk.Error {
+	if header == nil {
+		return nil
+	}
+	if err := header.Validate(k.GetDeployConfig(ctx), ctx.BlockTime()); err != nil {
+		return err
+	}
+	for _, dependency := range header.Dependencies {
+		deployHash, _ := hex.DecodeString(dependency)
+		if _, found := getExecutedDeploy(ctx, k, deployHash); !found {
+			return types.ErrDeployDependencyNotFound(types.DefaultCodespace, dependency)
+		}
+	}
+	return nil
+}
+
+// checkBlockLimits checks the deploy fits in the size and cost limits of the block, with the cost
+// of the deploy bounded by its gas limit. On CheckTx, only the size of the deploy itself is
+// checked, as the block is yet to be built.
+func checkBlockLimits(ctx sdk.Context, k ExecutionLayerKeeper, deploySize uint64, gasLimit uint64, simulate bool) sdk.Error {
+	config := k.GetDeployConfig(ctx)
+	candidateBlock := ctx.CandidateBlock()
+
+	size := deploySize
+	if !simulate {
+		size += candidateBlock.Size
+	}
+	if config.MaxBlockSizeBytes > 0 && size > uint64(config.MaxBlockSizeBytes) {
+		return types.ErrBlockSizeLimitExceeded(types.DefaultCodespace, size, uint64(config.MaxBlockSizeBytes))
+	}
+	if !simulate && config.MaxBlockCost > 0 && addCost(candidateBlock.Cost, gasLimit) > config.MaxBlockCost {
+		return types.ErrBlockCostLimitExceeded(types.DefaultCodespace, addCost(candidateBlock.Cost, gasLimit), config.MaxBlockCost)
+	}
+	return nil
+}
+
+// getGasLimit returns the gas the fee of a deploy pays for at the gas price of the deploys
+func getGasLimit(fee string) uint64 {
+	value, ok := sdk.NewIntFromString(fee)
+	if !ok {
+		return 0
+	}
+	return parseCost(value.QuoRaw(types.BASIC_GAS).String())
+}
+
+// getExecutedDeploy returns the record of the deploy hash if it's executed in the recent blocks or
+// earlier in the block, failed or not
+func getExecutedDeploy(ctx sdk.Context, k ExecutionLayerKeeper, deployHash []byte) (types.DeployRecord, bool) {
+	if record, found := k.GetDeployRecord(ctx, deployHash); found {
+		return record, true
+	}
+	for _, deploy := range ctx.CandidateBlock().ExecutedDeploys {
+		if bytes.Equal(deploy.DeployHash, deployHash) {
+			return types.NewDeployRecord(deploy.DeployHash, ctx.BlockHeight(), deploy.TxHash), true
+		}
+	}
+	return types.DeployRecord{}, false
+}
+
+// getDeploySize returns the size of the session of the deploy of msg
+func getDeploySize(msg types.MsgExecute) uint64 {
+	return uint64(len(msg.SessionCode) + len(msg.SessionArgs)/2)
+}
+
+// parseCost parses the cost of a deploy in the decimal string of the EE. A cost out of the range
+// of uint64 is the max.
+func parseCost(cost string) uint64 {
+	if cost == "" {
+		return 0
+	}
+	value, err := strconv.ParseUint(cost, 10, 64)
+	if err != nil {
+		return math.MaxUint64
+	}
+	return value
+}
+
+// addCost adds the costs, saturating at the max of uint64
+func addCost(a, b uint64) uint64 {
+	if a+b < a {
+		return math.MaxUint64
+	}
+	return a + b
+}
+
+func execute(ctx sdk.Context, k ExecutionLayerKeeper, msg types.MsgExecute, simulate bool) (bool, string) {
+	result, log, _ := executeWithEffects(ctx, k, msg, simulate)
+	return result, log
+}
+
+// executeWithEffects runs the deploy of msg and also returns the effects of the execution.
+// With a fee payer, the fee is first funded by a deploy of the payer's account. In simulation the
+// deploy of msg is not run, as it can't see the fee funded on an uncommitted state.
+func executeWithEffects(ctx sdk.Context, k ExecutionLayerKeeper, msg types.MsgExecute, simulate bool) (bool, string, []*transforms.TransformEntry) {
+	if feePayer := getFeePayer(ctx); !feePayer.Empty() {
+		if result, log := fundFee(ctx, k, feePayer, msg, simulate); !result || simulate {
+			return result, log, nil
+		}
+		ctx = ctx.WithValue(feePayerKey{}, sdk.AccAddress(nil))
+	}
+
+	// Parameter preparation
+	var stateHash []byte
+	var protocolVersion state.ProtocolVersion
+	if simulate {
+		stateHash = k.GetUnitHashMap(ctx, ctx.BlockHeight()).EEState
+		protocolVersion = k.GetProtocolVersion(ctx)
+	} else {
+		stateHash = ctx.CandidateBlock().State
+		protocolVersion = *ctx.CandidateBlock().ProtocolVersion
+	}
+	log := ""
+
+	deploySize := getDeploySize(msg)
+	if err := checkBlockLimits(ctx, k, deploySize, getGasLimit(msg.Fee), simulate); err != nil {
+		return false, err.Error(), nil
+	}
+
+	reqExecute, err := newExecuteRequest(ctx, k, msg, stateHash, protocolVersion)
+	if err != nil {
+		return false, err.Error(), nil
+	}
+	for _, deploy := range reqExecute.GetDeploys() {
+		if record, found := getExecutedDeploy(ctx, k, deploy.GetDeployHash()); found {
+			return false, types.ErrDuplicateDeploy(types.DefaultCodespace, record.DeployHash, record.Height).Error(), nil
+		}
+	}
+	resExecute, err := k.client.Execute(ctx.Context(), reqExecute)
+	if err != nil {
+		return false, err.Error(), nil
+	}
+
+	effects := []*transforms.TransformEntry{}
+	cost := uint64(0)
+	// results of the deploys reported to the metrics
+	deployResults := []string{}
+	switch resExecute.GetResult().(type) {
+	case *ipc.ExecuteResponse_Success:
+		for _, res := range resExecute.GetSuccess().GetDeployResults() {
+			cost = addCost(cost, parseCost(res.GetExecutionResult().GetCost().GetValue()))
+			switch res.GetExecutionResult().GetError().GetValue().(type) {
+			case *ipc.DeployError_GasError:
+				err = types.ErrGRpcExecuteDeployGasError(types.DefaultCodespace)
+				deployResults = append(deployResults, "gas_error")
+			case *ipc.DeployError_ExecError:
+				err = types.ErrGRpcExecuteDeployExecError(types.DefaultCodespace, res.GetExecutionResult().GetError().GetExecError().GetMessage())
+				deployResults = append(deployResults, "exec_error")
+			default:
+				deployResults = append(deployResults, "ok")
+			}
+
+			effects = append(effects, res.GetExecutionResult().GetEffects().GetTransformMap()...)
+			if err != nil {
+				log += fmt.Sprintf(log, err.Error())
+			}
+		}
+	case *ipc.ExecuteResponse_MissingParent:
+		err = types.ErrGRpcExecuteMissingParent(types.DefaultCodespace, util.EncodeToHexString(resExecute.GetMissingParent().GetHash()))
+		log += err.Error()
+		deployResults = append(deployResults, "missing_parent")
+	default:
+		err = fmt.Errorf("Unknown result : %s", resExecute.String())
+		log += err.Error()
+		deployResults = append(deployResults, "error")
+	}
+
+	if simulate {
+		return log == "", log, effects
+	}
+
+	for _, result := range deployResults {
+		k.metrics.Deploys.With("result", result).Add(1)
+	}
+
+	// Commit
+	postStateHash, bonds, errGrpc := grpc.Commit(k.client, stateHash, effects, &protocolVersion)
+	log += errGrpc
+
+	candidateBlock := ctx.CandidateBlock()
+	candidateBlock.State = postStateHash
+	candidateBlock.Bonds = bonds
+	candidateBlock.Size += deploySize
+	candidateBlock.Cost = addCost(candidateBlock.Cost, cost)
+	candidateBlock.Deploys += uint64(len(reqExecute.GetDeploys()))
+
+	// the deploys are committed to the EE even if they failed, so the failed ones are recorded as
+	// well, by the EndBlocker as the failed tx reverts its records
+	var txHash []byte
+	if len(ctx.TxBytes()) > 0 {
+		txHash = tmhash.Sum(ctx.TxBytes())
+	}
+	if errGrpc == "" {
+		for _, deploy := range reqExecute.GetDeploys() {
+			candidateBlock.ExecutedDeploys = append(candidateBlock.ExecutedDeploys, sdk.ExecutedDeploy{DeployHash: deploy.GetDeployHash(), TxHash: txHash})
+		}
+	}
+
+	result := false
 	if log == "" {
 		result = true
+		for _, deploy := range reqExecute.GetDeploys() {
+			record := types.NewDeployRecord(deploy.GetDeployHash(), ctx.BlockHeight(), txHash)
+			k.SetDeployRecord(ctx, record)
+			ctx.EventManager().EmitEvent(sdk.NewEvent(
+				types.EventTypeExecuteDeploy,
+				sdk.NewAttribute(types.AttributeKeyDeployHash, record.DeployHash),
+			))
+		}
 	}
 
-	return result, log
+	return result, log, effects
+}
+
+// newExecuteRequest builds the request running the deploy of msg on the given state
+func newExecuteRequest(ctx sdk.Context, k ExecutionLayerKeeper, msg types.MsgExecute, stateHash []byte, protocolVersion state.ProtocolVersion) (*ipc.ExecuteRequest, error) {
+	proxyContractHash := k.GetProxyContractHash(ctx)
+
+	paymentAbi, err := util.AbiDeployArgsTobytes(getPaymentArgs(msg.Fee))
+	if err != nil {
+		return nil, err
+	}
+
+	sessionAbi, err := hex.DecodeString(msg.SessionArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	deployHash := getDeployHash(ctx, k, msg.ExecAddress)
+	authorizationKeys := getAuthorizationKeys(ctx, k, msg.ExecAddress, stateHash, protocolVersion)
+
+	// Execute
+	deploys := []*ipc.DeployItem{
+		&ipc.DeployItem{
+			Address:           msg.ExecAddress,
+			Session:           util.MakeDeployPayload(msg.SessionType, msg.SessionCode, sessionAbi),
+			Payment:           util.MakeDeployPayload(util.HASH, proxyContractHash, paymentAbi),
+			AuthorizationKeys: authorizationKeys,
+			DeployHash:        deployHash,
+			GasPrice:          types.BASIC_GAS,
+		},
+	}
+	reqExecute := &ipc.ExecuteRequest{
+		ParentStateHash: stateHash,
+		BlockTime:       uint64(ctx.BlockTime().Unix()),
+		Deploys:         deploys,
+		ProtocolVersion: &protocolVersion,
+	}
+
+	return reqExecute, nil
+}
+
+// getPaymentArgs returns the args of the proxy contract paying the fee of a deploy
+func getPaymentArgs(fee string) []*consensus.Deploy_Arg {
+	return []*consensus.Deploy_Arg{
+		&consensus.Deploy_Arg{
+			Value: &state.CLValueInstance{
+				ClType: &state.CLType{Variants: &state.CLType_SimpleType{SimpleType: state.CLType_STRING}},
+				Value: &state.CLValueInstance_Value{
+					Value: &state.CLValueInstance_Value_StrValue{
+						StrValue: types.PaymentMethodName}}}},
+		&consensus.Deploy_Arg{
+			Value: &state.CLValueInstance{
+				ClType: &state.CLType{Variants: &state.CLType_SimpleType{SimpleType: state.CLType_U512}},
+				Value: &state.CLValueInstance_Value{
+					Value: &state.CLValueInstance_Value_U512{
+						U512: &state.CLValueInstance_U512{
+							Value: fee}}}}}}
+}
+
+// fundFee transfers the fee of the deploy of msg from the fee payer to the sender, in a deploy of
+// the payer's own account paying the same fee, so that the deploy of msg pays its fee from the
+// sender's account as any other deploy
+func fundFee(ctx sdk.Context, k ExecutionLayerKeeper, feePayer sdk.AccAddress, msg types.MsgExecute, simulate bool) (bool, string) {
+	fundingHash := types.NewFeeFundingDeployHash(getDeployHash(ctx, k, msg.ExecAddress), feePayer)
+	fundingCtx := withDeployHash(ctx.WithValue(feePayerKey{}, sdk.AccAddress(nil)), fundingHash)
+
+	funding := types.NewMsgTransfer("transfer", feePayer, msg.ExecAddress, msg.Fee, msg.Fee)
+	res := handlerMsgTransfer(fundingCtx, k, funding, simulate)
+	return res.IsOK(), res.Log
+}
+
+// getDeployHash derives the hash of the deploy of the msg being handled from the signed tx, with the
+// sequence execAddress signed it with. Without the signature, as in simulation, the current
+// sequence of the account is used. The deploy of a msg executed by a grantee is derived from the
+// grantee's signature of the exec instead, as the granter doesn't sign. A deploy made by the chain,
+// as a scheduled transfer, has the hash set to the context.
+func getDeployHash(ctx sdk.Context, k ExecutionLayerKeeper, execAddress sdk.AccAddress) []byte {
+	if deployHash, ok := ctx.Value(deployHashKey{}).([]byte); ok {
+		return deployHash
+	}
+	if exec, ok := ctx.Value(execKey{}).(execInfo); ok {
+		return types.NewExecDeployHash(getSignerDeployHash(ctx, k, exec.grantee), execAddress, exec.index)
+	}
+	return getSignerDeployHash(ctx, k, execAddress)
+}
+
+func getSignerDeployHash(ctx sdk.Context, k ExecutionLayerKeeper, execAddress sdk.AccAddress) []byte {
+	signers, sequences := auth.GetTxSigners(ctx), auth.GetTxSequences(ctx)
+	for i, signer := range signers {
+		if signer.Equals(execAddress) && i < len(sequences) {
+			return types.NewDeployHash(ctx.ChainID(), execAddress, sequences[i], ctx.MsgIndex())
+		}
+	}
+
+	sequence := uint64(0)
+	if account := k.AccountKeeper.GetAccount(ctx, execAddress); account != nil {
+		sequence = account.GetSequence()
+	}
+	return types.NewDeployHash(ctx.ChainID(), execAddress, sequence, ctx.MsgIndex())
+}
+
+// getAuthorizationKeys returns the keys authorizing a deploy of execAddress.
+// Besides execAddress itself, every other signer of the tx which is an associated key
+// of the account is passed, so that the weights of co-signers count toward the action thresholds.
+func getAuthorizationKeys(ctx sdk.Context, k ExecutionLayerKeeper, execAddress sdk.AccAddress, stateHash []byte, protocolVersion state.ProtocolVersion) [][]byte {
+	authorizationKeys := [][]byte{execAddress}
+
+	var coSigners []sdk.AccAddress
+	for _, signer := range auth.GetTxSigners(ctx) {
+		if !signer.Equals(execAddress) {
+			coSigners = append(coSigners, signer)
+		}
+	}
+	if len(coSigners) == 0 {
+		return authorizationKeys
+	}
+
+	res, errStr := grpc.Query(k.client, stateHash, "address", execAddress, []string{}, &protocolVersion)
+	if errStr != "" {
+		return authorizationKeys
+	}
+	var storedValue storedvalue.StoredValue
+	storedValue, err, _ := storedValue.FromBytes(res)
+	if err != nil || storedValue.Type != storedvalue.TYPE_ACCOUNT {
+		return authorizationKeys
+	}
+
+	for _, signer := range coSigners {
+		for _, associatedKey := range storedValue.Account.AssociatedKeys {
+			if signer.Equals(sdk.AccAddress(associatedKey.PublicKey)) {
+				authorizationKeys = append(authorizationKeys, signer)
+				break
+			}
+		}
+	}
+
+	return authorizationKeys
+}
+
+// dryRunExecute runs the deploy of msg on the state of the context's height without committing it,
+// and returns the cost, the effects and the error of the execution
+func dryRunExecute(ctx sdk.Context, k ExecutionLayerKeeper, msg types.MsgExecute) (types.DryRunResult, error) {
+	stateHash := k.GetUnitHashMap(ctx, ctx.BlockHeight()).EEState
+	protocolVersion := k.GetProtocolVersion(ctx)
+
+	reqExecute, err := newExecuteRequest(ctx, k, msg, stateHash, protocolVersion)
+	if err != nil {
+		return types.DryRunResult{}, err
+	}
+	resExecute, err := k.client.Execute(ctx.Context(), reqExecute)
+	if err != nil {
+		return types.DryRunResult{}, err
+	}
+
+	switch resExecute.GetResult().(type) {
+	case *ipc.ExecuteResponse_Success:
+	case *ipc.ExecuteResponse_MissingParent:
+		return types.DryRunResult{}, types.ErrGRpcExecuteMissingParent(types.DefaultCodespace, util.EncodeToHexString(resExecute.GetMissingParent().GetHash()))
+	default:
+		return types.DryRunResult{}, fmt.Errorf("Unknown result : %s", resExecute.String())
+	}
+
+	result := types.DryRunResult{Success: true, Cost: "0", Effects: []types.DryRunEffect{}}
+	for _, res := range resExecute.GetSuccess().GetDeployResults() {
+		if res.GetPreconditionFailure() != nil {
+			result.Success = false
+			result.Error = res.GetPreconditionFailure().GetMessage()
+			continue
+		}
+
+		executionResult := res.GetExecutionResult()
+		result.Cost = executionResult.GetCost().GetValue()
+		switch executionResult.GetError().GetValue().(type) {
+		case *ipc.DeployError_GasError:
+			result.Success = false
+			result.Error = types.ErrGRpcExecuteDeployGasError(types.DefaultCodespace).Error()
+		case *ipc.DeployError_ExecError:
+			result.Success = false
+			result.Error = types.ErrGRpcExecuteDeployExecError(types.DefaultCodespace, executionResult.GetError().GetExecError().GetMessage()).Error()
+		}
+
+		effects, err := getDryRunEffects(executionResult.GetEffects().GetTransformMap())
+		if err != nil {
+			return types.DryRunResult{}, err
+		}
+		result.Effects = append(result.Effects, effects...)
+	}
+	result.ReturnValues = getDryRunReturnValues(result.Effects)
+
+	return result, nil
 }
 
 func executeStep(ctx sdk.Context, k ExecutionLayerKeeper) (bool, error) {
@@ -792,6 +1771,30 @@ func getResult(ok bool, log string) sdk.Result {
 	return res
 }
 
+// getCommissionRateSessionArgs returns the session args setting the commission rate of the validator
+// in the PoS contract. The rate is passed in whole percents.
+func getCommissionRateSessionArgs(rate sdk.Dec) ([]byte, error) {
+	percents, err := types.ContractCommissionRate(rate)
+	if err != nil {
+		return nil, err
+	}
+	sessionArgs := []*consensus.Deploy_Arg{
+		&consensus.Deploy_Arg{
+			Value: &state.CLValueInstance{
+				ClType: &state.CLType{Variants: &state.CLType_SimpleType{SimpleType: state.CLType_STRING}},
+				Value: &state.CLValueInstance_Value{
+					Value: &state.CLValueInstance_Value_StrValue{
+						StrValue: types.SetCommissionRateMethodName}}}},
+		&consensus.Deploy_Arg{
+			Value: &state.CLValueInstance{
+				ClType: &state.CLType{Variants: &state.CLType_SimpleType{SimpleType: state.CLType_U512}},
+				Value: &state.CLValueInstance_Value{
+					Value: &state.CLValueInstance_Value_U512{
+						U512: &state.CLValueInstance_U512{
+							Value: percents.String()}}}}}}
+	return util.AbiDeployArgsTobytes(sessionArgs)
+}
+
 func getPayAmountSessionArgsStr(amount string) ([]byte, error) {
 	sessionArgs := []*consensus.Deploy_Arg{
 		&consensus.Deploy_Arg{
@@ -1,7 +1,11 @@
 package executionlayer
 
 import (
+	"encoding/binary"
+	"encoding/hex"
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/hdac-io/casperlabs-ee-grpc-go-util/grpc"
 	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/casper/consensus/state"
@@ -10,34 +14,79 @@ import (
 	"github.com/hdac-io/tendermint/crypto"
 
 	"github.com/hdac-io/friday/codec"
+	"github.com/hdac-io/friday/store"
 	sdk "github.com/hdac-io/friday/types"
 	"github.com/hdac-io/friday/x/auth"
 	"github.com/hdac-io/friday/x/executionlayer/types"
 	"github.com/hdac-io/friday/x/nickname"
+	"github.com/hdac-io/friday/x/params"
 )
 
 type ExecutionLayerKeeper struct {
 	HashMapStoreKey sdk.StoreKey
+	paramSpace      params.Subspace
 	client          ipc.ExecutionEngineServiceClient
 	AccountKeeper   auth.AccountKeeper
 	NicknameKeeper  nickname.NicknameKeeper
 	cdc             *codec.Codec
+	pruning         sdk.PruningOptions
+	metrics         *Metrics
 }
 
 func NewExecutionLayerKeeper(
-	cdc *codec.Codec, hashMapStoreKey sdk.StoreKey, path string,
+	cdc *codec.Codec, hashMapStoreKey sdk.StoreKey, paramSpace params.Subspace, path string,
 	accountKeeper auth.AccountKeeper,
 	nicknameKeeper nickname.NicknameKeeper) ExecutionLayerKeeper {
 
 	return ExecutionLayerKeeper{
 		HashMapStoreKey: hashMapStoreKey,
+		paramSpace:      paramSpace.WithKeyTable(types.ParamKeyTable()),
 		client:          grpc.Connect(path),
 		AccountKeeper:   accountKeeper,
 		NicknameKeeper:  nicknameKeeper,
 		cdc:             cdc,
+		pruning:         store.PruneNothing,
+		metrics:         NopMetrics(),
 	}
 }
 
+// SetPruning sets the pruning options the EE states of the past heights follow.
+// It should be the same as the pruning options of the multistore.
+func (k *ExecutionLayerKeeper) SetPruning(pruning sdk.PruningOptions) {
+	k.pruning = pruning
+}
+
+// SetMetrics sets the metrics the keeper reports to, including the duration of the requests to the EE
+func (k *ExecutionLayerKeeper) SetMetrics(metrics *Metrics) {
+	k.metrics = metrics
+	k.client = instrumentedClient{client: k.client, metrics: metrics}
+}
+
+// GetParams returns the total set of executionlayer parameters.
+func (k ExecutionLayerKeeper) GetParams(ctx sdk.Context) (params types.Params) {
+	k.paramSpace.GetParamSet(ctx, &params)
+	return params
+}
+
+// MigrateParams sets the parameters missing from the store of a chain started with an older
+// version of the module to their defaults, and returns their keys
+func (k ExecutionLayerKeeper) MigrateParams(ctx sdk.Context) (migrated []string) {
+	defaults := types.DefaultParams()
+	for _, pair := range defaults.ParamSetPairs() {
+		if k.paramSpace.Has(ctx, pair.Key) {
+			continue
+		}
+		k.paramSpace.Set(ctx, pair.Key, pair.Value)
+		migrated = append(migrated, string(pair.Key))
+	}
+	return migrated
+}
+
+// SetParams sets the total set of executionlayer parameters.
+func (k ExecutionLayerKeeper) SetParams(ctx sdk.Context, params types.Params) {
+	k.paramSpace.SetParamSet(ctx, &params)
+}
+
 // -----------------------------------------------------------------------------------------------------------
 
 // SetUnitHashMap map unitHash to blockHash
@@ -85,6 +134,25 @@ func (k ExecutionLayerKeeper) SetGenesisConf(ctx sdk.Context, genesisConf types.
 	store.Set([]byte(types.GenesisConfigKey), genesisConfBytes)
 }
 
+// GetDeployConfig retrieves the deploy limits of the chainspec from sdk store.
+// They're kept apart from GenesisConf not to read the system contracts for every deploy.
+func (k ExecutionLayerKeeper) GetDeployConfig(ctx sdk.Context) types.DeployConfig {
+	store := ctx.KVStore(k.HashMapStoreKey)
+	deployConfigBytes := store.Get([]byte(types.DeployConfigKey))
+
+	var deployConfig types.DeployConfig
+	if deployConfigBytes != nil {
+		k.cdc.MustUnmarshalBinaryBare(deployConfigBytes, &deployConfig)
+	}
+	return deployConfig
+}
+
+// SetDeployConfig saves the deploy limits of the chainspec in sdk store
+func (k ExecutionLayerKeeper) SetDeployConfig(ctx sdk.Context, deployConfig types.DeployConfig) {
+	store := ctx.KVStore(k.HashMapStoreKey)
+	store.Set([]byte(types.DeployConfigKey), k.cdc.MustMarshalBinaryBare(deployConfig))
+}
+
 // GetGenesisAccounts retrieves GenesisAccounts in sdk store
 func (k ExecutionLayerKeeper) GetGenesisAccounts(ctx sdk.Context) []types.Account {
 	store := ctx.KVStore(k.HashMapStoreKey)
@@ -215,6 +283,209 @@ func (k ExecutionLayerKeeper) SetValidatorByConsAddr(ctx sdk.Context, validator
 	store.Set(types.GetValidatorByConsAddrKey(consAddr), validator.OperatorAddress)
 }
 
+func (k ExecutionLayerKeeper) DeleteValidatorByConsAddr(ctx sdk.Context, consAddr sdk.ConsAddress) {
+	store := ctx.KVStore(k.HashMapStoreKey)
+	store.Delete(types.GetValidatorByConsAddrKey(consAddr))
+}
+
+// MigrateCommissions sets the commissions of the validators created before the commission rates
+// to the default rates, recording the rate set, and returns their operators
+func (k ExecutionLayerKeeper) MigrateCommissions(ctx sdk.Context) (migrated []sdk.AccAddress) {
+	rates := types.DefaultCommissionRates()
+	for _, validator := range k.GetAllValidators(ctx) {
+		if !validator.Commission.IsUnset() {
+			continue
+		}
+		validator.Commission = types.NewCommissionWithTime(rates.Rate, rates.MaxRate, rates.MaxChangeRate, ctx.BlockTime())
+		k.SetValidator(ctx, validator.OperatorAddress, validator)
+		k.SetCommissionRateChange(ctx, validator.OperatorAddress,
+			types.NewCommissionRateChange(ctx.BlockHeight(), ctx.BlockTime(), rates.Rate))
+		migrated = append(migrated, validator.OperatorAddress)
+	}
+	return migrated
+}
+
+// SetCommissionRateChange records the commission rate the validator set at the height of the change
+func (k ExecutionLayerKeeper) SetCommissionRateChange(ctx sdk.Context, operator sdk.AccAddress, change types.CommissionRateChange) {
+	store := ctx.KVStore(k.HashMapStoreKey)
+	store.Set(types.GetCommissionRateChangeKey(operator, change.Height), k.cdc.MustMarshalBinaryLengthPrefixed(change))
+}
+
+// GetCommissionRateChanges returns the commission rate changes of the validator in ascending order of height
+func (k ExecutionLayerKeeper) GetCommissionRateChanges(ctx sdk.Context, operator sdk.AccAddress) (changes []types.CommissionRateChange) {
+	store := ctx.KVStore(k.HashMapStoreKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.GetCommissionRateHistoryPrefix(operator))
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var change types.CommissionRateChange
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &change)
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// GetConsKeyRotationHeight returns the height the validator last rotated its consensus pubkey at
+func (k ExecutionLayerKeeper) GetConsKeyRotationHeight(ctx sdk.Context, operator sdk.AccAddress) (height int64, found bool) {
+	store := ctx.KVStore(k.HashMapStoreKey)
+	bz := store.Get(types.GetConsKeyRotationKey(operator))
+	if bz == nil {
+		return 0, false
+	}
+	return int64(binary.BigEndian.Uint64(bz)), true
+}
+
+// RotateValidatorConsPubKey replaces the consensus pubkey of the validator with the new one
+// and keeps the old one until the validator updates of the block are sent to tendermint.
+// The key the validator had at the beginning of the block is kept, if it rotates more than once in a block.
+func (k ExecutionLayerKeeper) RotateValidatorConsPubKey(ctx sdk.Context, validator types.Validator, newConsPubKey crypto.PubKey) {
+	store := ctx.KVStore(k.HashMapStoreKey)
+	pendingKey := types.GetPendingConsKeyRotationKey(validator.OperatorAddress)
+	if !store.Has(pendingKey) {
+		rotation := types.NewConsKeyRotation(validator.OperatorAddress, validator.ConsPubKey)
+		store.Set(pendingKey, k.cdc.MustMarshalBinaryLengthPrefixed(rotation))
+	}
+	store.Set(types.GetConsKeyRotationKey(validator.OperatorAddress), sdk.Uint64ToBigEndian(uint64(ctx.BlockHeight())))
+
+	k.DeleteValidatorByConsAddr(ctx, validator.ConsAddress())
+	validator.ConsPubKey = newConsPubKey
+	k.SetValidator(ctx, validator.OperatorAddress, validator)
+	k.SetValidatorByConsAddr(ctx, validator)
+}
+
+// GetPendingConsKeyRotations returns the rotations of the block whose validator updates are not sent yet
+func (k ExecutionLayerKeeper) GetPendingConsKeyRotations(ctx sdk.Context) (rotations []types.ConsKeyRotation) {
+	store := ctx.KVStore(k.HashMapStoreKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.PendingConsKeyRotationKey)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var rotation types.ConsKeyRotation
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &rotation)
+		rotations = append(rotations, rotation)
+	}
+	return rotations
+}
+
+// DeletePendingConsKeyRotation deletes the rotation of the validator once its updates are sent
+func (k ExecutionLayerKeeper) DeletePendingConsKeyRotation(ctx sdk.Context, operator sdk.AccAddress) {
+	store := ctx.KVStore(k.HashMapStoreKey)
+	store.Delete(types.GetPendingConsKeyRotationKey(operator))
+}
+
+// AddStakeRequest records an unbonding, undelegation or redelegation deployed at the height of the
+// context, pending until the UnbondingDelay blocks after it, and returns it with its id
+func (k ExecutionLayerKeeper) AddStakeRequest(ctx sdk.Context, kind string, delegator, validator, dstValidator sdk.AccAddress,
+	amount string) types.StakeRequest {
+	id := k.GetNextStakeRequestID(ctx)
+	k.SetNextStakeRequestID(ctx, id+1)
+
+	request := types.NewStakeRequest(id, kind, delegator, validator, dstValidator, amount,
+		ctx.BlockHeight(), ctx.BlockHeight()+k.GetParams(ctx).UnbondingDelay)
+	k.SetStakeRequest(ctx, request)
+	return request
+}
+
+// GetStakeRequest returns the stake request of the id
+func (k ExecutionLayerKeeper) GetStakeRequest(ctx sdk.Context, id uint64) (request types.StakeRequest, found bool) {
+	store := ctx.KVStore(k.HashMapStoreKey)
+	bz := store.Get(types.GetStakeRequestKey(id))
+	if bz == nil {
+		return request, false
+	}
+	k.cdc.MustUnmarshalBinaryBare(bz, &request)
+	return request, true
+}
+
+// SetStakeRequest saves the stake request with its delegator index entry, queued at its completion height
+func (k ExecutionLayerKeeper) SetStakeRequest(ctx sdk.Context, request types.StakeRequest) {
+	store := ctx.KVStore(k.HashMapStoreKey)
+	primaryKey := types.GetStakeRequestKey(request.ID)
+	store.Set(primaryKey, k.cdc.MustMarshalBinaryBare(request))
+	store.Set(types.GetStakeRequestByDelegatorKey(request.DelegatorAddress, request.ID), primaryKey)
+	store.Set(types.GetStakeRequestQueueKey(request.CompletionHeight, request.ID), primaryKey)
+}
+
+// DeleteStakeRequest deletes the stake request of the id with its index and queue entries
+func (k ExecutionLayerKeeper) DeleteStakeRequest(ctx sdk.Context, id uint64) {
+	request, found := k.GetStakeRequest(ctx, id)
+	if !found {
+		return
+	}
+
+	store := ctx.KVStore(k.HashMapStoreKey)
+	store.Delete(types.GetStakeRequestKey(id))
+	store.Delete(types.GetStakeRequestByDelegatorKey(request.DelegatorAddress, id))
+	store.Delete(types.GetStakeRequestQueueKey(request.CompletionHeight, id))
+}
+
+// GetNextStakeRequestID returns the id of the next stake request
+func (k ExecutionLayerKeeper) GetNextStakeRequestID(ctx sdk.Context) uint64 {
+	store := ctx.KVStore(k.HashMapStoreKey)
+	bz := store.Get(types.NextStakeRequestIDKey)
+	if bz == nil {
+		return 1
+	}
+	return binary.BigEndian.Uint64(bz)
+}
+
+// SetNextStakeRequestID saves the id of the next stake request
+func (k ExecutionLayerKeeper) SetNextStakeRequestID(ctx sdk.Context, id uint64) {
+	store := ctx.KVStore(k.HashMapStoreKey)
+	store.Set(types.NextStakeRequestIDKey, sdk.Uint64ToBigEndian(id))
+}
+
+// GetStakeRequestsByDelegator returns the pending stake requests of the delegator
+func (k ExecutionLayerKeeper) GetStakeRequestsByDelegator(ctx sdk.Context, delegator sdk.AccAddress) (requests types.StakeRequests) {
+	store := ctx.KVStore(k.HashMapStoreKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.GetStakeRequestsByDelegatorPrefix(delegator))
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		bz := store.Get(iterator.Value())
+		if bz == nil {
+			continue
+		}
+		var request types.StakeRequest
+		k.cdc.MustUnmarshalBinaryBare(bz, &request)
+		requests = append(requests, request)
+	}
+	return requests
+}
+
+// GetAllStakeRequests returns all pending stake requests
+func (k ExecutionLayerKeeper) GetAllStakeRequests(ctx sdk.Context) (requests types.StakeRequests) {
+	store := ctx.KVStore(k.HashMapStoreKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.StakeRequestKey)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var request types.StakeRequest
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &request)
+		requests = append(requests, request)
+	}
+	return requests
+}
+
+// GetMatureStakeRequests returns the stake requests completed at the height or before it, in the
+// order of their completion heights and ids
+func (k ExecutionLayerKeeper) GetMatureStakeRequests(ctx sdk.Context, height int64) (requests types.StakeRequests) {
+	store := ctx.KVStore(k.HashMapStoreKey)
+	iterator := store.Iterator(types.StakeRequestQueueKey, sdk.PrefixEndBytes(types.GetStakeRequestQueueHeightPrefix(height)))
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		bz := store.Get(iterator.Value())
+		if bz == nil {
+			continue
+		}
+		var request types.StakeRequest
+		k.cdc.MustUnmarshalBinaryBare(bz, &request)
+		requests = append(requests, request)
+	}
+	return requests
+}
+
 // -----------------------------------------------------------------------------------------------------------
 
 // GetProxyContractHash retrieves proxy_contract_hash
@@ -231,6 +502,48 @@ func (k ExecutionLayerKeeper) SetProxyContractHash(ctx sdk.Context, contractHash
 	store.Set([]byte(types.ProxyContractHashKey), contractHash)
 }
 
+// GetEEState returns the EE post-state hash of the height
+func (k ExecutionLayerKeeper) GetEEState(ctx sdk.Context, height int64) ([]byte, sdk.Error) {
+	eeState := k.GetUnitHashMap(ctx, height).EEState
+	if len(eeState) == 0 {
+		return nil, types.ErrEEStateNotFound(types.DefaultCodespace, height)
+	}
+	return eeState, nil
+}
+
+// PruneUnitHashMap deletes the EE states of every height up to the one which the pruning options
+// stop keeping at the current height, except the heights kept every KeepEvery, and returns the
+// heights pruned in order. The heights of the keys are little-endian and not in order, so every EE
+// state kept is iterated. The roots stay in the EE, which has no call to release them.
+func (k ExecutionLayerKeeper) PruneUnitHashMap(ctx sdk.Context) []int64 {
+	height := ctx.BlockHeight() - k.pruning.KeepRecent()
+	if height == ctx.BlockHeight() {
+		// keep the latest state for the next block
+		height--
+	}
+	if height < 0 || k.pruning.KeepEvery() == 1 {
+		return nil
+	}
+
+	store := ctx.KVStore(k.HashMapStoreKey)
+	pruned := []int64{}
+	iterator := sdk.KVStorePrefixIterator(store, types.EEStateKey)
+	for ; iterator.Valid(); iterator.Next() {
+		stateHeight := types.GetHeightFromEEStateKey(iterator.Key())
+		if stateHeight > height || (k.pruning.KeepEvery() > 0 && stateHeight%k.pruning.KeepEvery() == 0) {
+			continue
+		}
+		pruned = append(pruned, stateHeight)
+	}
+	iterator.Close()
+
+	sort.Slice(pruned, func(i, j int) bool { return pruned[i] < pruned[j] })
+	for _, stateHeight := range pruned {
+		store.Delete(types.GetEEStateKey(stateHeight))
+	}
+	return pruned
+}
+
 // -----------------------------------------------------------------------------------------------------------
 // GetProtocolVersion retrieves protocol version
 func (k ExecutionLayerKeeper) GetProtocolVersion(ctx sdk.Context) state.ProtocolVersion {
@@ -248,3 +561,634 @@ func (k ExecutionLayerKeeper) SetProtocolVersion(ctx sdk.Context, protocolVersio
 	protocolVersionBytes := k.cdc.MustMarshalBinaryBare(protocolVersion)
 	store.Set([]byte(types.ProtoclVersionKey), protocolVersionBytes)
 }
+
+// -----------------------------------------------------------------------------------------------------------
+
+// GetContractInfo retrieves a registered contract by deployer and name
+func (k ExecutionLayerKeeper) GetContractInfo(ctx sdk.Context, deployer sdk.AccAddress, name string) (contractInfo types.ContractInfo, found bool) {
+	store := ctx.KVStore(k.HashMapStoreKey)
+	contractInfoBytes := store.Get(types.GetContractInfoKey(deployer, name))
+	if contractInfoBytes == nil {
+		return contractInfo, false
+	}
+	contractInfo = types.MustUnmarshalContractInfo(k.cdc, contractInfoBytes)
+
+	return contractInfo, true
+}
+
+// SetContractInfo saves a contract in the registry with its code hash, name and contract key indexes.
+// Registering the same name again by the same deployer overwrites the previous entry.
+func (k ExecutionLayerKeeper) SetContractInfo(ctx sdk.Context, contractInfo types.ContractInfo) {
+	store := ctx.KVStore(k.HashMapStoreKey)
+	if prev, found := k.GetContractInfo(ctx, contractInfo.Deployer, contractInfo.Name); found {
+		store.Delete(types.GetContractByCodeHashIndexKey(prev.CodeHash, prev.Deployer, prev.Name))
+		for _, contractKey := range prev.ContractKeys {
+			store.Delete(types.GetContractByKeyIndexKey(contractKey))
+		}
+	}
+
+	primaryKey := types.GetContractInfoKey(contractInfo.Deployer, contractInfo.Name)
+	store.Set(primaryKey, types.MustMarshalContractInfo(k.cdc, contractInfo))
+	store.Set(types.GetContractByCodeHashIndexKey(contractInfo.CodeHash, contractInfo.Deployer, contractInfo.Name), primaryKey)
+	store.Set(types.GetContractByNameIndexKey(contractInfo.Name, contractInfo.Deployer), primaryKey)
+	for _, contractKey := range contractInfo.ContractKeys {
+		store.Set(types.GetContractByKeyIndexKey(contractKey), primaryKey)
+	}
+}
+
+// GetContractInfoByKey retrieves the registered contract stored under the bech32 contract hash or
+// uref address
+func (k ExecutionLayerKeeper) GetContractInfoByKey(ctx sdk.Context, contractKey string) (contractInfo types.ContractInfo, found bool) {
+	store := ctx.KVStore(k.HashMapStoreKey)
+	primaryKey := store.Get(types.GetContractByKeyIndexKey(contractKey))
+	if primaryKey == nil {
+		return contractInfo, false
+	}
+	contractInfoBytes := store.Get(primaryKey)
+	if contractInfoBytes == nil {
+		return contractInfo, false
+	}
+	return types.MustUnmarshalContractInfo(k.cdc, contractInfoBytes), true
+}
+
+// MigrateContractIndex indexes the contracts registered by an older version of the module by their
+// contract keys, and returns the number of the contracts
+func (k ExecutionLayerKeeper) MigrateContractIndex(ctx sdk.Context) int {
+	contractInfos := k.GetAllContracts(ctx)
+	for _, contractInfo := range contractInfos {
+		k.SetContractInfo(ctx, contractInfo)
+	}
+	return len(contractInfos)
+}
+
+// GetContractsByDeployer retrieves all contracts deployed by the given address
+func (k ExecutionLayerKeeper) GetContractsByDeployer(ctx sdk.Context, deployer sdk.AccAddress) (contractInfos types.ContractInfos) {
+	store := ctx.KVStore(k.HashMapStoreKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.GetContractsByDeployerKey(deployer))
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		contractInfos = append(contractInfos, types.MustUnmarshalContractInfo(k.cdc, iterator.Value()))
+	}
+	return contractInfos
+}
+
+// GetContractsByName retrieves all contracts registered under the given name
+func (k ExecutionLayerKeeper) GetContractsByName(ctx sdk.Context, name string) types.ContractInfos {
+	return k.getContractsByIndex(ctx, types.GetContractsByNameKey(name))
+}
+
+// GetContractsByCodeHash retrieves all contracts deployed with the given code hash
+func (k ExecutionLayerKeeper) GetContractsByCodeHash(ctx sdk.Context, codeHash []byte) types.ContractInfos {
+	return k.getContractsByIndex(ctx, types.GetContractsByCodeHashKey(codeHash))
+}
+
+// GetAllContracts retrieves all registered contracts
+func (k ExecutionLayerKeeper) GetAllContracts(ctx sdk.Context) (contractInfos types.ContractInfos) {
+	store := ctx.KVStore(k.HashMapStoreKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.ContractInfoKey)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		contractInfos = append(contractInfos, types.MustUnmarshalContractInfo(k.cdc, iterator.Value()))
+	}
+	return contractInfos
+}
+
+func (k ExecutionLayerKeeper) getContractsByIndex(ctx sdk.Context, indexPrefix []byte) (contractInfos types.ContractInfos) {
+	store := ctx.KVStore(k.HashMapStoreKey)
+	iterator := sdk.KVStorePrefixIterator(store, indexPrefix)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		contractInfoBytes := store.Get(iterator.Value())
+		if contractInfoBytes == nil {
+			continue
+		}
+		contractInfos = append(contractInfos, types.MustUnmarshalContractInfo(k.cdc, contractInfoBytes))
+	}
+	return contractInfos
+}
+
+// -----------------------------------------------------------------------------------------------------------
+
+// SetDeployRecord records the executed deploy in the index of the recent deploys
+func (k ExecutionLayerKeeper) SetDeployRecord(ctx sdk.Context, record types.DeployRecord) {
+	deployHash, err := hex.DecodeString(record.DeployHash)
+	if err != nil {
+		panic(err)
+	}
+
+	store := ctx.KVStore(k.HashMapStoreKey)
+	store.Set(types.GetDeployKey(deployHash), k.cdc.MustMarshalBinaryBare(record))
+	store.Set(types.GetDeployByHeightKey(record.Height, deployHash), []byte{})
+}
+
+// GetDeployRecord returns the record of the deploy hash if it's executed in the recent blocks
+func (k ExecutionLayerKeeper) GetDeployRecord(ctx sdk.Context, deployHash []byte) (record types.DeployRecord, found bool) {
+	store := ctx.KVStore(k.HashMapStoreKey)
+	bz := store.Get(types.GetDeployKey(deployHash))
+	if bz == nil {
+		return record, false
+	}
+	k.cdc.MustUnmarshalBinaryBare(bz, &record)
+	return record, true
+}
+
+// PruneDeployRecords deletes the records of the deploys executed below the height
+func (k ExecutionLayerKeeper) PruneDeployRecords(ctx sdk.Context, height int64) {
+	if height <= 0 {
+		return
+	}
+
+	store := ctx.KVStore(k.HashMapStoreKey)
+	iterator := store.Iterator(types.DeployByHeightKey, types.GetDeployByHeightPrefix(height))
+	defer iterator.Close()
+
+	var keys [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		keys = append(keys, iterator.Key())
+	}
+	// the index key is (prefix | height | deploy hash)
+	deployHashStart := len(types.GetDeployByHeightPrefix(height))
+	for _, key := range keys {
+		store.Delete(key)
+		store.Delete(types.GetDeployKey(key[deployHashStart:]))
+	}
+}
+
+// -----------------------------------------------------------------------------------------------------------
+
+// SetRewardHistoryEntry records the reward of a delegator at the height of the entry
+func (k ExecutionLayerKeeper) SetRewardHistoryEntry(ctx sdk.Context, delegator sdk.AccAddress, entry types.RewardHistoryEntry) {
+	key := types.GetRewardHistoryKey(delegator, entry.Height)
+	k.setHistoryEntry(ctx, key, entry.Height, k.cdc.MustMarshalBinaryBare(entry))
+}
+
+// GetRewardHistory returns the reward history entries of a delegator from fromHeight to
+// toHeight inclusive, in the order of the heights
+func (k ExecutionLayerKeeper) GetRewardHistory(ctx sdk.Context, delegator sdk.AccAddress, fromHeight, toHeight int64) (entries []types.RewardHistoryEntry) {
+	prefix := types.GetRewardHistoryPrefix(delegator)
+	k.iterateHistory(ctx, prefix, fromHeight, toHeight, func(bz []byte) {
+		var entry types.RewardHistoryEntry
+		k.cdc.MustUnmarshalBinaryBare(bz, &entry)
+		entries = append(entries, entry)
+	})
+	return entries
+}
+
+// SetCommissionHistoryEntry records the commission of a validator at the height of the entry
+func (k ExecutionLayerKeeper) SetCommissionHistoryEntry(ctx sdk.Context, validator sdk.AccAddress, entry types.CommissionHistoryEntry) {
+	key := types.GetCommissionHistoryKey(validator, entry.Height)
+	k.setHistoryEntry(ctx, key, entry.Height, k.cdc.MustMarshalBinaryBare(entry))
+}
+
+// GetCommissionHistory returns the commission history entries of a validator from fromHeight
+// to toHeight inclusive, in the order of the heights
+func (k ExecutionLayerKeeper) GetCommissionHistory(ctx sdk.Context, validator sdk.AccAddress, fromHeight, toHeight int64) (entries []types.CommissionHistoryEntry) {
+	prefix := types.GetCommissionHistoryPrefix(validator)
+	k.iterateHistory(ctx, prefix, fromHeight, toHeight, func(bz []byte) {
+		var entry types.CommissionHistoryEntry
+		k.cdc.MustUnmarshalBinaryBare(bz, &entry)
+		entries = append(entries, entry)
+	})
+	return entries
+}
+
+// SetPosBalances records the reward or commission balances of the PoS contract after the step by
+// their hex encoded addresses under the prefix, writing the changed ones only, and returns the
+// balances recorded before
+func (k ExecutionLayerKeeper) SetPosBalances(ctx sdk.Context, prefix []byte, balances map[string]sdk.Int) map[string]sdk.Int {
+	store := ctx.KVStore(k.HashMapStoreKey)
+	prevBalances := map[string]sdk.Int{}
+	iterator := sdk.KVStorePrefixIterator(store, prefix)
+	for ; iterator.Valid(); iterator.Next() {
+		amount, ok := sdk.NewIntFromString(string(iterator.Value()))
+		if ok {
+			prevBalances[hex.EncodeToString(iterator.Key()[len(prefix):])] = amount
+		}
+	}
+	iterator.Close()
+
+	for address := range prevBalances {
+		if _, found := balances[address]; !found {
+			addressBytes, _ := hex.DecodeString(address)
+			store.Delete(append(append([]byte{}, prefix...), addressBytes...))
+		}
+	}
+	for address, amount := range balances {
+		addressBytes, err := hex.DecodeString(address)
+		if err != nil {
+			continue
+		}
+		if prevAmount, found := prevBalances[address]; !found || !prevAmount.Equal(amount) {
+			store.Set(append(append([]byte{}, prefix...), addressBytes...), []byte(amount.String()))
+		}
+	}
+	return prevBalances
+}
+
+// GetPosBalancesHeight returns the height of the block the PoS balances were recorded after
+func (k ExecutionLayerKeeper) GetPosBalancesHeight(ctx sdk.Context) int64 {
+	bz := ctx.KVStore(k.HashMapStoreKey).Get(types.PosBalancesHeightKey)
+	if bz == nil {
+		return 0
+	}
+	return int64(binary.BigEndian.Uint64(bz))
+}
+
+// SetPosBalancesHeight sets the height of the block the PoS balances were recorded after
+func (k ExecutionLayerKeeper) SetPosBalancesHeight(ctx sdk.Context, height int64) {
+	ctx.KVStore(k.HashMapStoreKey).Set(types.PosBalancesHeightKey, sdk.Uint64ToBigEndian(uint64(height)))
+}
+
+// SetClaim records a claim of the reward or commission of the address in the block under the prefix
+func (k ExecutionLayerKeeper) SetClaim(ctx sdk.Context, prefix []byte, address sdk.AccAddress) {
+	ctx.KVStore(k.HashMapStoreKey).Set(append(append([]byte{}, prefix...), address.Bytes()...), []byte{})
+}
+
+// TakeClaims deletes the claims of the block under the prefix and returns their hex encoded addresses
+func (k ExecutionLayerKeeper) TakeClaims(ctx sdk.Context, prefix []byte) map[string]bool {
+	store := ctx.KVStore(k.HashMapStoreKey)
+	iterator := sdk.KVStorePrefixIterator(store, prefix)
+	var keys [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		keys = append(keys, iterator.Key())
+	}
+	iterator.Close()
+
+	claims := map[string]bool{}
+	for _, key := range keys {
+		store.Delete(key)
+		claims[hex.EncodeToString(key[len(prefix):])] = true
+	}
+	return claims
+}
+
+// PruneRewardHistory deletes the reward and commission history entries below the height
+func (k ExecutionLayerKeeper) PruneRewardHistory(ctx sdk.Context, height int64) {
+	if height <= 0 {
+		return
+	}
+
+	store := ctx.KVStore(k.HashMapStoreKey)
+	iterator := store.Iterator(types.RewardHistoryByHeightKey, types.GetRewardHistoryByHeightPrefix(height))
+	defer iterator.Close()
+
+	var keys [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		keys = append(keys, iterator.Key())
+	}
+	// the index key is (prefix | height | history key)
+	historyKeyStart := len(types.GetRewardHistoryByHeightPrefix(height))
+	for _, key := range keys {
+		store.Delete(key)
+		store.Delete(key[historyKeyStart:])
+	}
+}
+
+func (k ExecutionLayerKeeper) setHistoryEntry(ctx sdk.Context, key []byte, height int64, bz []byte) {
+	store := ctx.KVStore(k.HashMapStoreKey)
+	store.Set(key, bz)
+	store.Set(types.GetRewardHistoryByHeightKey(height, key), []byte{})
+}
+
+func (k ExecutionLayerKeeper) iterateHistory(ctx sdk.Context, prefix []byte, fromHeight, toHeight int64, fn func(bz []byte)) {
+	if fromHeight < 0 {
+		fromHeight = 0
+	}
+	if toHeight < fromHeight {
+		return
+	}
+
+	store := ctx.KVStore(k.HashMapStoreKey)
+	start := append(prefix[:len(prefix):len(prefix)], sdk.Uint64ToBigEndian(uint64(fromHeight))...)
+	end := append(prefix[:len(prefix):len(prefix)], sdk.Uint64ToBigEndian(uint64(toHeight)+1)...)
+	iterator := store.Iterator(start, end)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		fn(iterator.Value())
+	}
+}
+
+// -----------------------------------------------------------------------------------------------------------
+
+// GetGrant returns the grant of the granter to the grantee
+func (k ExecutionLayerKeeper) GetGrant(ctx sdk.Context, granter, grantee sdk.AccAddress) (grant types.Grant, found bool) {
+	store := ctx.KVStore(k.HashMapStoreKey)
+	bz := store.Get(types.GetGrantKey(granter, grantee))
+	if bz == nil {
+		return grant, false
+	}
+	k.cdc.MustUnmarshalBinaryBare(bz, &grant)
+	return grant, true
+}
+
+// SetGrant saves the grant with its grantee index and expiration queue entries.
+// It replaces the previous grant of the granter to the grantee.
+func (k ExecutionLayerKeeper) SetGrant(ctx sdk.Context, grant types.Grant) {
+	store := ctx.KVStore(k.HashMapStoreKey)
+	if prev, found := k.GetGrant(ctx, grant.Granter, grant.Grantee); found {
+		store.Delete(types.GetGrantByExpirationKey(prev.Expiration, prev.Granter, prev.Grantee))
+	}
+
+	primaryKey := types.GetGrantKey(grant.Granter, grant.Grantee)
+	store.Set(primaryKey, k.cdc.MustMarshalBinaryBare(grant))
+	store.Set(types.GetGrantByGranteeKey(grant.Grantee, grant.Granter), primaryKey)
+	store.Set(types.GetGrantByExpirationKey(grant.Expiration, grant.Granter, grant.Grantee), primaryKey)
+}
+
+// DeleteGrant deletes the grant of the granter to the grantee with its index entries
+func (k ExecutionLayerKeeper) DeleteGrant(ctx sdk.Context, granter, grantee sdk.AccAddress) {
+	grant, found := k.GetGrant(ctx, granter, grantee)
+	if !found {
+		return
+	}
+
+	store := ctx.KVStore(k.HashMapStoreKey)
+	store.Delete(types.GetGrantKey(granter, grantee))
+	store.Delete(types.GetGrantByGranteeKey(grantee, granter))
+	store.Delete(types.GetGrantByExpirationKey(grant.Expiration, granter, grantee))
+}
+
+// GetGrantsByGranter returns the grants the granter gave
+func (k ExecutionLayerKeeper) GetGrantsByGranter(ctx sdk.Context, granter sdk.AccAddress) (grants types.Grants) {
+	return k.getGrants(ctx, types.GetGrantsByGranterPrefix(granter))
+}
+
+// GetGrantsByGrantee returns the grants given to the grantee
+func (k ExecutionLayerKeeper) GetGrantsByGrantee(ctx sdk.Context, grantee sdk.AccAddress) (grants types.Grants) {
+	store := ctx.KVStore(k.HashMapStoreKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.GetGrantsByGranteePrefix(grantee))
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		bz := store.Get(iterator.Value())
+		if bz == nil {
+			continue
+		}
+		var grant types.Grant
+		k.cdc.MustUnmarshalBinaryBare(bz, &grant)
+		grants = append(grants, grant)
+	}
+	return grants
+}
+
+// GetAllGrants returns all grants
+func (k ExecutionLayerKeeper) GetAllGrants(ctx sdk.Context) (grants types.Grants) {
+	return k.getGrants(ctx, types.GrantKey)
+}
+
+func (k ExecutionLayerKeeper) getGrants(ctx sdk.Context, prefix []byte) (grants types.Grants) {
+	store := ctx.KVStore(k.HashMapStoreKey)
+	iterator := sdk.KVStorePrefixIterator(store, prefix)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var grant types.Grant
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &grant)
+		grants = append(grants, grant)
+	}
+	return grants
+}
+
+// PruneExpiredGrants deletes the grants expired at the block time
+func (k ExecutionLayerKeeper) PruneExpiredGrants(ctx sdk.Context, blockTime time.Time) {
+	store := ctx.KVStore(k.HashMapStoreKey)
+	iterator := store.Iterator(types.GrantByExpirationKey, sdk.PrefixEndBytes(types.GetGrantsByExpirationPrefix(blockTime)))
+	defer iterator.Close()
+
+	var grantKeys [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		grantKeys = append(grantKeys, iterator.Value())
+	}
+	for _, grantKey := range grantKeys {
+		bz := store.Get(grantKey)
+		if bz == nil {
+			continue
+		}
+		var grant types.Grant
+		k.cdc.MustUnmarshalBinaryBare(bz, &grant)
+		k.DeleteGrant(ctx, grant.Granter, grant.Grantee)
+	}
+}
+
+// GetFeeAllowance returns the fee allowance of the payer to the grantee
+func (k ExecutionLayerKeeper) GetFeeAllowance(ctx sdk.Context, payer, grantee sdk.AccAddress) (allowance types.FeeAllowance, found bool) {
+	store := ctx.KVStore(k.HashMapStoreKey)
+	bz := store.Get(types.GetFeeAllowanceKey(payer, grantee))
+	if bz == nil {
+		return allowance, false
+	}
+	k.cdc.MustUnmarshalBinaryBare(bz, &allowance)
+	return allowance, true
+}
+
+// SetFeeAllowance saves the fee allowance with its grantee index and expiration queue entries.
+// It replaces the previous allowance of the payer to the grantee.
+func (k ExecutionLayerKeeper) SetFeeAllowance(ctx sdk.Context, allowance types.FeeAllowance) {
+	store := ctx.KVStore(k.HashMapStoreKey)
+	if prev, found := k.GetFeeAllowance(ctx, allowance.Payer, allowance.Grantee); found {
+		store.Delete(types.GetFeeAllowanceByExpirationKey(prev.Expiration, prev.Payer, prev.Grantee))
+	}
+
+	primaryKey := types.GetFeeAllowanceKey(allowance.Payer, allowance.Grantee)
+	store.Set(primaryKey, k.cdc.MustMarshalBinaryBare(allowance))
+	store.Set(types.GetFeeAllowanceByGranteeKey(allowance.Grantee, allowance.Payer), primaryKey)
+	store.Set(types.GetFeeAllowanceByExpirationKey(allowance.Expiration, allowance.Payer, allowance.Grantee), primaryKey)
+}
+
+// DeleteFeeAllowance deletes the fee allowance of the payer to the grantee with its index entries
+func (k ExecutionLayerKeeper) DeleteFeeAllowance(ctx sdk.Context, payer, grantee sdk.AccAddress) {
+	allowance, found := k.GetFeeAllowance(ctx, payer, grantee)
+	if !found {
+		return
+	}
+
+	store := ctx.KVStore(k.HashMapStoreKey)
+	store.Delete(types.GetFeeAllowanceKey(payer, grantee))
+	store.Delete(types.GetFeeAllowanceByGranteeKey(grantee, payer))
+	store.Delete(types.GetFeeAllowanceByExpirationKey(allowance.Expiration, payer, grantee))
+}
+
+// GetFeeAllowancesByPayer returns the fee allowances the payer gave
+func (k ExecutionLayerKeeper) GetFeeAllowancesByPayer(ctx sdk.Context, payer sdk.AccAddress) (allowances types.FeeAllowances) {
+	return k.getFeeAllowances(ctx, types.GetFeeAllowancesByPayerPrefix(payer))
+}
+
+// GetFeeAllowancesByGrantee returns the fee allowances given to the grantee
+func (k ExecutionLayerKeeper) GetFeeAllowancesByGrantee(ctx sdk.Context, grantee sdk.AccAddress) (allowances types.FeeAllowances) {
+	store := ctx.KVStore(k.HashMapStoreKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.GetFeeAllowancesByGranteePrefix(grantee))
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		bz := store.Get(iterator.Value())
+		if bz == nil {
+			continue
+		}
+		var allowance types.FeeAllowance
+		k.cdc.MustUnmarshalBinaryBare(bz, &allowance)
+		allowances = append(allowances, allowance)
+	}
+	return allowances
+}
+
+// GetAllFeeAllowances returns all fee allowances
+func (k ExecutionLayerKeeper) GetAllFeeAllowances(ctx sdk.Context) (allowances types.FeeAllowances) {
+	return k.getFeeAllowances(ctx, types.FeeAllowanceKey)
+}
+
+func (k ExecutionLayerKeeper) getFeeAllowances(ctx sdk.Context, prefix []byte) (allowances types.FeeAllowances) {
+	store := ctx.KVStore(k.HashMapStoreKey)
+	iterator := sdk.KVStorePrefixIterator(store, prefix)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var allowance types.FeeAllowance
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &allowance)
+		allowances = append(allowances, allowance)
+	}
+	return allowances
+}
+
+// PruneExpiredFeeAllowances deletes the fee allowances expired at the block time
+func (k ExecutionLayerKeeper) PruneExpiredFeeAllowances(ctx sdk.Context, blockTime time.Time) {
+	store := ctx.KVStore(k.HashMapStoreKey)
+	iterator := store.Iterator(types.FeeAllowanceByExpirationKey, sdk.PrefixEndBytes(types.GetFeeAllowancesByExpirationPrefix(blockTime)))
+	defer iterator.Close()
+
+	var allowanceKeys [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		allowanceKeys = append(allowanceKeys, iterator.Value())
+	}
+	for _, allowanceKey := range allowanceKeys {
+		bz := store.Get(allowanceKey)
+		if bz == nil {
+			continue
+		}
+		var allowance types.FeeAllowance
+		k.cdc.MustUnmarshalBinaryBare(bz, &allowance)
+		k.DeleteFeeAllowance(ctx, allowance.Payer, allowance.Grantee)
+	}
+}
+
+// GetSchedule returns the schedule of the id
+func (k ExecutionLayerKeeper) GetSchedule(ctx sdk.Context, id uint64) (schedule types.Schedule, found bool) {
+	store := ctx.KVStore(k.HashMapStoreKey)
+	bz := store.Get(types.GetScheduleKey(id))
+	if bz == nil {
+		return schedule, false
+	}
+	k.cdc.MustUnmarshalBinaryBare(bz, &schedule)
+	return schedule, true
+}
+
+// SetSchedule saves the schedule with its sender and recipient index entries. A pending schedule
+// is queued at the height of its next payout and a cancelled one at the height of its refund,
+// replacing the previous queue entry.
+func (k ExecutionLayerKeeper) SetSchedule(ctx sdk.Context, schedule types.Schedule) {
+	store := ctx.KVStore(k.HashMapStoreKey)
+	if prev, found := k.GetSchedule(ctx, schedule.ID); found {
+		store.Delete(types.GetScheduleQueueKey(prev.NextHeight, prev.ID))
+	}
+
+	primaryKey := types.GetScheduleKey(schedule.ID)
+	store.Set(primaryKey, k.cdc.MustMarshalBinaryBare(schedule))
+	store.Set(types.GetScheduleBySenderKey(schedule.Sender, schedule.ID), primaryKey)
+	store.Set(types.GetScheduleByRecipientKey(schedule.Recipient, schedule.ID), primaryKey)
+	if schedule.Status == types.ScheduleStatusPending || schedule.Status == types.ScheduleStatusCancelled {
+		store.Set(types.GetScheduleQueueKey(schedule.NextHeight, schedule.ID), primaryKey)
+	}
+}
+
+// DeleteSchedule deletes the schedule of the id with its index and queue entries
+func (k ExecutionLayerKeeper) DeleteSchedule(ctx sdk.Context, id uint64) {
+	schedule, found := k.GetSchedule(ctx, id)
+	if !found {
+		return
+	}
+
+	store := ctx.KVStore(k.HashMapStoreKey)
+	store.Delete(types.GetScheduleKey(id))
+	store.Delete(types.GetScheduleBySenderKey(schedule.Sender, id))
+	store.Delete(types.GetScheduleByRecipientKey(schedule.Recipient, id))
+	store.Delete(types.GetScheduleQueueKey(schedule.NextHeight, id))
+}
+
+// GetNextScheduleID returns the id of the next schedule
+func (k ExecutionLayerKeeper) GetNextScheduleID(ctx sdk.Context) uint64 {
+	store := ctx.KVStore(k.HashMapStoreKey)
+	bz := store.Get(types.NextScheduleIDKey)
+	if bz == nil {
+		return 1
+	}
+	return binary.BigEndian.Uint64(bz)
+}
+
+// SetNextScheduleID saves the id of the next schedule
+func (k ExecutionLayerKeeper) SetNextScheduleID(ctx sdk.Context, id uint64) {
+	store := ctx.KVStore(k.HashMapStoreKey)
+	store.Set(types.NextScheduleIDKey, sdk.Uint64ToBigEndian(id))
+}
+
+// GetSchedulesBySender returns the schedules of the sender
+func (k ExecutionLayerKeeper) GetSchedulesBySender(ctx sdk.Context, sender sdk.AccAddress) types.Schedules {
+	return k.getIndexedSchedules(ctx, types.GetSchedulesBySenderPrefix(sender))
+}
+
+// GetSchedulesByRecipient returns the schedules to the recipient
+func (k ExecutionLayerKeeper) GetSchedulesByRecipient(ctx sdk.Context, recipient sdk.AccAddress) types.Schedules {
+	return k.getIndexedSchedules(ctx, types.GetSchedulesByRecipientPrefix(recipient))
+}
+
+// GetAllSchedules returns all schedules
+func (k ExecutionLayerKeeper) GetAllSchedules(ctx sdk.Context) (schedules types.Schedules) {
+	store := ctx.KVStore(k.HashMapStoreKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.ScheduleKey)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var schedule types.Schedule
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &schedule)
+		schedules = append(schedules, schedule)
+	}
+	return schedules
+}
+
+// GetDueSchedules returns up to limit pending schedules whose next payouts are due at the height,
+// and cancelled schedules to refund, in the order of the heights and the ids
+func (k ExecutionLayerKeeper) GetDueSchedules(ctx sdk.Context, height int64, limit int64) (schedules types.Schedules) {
+	store := ctx.KVStore(k.HashMapStoreKey)
+	iterator := store.Iterator(types.ScheduleQueueKey, sdk.PrefixEndBytes(types.GetScheduleQueueHeightPrefix(height)))
+	defer iterator.Close()
+
+	for ; iterator.Valid() && int64(len(schedules)) < limit; iterator.Next() {
+		bz := store.Get(iterator.Value())
+		if bz == nil {
+			continue
+		}
+		var schedule types.Schedule
+		k.cdc.MustUnmarshalBinaryBare(bz, &schedule)
+		schedules = append(schedules, schedule)
+	}
+	return schedules
+}
+
+func (k ExecutionLayerKeeper) getIndexedSchedules(ctx sdk.Context, prefix []byte) (schedules types.Schedules) {
+	store := ctx.KVStore(k.HashMapStoreKey)
+	iterator := sdk.KVStorePrefixIterator(store, prefix)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		bz := store.Get(iterator.Value())
+		if bz == nil {
+			continue
+		}
+		var schedule types.Schedule
+		k.cdc.MustUnmarshalBinaryBare(bz, &schedule)
+		schedules = append(schedules, schedule)
+	}
+	return schedules
+}
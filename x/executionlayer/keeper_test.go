@@ -2,15 +2,22 @@ package executionlayer
 
 import (
 	"encoding/hex"
+	"fmt"
+	"math"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/casper/consensus/state"
 	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/ipc/transforms"
+	storetypes "github.com/hdac-io/friday/store/types"
 	sdk "github.com/hdac-io/friday/types"
+	"github.com/hdac-io/friday/x/auth"
 	"github.com/hdac-io/friday/x/executionlayer/types"
+	abci "github.com/hdac-io/tendermint/abci/types"
+	"github.com/hdac-io/tendermint/crypto/ed25519"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -65,6 +72,48 @@ func TestUnitHashMapInCorrectInput(t *testing.T) {
 	unitHash := input.elk.GetUnitHashMap(input.ctx, input.ctx.BlockHeight())
 	assert.NotEqual(t, eeState, unitHash.EEState)
 }
+
+func TestPruneUnitHashMap(t *testing.T) {
+	input := setupTestInput()
+	input.elk.SetPruning(storetypes.NewPruningOptions(2, 5))
+
+	for height := int64(1); height <= 8; height++ {
+		ctx := input.ctx.WithBlockHeight(height)
+		eeState := make([]byte, 32)
+		eeState[0] = byte(height)
+		assert.True(t, input.elk.SetUnitHashMap(ctx, NewUnitHashMap(eeState)))
+	}
+
+	// every height below the recent ones is pruned at once
+	assert.Equal(t, []int64{1, 2, 3, 4, 6}, input.elk.PruneUnitHashMap(input.ctx.WithBlockHeight(8)))
+
+	for height := int64(9); height <= 12; height++ {
+		ctx := input.ctx.WithBlockHeight(height)
+		eeState := make([]byte, 32)
+		eeState[0] = byte(height)
+		assert.True(t, input.elk.SetUnitHashMap(ctx, NewUnitHashMap(eeState)))
+
+		pruned := input.elk.PruneUnitHashMap(ctx)
+		if height-2 == 10 {
+			assert.Empty(t, pruned)
+		} else {
+			assert.Equal(t, []int64{height - 2}, pruned)
+		}
+	}
+
+	// recent heights and every 5th height are kept
+	for _, height := range []int64{5, 10, 11, 12} {
+		eeState, err := input.elk.GetEEState(input.ctx, height)
+		assert.Nil(t, err)
+		assert.Equal(t, byte(height), eeState[0])
+	}
+	for _, height := range []int64{1, 4, 6, 7, 9} {
+		_, err := input.elk.GetEEState(input.ctx, height)
+		assert.NotNil(t, err)
+		assert.Equal(t, types.CodeEEStateNotFound, err.Code())
+	}
+}
+
 func TestMarsahlAndUnMarshal(t *testing.T) {
 	src := &transforms.TransformEntry{
 		Transform: &transforms.Transform{TransformInstance: &transforms.Transform_Write{Write: &transforms.TransformWrite{Value: &state.StoredValue{Variants: &state.StoredValue_ClValue{ClValue: &state.CLValue{ClType: &state.CLType{Variants: &state.CLType_SimpleType{SimpleType: state.CLType_BOOL}}, SerializedValue: []byte{1, 2, 3}}}}}}}}
@@ -164,3 +213,642 @@ func TestProtocolVersionKeeper(t *testing.T) {
 
 	assert.Equal(t, src, res)
 }
+
+func TestContractRegistry(t *testing.T) {
+	input := setupTestInput()
+
+	deployer, _ := sdk.AccAddressFromBech32("friday1gp2u22697kz6slwa25k2tkhz6st2l0zx3hkfc5wdlpjaauv5czsq2dwu8m")
+	otherDeployer, _ := sdk.AccAddressFromBech32("friday16wfryel63g7axeamw68630wglalcnk3llh7z665n05qrrmmfqztqkhgkwv")
+	codeHash := []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32}
+	otherCodeHash := []byte{32, 31, 30, 29, 28, 27, 26, 25, 24, 23, 22, 21, 20, 19, 18, 17, 16, 15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1}
+
+	counter := types.NewContractInfo(deployer, "counter", codeHash, 1, []string{"fridaycontracthash1dl45lfet0wrsduxfeegwmskmmr8yhlpk6lk4qdpyhpjsffkymstq6ajv0a"})
+	counterOfOther := types.NewContractInfo(otherDeployer, "counter", codeHash, 2, nil)
+	token := types.NewContractInfo(deployer, "counter_token", otherCodeHash, 3, nil)
+
+	input.elk.SetContractInfo(input.ctx, counter)
+	input.elk.SetContractInfo(input.ctx, counterOfOther)
+	input.elk.SetContractInfo(input.ctx, token)
+
+	got, found := input.elk.GetContractInfo(input.ctx, deployer, "counter")
+	assert.True(t, found)
+	assert.Equal(t, counter, got)
+	_, found = input.elk.GetContractInfo(input.ctx, deployer, "unknown")
+	assert.False(t, found)
+
+	assert.Equal(t, 2, len(input.elk.GetContractsByDeployer(input.ctx, deployer)))
+	assert.Equal(t, 1, len(input.elk.GetContractsByDeployer(input.ctx, otherDeployer)))
+	assert.Equal(t, 2, len(input.elk.GetContractsByName(input.ctx, "counter")))
+	assert.Equal(t, 1, len(input.elk.GetContractsByName(input.ctx, "counter_token")))
+	assert.Equal(t, 2, len(input.elk.GetContractsByCodeHash(input.ctx, codeHash)))
+	assert.Equal(t, 3, len(input.elk.GetAllContracts(input.ctx)))
+	contractKey := counter.ContractKeys[0]
+	got, found = input.elk.GetContractInfoByKey(input.ctx, contractKey)
+	assert.True(t, found)
+	assert.Equal(t, counter, got)
+
+	// the querier looks the contract key up in the index
+	querier := NewQuerier(input.elk)
+	res, err := querier(input.ctx, []string{QueryContractByKey}, abci.RequestQuery{Data: types.ModuleCdc.MustMarshalJSON(types.NewQueryContractByKeyParams(contractKey))})
+	assert.Nil(t, err)
+	var queried *types.ContractInfo
+	types.ModuleCdc.MustUnmarshalJSON(res, &queried)
+	assert.Equal(t, counter, *queried)
+	_, err = querier(input.ctx, []string{QueryContractByKey}, abci.RequestQuery{Data: types.ModuleCdc.MustMarshalJSON(types.NewQueryContractByKeyParams("counter"))})
+	assert.NotNil(t, err)
+
+	// redeploy under the same name moves the code hash and contract key indexes
+	redeployed := types.NewContractInfo(deployer, "counter", otherCodeHash, 4, nil)
+	input.elk.SetContractInfo(input.ctx, redeployed)
+
+	assert.Equal(t, 1, len(input.elk.GetContractsByCodeHash(input.ctx, codeHash)))
+	assert.Equal(t, 2, len(input.elk.GetContractsByCodeHash(input.ctx, otherCodeHash)))
+	assert.Equal(t, 2, len(input.elk.GetContractsByName(input.ctx, "counter")))
+	assert.Equal(t, 3, len(input.elk.GetAllContracts(input.ctx)))
+	_, found = input.elk.GetContractInfoByKey(input.ctx, contractKey)
+	assert.False(t, found)
+	res, err = querier(input.ctx, []string{QueryContractByKey}, abci.RequestQuery{Data: types.ModuleCdc.MustMarshalJSON(types.NewQueryContractByKeyParams(contractKey))})
+	assert.Nil(t, err)
+	assert.Equal(t, "null", string(res))
+}
+
+func TestMigrateContractIndex(t *testing.T) {
+	input := setupTestInput()
+
+	deployer, _ := sdk.AccAddressFromBech32("friday1gp2u22697kz6slwa25k2tkhz6st2l0zx3hkfc5wdlpjaauv5czsq2dwu8m")
+	contractKey := "fridaycontracthash1dl45lfet0wrsduxfeegwmskmmr8yhlpk6lk4qdpyhpjsffkymstq6ajv0a"
+	counter := types.NewContractInfo(deployer, "counter", []byte(strings.Repeat("c", 32)), 1, []string{contractKey})
+
+	// a contract registered without the contract key index
+	store := input.ctx.KVStore(input.elk.HashMapStoreKey)
+	store.Set(types.GetContractInfoKey(deployer, "counter"), types.MustMarshalContractInfo(input.elk.cdc, counter))
+	_, found := input.elk.GetContractInfoByKey(input.ctx, contractKey)
+	assert.False(t, found)
+
+	assert.Equal(t, 1, input.elk.MigrateContractIndex(input.ctx))
+	got, found := input.elk.GetContractInfoByKey(input.ctx, contractKey)
+	assert.True(t, found)
+	assert.Equal(t, counter, got)
+	assert.Equal(t, 1, len(input.elk.GetContractsByName(input.ctx, "counter")))
+}
+
+func TestRewardHistory(t *testing.T) {
+	input := setupTestInput()
+
+	input.elk.SetParams(input.ctx, types.NewParams(10, 10, 10, 10, 0))
+	assert.Equal(t, int64(10), input.elk.GetParams(input.ctx).RewardHistoryRetention)
+
+	delegator, _ := sdk.AccAddressFromBech32("friday1gp2u22697kz6slwa25k2tkhz6st2l0zx3hkfc5wdlpjaauv5czsq2dwu8m")
+	validator, _ := sdk.AccAddressFromBech32("friday16wfryel63g7axeamw68630wglalcnk3llh7z665n05qrrmmfqztqkhgkwv")
+
+	for height := int64(1); height <= 5; height++ {
+		input.elk.SetRewardHistoryEntry(input.ctx, delegator, types.RewardHistoryEntry{
+			Height:      height,
+			Earned:      "10",
+			Claimed:     "0",
+			Balance:     "10",
+			Delegations: []types.DelegationStake{{Validator: validator, Amount: "1000"}},
+		})
+		input.elk.SetCommissionHistoryEntry(input.ctx, validator, types.CommissionHistoryEntry{
+			Height: height, Earned: "1", Claimed: "0", Balance: "1", Stake: "1000",
+		})
+	}
+
+	entries := input.elk.GetRewardHistory(input.ctx, delegator, 2, 4)
+	assert.Equal(t, 3, len(entries))
+	assert.Equal(t, int64(2), entries[0].Height)
+	assert.Equal(t, int64(4), entries[2].Height)
+	assert.Equal(t, validator, entries[0].Delegations[0].Validator)
+	assert.Equal(t, 0, len(input.elk.GetRewardHistory(input.ctx, validator, 1, 5)))
+	assert.Equal(t, 5, len(input.elk.GetCommissionHistory(input.ctx, validator, 0, 5)))
+
+	input.elk.PruneRewardHistory(input.ctx, 4)
+	entries = input.elk.GetRewardHistory(input.ctx, delegator, 0, 5)
+	assert.Equal(t, 2, len(entries))
+	assert.Equal(t, int64(4), entries[0].Height)
+	assert.Equal(t, 2, len(input.elk.GetCommissionHistory(input.ctx, validator, 0, 5)))
+}
+
+func TestPosBalances(t *testing.T) {
+	input := setupTestInput()
+	delegatorHex := hex.EncodeToString([]byte(strings.Repeat("d", 32)))
+	otherHex := hex.EncodeToString([]byte(strings.Repeat("o", 32)))
+
+	prev := input.elk.SetPosBalances(input.ctx, types.RewardBalanceKey,
+		map[string]sdk.Int{delegatorHex: sdk.NewInt(10), otherHex: sdk.NewInt(5)})
+	assert.Equal(t, 0, len(prev))
+	prev = input.elk.SetPosBalances(input.ctx, types.RewardBalanceKey, map[string]sdk.Int{delegatorHex: sdk.NewInt(20)})
+	assert.Equal(t, map[string]sdk.Int{delegatorHex: sdk.NewInt(10), otherHex: sdk.NewInt(5)}, prev)
+	prev = input.elk.SetPosBalances(input.ctx, types.RewardBalanceKey, map[string]sdk.Int{})
+	assert.Equal(t, map[string]sdk.Int{delegatorHex: sdk.NewInt(20)}, prev)
+	assert.Equal(t, 0, len(input.elk.SetPosBalances(input.ctx, types.CommissionBalanceKey, map[string]sdk.Int{})))
+
+	input.elk.SetPosBalancesHeight(input.ctx, 7)
+	assert.Equal(t, int64(7), input.elk.GetPosBalancesHeight(input.ctx))
+
+	delegator := sdk.AccAddress([]byte(strings.Repeat("d", 32)))
+	input.elk.SetClaim(input.ctx, types.RewardClaimKey, delegator)
+	assert.Equal(t, 0, len(input.elk.TakeClaims(input.ctx, types.CommissionClaimKey)))
+	assert.Equal(t, map[string]bool{delegatorHex: true}, input.elk.TakeClaims(input.ctx, types.RewardClaimKey))
+	assert.Equal(t, 0, len(input.elk.TakeClaims(input.ctx, types.RewardClaimKey)))
+}
+
+func TestMigrateParams(t *testing.T) {
+	input := setupTestInput()
+
+	// the store of a chain started without the params has none of them
+	defaults := types.DefaultParams()
+	assert.Equal(t, len(defaults.ParamSetPairs()), len(input.elk.MigrateParams(input.ctx)))
+	assert.Equal(t, types.DefaultParams(), input.elk.GetParams(input.ctx))
+
+	params := types.NewParams(10, 10, 10, 10, 0)
+	input.elk.SetParams(input.ctx, params)
+	assert.Equal(t, 0, len(input.elk.MigrateParams(input.ctx)))
+	assert.Equal(t, params, input.elk.GetParams(input.ctx))
+}
+
+func TestDeployLimits(t *testing.T) {
+	input := setupTestInput()
+	ctx := input.ctx.WithBlockTime(time.Unix(1000, 0)).WithCandidateBlock(&sdk.CandidateBlock{})
+
+	input.elk.SetDeployConfig(ctx, types.DeployConfig{MaxTtlMillis: 60000, MaxDependencies: 2, MaxBlockSizeBytes: 100, MaxBlockCost: 1000})
+	assert.Equal(t, uint32(100), input.elk.GetDeployConfig(ctx).MaxBlockSizeBytes)
+
+	// dependencies
+	deployHash := []byte(strings.Repeat("d", types.DeployHashLength))
+	header := types.NewDeployHeader(999000, 0, []string{hex.EncodeToString(deployHash)})
+	assert.Equal(t, types.CodeDeployDependencyNotFound, validateDeployHeader(ctx, input.elk, header).Code())
+	input.elk.SetDeployRecord(ctx, types.NewDeployRecord(deployHash, 3, nil))
+	assert.Nil(t, validateDeployHeader(ctx, input.elk, header))
+	assert.Nil(t, validateDeployHeader(ctx, input.elk, nil))
+
+	// size and cost of the block
+	assert.Nil(t, checkBlockLimits(ctx, input.elk, 60, 0, false))
+	ctx.CandidateBlock().Size = 60
+	assert.Equal(t, types.CodeBlockSizeLimitExceeded, checkBlockLimits(ctx, input.elk, 60, 0, false).Code())
+	assert.Nil(t, checkBlockLimits(ctx, input.elk, 60, 0, true))
+	assert.Equal(t, types.CodeBlockSizeLimitExceeded, checkBlockLimits(ctx, input.elk, 101, 0, true).Code())
+
+	// the cost of a deploy is bounded by its gas limit
+	ctx.CandidateBlock().Cost = 990
+	assert.Nil(t, checkBlockLimits(ctx, input.elk, 1, getGasLimit("100"), false))
+	assert.Equal(t, types.CodeBlockCostLimitExceeded, checkBlockLimits(ctx, input.elk, 1, getGasLimit("110"), false).Code())
+	assert.Nil(t, checkBlockLimits(ctx, input.elk, 1, getGasLimit("110"), true))
+	ctx.CandidateBlock().Cost = 1000
+	assert.Equal(t, types.CodeBlockCostLimitExceeded, checkBlockLimits(ctx, input.elk, 1, 1, false).Code())
+	assert.Equal(t, uint64(10), getGasLimit("100"))
+	assert.Equal(t, uint64(math.MaxUint64), getGasLimit("1000000000000000000000000"))
+	assert.Equal(t, uint64(0), getGasLimit("fee"))
+
+	assert.Equal(t, uint64(30), addCost(10, parseCost("20")))
+	assert.Equal(t, uint64(math.MaxUint64), addCost(10, parseCost("100000000000000000000000")))
+	assert.Equal(t, uint64(10), addCost(10, parseCost("")))
+}
+
+func TestDeployIndex(t *testing.T) {
+	input := setupTestInput()
+	ctx := input.ctx
+
+	deployHash := []byte(strings.Repeat("a", types.DeployHashLength))
+	otherHash := []byte(strings.Repeat("b", types.DeployHashLength))
+	txHash := []byte(strings.Repeat("t", 32))
+	input.elk.SetDeployRecord(ctx, types.NewDeployRecord(deployHash, 3, txHash))
+	input.elk.SetDeployRecord(ctx, types.NewDeployRecord(otherHash, 5, nil))
+
+	record, found := input.elk.GetDeployRecord(ctx, deployHash)
+	assert.True(t, found)
+	assert.Equal(t, int64(3), record.Height)
+	assert.Equal(t, fmt.Sprintf("%X", txHash), record.TxHash)
+	_, found = input.elk.GetDeployRecord(ctx, []byte(strings.Repeat("c", types.DeployHashLength)))
+	assert.False(t, found)
+
+	input.elk.PruneDeployRecords(ctx, 4)
+	_, found = input.elk.GetDeployRecord(ctx, deployHash)
+	assert.False(t, found)
+	record, found = input.elk.GetDeployRecord(ctx, otherHash)
+	assert.True(t, found)
+	assert.Equal(t, "", record.TxHash)
+
+	// without a signed tx, the hash is derived from the current sequence of the account
+	addr := sdk.AccAddress([]byte(strings.Repeat("s", 20)))
+	ctx = ctx.WithChainID("test-chain").WithMsgIndex(1)
+	assert.Equal(t, types.NewDeployHash("test-chain", addr, 0, 1), getDeployHash(ctx, input.elk, addr))
+	ctx = auth.WithTxSequences(auth.WithTxSigners(ctx, []sdk.AccAddress{addr}), []uint64{7})
+	assert.Equal(t, types.NewDeployHash("test-chain", addr, 7, 1), getDeployHash(ctx, input.elk, addr))
+}
+
+func TestExecutedDeploys(t *testing.T) {
+	input := setupTestInput()
+	ctx := input.ctx.WithBlockHeight(8).WithBlockTime(time.Unix(1000, 0)).WithCandidateBlock(&sdk.CandidateBlock{})
+	input.elk.SetDeployConfig(ctx, types.DeployConfig{MaxTtlMillis: 60000, MaxDependencies: 2})
+
+	recorded := []byte(strings.Repeat("a", types.DeployHashLength))
+	failed := []byte(strings.Repeat("f", types.DeployHashLength))
+	txHash := []byte(strings.Repeat("t", 32))
+	input.elk.SetDeployRecord(ctx, types.NewDeployRecord(recorded, 8, nil))
+	ctx.CandidateBlock().ExecutedDeploys = []sdk.ExecutedDeploy{{DeployHash: recorded}, {DeployHash: failed, TxHash: txHash}}
+
+	// a failed deploy of the block is a duplicate and a dependency before it's recorded
+	_, found := input.elk.GetDeployRecord(ctx, failed)
+	assert.False(t, found)
+	record, found := getExecutedDeploy(ctx, input.elk, failed)
+	assert.True(t, found)
+	assert.Equal(t, int64(8), record.Height)
+	assert.Nil(t, validateDeployHeader(ctx, input.elk, types.NewDeployHeader(999000, 0, []string{hex.EncodeToString(failed)})))
+	_, found = getExecutedDeploy(ctx, input.elk, []byte(strings.Repeat("c", types.DeployHashLength)))
+	assert.False(t, found)
+
+	recordExecutedDeploys(ctx, input.elk)
+	record, found = input.elk.GetDeployRecord(ctx, failed)
+	assert.True(t, found)
+	assert.Equal(t, fmt.Sprintf("%X", txHash), record.TxHash)
+	record, _ = input.elk.GetDeployRecord(ctx, recorded)
+	assert.Equal(t, "", record.TxHash)
+}
+
+func TestRotateConsPubKey(t *testing.T) {
+	input := setupTestInput()
+	input.elk.SetParams(input.ctx, types.NewParams(10, 10, 5, 10, 0))
+	ctx := input.ctx.WithBlockHeight(10)
+
+	valAddr := sdk.AccAddress([]byte(strings.Repeat("v", 20)))
+	oldConsPubKey, newConsPubKey, otherConsPubKey := ed25519.GenPrivKey().PubKey(), ed25519.GenPrivKey().PubKey(), ed25519.GenPrivKey().PubKey()
+	msg := types.NewMsgRotateConsPubKey("system:rotate_cons_pubkey", valAddr, newConsPubKey, "0")
+	assert.Nil(t, msg.ValidateBasic())
+	assert.False(t, handlerMsgRotateConsPubKey(ctx, input.elk, msg, false).IsOK())
+
+	validator := types.NewValidator(valAddr, oldConsPubKey, types.Description{Moniker: "validator"}, "")
+	input.elk.SetValidator(ctx, valAddr, validator)
+	input.elk.SetValidatorByConsAddr(ctx, validator)
+	assert.False(t, handlerMsgRotateConsPubKey(ctx, input.elk, types.NewMsgRotateConsPubKey("", valAddr, oldConsPubKey, "0"), false).IsOK())
+
+	assert.True(t, handlerMsgRotateConsPubKey(ctx, input.elk, msg, false).IsOK())
+	validator, _ = input.elk.GetValidator(ctx, valAddr)
+	assert.Equal(t, newConsPubKey, validator.ConsPubKey)
+	_, found := input.elk.GetValidatorByConsAddr(ctx, sdk.GetConsAddress(oldConsPubKey))
+	assert.False(t, found)
+	validator, found = input.elk.GetValidatorByConsAddr(ctx, sdk.GetConsAddress(newConsPubKey))
+	assert.True(t, found)
+	assert.Equal(t, valAddr, validator.OperatorAddress)
+
+	rotations := input.elk.GetPendingConsKeyRotations(ctx)
+	assert.Equal(t, 1, len(rotations))
+	assert.Equal(t, oldConsPubKey, rotations[0].OldConsPubKey)
+
+	// cooldown
+	msg = types.NewMsgRotateConsPubKey("system:rotate_cons_pubkey", valAddr, otherConsPubKey, "0")
+	assert.False(t, handlerMsgRotateConsPubKey(ctx.WithBlockHeight(14), input.elk, msg, false).IsOK())
+	assert.True(t, handlerMsgRotateConsPubKey(ctx.WithBlockHeight(15), input.elk, msg, false).IsOK())
+
+	// the key tendermint holds is kept until the updates are sent
+	rotations = input.elk.GetPendingConsKeyRotations(ctx)
+	assert.Equal(t, 1, len(rotations))
+	assert.Equal(t, oldConsPubKey, rotations[0].OldConsPubKey)
+	input.elk.DeletePendingConsKeyRotation(ctx, valAddr)
+	assert.Equal(t, 0, len(input.elk.GetPendingConsKeyRotations(ctx)))
+}
+
+func TestStakeRequests(t *testing.T) {
+	input := setupTestInput()
+	ctx := input.ctx.WithBlockHeight(5).WithEventManager(sdk.NewEventManager())
+	input.elk.SetParams(ctx, types.NewParams(10, 10, 10, 10, 3))
+	delegator := sdk.AccAddress([]byte(strings.Repeat("d", 32)))
+	validator := sdk.AccAddress([]byte(strings.Repeat("v", 32)))
+
+	unbonding := input.elk.AddStakeRequest(ctx, types.StakeRequestUnbond, validator, validator, nil, "10")
+	redelegation := input.elk.AddStakeRequest(ctx, types.StakeRequestRedelegate, delegator, validator, delegator, "20")
+	assert.Equal(t, uint64(1), unbonding.ID)
+	assert.Equal(t, uint64(2), redelegation.ID)
+	assert.Equal(t, int64(8), redelegation.CompletionHeight)
+
+	assert.Equal(t, types.StakeRequests{redelegation}, input.elk.GetStakeRequestsByDelegator(ctx, delegator))
+	assert.Equal(t, types.StakeRequests{unbonding, redelegation}, input.elk.GetAllStakeRequests(ctx))
+
+	// the queries filter the requests by kind and validator
+	querier := NewQuerier(input.elk)
+	res, err := querier(ctx, []string{QueryUnbonding}, abci.RequestQuery{Data: types.ModuleCdc.MustMarshalJSON(types.NewQueryDelegatorParams(nil, validator))})
+	assert.Nil(t, err)
+	var requests types.StakeRequests
+	types.ModuleCdc.MustUnmarshalJSON(res, &requests)
+	assert.Equal(t, types.StakeRequests{unbonding}, requests)
+	res, err = querier(ctx, []string{QueryRedelegation}, abci.RequestQuery{Data: types.ModuleCdc.MustMarshalJSON(types.NewQueryDelegatorParams(delegator, delegator))})
+	assert.Nil(t, err)
+	types.ModuleCdc.MustUnmarshalJSON(res, &requests)
+	assert.Equal(t, types.StakeRequests{redelegation}, requests)
+
+	// the requests complete at their completion height
+	completeStakeRequests(ctx.WithBlockHeight(7), input.elk)
+	assert.Equal(t, 0, len(ctx.EventManager().Events()))
+	assert.Equal(t, 2, len(input.elk.GetAllStakeRequests(ctx)))
+
+	completeStakeRequests(ctx.WithBlockHeight(8), input.elk)
+	assert.Equal(t, 2, len(ctx.EventManager().Events()))
+	assert.Equal(t, types.EventTypeCompleteUnbonding, ctx.EventManager().Events()[0].Type)
+	assert.Equal(t, 0, len(input.elk.GetAllStakeRequests(ctx)))
+	assert.Equal(t, 0, len(input.elk.GetStakeRequestsByDelegator(ctx, delegator)))
+	assert.Equal(t, uint64(3), input.elk.GetNextStakeRequestID(ctx))
+}
+
+func TestCommissionRateHistory(t *testing.T) {
+	input := setupTestInput()
+	ctx := input.ctx.WithBlockHeight(3).WithBlockTime(time.Unix(1000, 0))
+
+	valAddr := sdk.AccAddress([]byte(strings.Repeat("c", 20)))
+	rates := types.NewCommissionRates(sdk.NewDecWithPrec(1, 1), sdk.NewDecWithPrec(2, 1), sdk.NewDecWithPrec(1, 2))
+	msg := types.NewMsgCreateValidator("system:create_validator", valAddr, ed25519.GenPrivKey().PubKey(), types.Description{Moniker: "validator"}, rates, "0")
+	assert.True(t, handlerMsgCreateValidator(ctx, input.elk, msg, false).IsOK())
+
+	validator, found := input.elk.GetValidator(ctx, valAddr)
+	assert.True(t, found)
+	assert.True(t, rates.Rate.Equal(validator.Commission.Rate))
+	assert.True(t, rates.MaxRate.Equal(validator.Commission.MaxRate))
+	assert.Equal(t, ctx.BlockTime(), validator.Commission.UpdateTime)
+
+	input.elk.SetCommissionRateChange(ctx, valAddr, types.NewCommissionRateChange(10, time.Unix(90000, 0), sdk.NewDecWithPrec(11, 2)))
+	changes := input.elk.GetCommissionRateChanges(ctx, valAddr)
+	assert.Equal(t, 2, len(changes))
+	assert.Equal(t, int64(3), changes[0].Height)
+	assert.True(t, rates.Rate.Equal(changes[0].Rate))
+	assert.Equal(t, int64(10), changes[1].Height)
+	assert.Equal(t, 0, len(input.elk.GetCommissionRateChanges(ctx, sdk.AccAddress([]byte(strings.Repeat("d", 20))))))
+
+	// invalid rates
+	msg = types.NewMsgCreateValidator("system:create_validator", sdk.AccAddress([]byte(strings.Repeat("e", 20))), ed25519.GenPrivKey().PubKey(),
+		types.Description{Moniker: "validator"}, types.NewCommissionRates(sdk.OneDec(), sdk.NewDecWithPrec(2, 1), sdk.ZeroDec()), "0")
+	assert.NotNil(t, msg.ValidateBasic())
+	assert.False(t, handlerMsgCreateValidator(ctx, input.elk, msg, false).IsOK())
+
+	// a rate out of the bounds is rejected before the deploy
+	newRate := sdk.NewDecWithPrec(5, 1)
+	edit := types.NewMsgEditValidator("system:edit_validator", valAddr, types.Description{}, &newRate, "0")
+	res := handlerMsgEditValidator(ctx.WithBlockTime(time.Unix(100000, 0)), input.elk, edit, false)
+	assert.Equal(t, types.CodeInvalidValidator, res.Code)
+	edit = types.NewMsgEditValidator("system:edit_validator", sdk.AccAddress([]byte(strings.Repeat("d", 20))), types.Description{Moniker: "v"}, nil, "0")
+	assert.False(t, handlerMsgEditValidator(ctx, input.elk, edit, false).IsOK())
+}
+
+func TestMigrateCommissions(t *testing.T) {
+	input := setupTestInput()
+	ctx := input.ctx.WithBlockHeight(5).WithBlockTime(time.Unix(1000, 0))
+
+	// validators created before the commission rates
+	oldAddr := sdk.AccAddress([]byte(strings.Repeat("o", 20)))
+	input.elk.SetValidator(ctx, oldAddr, types.NewValidator(oldAddr, ed25519.GenPrivKey().PubKey(), types.Description{Moniker: "old"}, "1"))
+	zeroAddr := sdk.AccAddress([]byte(strings.Repeat("z", 20)))
+	zero := types.NewValidator(zeroAddr, ed25519.GenPrivKey().PubKey(), types.Description{Moniker: "zero"}, "1")
+	zero.Commission = types.NewCommissionWithTime(sdk.ZeroDec(), sdk.ZeroDec(), sdk.ZeroDec(), time.Time{})
+	input.elk.SetValidator(ctx, zeroAddr, zero)
+	newAddr := sdk.AccAddress([]byte(strings.Repeat("n", 20)))
+	rates := types.NewCommissionRates(sdk.NewDecWithPrec(5, 2), sdk.NewDecWithPrec(1, 1), sdk.NewDecWithPrec(1, 2))
+	created := types.NewValidator(newAddr, ed25519.GenPrivKey().PubKey(), types.Description{Moniker: "new"}, "1")
+	created.Commission = types.NewCommissionWithTime(rates.Rate, rates.MaxRate, rates.MaxChangeRate, time.Unix(10, 0))
+	input.elk.SetValidator(ctx, newAddr, created)
+
+	migrated := input.elk.MigrateCommissions(ctx)
+	assert.Equal(t, 2, len(migrated))
+	assert.Equal(t, 0, len(input.elk.MigrateCommissions(ctx)))
+
+	defaults := types.DefaultCommissionRates()
+	for _, addr := range []sdk.AccAddress{oldAddr, zeroAddr} {
+		validator, _ := input.elk.GetValidator(ctx, addr)
+		assert.True(t, defaults.Rate.Equal(validator.Commission.Rate))
+		assert.True(t, defaults.MaxRate.Equal(validator.Commission.MaxRate))
+		assert.Equal(t, ctx.BlockTime(), validator.Commission.UpdateTime)
+		assert.Equal(t, 1, len(input.elk.GetCommissionRateChanges(ctx, addr)))
+	}
+	validator, _ := input.elk.GetValidator(ctx, newAddr)
+	assert.True(t, rates.Rate.Equal(validator.Commission.Rate))
+}
+
+func TestGrants(t *testing.T) {
+	input := setupTestInput()
+	now := time.Unix(1000, 0).UTC()
+	ctx := input.ctx.WithBlockTime(now).WithChainID("test-chain")
+
+	granter := sdk.AccAddress([]byte(strings.Repeat("g", 32)))
+	grantee := sdk.AccAddress([]byte(strings.Repeat("e", 32)))
+	otherGranter := sdk.AccAddress([]byte(strings.Repeat("o", 32)))
+
+	// granting
+	msg := types.NewMsgGrant(granter, grantee, []string{types.GrantMsgTypeClaim, types.GrantMsgTypeDelegate}, "100", now.Add(time.Hour))
+	assert.Nil(t, msg.ValidateBasic())
+	assert.False(t, handlerMsgGrant(ctx, input.elk, types.NewMsgGrant(granter, grantee, msg.MsgTypes, "", now), false).IsOK())
+	assert.True(t, handlerMsgGrant(ctx, input.elk, msg, false).IsOK())
+	input.elk.SetGrant(ctx, types.NewGrant(otherGranter, grantee, []string{types.GrantMsgTypeClaim}, "", now.Add(2*time.Hour)))
+
+	grant, found := input.elk.GetGrant(ctx, granter, grantee)
+	assert.True(t, found)
+	assert.Equal(t, "100", grant.SpendLimit)
+	assert.Equal(t, 1, len(input.elk.GetGrantsByGranter(ctx, granter)))
+	assert.Equal(t, 2, len(input.elk.GetGrantsByGrantee(ctx, grantee)))
+	assert.Equal(t, 2, len(input.elk.GetAllGrants(ctx)))
+
+	// the grant has to allow the msgs, and the msgs spend within the limit in total
+	charge := func(msgs ...sdk.Msg) sdk.CodeType {
+		if err := chargeGrants(ctx, input.elk, []sdk.Msg{types.NewMsgExec(grantee, msgs)}); err != nil {
+			return err.Code()
+		}
+		return sdk.CodeOK
+	}
+	assert.Equal(t, types.CodeMsgNotGranted, charge(types.NewMsgUnBond("system:unbond", granter, "1", "1")))
+	assert.Equal(t, types.CodeSpendLimitExceeded, charge(types.NewMsgDelegate("system:delegate", granter, grantee, "100", "1")))
+	assert.Equal(t, types.CodeSpendLimitExceeded, charge(
+		types.NewMsgDelegate("system:delegate", granter, grantee, "50", "1"),
+		types.NewMsgDelegate("system:delegate", granter, grantee, "49", "1"),
+	))
+	assert.Equal(t, types.CodeGrantNotFound, charge(types.NewMsgClaim("system:claim_reward", grantee, true, "1")))
+	assert.Equal(t, types.CodeGrantExpired, chargeGrants(ctx.WithBlockTime(now.Add(time.Hour)), input.elk,
+		[]sdk.Msg{types.NewMsgExec(grantee, []sdk.Msg{types.NewMsgClaim("system:claim_reward", granter, true, "1")})}).Code())
+
+	// an exec shares its tx with authorizations only
+	claim := types.NewMsgClaim("system:claim_reward", granter, true, "1")
+	assert.Equal(t, types.CodeInvalidExec, chargeGrants(ctx, input.elk, []sdk.Msg{types.NewMsgExec(grantee, []sdk.Msg{claim}), claim}).Code())
+	assert.Nil(t, chargeGrants(ctx, input.elk, []sdk.Msg{types.NewMsgExec(grantee, []sdk.Msg{claim}), types.NewMsgAuthorize(granter)}))
+
+	// the spending of all the msgs is charged before any of them is handled
+	assert.Equal(t, sdk.CodeOK, charge(
+		types.NewMsgDelegate("system:delegate", granter, grantee, "40", "5"),
+		types.NewMsgDelegate("system:delegate", granter, grantee, "40", "5"),
+	))
+	grant, found = input.elk.GetGrant(ctx, granter, grantee)
+	assert.True(t, found)
+	assert.Equal(t, "9", grant.SpendLimit)
+
+	// the handler runs the msgs of charged execs only
+	assert.Equal(t, types.CodeInvalidExec, handlerMsgExec(ctx, input.elk, types.NewMsgExec(grantee, []sdk.Msg{claim}), false).Code)
+
+	// the deploys of an exec are derived from the grantee's signature
+	execCtx := withExec(ctx, grantee, 1)
+	execHash := types.NewDeployHash("test-chain", grantee, 0, 0)
+	assert.Equal(t, types.NewExecDeployHash(execHash, granter, 1), getDeployHash(execCtx, input.elk, granter))
+	assert.NotEqual(t, getDeployHash(execCtx, input.elk, granter), getDeployHash(withExec(ctx, grantee, 0), input.elk, granter))
+
+	// revoking
+	assert.True(t, handlerMsgRevoke(ctx, input.elk, types.NewMsgRevoke(granter, grantee), false).IsOK())
+	assert.False(t, handlerMsgRevoke(ctx, input.elk, types.NewMsgRevoke(granter, grantee), false).IsOK())
+	assert.Equal(t, 1, len(input.elk.GetGrantsByGrantee(ctx, grantee)))
+
+	// pruning at the expiration
+	input.elk.PruneExpiredGrants(ctx, now.Add(2*time.Hour-time.Nanosecond))
+	assert.Equal(t, 1, len(input.elk.GetAllGrants(ctx)))
+	input.elk.PruneExpiredGrants(ctx, now.Add(2*time.Hour))
+	assert.Equal(t, 0, len(input.elk.GetAllGrants(ctx)))
+	assert.Equal(t, 0, len(input.elk.GetGrantsByGrantee(ctx, grantee)))
+}
+
+func TestFeeAllowances(t *testing.T) {
+	input := setupTestInput()
+	now := time.Unix(1000, 0).UTC()
+	ctx := input.ctx.WithBlockTime(now)
+
+	payer := sdk.AccAddress([]byte(strings.Repeat("p", 32)))
+	sender := sdk.AccAddress([]byte(strings.Repeat("s", 32)))
+	otherPayer := sdk.AccAddress([]byte(strings.Repeat("o", 32)))
+
+	// granting
+	msg := types.NewMsgGrantFeeAllowance(payer, sender, "100", now.Add(time.Hour))
+	assert.Nil(t, msg.ValidateBasic())
+	assert.False(t, handlerMsgGrantFeeAllowance(ctx, input.elk, types.NewMsgGrantFeeAllowance(payer, sender, "", now), false).IsOK())
+	assert.True(t, handlerMsgGrantFeeAllowance(ctx, input.elk, msg, false).IsOK())
+	input.elk.SetFeeAllowance(ctx, types.NewFeeAllowance(otherPayer, sender, "", now.Add(2*time.Hour)))
+
+	assert.Equal(t, 1, len(input.elk.GetFeeAllowancesByPayer(ctx, payer)))
+	assert.Equal(t, 2, len(input.elk.GetFeeAllowancesByGrantee(ctx, sender)))
+	assert.Equal(t, 2, len(input.elk.GetAllFeeAllowances(ctx)))
+
+	// the payer co-signs, and the fee is within the allowance
+	sponsored := func(fee string, feePayer sdk.AccAddress) types.DeployMsg {
+		header := types.NewDeployHeader(now.UnixNano()/int64(time.Millisecond), 0, nil)
+		header.FeePayer = feePayer
+		return types.NewMsgClaim("system:claim_reward", sender, true, fee).WithDeployHeader(header)
+	}
+	signedCtx := auth.WithTxSigners(ctx, []sdk.AccAddress{sender, payer, otherPayer})
+
+	charge := func(ctx sdk.Context, msgs ...sdk.Msg) sdk.Error {
+		return chargeFeeAllowances(ctx, input.elk, msgs)
+	}
+
+	assert.Equal(t, types.CodeFeePayerNotSigned, charge(ctx, sponsored("10", payer)).Code())
+	assert.Equal(t, types.CodeInvalidDeployHeader, charge(signedCtx, sponsored("10", sender)).Code())
+	assert.Equal(t, types.CodeSpendLimitExceeded, charge(signedCtx, sponsored("51", payer)).Code())
+	assert.Equal(t, types.CodeFeeAllowanceExpired, charge(signedCtx.WithBlockTime(now.Add(time.Hour)), sponsored("10", payer)).Code())
+
+	// the fee and the fee of the transfer funding it are charged, in total over the msgs of the tx
+	assert.Equal(t, types.CodeSpendLimitExceeded, charge(signedCtx, sponsored("30", payer), sponsored("21", payer)).Code())
+	assert.Nil(t, charge(signedCtx, sponsored("10", payer), types.NewMsgClaim("system:claim_reward", sender, true, "10")))
+	allowance, found := input.elk.GetFeeAllowance(ctx, payer, sender)
+	assert.True(t, found)
+	assert.Equal(t, "80", allowance.SpendLimit)
+
+	// including the msgs of an exec
+	assert.Nil(t, charge(signedCtx, types.NewMsgExec(otherPayer, []sdk.Msg{sponsored("5", payer)})))
+	allowance, _ = input.elk.GetFeeAllowance(ctx, payer, sender)
+	assert.Equal(t, "70", allowance.SpendLimit)
+
+	// the handler funds the fee from the payer only after the ante handler charged the allowance
+	_, err := withFeePayer(ctx, sponsored("10", payer))
+	assert.Equal(t, types.CodeInvalidDeployHeader, err.Code())
+	chargedCtx := ctx.WithValue(chargedKey{}, true)
+	payerCtx, err := withFeePayer(chargedCtx, sponsored("10", payer))
+	assert.Nil(t, err)
+	assert.Equal(t, payer, getFeePayer(payerCtx))
+	unsponsoredCtx, err := withFeePayer(chargedCtx, types.NewMsgClaim("system:claim_reward", sender, true, "10"))
+	assert.Nil(t, err)
+	assert.True(t, getFeePayer(unsponsoredCtx).Empty())
+
+	// revoking
+	assert.True(t, handlerMsgRevokeFeeAllowance(ctx, input.elk, types.NewMsgRevokeFeeAllowance(payer, sender), false).IsOK())
+	assert.False(t, handlerMsgRevokeFeeAllowance(ctx, input.elk, types.NewMsgRevokeFeeAllowance(payer, sender), false).IsOK())
+	assert.Equal(t, types.CodeFeeAllowanceNotFound, charge(signedCtx, sponsored("10", payer)).Code())
+
+	// pruning at the expiration
+	input.elk.PruneExpiredFeeAllowances(ctx, now.Add(2*time.Hour-time.Nanosecond))
+	assert.Equal(t, 1, len(input.elk.GetAllFeeAllowances(ctx)))
+	input.elk.PruneExpiredFeeAllowances(ctx, now.Add(2*time.Hour))
+	assert.Equal(t, 0, len(input.elk.GetAllFeeAllowances(ctx)))
+	assert.Equal(t, 0, len(input.elk.GetFeeAllowancesByGrantee(ctx, sender)))
+}
+
+func TestSchedules(t *testing.T) {
+	input := setupTestInput()
+	ctx := input.ctx.WithBlockHeight(10)
+	input.elk.SetParams(ctx, types.NewParams(10, 10, 10, 2, 0))
+
+	sender := sdk.AccAddress([]byte(strings.Repeat("s", 32)))
+	recipient := sdk.AccAddress([]byte(strings.Repeat("r", 32)))
+	other := sdk.AccAddress([]byte(strings.Repeat("o", 32)))
+
+	// the start height cannot be in the past
+	res := handlerMsgScheduleTransfer(ctx, input.elk, types.NewMsgScheduleTransfer("transfer", sender, recipient, "100", "10", 9, 0, 1, "10"), false)
+	assert.Equal(t, types.CodeInvalidSchedule, res.Code)
+
+	assert.Equal(t, uint64(1), input.elk.GetNextScheduleID(ctx))
+	input.elk.SetSchedule(ctx, types.NewSchedule(1, sender, recipient, "100", "10", 12, 5, 3))
+	input.elk.SetSchedule(ctx, types.NewSchedule(2, sender, other, "100", "10", 11, 0, 1))
+	input.elk.SetSchedule(ctx, types.NewSchedule(3, other, recipient, "100", "10", 11, 0, 1))
+	input.elk.SetSchedule(ctx, types.NewSchedule(4, other, recipient, "100", "10", 20, 0, 1))
+
+	assert.Equal(t, 2, len(input.elk.GetSchedulesBySender(ctx, sender)))
+	assert.Equal(t, 3, len(input.elk.GetSchedulesByRecipient(ctx, recipient)))
+	assert.Equal(t, 4, len(input.elk.GetAllSchedules(ctx)))
+
+	// the due schedules are in the order of their heights, up to the limit
+	assert.Equal(t, 0, len(input.elk.GetDueSchedules(ctx, 10, 10)))
+	due := input.elk.GetDueSchedules(ctx, 12, 10)
+	assert.Equal(t, 3, len(due))
+	assert.Equal(t, uint64(2), due[0].ID)
+	assert.Equal(t, uint64(3), due[1].ID)
+	assert.Equal(t, uint64(1), due[2].ID)
+	assert.Equal(t, 2, len(input.elk.GetDueSchedules(ctx, 12, 2)))
+
+	// the schedule is requeued at its next height
+	input.elk.SetSchedule(ctx, due[2].Next(sdk.NewInt(220)))
+	due = input.elk.GetDueSchedules(ctx, 12, 10)
+	assert.Equal(t, 2, len(due))
+	due = input.elk.GetDueSchedules(ctx, 17, 10)
+	assert.Equal(t, uint64(1), due[2].ID)
+	assert.Equal(t, uint64(2), due[2].Remaining)
+	assert.Equal(t, "220", due[2].Escrow)
+
+	// failed schedules are not due
+	failed, _ := input.elk.GetSchedule(ctx, 2)
+	failed.Status = types.ScheduleStatusFailed
+	input.elk.SetSchedule(ctx, failed)
+	assert.Equal(t, 2, len(input.elk.GetDueSchedules(ctx, 17, 10)))
+
+	// only the sender cancels its schedule
+	res = handlerMsgCancelSchedule(ctx, input.elk, types.NewMsgCancelSchedule(other, 1), false)
+	assert.Equal(t, types.CodeScheduleNotFound, res.Code)
+	res = handlerMsgCancelSchedule(ctx, input.elk, types.NewMsgCancelSchedule(sender, 5), false)
+	assert.Equal(t, types.CodeScheduleNotFound, res.Code)
+
+	// a cancelled schedule is queued at the current height to be refunded, once
+	res = handlerMsgCancelSchedule(ctx, input.elk, types.NewMsgCancelSchedule(sender, 2), false)
+	assert.True(t, res.IsOK())
+	due = input.elk.GetDueSchedules(ctx, 10, 10)
+	assert.Equal(t, 1, len(due))
+	assert.Equal(t, uint64(2), due[0].ID)
+	assert.Equal(t, types.ScheduleStatusCancelled, due[0].Status)
+	res = handlerMsgCancelSchedule(ctx, input.elk, types.NewMsgCancelSchedule(sender, 2), false)
+	assert.Equal(t, types.CodeScheduleNotFound, res.Code)
+
+	input.elk.DeleteSchedule(ctx, 1)
+	_, found := input.elk.GetSchedule(ctx, 1)
+	assert.False(t, found)
+	assert.Equal(t, 1, len(input.elk.GetSchedulesBySender(ctx, sender)))
+	assert.Equal(t, 2, len(input.elk.GetDueSchedules(ctx, 17, 10)))
+}
+
+func TestCheckScheduleTransfers(t *testing.T) {
+	sender := sdk.AccAddress([]byte(strings.Repeat("s", 32)))
+	recipient := sdk.AccAddress([]byte(strings.Repeat("r", 32)))
+	schedule := types.NewMsgScheduleTransfer("transfer", sender, recipient, "100", "10", 9, 0, 1, "10")
+	transfer := types.NewMsgTransfer("transfer", sender, recipient, "100", "10")
+
+	assert.Nil(t, checkScheduleTransfers([]sdk.Msg{schedule}))
+	assert.Nil(t, checkScheduleTransfers([]sdk.Msg{schedule, types.NewMsgAuthorize(recipient)}))
+	assert.Nil(t, checkScheduleTransfers([]sdk.Msg{transfer, transfer}))
+	assert.Equal(t, types.CodeInvalidSchedule, checkScheduleTransfers([]sdk.Msg{schedule, transfer}).Code())
+	assert.Equal(t, types.CodeInvalidSchedule, checkScheduleTransfers([]sdk.Msg{transfer, schedule}).Code())
+	assert.Equal(t, types.CodeInvalidSchedule, checkScheduleTransfers([]sdk.Msg{schedule, schedule}).Code())
+}
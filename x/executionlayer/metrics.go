@@ -0,0 +1,232 @@
+package executionlayer
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/ipc"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "executionlayer"
+)
+
+// Metrics contains metrics exposed by this package.
+type Metrics struct {
+	// Duration of the requests to the EE, by the method and whether it failed.
+	EERequestDuration metrics.Histogram
+	// Number of handled msgs, by the type of the msg and whether it failed.
+	Msgs metrics.Counter
+	// Number of executed deploys, by the result of the EE: ok, gas_error,
+	// exec_error, missing_parent or error.
+	Deploys metrics.Counter
+	// Number of deploys executed in the block.
+	BlockDeploys metrics.Gauge
+	// Cost of the deploys executed in the block.
+	BlockCost metrics.Gauge
+	// Number of validators with stake.
+	Validators metrics.Gauge
+	// Number of voting power updates of the validators, by the stake changes.
+	ValidatorUpdates metrics.Counter
+	// Duration of the EndBlocker.
+	EndBlockerDuration metrics.Histogram
+}
+
+// PrometheusMetrics returns Metrics build using Prometheus client library.
+// Optionally, labels can be provided along with their values ("foo",
+// "fooValue"). The metrics of the apps of a process share the collectors,
+// so it can be called more than once with the same namespace.
+func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	return &Metrics{
+		EERequestDuration: prometheus.NewHistogram(registerHistogram(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "ee_request_duration_seconds",
+			Help:      "Duration of the requests to the execution engine.",
+			Buckets:   stdprometheus.DefBuckets,
+		}, withLabels(labels, "method", "result"))).With(labelsAndValues...),
+		Msgs: prometheus.NewCounter(registerCounter(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "msgs",
+			Help:      "Number of handled msgs.",
+		}, withLabels(labels, "msg_type", "result"))).With(labelsAndValues...),
+		Deploys: prometheus.NewCounter(registerCounter(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "deploys",
+			Help:      "Number of executed deploys.",
+		}, withLabels(labels, "result"))).With(labelsAndValues...),
+		BlockDeploys: prometheus.NewGauge(registerGauge(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "block_deploys",
+			Help:      "Number of deploys executed in the block.",
+		}, labels)).With(labelsAndValues...),
+		BlockCost: prometheus.NewGauge(registerGauge(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "block_cost",
+			Help:      "Cost of the deploys executed in the block.",
+		}, labels)).With(labelsAndValues...),
+		Validators: prometheus.NewGauge(registerGauge(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "validators",
+			Help:      "Number of validators with stake.",
+		}, labels)).With(labelsAndValues...),
+		ValidatorUpdates: prometheus.NewCounter(registerCounter(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "validator_updates",
+			Help:      "Number of voting power updates of the validators.",
+		}, labels)).With(labelsAndValues...),
+		EndBlockerDuration: prometheus.NewHistogram(registerHistogram(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "end_blocker_duration_seconds",
+			Help:      "Duration of the EndBlocker.",
+			Buckets:   stdprometheus.DefBuckets,
+		}, labels)).With(labelsAndValues...),
+	}
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		EERequestDuration:  discard.NewHistogram(),
+		Msgs:               discard.NewCounter(),
+		Deploys:            discard.NewCounter(),
+		BlockDeploys:       discard.NewGauge(),
+		BlockCost:          discard.NewGauge(),
+		Validators:         discard.NewGauge(),
+		ValidatorUpdates:   discard.NewCounter(),
+		EndBlockerDuration: discard.NewHistogram(),
+	}
+}
+
+// withLabels returns the labels followed by the labels of a metric
+func withLabels(labels []string, metricLabels ...string) []string {
+	return append(append([]string{}, labels...), metricLabels...)
+}
+
+// registerCounter registers the counter to the default registerer, or returns
+// the one already registered
+func registerCounter(opts stdprometheus.CounterOpts, labels []string) *stdprometheus.CounterVec {
+	cv := stdprometheus.NewCounterVec(opts, labels)
+	if err := stdprometheus.Register(cv); err != nil {
+		if are, ok := err.(stdprometheus.AlreadyRegisteredError); ok {
+			return are.ExistingCollector.(*stdprometheus.CounterVec)
+		}
+		panic(err)
+	}
+	return cv
+}
+
+// registerGauge registers the gauge to the default registerer, or returns
+// the one already registered
+func registerGauge(opts stdprometheus.GaugeOpts, labels []string) *stdprometheus.GaugeVec {
+	gv := stdprometheus.NewGaugeVec(opts, labels)
+	if err := stdprometheus.Register(gv); err != nil {
+		if are, ok := err.(stdprometheus.AlreadyRegisteredError); ok {
+			return are.ExistingCollector.(*stdprometheus.GaugeVec)
+		}
+		panic(err)
+	}
+	return gv
+}
+
+// registerHistogram registers the histogram to the default registerer, or
+// returns the one already registered
+func registerHistogram(opts stdprometheus.HistogramOpts, labels []string) *stdprometheus.HistogramVec {
+	hv := stdprometheus.NewHistogramVec(opts, labels)
+	if err := stdprometheus.Register(hv); err != nil {
+		if are, ok := err.(stdprometheus.AlreadyRegisteredError); ok {
+			return are.ExistingCollector.(*stdprometheus.HistogramVec)
+		}
+		panic(err)
+	}
+	return hv
+}
+
+// resultLabel returns the value of the result label of a metric
+func resultLabel(ok bool) string {
+	if ok {
+		return "ok"
+	}
+	return "failed"
+}
+
+// instrumentedClient is an EE client observing the duration of the requests
+type instrumentedClient struct {
+	client  ipc.ExecutionEngineServiceClient
+	metrics *Metrics
+}
+
+var _ ipc.ExecutionEngineServiceClient = instrumentedClient{}
+
+func (c instrumentedClient) observe(method string, start time.Time, err error) {
+	c.metrics.EERequestDuration.With("method", method, "result", resultLabel(err == nil)).Observe(time.Since(start).Seconds())
+}
+
+func (c instrumentedClient) Commit(ctx context.Context, in *ipc.CommitRequest, opts ...grpc.CallOption) (res *ipc.CommitResponse, err error) {
+	defer func(start time.Time) { c.observe("commit", start, err) }(time.Now())
+	return c.client.Commit(ctx, in, opts...)
+}
+
+func (c instrumentedClient) Query(ctx context.Context, in *ipc.QueryRequest, opts ...grpc.CallOption) (res *ipc.QueryResponse, err error) {
+	defer func(start time.Time) { c.observe("query", start, err) }(time.Now())
+	return c.client.Query(ctx, in, opts...)
+}
+
+func (c instrumentedClient) Execute(ctx context.Context, in *ipc.ExecuteRequest, opts ...grpc.CallOption) (res *ipc.ExecuteResponse, err error) {
+	defer func(start time.Time) { c.observe("execute", start, err) }(time.Now())
+	return c.client.Execute(ctx, in, opts...)
+}
+
+func (c instrumentedClient) RunGenesis(ctx context.Context, in *ipc.ChainSpec_GenesisConfig, opts ...grpc.CallOption) (res *ipc.GenesisResponse, err error) {
+	defer func(start time.Time) { c.observe("run_genesis", start, err) }(time.Now())
+	return c.client.RunGenesis(ctx, in, opts...)
+}
+
+func (c instrumentedClient) Upgrade(ctx context.Context, in *ipc.UpgradeRequest, opts ...grpc.CallOption) (res *ipc.UpgradeResponse, err error) {
+	defer func(start time.Time) { c.observe("upgrade", start, err) }(time.Now())
+	return c.client.Upgrade(ctx, in, opts...)
+}
+
+func (c instrumentedClient) BidState(ctx context.Context, in *ipc.BidStateRequest, opts ...grpc.CallOption) (res *ipc.BidStateResponse, err error) {
+	defer func(start time.Time) { c.observe("bid_state", start, err) }(time.Now())
+	return c.client.BidState(ctx, in, opts...)
+}
+
+func (c instrumentedClient) DistributeRewards(ctx context.Context, in *ipc.DistributeRewardsRequest, opts ...grpc.CallOption) (res *ipc.DistributeRewardsResponse, err error) {
+	defer func(start time.Time) { c.observe("distribute_rewards", start, err) }(time.Now())
+	return c.client.DistributeRewards(ctx, in, opts...)
+}
+
+func (c instrumentedClient) Slash(ctx context.Context, in *ipc.SlashRequest, opts ...grpc.CallOption) (res *ipc.SlashResponse, err error) {
+	defer func(start time.Time) { c.observe("slash", start, err) }(time.Now())
+	return c.client.Slash(ctx, in, opts...)
+}
+
+func (c instrumentedClient) UnbondPayout(ctx context.Context, in *ipc.UnbondPayoutRequest, opts ...grpc.CallOption) (res *ipc.UnbondPayoutResponse, err error) {
+	defer func(start time.Time) { c.observe("unbond_payout", start, err) }(time.Now())
+	return c.client.UnbondPayout(ctx, in, opts...)
+}
+
+func (c instrumentedClient) Step(ctx context.Context, in *ipc.StepRequest, opts ...grpc.CallOption) (res *ipc.StepResponse, err error) {
+	defer func(start time.Time) { c.observe("step", start, err) }(time.Now())
+	return c.client.Step(ctx, in, opts...)
+}
@@ -0,0 +1,67 @@
+package executionlayer
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/ipc"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+type stepClient struct {
+	ipc.ExecutionEngineServiceClient
+	err error
+}
+
+func (c stepClient) Step(ctx context.Context, in *ipc.StepRequest, opts ...grpc.CallOption) (*ipc.StepResponse, error) {
+	return &ipc.StepResponse{}, c.err
+}
+
+func gatherMetric(t *testing.T, name string, labels map[string]string) *dto.Metric {
+	families, err := stdprometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+	metrics:
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if labels[label.GetName()] != label.GetValue() {
+					continue metrics
+				}
+			}
+			return metric
+		}
+	}
+	return nil
+}
+
+func TestPrometheusMetrics(t *testing.T) {
+	// the apps of a process share the collectors
+	metrics := PrometheusMetrics("test", "chain_id", "test-chain")
+	require.NotPanics(t, func() { PrometheusMetrics("test", "chain_id", "test-chain") })
+
+	metrics.Msgs.With("msg_type", "transfer", "result", resultLabel(true)).Add(1)
+	PrometheusMetrics("test", "chain_id", "test-chain").Msgs.With("msg_type", "transfer", "result", resultLabel(true)).Add(1)
+	msgs := gatherMetric(t, "test_executionlayer_msgs", map[string]string{"chain_id": "test-chain", "msg_type": "transfer", "result": "ok"})
+	require.NotNil(t, msgs)
+	require.Equal(t, float64(2), msgs.GetCounter().GetValue())
+
+	client := instrumentedClient{client: stepClient{}, metrics: metrics}
+	_, err := client.Step(context.Background(), &ipc.StepRequest{})
+	require.NoError(t, err)
+	client = instrumentedClient{client: stepClient{err: fmt.Errorf("unavailable")}, metrics: metrics}
+	_, err = client.Step(context.Background(), &ipc.StepRequest{})
+	require.Error(t, err)
+
+	for _, result := range []string{"ok", "failed"} {
+		duration := gatherMetric(t, "test_executionlayer_ee_request_duration_seconds", map[string]string{"chain_id": "test-chain", "method": "step", "result": result})
+		require.NotNil(t, duration)
+		require.Equal(t, uint64(1), duration.GetHistogram().GetSampleCount())
+	}
+}
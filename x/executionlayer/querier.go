@@ -3,6 +3,7 @@ package executionlayer
 import (
 	"bytes"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -19,6 +20,7 @@ import (
 
 const (
 	QueryEEDetail        = "querydetail"
+	QueryEEDecodedDetail = "querydecodeddetail"
 	QueryEEBalanceDetail = "querybalancedetail"
 	QueryStakeDetail     = "querystakedetail"
 	QueryVoteDetail      = "queryvotedetail"
@@ -31,6 +33,25 @@ const (
 
 	QueryReward     = "queryreward"
 	QueryCommission = "querycommission"
+
+	QueryCommissionRates = "querycommissionrates"
+
+	QueryRewardHistory     = "queryrewardhistory"
+	QueryCommissionHistory = "querycommissionhistory"
+	QueryParams            = "queryparams"
+
+	QueryContract      = "querycontract"
+	QueryContractByKey = "querycontractbykey"
+	QueryDeploy        = "querydeploy"
+
+	QueryGrants        = "querygrants"
+	QueryFeeAllowances = "queryfeeallowances"
+	QuerySchedules     = "queryschedules"
+
+	QueryUnbonding    = "queryunbonding"
+	QueryRedelegation = "queryredelegation"
+
+	QueryDryRun = "querydryrun"
 )
 
 // NewQuerier is the module level router for state queries
@@ -39,6 +60,8 @@ func NewQuerier(keeper ExecutionLayerKeeper) sdk.Querier {
 		switch path[0] {
 		case QueryEEDetail:
 			return queryEEDetail(ctx, path[1:], req, keeper)
+		case QueryEEDecodedDetail:
+			return queryEEDecodedDetail(ctx, req, keeper)
 		case QueryEEBalanceDetail:
 			return queryBalanceDetail(ctx, path[1:], req, keeper)
 		case QueryStakeDetail:
@@ -57,6 +80,32 @@ func NewQuerier(keeper ExecutionLayerKeeper) sdk.Querier {
 			return queryReward(ctx, req, keeper)
 		case QueryCommission:
 			return queryCommission(ctx, req, keeper)
+		case QueryCommissionRates:
+			return queryCommissionRates(ctx, req, keeper)
+		case QueryRewardHistory:
+			return queryRewardHistory(ctx, req, keeper)
+		case QueryCommissionHistory:
+			return queryCommissionHistory(ctx, req, keeper)
+		case QueryParams:
+			return queryParams(ctx, keeper)
+		case QueryContract:
+			return queryContract(ctx, req, keeper)
+		case QueryContractByKey:
+			return queryContractByKey(ctx, req, keeper)
+		case QueryDeploy:
+			return queryDeploy(ctx, req, keeper)
+		case QueryGrants:
+			return queryGrants(ctx, req, keeper)
+		case QueryFeeAllowances:
+			return queryFeeAllowances(ctx, req, keeper)
+		case QuerySchedules:
+			return querySchedules(ctx, req, keeper)
+		case QueryUnbonding:
+			return queryUnbonding(ctx, req, keeper)
+		case QueryRedelegation:
+			return queryRedelegation(ctx, req, keeper)
+		case QueryDryRun:
+			return queryDryRun(ctx, req, keeper)
 		default:
 			return nil, sdk.ErrUnknownRequest("unknown ee query")
 		}
@@ -79,6 +128,33 @@ func queryEEDetail(ctx sdk.Context, path []string, req abci.RequestQuery, keeper
 	return res, nil
 }
 
+func queryEEDecodedDetail(ctx sdk.Context, req abci.RequestQuery, keeper ExecutionLayerKeeper) ([]byte, sdk.Error) {
+	var param QueryExecutionLayerDetail
+	err := types.ModuleCdc.UnmarshalJSON(req.Data, &param)
+	if err != nil {
+		return nil, sdk.NewError(sdk.CodespaceUndefined, sdk.CodeUnknownRequest, "Bad request: {}", err.Error())
+	}
+
+	ctx = ctx.WithBlockHeight(req.Height)
+	res, err := getQueryResult(ctx, keeper, param.KeyType, param.KeyData, param.Path)
+	if err != nil {
+		return nil, sdk.NewError(sdk.CodespaceUndefined, sdk.CodeUnknownRequest, err.Error())
+	}
+
+	var storedValue storedvalue.StoredValue
+	storedValue, err, _ = storedValue.FromBytes(res)
+	if err != nil {
+		return nil, sdk.NewError(sdk.CodespaceUndefined, sdk.CodeUnknownRequest, "Bad request: {}", err.Error())
+	}
+
+	bz, err := types.MarshalStoredValueJSON(storedValue)
+	if err != nil {
+		return nil, sdk.NewError(sdk.CodespaceUndefined, sdk.CodeUnknownRequest, "Bad request: {}", err.Error())
+	}
+
+	return bz, nil
+}
+
 func queryBalanceDetail(ctx sdk.Context, path []string, req abci.RequestQuery, keeper ExecutionLayerKeeper) ([]byte, sdk.Error) {
 	var param QueryGetBalanceDetail
 	err := types.ModuleCdc.UnmarshalJSON(req.Data, &param)
@@ -86,7 +162,10 @@ func queryBalanceDetail(ctx sdk.Context, path []string, req abci.RequestQuery, k
 		return nil, sdk.NewError(sdk.CodespaceUndefined, sdk.CodeUnknownRequest, "Bad request: {}", err.Error())
 	}
 
-	eeState := keeper.GetUnitHashMap(ctx, req.GetHeight()).EEState
+	eeState, sdkErr := keeper.GetEEState(ctx, req.GetHeight())
+	if sdkErr != nil {
+		return nil, sdkErr
+	}
 	protocolVersion := keeper.GetProtocolVersion(ctx)
 	val, errMsg := grpc.QueryBalance(keeper.client, eeState, param.Address, &protocolVersion)
 	if errMsg != "" {
@@ -112,7 +191,10 @@ func queryStakeDetail(ctx sdk.Context, req abci.RequestQuery, keeper ExecutionLa
 		return nil, sdk.NewError(sdk.CodespaceUndefined, sdk.CodeUnknownRequest, "Bad request: {}", err.Error())
 	}
 
-	eeState := keeper.GetUnitHashMap(ctx, req.GetHeight()).EEState
+	eeState, sdkErr := keeper.GetEEState(ctx, req.GetHeight())
+	if sdkErr != nil {
+		return nil, sdkErr
+	}
 	protocolVersion := keeper.GetProtocolVersion(ctx)
 	val, errMsg := grpc.QueryStake(keeper.client, eeState, param.Address, &protocolVersion)
 	if errMsg != "" {
@@ -138,7 +220,10 @@ func queryVoteDetail(ctx sdk.Context, req abci.RequestQuery, keeper ExecutionLay
 		return nil, sdk.NewError(sdk.CodespaceUndefined, sdk.CodeUnknownRequest, "Bad request: {}", err.Error())
 	}
 
-	eeState := keeper.GetUnitHashMap(ctx, req.GetHeight()).EEState
+	eeState, sdkErr := keeper.GetEEState(ctx, req.GetHeight())
+	if sdkErr != nil {
+		return nil, sdkErr
+	}
 	protocolVersion := keeper.GetProtocolVersion(ctx)
 
 	val := ""
@@ -215,6 +300,28 @@ func queryValidator(ctx sdk.Context, req abci.RequestQuery, keeper ExecutionLaye
 	return res, nil
 }
 
+func queryCommissionRates(ctx sdk.Context, req abci.RequestQuery, keeper ExecutionLayerKeeper) ([]byte, sdk.Error) {
+	var param QueryValidatorParams
+	err := types.ModuleCdc.UnmarshalJSON(req.Data, &param)
+	if err != nil {
+		return nil, sdk.NewError(sdk.CodespaceUndefined, sdk.CodeUnknownRequest, "Bad request: {}", err.Error())
+	}
+
+	validator, found := keeper.GetValidator(ctx, param.ValidatorAddr)
+	if !found {
+		return nil, types.ErrNoValidatorFound(types.DefaultCodespace)
+	}
+
+	changes := keeper.GetCommissionRateChanges(ctx, param.ValidatorAddr)
+	history := types.NewCommissionRateHistory(param.ValidatorAddr, validator.Commission, changes)
+
+	res, err := codec.MarshalJSONIndent(types.ModuleCdc, history)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return res, nil
+}
+
 func queryAllValidator(ctx sdk.Context, req abci.RequestQuery, keeper ExecutionLayerKeeper) ([]byte, sdk.Error) {
 	validators := keeper.GetAllValidators(ctx)
 
@@ -254,6 +361,9 @@ func getQueryResult(ctx sdk.Context, k ExecutionLayerKeeper,
 	if len(stateHash) == 0 {
 		stateHash = ctx.CandidateBlock().State
 	}
+	if len(stateHash) == 0 {
+		return []byte{}, types.ErrEEStateNotFound(types.DefaultCodespace, ctx.BlockHeight())
+	}
 	keyDataBytes, err := toBytes(keyType, keyData, k.NicknameKeeper, ctx)
 	if err != nil {
 		return []byte{}, err
@@ -413,7 +523,10 @@ func queryReward(ctx sdk.Context, req abci.RequestQuery, keeper ExecutionLayerKe
 		return nil, sdk.NewError(sdk.CodespaceUndefined, sdk.CodeUnknownRequest, "Bad request: {}", err.Error())
 	}
 
-	eeState := keeper.GetUnitHashMap(ctx, req.GetHeight()).EEState
+	eeState, sdkErr := keeper.GetEEState(ctx, req.GetHeight())
+	if sdkErr != nil {
+		return nil, sdkErr
+	}
 	protocolVersion := keeper.GetProtocolVersion(ctx)
 	val, errMsg := grpc.QueryReward(keeper.client, eeState, param.Address, &protocolVersion)
 	if errMsg != "" {
@@ -439,7 +552,10 @@ func queryCommission(ctx sdk.Context, req abci.RequestQuery, keeper ExecutionLay
 		return nil, sdk.NewError(sdk.CodespaceUndefined, sdk.CodeUnknownRequest, "Bad request: {}", err.Error())
 	}
 
-	eeState := keeper.GetUnitHashMap(ctx, req.GetHeight()).EEState
+	eeState, sdkErr := keeper.GetEEState(ctx, req.GetHeight())
+	if sdkErr != nil {
+		return nil, sdkErr
+	}
 	protocolVersion := keeper.GetProtocolVersion(ctx)
 	val, errMsg := grpc.QueryCommission(keeper.client, eeState, param.Address, &protocolVersion)
 	if errMsg != "" {
@@ -457,3 +573,317 @@ func queryCommission(ctx sdk.Context, req abci.RequestQuery, keeper ExecutionLay
 	}
 	return res.Bytes(), nil
 }
+
+func queryRewardHistory(ctx sdk.Context, req abci.RequestQuery, keeper ExecutionLayerKeeper) ([]byte, sdk.Error) {
+	var param types.QueryRewardHistoryParams
+	err := types.ModuleCdc.UnmarshalJSON(req.Data, &param)
+	if err != nil {
+		return nil, sdk.NewError(sdk.CodespaceUndefined, sdk.CodeUnknownRequest, "Bad request: {}", err.Error())
+	}
+	if param.Delegator.Empty() {
+		return nil, sdk.ErrInvalidAddress("delegator cannot be empty")
+	}
+
+	fromHeight, toHeight := historyRange(ctx, param.FromHeight, param.ToHeight)
+	entries := keeper.GetRewardHistory(ctx, param.Delegator, fromHeight, toHeight)
+	history := types.NewRewardHistory(param.Delegator, param.Validator, fromHeight, toHeight, entries)
+
+	res, err := codec.MarshalJSONIndent(types.ModuleCdc, history)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return res, nil
+}
+
+func queryCommissionHistory(ctx sdk.Context, req abci.RequestQuery, keeper ExecutionLayerKeeper) ([]byte, sdk.Error) {
+	var param types.QueryCommissionHistoryParams
+	err := types.ModuleCdc.UnmarshalJSON(req.Data, &param)
+	if err != nil {
+		return nil, sdk.NewError(sdk.CodespaceUndefined, sdk.CodeUnknownRequest, "Bad request: {}", err.Error())
+	}
+	if param.Validator.Empty() {
+		return nil, sdk.ErrInvalidAddress("validator cannot be empty")
+	}
+
+	fromHeight, toHeight := historyRange(ctx, param.FromHeight, param.ToHeight)
+	entries := keeper.GetCommissionHistory(ctx, param.Validator, fromHeight, toHeight)
+	history := types.NewCommissionHistory(param.Validator, fromHeight, toHeight, entries)
+
+	res, err := codec.MarshalJSONIndent(types.ModuleCdc, history)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return res, nil
+}
+
+// historyRange fills the zero heights of a history range with the first and the current heights
+func historyRange(ctx sdk.Context, fromHeight, toHeight int64) (int64, int64) {
+	if toHeight <= 0 {
+		toHeight = ctx.BlockHeight()
+	}
+	if fromHeight <= 0 {
+		fromHeight = 1
+	}
+	return fromHeight, toHeight
+}
+
+func queryParams(ctx sdk.Context, keeper ExecutionLayerKeeper) ([]byte, sdk.Error) {
+	res, err := codec.MarshalJSONIndent(types.ModuleCdc, keeper.GetParams(ctx))
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return res, nil
+}
+
+func queryDeploy(ctx sdk.Context, req abci.RequestQuery, keeper ExecutionLayerKeeper) ([]byte, sdk.Error) {
+	var param types.QueryDeployParams
+	err := types.ModuleCdc.UnmarshalJSON(req.Data, &param)
+	if err != nil {
+		return nil, sdk.NewError(sdk.CodespaceUndefined, sdk.CodeUnknownRequest, "Bad request: {}", err.Error())
+	}
+	if len(param.DeployHash) != types.DeployHashLength {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("deploy hash must be %d bytes", types.DeployHashLength))
+	}
+
+	record, found := keeper.GetDeployRecord(ctx, param.DeployHash)
+	if !found {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("deploy %s is not found in the index", hex.EncodeToString(param.DeployHash)))
+	}
+
+	res, err := codec.MarshalJSONIndent(types.ModuleCdc, record)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return res, nil
+}
+
+// queryGrants returns the grant of the granter to the grantee, or all grants of the granter or to the grantee
+func queryGrants(ctx sdk.Context, req abci.RequestQuery, keeper ExecutionLayerKeeper) ([]byte, sdk.Error) {
+	var param types.QueryGrantsParams
+	err := types.ModuleCdc.UnmarshalJSON(req.Data, &param)
+	if err != nil {
+		return nil, sdk.NewError(sdk.CodespaceUndefined, sdk.CodeUnknownRequest, "Bad request: {}", err.Error())
+	}
+
+	grants := types.Grants{}
+	switch {
+	case !param.Granter.Empty() && !param.Grantee.Empty():
+		if grant, found := keeper.GetGrant(ctx, param.Granter, param.Grantee); found {
+			grants = append(grants, grant)
+		}
+	case !param.Granter.Empty():
+		grants = append(grants, keeper.GetGrantsByGranter(ctx, param.Granter)...)
+	case !param.Grantee.Empty():
+		grants = append(grants, keeper.GetGrantsByGrantee(ctx, param.Grantee)...)
+	default:
+		return nil, sdk.ErrUnknownRequest("granter or grantee is required")
+	}
+
+	res, err := codec.MarshalJSONIndent(types.ModuleCdc, grants)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return res, nil
+}
+
+// queryFeeAllowances returns the fee allowance of the payer to the grantee, or all fee allowances of
+// the payer or to the grantee
+func queryFeeAllowances(ctx sdk.Context, req abci.RequestQuery, keeper ExecutionLayerKeeper) ([]byte, sdk.Error) {
+	var param types.QueryFeeAllowancesParams
+	err := types.ModuleCdc.UnmarshalJSON(req.Data, &param)
+	if err != nil {
+		return nil, sdk.NewError(sdk.CodespaceUndefined, sdk.CodeUnknownRequest, "Bad request: {}", err.Error())
+	}
+
+	allowances := types.FeeAllowances{}
+	switch {
+	case !param.Payer.Empty() && !param.Grantee.Empty():
+		if allowance, found := keeper.GetFeeAllowance(ctx, param.Payer, param.Grantee); found {
+			allowances = append(allowances, allowance)
+		}
+	case !param.Payer.Empty():
+		allowances = append(allowances, keeper.GetFeeAllowancesByPayer(ctx, param.Payer)...)
+	case !param.Grantee.Empty():
+		allowances = append(allowances, keeper.GetFeeAllowancesByGrantee(ctx, param.Grantee)...)
+	default:
+		return nil, sdk.ErrUnknownRequest("payer or grantee is required")
+	}
+
+	res, err := codec.MarshalJSONIndent(types.ModuleCdc, allowances)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return res, nil
+}
+
+// querySchedules returns the schedules of the sender, or to the recipient
+func querySchedules(ctx sdk.Context, req abci.RequestQuery, keeper ExecutionLayerKeeper) ([]byte, sdk.Error) {
+	var param types.QuerySchedulesParams
+	err := types.ModuleCdc.UnmarshalJSON(req.Data, &param)
+	if err != nil {
+		return nil, sdk.NewError(sdk.CodespaceUndefined, sdk.CodeUnknownRequest, "Bad request: {}", err.Error())
+	}
+
+	schedules := types.Schedules{}
+	switch {
+	case !param.Sender.Empty():
+		for _, schedule := range keeper.GetSchedulesBySender(ctx, param.Sender) {
+			if param.Recipient.Empty() || schedule.Recipient.Equals(param.Recipient) {
+				schedules = append(schedules, schedule)
+			}
+		}
+	case !param.Recipient.Empty():
+		schedules = append(schedules, keeper.GetSchedulesByRecipient(ctx, param.Recipient)...)
+	default:
+		return nil, sdk.ErrUnknownRequest("sender or recipient is required")
+	}
+
+	res, err := codec.MarshalJSONIndent(types.ModuleCdc, schedules)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return res, nil
+}
+
+// queryUnbonding returns the pending unbondings and undelegations of the delegator, or of every
+// delegator, from the validator if given
+func queryUnbonding(ctx sdk.Context, req abci.RequestQuery, keeper ExecutionLayerKeeper) ([]byte, sdk.Error) {
+	var param QueryDelegatorParams
+	err := types.ModuleCdc.UnmarshalJSON(req.Data, &param)
+	if err != nil {
+		return nil, sdk.NewError(sdk.CodespaceUndefined, sdk.CodeUnknownRequest, "Bad request: {}", err.Error())
+	}
+
+	requests := getPendingStakeRequests(ctx, keeper, param.DelegatorAddr).Unbondings(param.ValidatorAddr)
+	res, err := codec.MarshalJSONIndent(types.ModuleCdc, requests)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return res, nil
+}
+
+// queryRedelegation returns the pending redelegations of the delegator, or of every delegator,
+// from or to the validator if given
+func queryRedelegation(ctx sdk.Context, req abci.RequestQuery, keeper ExecutionLayerKeeper) ([]byte, sdk.Error) {
+	var param QueryDelegatorParams
+	err := types.ModuleCdc.UnmarshalJSON(req.Data, &param)
+	if err != nil {
+		return nil, sdk.NewError(sdk.CodespaceUndefined, sdk.CodeUnknownRequest, "Bad request: {}", err.Error())
+	}
+
+	requests := getPendingStakeRequests(ctx, keeper, param.DelegatorAddr).Redelegations(param.ValidatorAddr)
+	res, err := codec.MarshalJSONIndent(types.ModuleCdc, requests)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return res, nil
+}
+
+// getPendingStakeRequests returns the pending stake requests of the delegator, or all of them
+// when the delegator is empty
+func getPendingStakeRequests(ctx sdk.Context, keeper ExecutionLayerKeeper, delegator sdk.AccAddress) types.StakeRequests {
+	if delegator.Empty() {
+		return keeper.GetAllStakeRequests(ctx)
+	}
+	return keeper.GetStakeRequestsByDelegator(ctx, delegator)
+}
+
+func queryContract(ctx sdk.Context, req abci.RequestQuery, keeper ExecutionLayerKeeper) ([]byte, sdk.Error) {
+	var param types.QueryContractParams
+	err := types.ModuleCdc.UnmarshalJSON(req.Data, &param)
+	if err != nil {
+		return nil, sdk.NewError(sdk.CodespaceUndefined, sdk.CodeUnknownRequest, "Bad request: {}", err.Error())
+	}
+
+	var candidates types.ContractInfos
+	switch {
+	case len(param.CodeHash) != 0:
+		candidates = keeper.GetContractsByCodeHash(ctx, param.CodeHash)
+	case param.Name != "":
+		candidates = keeper.GetContractsByName(ctx, param.Name)
+	case !param.Deployer.Empty():
+		candidates = keeper.GetContractsByDeployer(ctx, param.Deployer)
+	default:
+		candidates = keeper.GetAllContracts(ctx)
+	}
+
+	contractInfos := types.ContractInfos{}
+	for _, contractInfo := range candidates {
+		if !param.Deployer.Empty() && !contractInfo.Deployer.Equals(param.Deployer) {
+			continue
+		}
+		if param.Name != "" && contractInfo.Name != param.Name {
+			continue
+		}
+		if len(param.CodeHash) != 0 && !bytes.Equal(contractInfo.CodeHash, param.CodeHash) {
+			continue
+		}
+		contractInfos = append(contractInfos, contractInfo)
+	}
+
+	res, err := codec.MarshalJSONIndent(types.ModuleCdc, contractInfos)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+
+	return res, nil
+}
+
+// queryContractByKey returns the registry record of the contract stored under the contract key,
+// or null if the contract isn't registered
+func queryContractByKey(ctx sdk.Context, req abci.RequestQuery, keeper ExecutionLayerKeeper) ([]byte, sdk.Error) {
+	var param types.QueryContractByKeyParams
+	err := types.ModuleCdc.UnmarshalJSON(req.Data, &param)
+	if err != nil {
+		return nil, sdk.NewError(sdk.CodespaceUndefined, sdk.CodeUnknownRequest, "Bad request: {}", err.Error())
+	}
+	_, hashErr := sdk.ContractHashAddressFromBech32(param.ContractKey)
+	_, urefErr := sdk.ContractUrefAddressFromBech32(param.ContractKey)
+	if hashErr != nil && urefErr != nil {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("%s is not a contract hash or uref address", param.ContractKey))
+	}
+
+	var contractInfo *types.ContractInfo
+	if info, found := keeper.GetContractInfoByKey(ctx, param.ContractKey); found {
+		contractInfo = &info
+	}
+
+	res, err := codec.MarshalJSONIndent(types.ModuleCdc, contractInfo)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return res, nil
+}
+
+func queryDryRun(ctx sdk.Context, req abci.RequestQuery, keeper ExecutionLayerKeeper) ([]byte, sdk.Error) {
+	var param types.QueryDryRunParams
+	err := types.ModuleCdc.UnmarshalJSON(req.Data, &param)
+	if err != nil {
+		return nil, sdk.NewError(sdk.CodespaceUndefined, sdk.CodeUnknownRequest, "Bad request: {}", err.Error())
+	}
+
+	msg := param.Msg
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	deployAbi, _, err := sessionArgsToAbi(msg.SessionArgs)
+	if err != nil {
+		return nil, sdk.NewError(sdk.CodespaceUndefined, sdk.CodeUnknownRequest, "Bad request: {}", err.Error())
+	}
+	msg.SessionArgs = hex.EncodeToString(deployAbi)
+
+	ctx = ctx.WithBlockHeight(req.Height)
+	result, err := dryRunExecute(ctx, keeper, msg)
+	if err != nil {
+		return nil, sdk.NewError(sdk.CodespaceUndefined, sdk.CodeUnknownRequest, err.Error())
+	}
+
+	// effects hold decoded values of arbitrary types, which amino can't encode
+	res, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return nil, sdk.NewError(sdk.CodespaceUndefined, sdk.CodeUnknownRequest, "Bad request: {}", err.Error())
+	}
+
+	return res, nil
+}
@@ -63,6 +63,7 @@ func setupTestInput() testInput {
 	tkeyParams := sdk.NewTransientStoreKey("transient_subspace")
 
 	ps := subspace.NewSubspace(cdc, keyParams, tkeyParams, authtypes.DefaultParamspace)
+	elps := subspace.NewSubspace(cdc, keyParams, tkeyParams, types.DefaultParamspace)
 
 	ms := store.NewCommitMultiStore(db)
 	ms.MountStoreWithDB(authCapKey, sdk.StoreTypeIAVL, db)
@@ -76,7 +77,7 @@ func setupTestInput() testInput {
 	accountKeeper := auth.NewAccountKeeper(cdc, authCapKey, ps, auth.ProtoBaseAccount)
 	nicknameKeeper := nickname.NewNicknameKeeper(nicknameStoreKey, cdc, accountKeeper)
 
-	elk := NewExecutionLayerKeeper(cdc, hashMapStoreKey, os.ExpandEnv("$HOME/.casperlabs/.casper-node.sock"),
+	elk := NewExecutionLayerKeeper(cdc, hashMapStoreKey, elps, os.ExpandEnv("$HOME/.casperlabs/.casper-node.sock"),
 		accountKeeper, nicknameKeeper)
 
 	gs := types.DefaultGenesisState()
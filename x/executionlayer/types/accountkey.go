@@ -0,0 +1,348 @@
+package types
+
+import (
+	sdk "github.com/hdac-io/friday/types"
+)
+
+// Action types of the action thresholds of an EE account
+const (
+	ActionTypeDeployment    = "deployment"
+	ActionTypeKeyManagement = "key_management"
+
+	// MaxKeyWeight - weights and thresholds are a single byte in the EE
+	MaxKeyWeight = 255
+)
+
+// ActionTypeToEE converts the action type into the value the EE uses
+func ActionTypeToEE(actionType string) (uint32, bool) {
+	switch actionType {
+	case ActionTypeDeployment:
+		return 0, true
+	case ActionTypeKeyManagement:
+		return 1, true
+	default:
+		return 0, false
+	}
+}
+
+func validateKeyWeight(weight uint32) sdk.Error {
+	if weight == 0 || weight > MaxKeyWeight {
+		return sdk.NewError(DefaultCodespace, CodeInvalidInput, "weight must be between 1 and %d", MaxKeyWeight)
+	}
+	return nil
+}
+
+//______________________________________________________________________
+
+// MsgAddAssociatedKey - adds a key which can authorize deploys of the account
+type MsgAddAssociatedKey struct {
+	ContractAddress   string         `json:"contract_address" yaml:"contract_address"`
+	FromAddress       sdk.AccAddress `json:"from_address" yaml:"from_address"`
+	AssociatedAddress sdk.AccAddress `json:"associated_address" yaml:"associated_address"`
+	Weight            uint32         `json:"weight" yaml:"weight"`
+	Fee               string         `json:"fee" yaml:"fee"`
+	DeployHeader      *DeployHeader  `json:"deploy_header,omitempty" yaml:"deploy_header,omitempty"`
+}
+
+// NewMsgAddAssociatedKey is a constructor function for MsgAddAssociatedKey
+func NewMsgAddAssociatedKey(
+	contractAddress string,
+	fromAddress, associatedAddress sdk.AccAddress,
+	weight uint32,
+	fee string,
+) MsgAddAssociatedKey {
+	return MsgAddAssociatedKey{
+		ContractAddress:   contractAddress,
+		FromAddress:       fromAddress,
+		AssociatedAddress: associatedAddress,
+		Weight:            weight,
+		Fee:               fee,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgAddAssociatedKey) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgAddAssociatedKey) Type() string { return "add_associated_key" }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgAddAssociatedKey) ValidateBasic() sdk.Error {
+	if err := msg.DeployHeader.ValidateBasic(); err != nil {
+		return err
+	}
+	if msg.FromAddress.Equals(sdk.AccAddress("")) {
+		return sdk.ErrUnknownRequest("Address cannot be empty")
+	}
+	if msg.AssociatedAddress.Equals(sdk.AccAddress("")) {
+		return sdk.ErrUnknownRequest("Associated address cannot be empty")
+	}
+	return validateKeyWeight(msg.Weight)
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgAddAssociatedKey) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgAddAssociatedKey) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.FromAddress}
+}
+
+// GetDeployHeader returns the header of the deploy of the msg
+func (msg MsgAddAssociatedKey) GetDeployHeader() *DeployHeader {
+	return msg.DeployHeader
+}
+
+// WithDeployHeader returns the msg with the deploy header
+func (msg MsgAddAssociatedKey) WithDeployHeader(header *DeployHeader) DeployMsg {
+	msg.DeployHeader = header
+	return msg
+}
+
+//______________________________________________________________________
+
+// MsgRemoveAssociatedKey - removes an associated key of the account
+type MsgRemoveAssociatedKey struct {
+	ContractAddress   string         `json:"contract_address" yaml:"contract_address"`
+	FromAddress       sdk.AccAddress `json:"from_address" yaml:"from_address"`
+	AssociatedAddress sdk.AccAddress `json:"associated_address" yaml:"associated_address"`
+	Fee               string         `json:"fee" yaml:"fee"`
+	DeployHeader      *DeployHeader  `json:"deploy_header,omitempty" yaml:"deploy_header,omitempty"`
+}
+
+// NewMsgRemoveAssociatedKey is a constructor function for MsgRemoveAssociatedKey
+func NewMsgRemoveAssociatedKey(
+	contractAddress string,
+	fromAddress, associatedAddress sdk.AccAddress,
+	fee string,
+) MsgRemoveAssociatedKey {
+	return MsgRemoveAssociatedKey{
+		ContractAddress:   contractAddress,
+		FromAddress:       fromAddress,
+		AssociatedAddress: associatedAddress,
+		Fee:               fee,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgRemoveAssociatedKey) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgRemoveAssociatedKey) Type() string { return "remove_associated_key" }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgRemoveAssociatedKey) ValidateBasic() sdk.Error {
+	if err := msg.DeployHeader.ValidateBasic(); err != nil {
+		return err
+	}
+	if msg.FromAddress.Equals(sdk.AccAddress("")) {
+		return sdk.ErrUnknownRequest("Address cannot be empty")
+	}
+	if msg.AssociatedAddress.Equals(sdk.AccAddress("")) {
+		return sdk.ErrUnknownRequest("Associated address cannot be empty")
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgRemoveAssociatedKey) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgRemoveAssociatedKey) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.FromAddress}
+}
+
+// GetDeployHeader returns the header of the deploy of the msg
+func (msg MsgRemoveAssociatedKey) GetDeployHeader() *DeployHeader {
+	return msg.DeployHeader
+}
+
+// WithDeployHeader returns the msg with the deploy header
+func (msg MsgRemoveAssociatedKey) WithDeployHeader(header *DeployHeader) DeployMsg {
+	msg.DeployHeader = header
+	return msg
+}
+
+//______________________________________________________________________
+
+// MsgUpdateAssociatedKey - changes the weight of an associated key of the account
+type MsgUpdateAssociatedKey struct {
+	ContractAddress   string         `json:"contract_address" yaml:"contract_address"`
+	FromAddress       sdk.AccAddress `json:"from_address" yaml:"from_address"`
+	AssociatedAddress sdk.AccAddress `json:"associated_address" yaml:"associated_address"`
+	Weight            uint32         `json:"weight" yaml:"weight"`
+	Fee               string         `json:"fee" yaml:"fee"`
+	DeployHeader      *DeployHeader  `json:"deploy_header,omitempty" yaml:"deploy_header,omitempty"`
+}
+
+// NewMsgUpdateAssociatedKey is a constructor function for MsgUpdateAssociatedKey
+func NewMsgUpdateAssociatedKey(
+	contractAddress string,
+	fromAddress, associatedAddress sdk.AccAddress,
+	weight uint32,
+	fee string,
+) MsgUpdateAssociatedKey {
+	return MsgUpdateAssociatedKey{
+		ContractAddress:   contractAddress,
+		FromAddress:       fromAddress,
+		AssociatedAddress: associatedAddress,
+		Weight:            weight,
+		Fee:               fee,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgUpdateAssociatedKey) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgUpdateAssociatedKey) Type() string { return "update_associated_key" }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgUpdateAssociatedKey) ValidateBasic() sdk.Error {
+	if err := msg.DeployHeader.ValidateBasic(); err != nil {
+		return err
+	}
+	if msg.FromAddress.Equals(sdk.AccAddress("")) {
+		return sdk.ErrUnknownRequest("Address cannot be empty")
+	}
+	if msg.AssociatedAddress.Equals(sdk.AccAddress("")) {
+		return sdk.ErrUnknownRequest("Associated address cannot be empty")
+	}
+	return validateKeyWeight(msg.Weight)
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgUpdateAssociatedKey) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgUpdateAssociatedKey) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.FromAddress}
+}
+
+// GetDeployHeader returns the header of the deploy of the msg
+func (msg MsgUpdateAssociatedKey) GetDeployHeader() *DeployHeader {
+	return msg.DeployHeader
+}
+
+// WithDeployHeader returns the msg with the deploy header
+func (msg MsgUpdateAssociatedKey) WithDeployHeader(header *DeployHeader) DeployMsg {
+	msg.DeployHeader = header
+	return msg
+}
+
+//______________________________________________________________________
+
+// MsgSetActionThreshold - sets the total weight of keys required for deployment or key management
+type MsgSetActionThreshold struct {
+	ContractAddress string         `json:"contract_address" yaml:"contract_address"`
+	FromAddress     sdk.AccAddress `json:"from_address" yaml:"from_address"`
+	ActionType      string         `json:"action_type" yaml:"action_type"`
+	Threshold       uint32         `json:"threshold" yaml:"threshold"`
+	Fee             string         `json:"fee" yaml:"fee"`
+	DeployHeader    *DeployHeader  `json:"deploy_header,omitempty" yaml:"deploy_header,omitempty"`
+}
+
+// NewMsgSetActionThreshold is a constructor function for MsgSetActionThreshold
+func NewMsgSetActionThreshold(
+	contractAddress string,
+	fromAddress sdk.AccAddress,
+	actionType string,
+	threshold uint32,
+	fee string,
+) MsgSetActionThreshold {
+	return MsgSetActionThreshold{
+		ContractAddress: contractAddress,
+		FromAddress:     fromAddress,
+		ActionType:      actionType,
+		Threshold:       threshold,
+		Fee:             fee,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgSetActionThreshold) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgSetActionThreshold) Type() string { return "set_action_threshold" }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgSetActionThreshold) ValidateBasic() sdk.Error {
+	if err := msg.DeployHeader.ValidateBasic(); err != nil {
+		return err
+	}
+	if msg.FromAddress.Equals(sdk.AccAddress("")) {
+		return sdk.ErrUnknownRequest("Address cannot be empty")
+	}
+	if _, ok := ActionTypeToEE(msg.ActionType); !ok {
+		return sdk.NewError(DefaultCodespace, CodeInvalidInput,
+			"action type must be one of %s, %s", ActionTypeDeployment, ActionTypeKeyManagement)
+	}
+	return validateKeyWeight(msg.Threshold)
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgSetActionThreshold) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgSetActionThreshold) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.FromAddress}
+}
+
+// GetDeployHeader returns the header of the deploy of the msg
+func (msg MsgSetActionThreshold) GetDeployHeader() *DeployHeader {
+	return msg.DeployHeader
+}
+
+// WithDeployHeader returns the msg with the deploy header
+func (msg MsgSetActionThreshold) WithDeployHeader(header *DeployHeader) DeployMsg {
+	msg.DeployHeader = header
+	return msg
+}
+
+//______________________________________________________________________
+
+// MsgAuthorize - adds a co-signer to the transaction.
+// It changes nothing by itself, but its signer is passed to the EE as an authorization key
+// of the other messages in the same transaction, so that associated keys can sign together.
+type MsgAuthorize struct {
+	Authorizer sdk.AccAddress `json:"authorizer" yaml:"authorizer"`
+}
+
+// NewMsgAuthorize is a constructor function for MsgAuthorize
+func NewMsgAuthorize(authorizer sdk.AccAddress) MsgAuthorize {
+	return MsgAuthorize{
+		Authorizer: authorizer,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgAuthorize) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgAuthorize) Type() string { return "authorize" }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgAuthorize) ValidateBasic() sdk.Error {
+	if msg.Authorizer.Equals(sdk.AccAddress("")) {
+		return sdk.ErrUnknownRequest("Address cannot be empty")
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgAuthorize) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgAuthorize) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Authorizer}
+}
@@ -0,0 +1,52 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/hdac-io/friday/types"
+)
+
+func TestMsgAddAssociatedKeyValidateBasic(t *testing.T) {
+	fromAddr, err := sdk.AccAddressFromBech32("friday1gp2u22697kz6slwa25k2tkhz6st2l0zx3hkfc5wdlpjaauv5czsq2dwu8m")
+	require.NoError(t, err)
+	associatedAddr, err := sdk.AccAddressFromBech32("friday16wfryel63g7axeamw68630wglalcnk3llh7z665n05qrrmmfqztqkhgkwv")
+	require.NoError(t, err)
+
+	require.Nil(t, NewMsgAddAssociatedKey("system:add_associated_key", fromAddr, associatedAddr, 1, "10000000").ValidateBasic())
+	require.Nil(t, NewMsgAddAssociatedKey("system:add_associated_key", fromAddr, associatedAddr, MaxKeyWeight, "10000000").ValidateBasic())
+	require.NotNil(t, NewMsgAddAssociatedKey("system:add_associated_key", fromAddr, associatedAddr, 0, "10000000").ValidateBasic())
+	require.NotNil(t, NewMsgAddAssociatedKey("system:add_associated_key", fromAddr, associatedAddr, MaxKeyWeight+1, "10000000").ValidateBasic())
+	require.NotNil(t, NewMsgAddAssociatedKey("system:add_associated_key", fromAddr, sdk.AccAddress{}, 1, "10000000").ValidateBasic())
+
+	require.Nil(t, NewMsgRemoveAssociatedKey("system:remove_associated_key", fromAddr, associatedAddr, "10000000").ValidateBasic())
+	require.NotNil(t, NewMsgRemoveAssociatedKey("system:remove_associated_key", sdk.AccAddress{}, associatedAddr, "10000000").ValidateBasic())
+
+	require.Nil(t, NewMsgUpdateAssociatedKey("system:update_associated_key", fromAddr, associatedAddr, 2, "10000000").ValidateBasic())
+	require.NotNil(t, NewMsgUpdateAssociatedKey("system:update_associated_key", fromAddr, associatedAddr, 0, "10000000").ValidateBasic())
+}
+
+func TestMsgSetActionThresholdValidateBasic(t *testing.T) {
+	fromAddr, err := sdk.AccAddressFromBech32("friday1gp2u22697kz6slwa25k2tkhz6st2l0zx3hkfc5wdlpjaauv5czsq2dwu8m")
+	require.NoError(t, err)
+
+	require.Nil(t, NewMsgSetActionThreshold("system:set_action_threshold", fromAddr, ActionTypeDeployment, 2, "10000000").ValidateBasic())
+	require.Nil(t, NewMsgSetActionThreshold("system:set_action_threshold", fromAddr, ActionTypeKeyManagement, 3, "10000000").ValidateBasic())
+	require.NotNil(t, NewMsgSetActionThreshold("system:set_action_threshold", fromAddr, "transfer", 2, "10000000").ValidateBasic())
+	require.NotNil(t, NewMsgSetActionThreshold("system:set_action_threshold", fromAddr, ActionTypeDeployment, 0, "10000000").ValidateBasic())
+
+	actionType, ok := ActionTypeToEE(ActionTypeKeyManagement)
+	require.True(t, ok)
+	require.Equal(t, uint32(1), actionType)
+}
+
+func TestMsgAuthorize(t *testing.T) {
+	authorizer, err := sdk.AccAddressFromBech32("friday16wfryel63g7axeamw68630wglalcnk3llh7z665n05qrrmmfqztqkhgkwv")
+	require.NoError(t, err)
+
+	msg := NewMsgAuthorize(authorizer)
+	require.Nil(t, msg.ValidateBasic())
+	require.Equal(t, []sdk.AccAddress{authorizer}, msg.GetSigners())
+	require.NotNil(t, NewMsgAuthorize(sdk.AccAddress{}).ValidateBasic())
+}
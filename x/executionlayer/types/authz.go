@@ -0,0 +1,383 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	sdk "github.com/hdac-io/friday/types"
+)
+
+// Types of the msgs a granter can authorize a grantee to execute on its behalf
+const (
+	GrantMsgTypeTransfer   = "transfer"
+	GrantMsgTypeBond       = "bond"
+	GrantMsgTypeUnbond     = "unbond"
+	GrantMsgTypeDelegate   = "delegate"
+	GrantMsgTypeUndelegate = "undelegate"
+	GrantMsgTypeRedelegate = "redelegate"
+	GrantMsgTypeVote       = "vote"
+	GrantMsgTypeUnvote     = "unvote"
+	GrantMsgTypeClaim      = "claim"
+)
+
+// GrantableMsgTypes - msg types which can be granted. Validator and key management msgs are
+// left out, as they change who controls the account.
+var GrantableMsgTypes = []string{
+	GrantMsgTypeTransfer,
+	GrantMsgTypeBond,
+	GrantMsgTypeUnbond,
+	GrantMsgTypeDelegate,
+	GrantMsgTypeUndelegate,
+	GrantMsgTypeRedelegate,
+	GrantMsgTypeVote,
+	GrantMsgTypeUnvote,
+	GrantMsgTypeClaim,
+}
+
+// GetGrantMsgType returns the grant msg type of the msg, false if the msg can't be granted
+func GetGrantMsgType(msg sdk.Msg) (string, bool) {
+	switch msg.(type) {
+	case MsgTransfer:
+		return GrantMsgTypeTransfer, true
+	case MsgBond:
+		return GrantMsgTypeBond, true
+	case MsgUnBond:
+		return GrantMsgTypeUnbond, true
+	case MsgDelegate:
+		return GrantMsgTypeDelegate, true
+	case MsgUndelegate:
+		return GrantMsgTypeUndelegate, true
+	case MsgRedelegate:
+		return GrantMsgTypeRedelegate, true
+	case MsgVote:
+		return GrantMsgTypeVote, true
+	case MsgUnvote:
+		return GrantMsgTypeUnvote, true
+	case MsgClaim:
+		return GrantMsgTypeClaim, true
+	default:
+		return "", false
+	}
+}
+
+// GetSpendAmount returns the amount of the granter's tokens the msg spends, that is the fee
+// of the deploy and the amount transferred, bonded, delegated or voted
+func GetSpendAmount(msg sdk.Msg) (sdk.Int, sdk.Error) {
+	var fee, amount string
+	switch msg := msg.(type) {
+	case MsgTransfer:
+		fee, amount = msg.Fee, msg.Amount
+	case MsgBond:
+		fee, amount = msg.Fee, msg.Amount
+	case MsgUnBond:
+		fee = msg.Fee
+	case MsgDelegate:
+		fee, amount = msg.Fee, msg.Amount
+	case MsgUndelegate:
+		fee = msg.Fee
+	case MsgRedelegate:
+		fee = msg.Fee
+	case MsgVote:
+		fee, amount = msg.Fee, msg.Amount
+	case MsgUnvote:
+		fee = msg.Fee
+	case MsgClaim:
+		fee = msg.Fee
+	default:
+		return sdk.ZeroInt(), ErrMsgNotGrantable(DefaultCodespace, msg)
+	}
+
+	spend, err := parseSpendAmount(fee)
+	if err != nil {
+		return sdk.ZeroInt(), err
+	}
+	if amount != "" {
+		value, err := parseSpendAmount(amount)
+		if err != nil {
+			return sdk.ZeroInt(), err
+		}
+		spend = spend.Add(value)
+	}
+	return spend, nil
+}
+
+func parseSpendAmount(amount string) (sdk.Int, sdk.Error) {
+	if amount == "" {
+		return sdk.ZeroInt(), nil
+	}
+	value, ok := sdk.NewIntFromString(amount)
+	if !ok || value.IsNegative() {
+		return sdk.ZeroInt(), ErrInvalidSpendAmount(DefaultCodespace, amount)
+	}
+	return value, nil
+}
+
+//______________________________________________________________________
+
+// Grant - authorization of the grantee to execute msgs of the types on behalf of the granter
+// until the expiration, spending up to the spend limit of the granter's tokens
+type Grant struct {
+	Granter    sdk.AccAddress `json:"granter" yaml:"granter"`
+	Grantee    sdk.AccAddress `json:"grantee" yaml:"grantee"`
+	MsgTypes   []string       `json:"msg_types" yaml:"msg_types"`
+	SpendLimit string         `json:"spend_limit" yaml:"spend_limit"` // remaining amount in bigsun, empty for no limit
+	Expiration time.Time      `json:"expiration" yaml:"expiration"`
+}
+
+// NewGrant creates a new Grant instance
+func NewGrant(granter, grantee sdk.AccAddress, msgTypes []string, spendLimit string, expiration time.Time) Grant {
+	return Grant{
+		Granter:    granter,
+		Grantee:    grantee,
+		MsgTypes:   msgTypes,
+		SpendLimit: spendLimit,
+		Expiration: expiration,
+	}
+}
+
+// ValidateBasic runs stateless checks on the grant
+func (g Grant) ValidateBasic() sdk.Error {
+	if g.Granter.Empty() || g.Grantee.Empty() {
+		return sdk.ErrInvalidAddress("granter and grantee cannot be empty")
+	}
+	if g.Granter.Equals(g.Grantee) {
+		return ErrInvalidGrant(DefaultCodespace, "granter and grantee must be different")
+	}
+	if len(g.MsgTypes) == 0 {
+		return ErrInvalidGrant(DefaultCodespace, "at least one msg type must be granted")
+	}
+	seen := map[string]bool{}
+	for _, msgType := range g.MsgTypes {
+		if !isGrantableMsgType(msgType) {
+			return ErrInvalidGrant(DefaultCodespace, fmt.Sprintf("msg type %s cannot be granted, must be one of %s",
+				msgType, strings.Join(GrantableMsgTypes, ",")))
+		}
+		if seen[msgType] {
+			return ErrInvalidGrant(DefaultCodespace, fmt.Sprintf("duplicate msg type %s", msgType))
+		}
+		seen[msgType] = true
+	}
+	if g.SpendLimit != "" {
+		limit, err := parseSpendAmount(g.SpendLimit)
+		if err != nil {
+			return err
+		}
+		if !limit.IsPositive() {
+			return ErrInvalidGrant(DefaultCodespace, "spend limit must be positive")
+		}
+	}
+	if g.Expiration.IsZero() {
+		return ErrInvalidGrant(DefaultCodespace, "expiration must be set")
+	}
+	return nil
+}
+
+// Allows returns whether the grant authorizes msgs of the type
+func (g Grant) Allows(msgType string) bool {
+	for _, granted := range g.MsgTypes {
+		if granted == msgType {
+			return true
+		}
+	}
+	return false
+}
+
+// IsExpired returns whether the grant is expired at the time
+func (g Grant) IsExpired(blockTime time.Time) bool {
+	return !blockTime.Before(g.Expiration)
+}
+
+// Spend returns the grant with the amount deducted from the spend limit.
+// A grant without a limit is returned as is.
+func (g Grant) Spend(amount sdk.Int) (Grant, sdk.Error) {
+	if g.SpendLimit == "" {
+		return g, nil
+	}
+	limit, err := parseSpendAmount(g.SpendLimit)
+	if err != nil {
+		return g, err
+	}
+	if amount.GT(limit) {
+		return g, ErrSpendLimitExceeded(DefaultCodespace, amount.String(), g.SpendLimit)
+	}
+	g.SpendLimit = limit.Sub(amount).String()
+	return g, nil
+}
+
+// String returns a human readable string representation of a grant
+func (g Grant) String() string {
+	spendLimit := g.SpendLimit
+	if spendLimit == "" {
+		spendLimit = "none"
+	}
+	return fmt.Sprintf(`Grant:
+  Granter:      %s
+  Grantee:      %s
+  Msg Types:    %s
+  Spend Limit:  %s
+  Expiration:   %v`, g.Granter, g.Grantee, strings.Join(g.MsgTypes, ","), spendLimit, g.Expiration)
+}
+
+// Grants is a collection of Grant
+type Grants []Grant
+
+func (g Grants) String() (out string) {
+	for _, grant := range g {
+		out += grant.String() + "\n"
+	}
+	return strings.TrimSpace(out)
+}
+
+func isGrantableMsgType(msgType string) bool {
+	for _, grantable := range GrantableMsgTypes {
+		if grantable == msgType {
+			return true
+		}
+	}
+	return false
+}
+
+//______________________________________________________________________
+
+// MsgGrant - authorizes the grantee to execute msgs of the types on behalf of the granter.
+// A grant replaces the previous grant of the granter to the grantee.
+type MsgGrant struct {
+	Granter    sdk.AccAddress `json:"granter" yaml:"granter"`
+	Grantee    sdk.AccAddress `json:"grantee" yaml:"grantee"`
+	MsgTypes   []string       `json:"msg_types" yaml:"msg_types"`
+	SpendLimit string         `json:"spend_limit" yaml:"spend_limit"`
+	Expiration time.Time      `json:"expiration" yaml:"expiration"`
+}
+
+// NewMsgGrant is a constructor function for MsgGrant
+func NewMsgGrant(granter, grantee sdk.AccAddress, msgTypes []string, spendLimit string, expiration time.Time) MsgGrant {
+	return MsgGrant{
+		Granter:    granter,
+		Grantee:    grantee,
+		MsgTypes:   msgTypes,
+		SpendLimit: spendLimit,
+		Expiration: expiration,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgGrant) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgGrant) Type() string { return "grant" }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgGrant) ValidateBasic() sdk.Error {
+	return msg.Grant().ValidateBasic()
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgGrant) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgGrant) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Granter}
+}
+
+// Grant returns the grant the msg creates
+func (msg MsgGrant) Grant() Grant {
+	return NewGrant(msg.Granter, msg.Grantee, msg.MsgTypes, msg.SpendLimit, msg.Expiration)
+}
+
+//______________________________________________________________________
+
+// MsgRevoke - revokes the grant of the granter to the grantee
+type MsgRevoke struct {
+	Granter sdk.AccAddress `json:"granter" yaml:"granter"`
+	Grantee sdk.AccAddress `json:"grantee" yaml:"grantee"`
+}
+
+// NewMsgRevoke is a constructor function for MsgRevoke
+func NewMsgRevoke(granter, grantee sdk.AccAddress) MsgRevoke {
+	return MsgRevoke{
+		Granter: granter,
+		Grantee: grantee,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgRevoke) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgRevoke) Type() string { return "revoke" }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgRevoke) ValidateBasic() sdk.Error {
+	if msg.Granter.Empty() || msg.Grantee.Empty() {
+		return sdk.ErrInvalidAddress("granter and grantee cannot be empty")
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgRevoke) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgRevoke) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Granter}
+}
+
+//______________________________________________________________________
+
+// MsgExec - executes the msgs on behalf of their signers, who granted the grantee to.
+// Only the grantee signs the tx, and the deploys of the msgs run as the granters' deploys.
+type MsgExec struct {
+	Grantee sdk.AccAddress `json:"grantee" yaml:"grantee"`
+	Msgs    []sdk.Msg      `json:"msgs" yaml:"msgs"`
+}
+
+// NewMsgExec is a constructor function for MsgExec
+func NewMsgExec(grantee sdk.AccAddress, msgs []sdk.Msg) MsgExec {
+	return MsgExec{
+		Grantee: grantee,
+		Msgs:    msgs,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgExec) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgExec) Type() string { return "exec" }
+
+// ValidateBasic runs stateless checks on the message and the msgs it executes
+func (msg MsgExec) ValidateBasic() sdk.Error {
+	if msg.Grantee.Empty() {
+		return sdk.ErrInvalidAddress("grantee cannot be empty")
+	}
+	if len(msg.Msgs) == 0 {
+		return sdk.ErrUnknownRequest("msgs to execute cannot be empty")
+	}
+	for _, execMsg := range msg.Msgs {
+		if _, ok := GetGrantMsgType(execMsg); !ok {
+			return ErrMsgNotGrantable(DefaultCodespace, execMsg)
+		}
+		if len(execMsg.GetSigners()) != 1 {
+			return sdk.ErrUnknownRequest("msg to execute must have a single signer")
+		}
+		if err := execMsg.ValidateBasic(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgExec) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgExec) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Grantee}
+}
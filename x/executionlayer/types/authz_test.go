@@ -0,0 +1,84 @@
+package types
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hdac-io/friday/codec"
+	sdk "github.com/hdac-io/friday/types"
+)
+
+func TestGrantValidateBasic(t *testing.T) {
+	granter := sdk.AccAddress([]byte(strings.Repeat("g", 32)))
+	grantee := sdk.AccAddress([]byte(strings.Repeat("e", 32)))
+	expiration := time.Unix(1000, 0).UTC()
+
+	require.Nil(t, NewGrant(granter, grantee, []string{GrantMsgTypeClaim}, "", expiration).ValidateBasic())
+	require.Nil(t, NewGrant(granter, grantee, []string{GrantMsgTypeClaim, GrantMsgTypeDelegate}, "10", expiration).ValidateBasic())
+
+	require.NotNil(t, NewGrant(granter, granter, []string{GrantMsgTypeClaim}, "", expiration).ValidateBasic())
+	require.NotNil(t, NewGrant(nil, grantee, []string{GrantMsgTypeClaim}, "", expiration).ValidateBasic())
+	require.NotNil(t, NewGrant(granter, grantee, nil, "", expiration).ValidateBasic())
+	require.NotNil(t, NewGrant(granter, grantee, []string{"create_validator"}, "", expiration).ValidateBasic())
+	require.NotNil(t, NewGrant(granter, grantee, []string{GrantMsgTypeClaim, GrantMsgTypeClaim}, "", expiration).ValidateBasic())
+	require.NotNil(t, NewGrant(granter, grantee, []string{GrantMsgTypeClaim}, "0", expiration).ValidateBasic())
+	require.NotNil(t, NewGrant(granter, grantee, []string{GrantMsgTypeClaim}, "1.5", expiration).ValidateBasic())
+	require.NotNil(t, NewGrant(granter, grantee, []string{GrantMsgTypeClaim}, "", time.Time{}).ValidateBasic())
+}
+
+func TestGrantSpend(t *testing.T) {
+	granter := sdk.AccAddress([]byte(strings.Repeat("g", 32)))
+	grantee := sdk.AccAddress([]byte(strings.Repeat("e", 32)))
+	grant := NewGrant(granter, grantee, []string{GrantMsgTypeDelegate}, "100", time.Unix(1000, 0).UTC())
+
+	amount, err := GetSpendAmount(NewMsgDelegate("system:delegate", granter, grantee, "60", "10"))
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewInt(70), amount)
+	amount, err = GetSpendAmount(NewMsgClaim("system:claim_reward", granter, true, "10"))
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewInt(10), amount)
+	_, err = GetSpendAmount(NewMsgClaim("system:claim_reward", granter, true, "ten"))
+	require.NotNil(t, err)
+
+	grant, err = grant.Spend(sdk.NewInt(70))
+	require.Nil(t, err)
+	require.Equal(t, "30", grant.SpendLimit)
+	_, err = grant.Spend(sdk.NewInt(31))
+	require.NotNil(t, err)
+
+	unlimited, err := NewGrant(granter, grantee, []string{GrantMsgTypeDelegate}, "", time.Unix(1000, 0).UTC()).Spend(sdk.NewInt(1000))
+	require.Nil(t, err)
+	require.Equal(t, "", unlimited.SpendLimit)
+
+	require.True(t, grant.Allows(GrantMsgTypeDelegate))
+	require.False(t, grant.Allows(GrantMsgTypeClaim))
+	require.False(t, grant.IsExpired(time.Unix(999, 0)))
+	require.True(t, grant.IsExpired(time.Unix(1000, 0)))
+}
+
+func TestMsgExec(t *testing.T) {
+	granter := sdk.AccAddress([]byte(strings.Repeat("g", 32)))
+	grantee := sdk.AccAddress([]byte(strings.Repeat("e", 32)))
+	claim := NewMsgClaim("system:claim_reward", granter, true, "10")
+	delegate := NewMsgDelegate("system:delegate", granter, grantee, "60", "10")
+
+	msg := NewMsgExec(grantee, []sdk.Msg{claim, delegate})
+	require.Nil(t, msg.ValidateBasic())
+	require.Equal(t, []sdk.AccAddress{grantee}, msg.GetSigners())
+	require.NotNil(t, NewMsgExec(grantee, nil).ValidateBasic())
+	require.NotNil(t, NewMsgExec(nil, []sdk.Msg{claim}).ValidateBasic())
+	require.NotNil(t, NewMsgExec(grantee, []sdk.Msg{NewMsgAuthorize(granter)}).ValidateBasic())
+	require.NotNil(t, NewMsgExec(grantee, []sdk.Msg{NewMsgClaim("system:claim_reward", nil, true, "10")}).ValidateBasic())
+
+	// the executed msgs survive the codec of the app
+	cdc := codec.New()
+	sdk.RegisterCodec(cdc)
+	RegisterCodec(cdc)
+	var decoded MsgExec
+	require.Nil(t, cdc.UnmarshalJSON(cdc.MustMarshalJSON(msg), &decoded))
+	require.Equal(t, msg, decoded)
+	require.NotPanics(t, func() { msg.GetSignBytes() })
+}
@@ -2,6 +2,7 @@ package types
 
 import (
 	"github.com/hdac-io/friday/codec"
+	sdk "github.com/hdac-io/friday/types"
 )
 
 // ModuleCdc is used as a codec in types package
@@ -9,6 +10,7 @@ var ModuleCdc *codec.Codec
 
 func init() {
 	ModuleCdc = codec.New()
+	sdk.RegisterCodec(ModuleCdc) // MsgExec holds the msgs it executes
 	RegisterCodec(ModuleCdc)
 }
 
@@ -18,6 +20,7 @@ func RegisterCodec(cdc *codec.Codec) {
 
 	cdc.RegisterConcrete(MsgCreateValidator{}, "executionengine/CreateValidator", nil)
 	cdc.RegisterConcrete(MsgEditValidator{}, "executionengine/EditValidator", nil)
+	cdc.RegisterConcrete(MsgRotateConsPubKey{}, "executionengine/RotateConsPubKey", nil)
 	cdc.RegisterConcrete(MsgExecute{}, "executionengine/Execute", nil)
 	cdc.RegisterConcrete(MsgTransfer{}, "executionengine/Transfer", nil)
 	cdc.RegisterConcrete(MsgBond{}, "executionengine/Bond", nil)
@@ -28,6 +31,20 @@ func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(MsgVote{}, "executionengine/Vote", nil)
 	cdc.RegisterConcrete(MsgUnvote{}, "executionengine/Unvote", nil)
 	cdc.RegisterConcrete(MsgClaim{}, "executionengine/Claim", nil)
+	cdc.RegisterConcrete(MsgDeployContract{}, "executionengine/DeployContract", nil)
+	cdc.RegisterConcrete(MsgSetContractSchema{}, "executionengine/SetContractSchema", nil)
+	cdc.RegisterConcrete(MsgAddAssociatedKey{}, "executionengine/AddAssociatedKey", nil)
+	cdc.RegisterConcrete(MsgRemoveAssociatedKey{}, "executionengine/RemoveAssociatedKey", nil)
+	cdc.RegisterConcrete(MsgUpdateAssociatedKey{}, "executionengine/UpdateAssociatedKey", nil)
+	cdc.RegisterConcrete(MsgSetActionThreshold{}, "executionengine/SetActionThreshold", nil)
+	cdc.RegisterConcrete(MsgAuthorize{}, "executionengine/Authorize", nil)
+	cdc.RegisterConcrete(MsgGrant{}, "executionengine/Grant", nil)
+	cdc.RegisterConcrete(MsgRevoke{}, "executionengine/Revoke", nil)
+	cdc.RegisterConcrete(MsgExec{}, "executionengine/Exec", nil)
+	cdc.RegisterConcrete(MsgGrantFeeAllowance{}, "executionengine/GrantFeeAllowance", nil)
+	cdc.RegisterConcrete(MsgRevokeFeeAllowance{}, "executionengine/RevokeFeeAllowance", nil)
+	cdc.RegisterConcrete(MsgScheduleTransfer{}, "executionengine/ScheduleTransfer", nil)
+	cdc.RegisterConcrete(MsgCancelSchedule{}, "executionengine/CancelSchedule", nil)
 	cdc.RegisterConcrete(ContractHashAddress{}, "types/ContractHashAddress", nil)
 	cdc.RegisterConcrete(ContractUrefAddress{}, "types/ContractUrefAddress", nil)
 }
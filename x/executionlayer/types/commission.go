@@ -0,0 +1,200 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	sdk "github.com/hdac-io/friday/types"
+)
+
+type (
+	// Commission defines the commission parameters of a validator.
+	Commission struct {
+		CommissionRates `json:"commission_rates" yaml:"commission_rates"`
+		UpdateTime      time.Time `json:"update_time" yaml:"update_time"` // the last time the commission rate was changed
+	}
+
+	// CommissionRates defines the commission rates a validator is created with.
+	CommissionRates struct {
+		Rate          sdk.Dec `json:"rate" yaml:"rate"`                       // the commission rate charged to delegators, as a fraction
+		MaxRate       sdk.Dec `json:"max_rate" yaml:"max_rate"`               // maximum commission rate which validator can ever charge, as a fraction
+		MaxChangeRate sdk.Dec `json:"max_change_rate" yaml:"max_change_rate"` // maximum daily increase of the validator commission, as a fraction
+	}
+)
+
+// NewCommissionRates returns an initialized validator commission rates.
+func NewCommissionRates(rate, maxRate, maxChangeRate sdk.Dec) CommissionRates {
+	return CommissionRates{
+		Rate:          rate,
+		MaxRate:       maxRate,
+		MaxChangeRate: maxChangeRate,
+	}
+}
+
+// NewCommissionWithTime returns an initialized validator commission with a specified
+// update time which should be the current block BFT time.
+func NewCommissionWithTime(rate, maxRate, maxChangeRate sdk.Dec, updatedAt time.Time) Commission {
+	return Commission{
+		CommissionRates: NewCommissionRates(rate, maxRate, maxChangeRate),
+		UpdateTime:      updatedAt,
+	}
+}
+
+// CommissionRatePercents is the scale of the commission rate the PoS contract takes, in whole percents
+const CommissionRatePercents = 100
+
+// DefaultCommissionRates returns the commission rates the validators created before the
+// commission rates are migrated to
+func DefaultCommissionRates() CommissionRates {
+	return NewCommissionRates(sdk.NewDecWithPrec(1, 1), sdk.NewDecWithPrec(2, 1), sdk.NewDecWithPrec(1, 2))
+}
+
+// IsEmpty returns true for the commission of a validator created before the commission rates,
+// which has none of the rates set.
+func (c CommissionRates) IsEmpty() bool {
+	return c.Rate.IsNil() || c.MaxRate.IsNil() || c.MaxChangeRate.IsNil()
+}
+
+// IsUnset returns true for the commission of a validator created before the commission rates,
+// which is empty or decoded as zero rates never set by the validator
+func (c Commission) IsUnset() bool {
+	return c.IsEmpty() || c.UpdateTime.IsZero()
+}
+
+// ContractCommissionRate returns the rate in whole percents, as the PoS contract takes it.
+// A rate with a fraction of a percent is rejected, so the contract charges the rate recorded.
+func ContractCommissionRate(rate sdk.Dec) (sdk.Int, sdk.Error) {
+	percents := rate.MulInt64(CommissionRatePercents)
+	if !percents.Equal(percents.TruncateDec()) {
+		return sdk.Int{}, ErrCommissionRatePrecision(DefaultCodespace)
+	}
+	return percents.TruncateInt(), nil
+}
+
+// String implements the Stringer interface for a Commission.
+func (c Commission) String() string {
+	return fmt.Sprintf("rate: %s, maxRate: %s, maxChangeRate: %s, updateTime: %s",
+		c.Rate, c.MaxRate, c.MaxChangeRate, c.UpdateTime,
+	)
+}
+
+// Validate performs basic sanity validation checks of initial commission
+// parameters. If validation fails, an SDK error is returned.
+func (c CommissionRates) Validate() sdk.Error {
+	switch {
+	case c.IsEmpty():
+		// all the rates must be given
+		return ErrCommissionEmpty(DefaultCodespace)
+
+	case c.MaxRate.LT(sdk.ZeroDec()):
+		// max rate cannot be negative
+		return ErrCommissionNegative(DefaultCodespace)
+
+	case c.MaxRate.GT(sdk.OneDec()):
+		// max rate cannot be greater than 1
+		return ErrCommissionHuge(DefaultCodespace)
+
+	case c.Rate.LT(sdk.ZeroDec()):
+		// rate cannot be negative
+		return ErrCommissionNegative(DefaultCodespace)
+
+	case c.Rate.GT(c.MaxRate):
+		// rate cannot be greater than the max rate
+		return ErrCommissionGTMaxRate(DefaultCodespace)
+
+	case !isWholePercents(c.Rate):
+		// the PoS contract takes the rate in whole percents
+		return ErrCommissionRatePrecision(DefaultCodespace)
+
+	case c.MaxChangeRate.LT(sdk.ZeroDec()):
+		// change rate cannot be negative
+		return ErrCommissionChangeRateNegative(DefaultCodespace)
+
+	case c.MaxChangeRate.GT(c.MaxRate):
+		// change rate cannot be greater than the max rate
+		return ErrCommissionChangeRateGTMaxRate(DefaultCodespace)
+	}
+
+	return nil
+}
+
+// ValidateNewRate performs basic sanity validation checks of a new commission
+// rate. If validation fails, an SDK error is returned.
+func (c Commission) ValidateNewRate(newRate sdk.Dec, blockTime time.Time) sdk.Error {
+	switch {
+	case c.IsUnset():
+		// a validator without the rates has no bounds to change the rate within
+		return ErrCommissionEmpty(DefaultCodespace)
+
+	case blockTime.Sub(c.UpdateTime).Hours() < 24:
+		// new rate cannot be changed more than once within 24 hours
+		return ErrCommissionUpdateTime(DefaultCodespace)
+
+	case newRate.LT(sdk.ZeroDec()):
+		// new rate cannot be negative
+		return ErrCommissionNegative(DefaultCodespace)
+
+	case newRate.GT(c.MaxRate):
+		// new rate cannot be greater than the max rate
+		return ErrCommissionGTMaxRate(DefaultCodespace)
+
+	case !isWholePercents(newRate):
+		// the PoS contract takes the rate in whole percents
+		return ErrCommissionRatePrecision(DefaultCodespace)
+
+	case newRate.Sub(c.Rate).GT(c.MaxChangeRate):
+		// new rate % points change cannot be greater than the max change rate
+		return ErrCommissionGTMaxChangeRate(DefaultCodespace)
+	}
+
+	return nil
+}
+
+func isWholePercents(rate sdk.Dec) bool {
+	_, err := ContractCommissionRate(rate)
+	return err == nil
+}
+
+// CommissionRateChange - commission rate of a validator set at a height, by creating the
+// validator or editing its rate
+type CommissionRateChange struct {
+	Height int64     `json:"height" yaml:"height"`
+	Time   time.Time `json:"time" yaml:"time"`
+	Rate   sdk.Dec   `json:"rate" yaml:"rate"`
+}
+
+// NewCommissionRateChange creates a new CommissionRateChange instance
+func NewCommissionRateChange(height int64, time time.Time, rate sdk.Dec) CommissionRateChange {
+	return CommissionRateChange{
+		Height: height,
+		Time:   time,
+		Rate:   rate,
+	}
+}
+
+// CommissionRateHistory - current commission of a validator with the changes of its rate
+type CommissionRateHistory struct {
+	Validator  sdk.AccAddress         `json:"validator" yaml:"validator"`
+	Commission Commission             `json:"commission" yaml:"commission"`
+	Changes    []CommissionRateChange `json:"changes" yaml:"changes"`
+}
+
+// NewCommissionRateHistory creates a new CommissionRateHistory instance
+func NewCommissionRateHistory(validator sdk.AccAddress, commission Commission, changes []CommissionRateChange) CommissionRateHistory {
+	return CommissionRateHistory{
+		Validator:  validator,
+		Commission: commission,
+		Changes:    changes,
+	}
+}
+
+// String returns a human readable string representation of a commission rate history
+func (h CommissionRateHistory) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Commission of %s:\n  %s\nRate Changes:", h.Validator, h.Commission)
+	for _, change := range h.Changes {
+		fmt.Fprintf(&b, "\n  Height %d (%s): %s", change.Height, change.Time.Format(time.RFC3339), change.Rate)
+	}
+	return b.String()
+}
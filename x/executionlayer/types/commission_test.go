@@ -0,0 +1,105 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	sdk "github.com/hdac-io/friday/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommissionValidate(t *testing.T) {
+	testCases := []struct {
+		input     CommissionRates
+		expectErr bool
+	}{
+		// invalid commission; max rate < 0%
+		{NewCommissionRates(sdk.ZeroDec(), sdk.MustNewDecFromStr("-1.00"), sdk.ZeroDec()), true},
+		// invalid commission; max rate > 100%
+		{NewCommissionRates(sdk.ZeroDec(), sdk.MustNewDecFromStr("2.00"), sdk.ZeroDec()), true},
+		// invalid commission; rate < 0%
+		{NewCommissionRates(sdk.MustNewDecFromStr("-1.00"), sdk.ZeroDec(), sdk.ZeroDec()), true},
+		// invalid commission; rate > max rate
+		{NewCommissionRates(sdk.MustNewDecFromStr("0.75"), sdk.MustNewDecFromStr("0.50"), sdk.ZeroDec()), true},
+		// invalid commission; max change rate < 0%
+		{NewCommissionRates(sdk.OneDec(), sdk.OneDec(), sdk.MustNewDecFromStr("-1.00")), true},
+		// invalid commission; max change rate > max rate
+		{NewCommissionRates(sdk.OneDec(), sdk.MustNewDecFromStr("0.75"), sdk.MustNewDecFromStr("0.90")), true},
+		// invalid commission; rates not given
+		{CommissionRates{}, true},
+		// invalid commission; rate with a fraction of a percent
+		{NewCommissionRates(sdk.MustNewDecFromStr("0.205"), sdk.OneDec(), sdk.MustNewDecFromStr("0.10")), true},
+		// valid commission
+		{NewCommissionRates(sdk.MustNewDecFromStr("0.20"), sdk.OneDec(), sdk.MustNewDecFromStr("0.10")), false},
+	}
+
+	for i, tc := range testCases {
+		err := tc.input.Validate()
+		require.Equal(t, tc.expectErr, err != nil, "unexpected result; tc #%d, input: %v", i, tc.input)
+	}
+}
+
+func TestCommissionValidateNewRate(t *testing.T) {
+	now := time.Now().UTC()
+	c1 := NewCommissionWithTime(sdk.MustNewDecFromStr("0.40"), sdk.MustNewDecFromStr("0.80"), sdk.MustNewDecFromStr("0.10"), now)
+
+	testCases := []struct {
+		input     Commission
+		newRate   sdk.Dec
+		blockTime time.Time
+		expectErr bool
+	}{
+		// invalid new commission rate; last update < 24h ago
+		{c1, sdk.MustNewDecFromStr("0.50"), now, true},
+		// invalid new commission rate; new rate < 0%
+		{c1, sdk.MustNewDecFromStr("-1.00"), now.Add(48 * time.Hour), true},
+		// invalid new commission rate; new rate > max rate
+		{c1, sdk.MustNewDecFromStr("0.90"), now.Add(48 * time.Hour), true},
+		// invalid new commission rate; new rate > max change rate
+		{c1, sdk.MustNewDecFromStr("0.60"), now.Add(48 * time.Hour), true},
+		// invalid new commission rate; validator without the rates
+		{Commission{}, sdk.MustNewDecFromStr("0.10"), now.Add(48 * time.Hour), true},
+		{NewCommissionWithTime(sdk.ZeroDec(), sdk.ZeroDec(), sdk.ZeroDec(), time.Time{}), sdk.ZeroDec(), now, true},
+		// invalid new commission rate; fraction of a percent
+		{c1, sdk.MustNewDecFromStr("0.455"), now.Add(48 * time.Hour), true},
+		// valid commission
+		{c1, sdk.MustNewDecFromStr("0.50"), now.Add(48 * time.Hour), false},
+		// valid commission
+		{c1, sdk.MustNewDecFromStr("0.10"), now.Add(48 * time.Hour), false},
+	}
+
+	for i, tc := range testCases {
+		err := tc.input.ValidateNewRate(tc.newRate, tc.blockTime)
+		require.Equal(t, tc.expectErr, err != nil, "unexpected result; tc #%d, input: %v, newRate: %s, blockTime: %s", i, tc.input, tc.newRate, tc.blockTime)
+	}
+}
+
+func TestContractCommissionRate(t *testing.T) {
+	percents, err := ContractCommissionRate(sdk.MustNewDecFromStr("0.15"))
+	require.Nil(t, err)
+	require.Equal(t, "15", percents.String())
+	percents, err = ContractCommissionRate(sdk.OneDec())
+	require.Nil(t, err)
+	require.Equal(t, "100", percents.String())
+	_, err = ContractCommissionRate(sdk.MustNewDecFromStr("0.155"))
+	require.NotNil(t, err)
+
+	require.Nil(t, DefaultCommissionRates().Validate())
+}
+
+func TestMsgEditValidatorCommissionRate(t *testing.T) {
+	acc := sdk.AccAddress([]byte("validator___________"))
+	rate := sdk.MustNewDecFromStr("0.30")
+	msg := NewMsgEditValidator("system:edit_validator", acc, Description{}, &rate, "100")
+	require.Nil(t, msg.ValidateBasic())
+	require.Contains(t, string(msg.GetSignBytes()), "commission_rate")
+
+	// sign bytes without a new rate are kept as before
+	msg = NewMsgEditValidator("system:edit_validator", acc, Description{Moniker: "validator"}, nil, "100")
+	require.Nil(t, msg.ValidateBasic())
+	require.NotContains(t, string(msg.GetSignBytes()), "commission_rate")
+
+	require.NotNil(t, NewMsgEditValidator("system:edit_validator", acc, Description{}, nil, "100").ValidateBasic())
+	rate = sdk.MustNewDecFromStr("1.10")
+	require.NotNil(t, NewMsgEditValidator("system:edit_validator", acc, Description{}, &rate, "100").ValidateBasic())
+}
@@ -0,0 +1,88 @@
+package types
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/hdac-io/friday/codec"
+	sdk "github.com/hdac-io/friday/types"
+)
+
+// MaxContractNameLength - maximum length of a name of the registered contract
+const MaxContractNameLength = 140
+
+// ContractInfo - registry entry of a contract deployed by MsgDeployContract
+type ContractInfo struct {
+	Deployer     sdk.AccAddress  `json:"deployer" yaml:"deployer"`
+	Name         string          `json:"name" yaml:"name"`
+	CodeHash     []byte          `json:"code_hash" yaml:"code_hash"`
+	Height       int64           `json:"height" yaml:"height"`
+	ContractKeys []string        `json:"contract_keys" yaml:"contract_keys"` // bech32 encoded contract hash or uref addresses
+	Schema       *ContractSchema `json:"schema,omitempty" yaml:"schema,omitempty"`
+}
+
+// NewContractInfo - initialize a new contract registry entry
+func NewContractInfo(deployer sdk.AccAddress, name string, codeHash []byte, height int64, contractKeys []string) ContractInfo {
+	return ContractInfo{
+		Deployer:     deployer,
+		Name:         name,
+		CodeHash:     codeHash,
+		Height:       height,
+		ContractKeys: contractKeys,
+	}
+}
+
+// return the contract info
+func MustMarshalContractInfo(cdc *codec.Codec, contractInfo ContractInfo) []byte {
+	return cdc.MustMarshalBinaryLengthPrefixed(contractInfo)
+}
+
+// unmarshal a contract info from a store value
+func MustUnmarshalContractInfo(cdc *codec.Codec, value []byte) ContractInfo {
+	contractInfo, err := UnmarshalContractInfo(cdc, value)
+	if err != nil {
+		panic(err)
+	}
+	return contractInfo
+}
+
+// unmarshal a contract info from a store value
+func UnmarshalContractInfo(cdc *codec.Codec, value []byte) (contractInfo ContractInfo, err error) {
+	err = cdc.UnmarshalBinaryLengthPrefixed(value, &contractInfo)
+	return contractInfo, err
+}
+
+// String returns a human readable string representation of a contract info.
+func (c ContractInfo) String() string {
+	out := fmt.Sprintf(`Contract
+  Deployer:      %s
+  Name:          %s
+  Code Hash:     %s
+  Height:        %d
+  Contract Keys: %s`, c.Deployer, c.Name, hex.EncodeToString(c.CodeHash), c.Height, strings.Join(c.ContractKeys, ", "))
+	if c.Schema != nil {
+		out += "\n  " + c.Schema.String()
+	}
+	return out
+}
+
+// HasContractKey returns whether the contract is stored under the bech32 encoded hash or uref address
+func (c ContractInfo) HasContractKey(contractKey string) bool {
+	for _, key := range c.ContractKeys {
+		if key == contractKey {
+			return true
+		}
+	}
+	return false
+}
+
+// ContractInfos is a collection of ContractInfo
+type ContractInfos []ContractInfo
+
+func (c ContractInfos) String() (out string) {
+	for _, val := range c {
+		out += val.String() + "\n"
+	}
+	return strings.TrimSpace(out)
+}
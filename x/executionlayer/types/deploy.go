@@ -0,0 +1,234 @@
+package types
+
+import (
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/util"
+
+	sdk "github.com/hdac-io/friday/types"
+)
+
+// DeployHashLength - length of the deploy hash, a blake2b256 digest
+const DeployHashLength = 32
+
+// NewDeployHash derives the hash of the deploy of the msg at msgIndex of a tx, which the signer
+// signed with the sequence on the chain. As a signer never signs twice with a sequence, deploy
+// hashes don't repeat, and the sender knows the hash of a deploy before broadcasting it.
+func NewDeployHash(chainID string, signer sdk.AccAddress, sequence uint64, msgIndex int) []byte {
+	bz := append(sdk.Uint64ToBigEndian(uint64(len(chainID))), []byte(chainID)...)
+	bz = append(bz, signer.Bytes()...)
+	bz = append(bz, sdk.Uint64ToBigEndian(sequence)...)
+	bz = append(bz, sdk.Uint64ToBigEndian(uint64(msgIndex))...)
+	return util.Blake2b256(bz)
+}
+
+// NewExecDeployHash derives the hash of the deploy of the msg at index of an exec from the deploy
+// hash of the exec of the grantee, and the granter the deploy runs as
+func NewExecDeployHash(execDeployHash []byte, granter sdk.AccAddress, index int) []byte {
+	bz := append(execDeployHash[:len(execDeployHash):len(execDeployHash)], granter.Bytes()...)
+	bz = append(bz, sdk.Uint64ToBigEndian(uint64(index))...)
+	return util.Blake2b256(bz)
+}
+
+// NewFeeFundingDeployHash derives the hash of the deploy transferring the fee of a sponsored deploy
+// from the fee payer to the sender, from the hash of the sponsored deploy
+func NewFeeFundingDeployHash(deployHash []byte, feePayer sdk.AccAddress) []byte {
+	bz := append(deployHash[:len(deployHash):len(deployHash)], feePayer.Bytes()...)
+	return util.Blake2b256(bz)
+}
+
+// DeployRecord - tx and height which executed a deploy, kept in the index of the recent deploys
+type DeployRecord struct {
+	DeployHash string `json:"deploy_hash" yaml:"deploy_hash"` // hex encoded
+	Height     int64  `json:"height" yaml:"height"`
+	TxHash     string `json:"tx_hash" yaml:"tx_hash"` // hex encoded as tendermint does, empty for a deploy out of a tx
+}
+
+// NewDeployRecord creates a new DeployRecord instance
+func NewDeployRecord(deployHash []byte, height int64, txHash []byte) DeployRecord {
+	return DeployRecord{
+		DeployHash: hex.EncodeToString(deployHash),
+		Height:     height,
+		TxHash:     fmt.Sprintf("%X", txHash),
+	}
+}
+
+// String returns a human readable string representation of a deploy record
+func (r DeployRecord) String() string {
+	return fmt.Sprintf(`Deploy %s:
+  Height:   %d
+  Tx Hash:  %s`, r.DeployHash, r.Height, r.TxHash)
+}
+
+// DeployHeader - optional header of the deploy produced by a msg, bounding when the deploy can be
+// executed as the chainspec deploy config does. With a fee payer, which must co-sign the tx and allow
+// the sender to spend the fee, a deploy of the payer's account transfers the fee to the sender
+// before the deploy runs.
+type DeployHeader struct {
+	Timestamp    int64          `json:"timestamp" yaml:"timestamp"`                     // creation time in unix milliseconds
+	TTLMillis    uint32         `json:"ttl_millis" yaml:"ttl_millis"`                   // zero for the max TTL of the chainspec
+	Dependencies []string       `json:"dependencies" yaml:"dependencies"`               // hex encoded hashes of deploys to be executed before
+	FeePayer     sdk.AccAddress `json:"fee_payer,omitempty" yaml:"fee_payer,omitempty"` // empty for the sender paying the fee
+}
+
+// NewDeployHeader creates a new DeployHeader instance
+func NewDeployHeader(timestamp int64, ttlMillis uint32, dependencies []string) *DeployHeader {
+	return &DeployHeader{
+		Timestamp:    timestamp,
+		TTLMillis:    ttlMillis,
+		Dependencies: dependencies,
+	}
+}
+
+// DeployMsg is a msg producing a deploy of the EE, which may carry a deploy header
+type DeployMsg interface {
+	sdk.Msg
+	GetDeployHeader() *DeployHeader
+	WithDeployHeader(header *DeployHeader) DeployMsg
+}
+
+var (
+	_ DeployMsg = MsgExecute{}
+	_ DeployMsg = MsgTransfer{}
+	_ DeployMsg = MsgCreateValidator{}
+	_ DeployMsg = MsgEditValidator{}
+	_ DeployMsg = MsgRotateConsPubKey{}
+	_ DeployMsg = MsgBond{}
+	_ DeployMsg = MsgUnBond{}
+	_ DeployMsg = MsgDelegate{}
+	_ DeployMsg = MsgUndelegate{}
+	_ DeployMsg = MsgRedelegate{}
+	_ DeployMsg = MsgVote{}
+	_ DeployMsg = MsgUnvote{}
+	_ DeployMsg = MsgClaim{}
+	_ DeployMsg = MsgDeployContract{}
+	_ DeployMsg = MsgAddAssociatedKey{}
+	_ DeployMsg = MsgRemoveAssociatedKey{}
+	_ DeployMsg = MsgUpdateAssociatedKey{}
+	_ DeployMsg = MsgSetActionThreshold{}
+	_ DeployMsg = MsgScheduleTransfer{}
+)
+
+// GetDeployFee returns the fee of the deploy of the msg
+func GetDeployFee(msg DeployMsg) string {
+	switch msg := msg.(type) {
+	case MsgExecute:
+		return msg.Fee
+	case MsgTransfer:
+		return msg.Fee
+	case MsgCreateValidator:
+		return msg.Fee
+	case MsgEditValidator:
+		return msg.Fee
+	case MsgRotateConsPubKey:
+		return msg.Fee
+	case MsgBond:
+		return msg.Fee
+	case MsgUnBond:
+		return msg.Fee
+	case MsgDelegate:
+		return msg.Fee
+	case MsgUndelegate:
+		return msg.Fee
+	case MsgRedelegate:
+		return msg.Fee
+	case MsgVote:
+		return msg.Fee
+	case MsgUnvote:
+		return msg.Fee
+	case MsgClaim:
+		return msg.Fee
+	case MsgDeployContract:
+		return msg.Fee
+	case MsgAddAssociatedKey:
+		return msg.Fee
+	case MsgRemoveAssociatedKey:
+		return msg.Fee
+	case MsgUpdateAssociatedKey:
+		return msg.Fee
+	case MsgSetActionThreshold:
+		return msg.Fee
+	case MsgScheduleTransfer:
+		return msg.Fee
+	default:
+		return ""
+	}
+}
+
+// ValidateBasic checks the header without the deploy config. A nil header is valid.
+func (h *DeployHeader) ValidateBasic() sdk.Error {
+	if h == nil {
+		return nil
+	}
+	if h.Timestamp <= 0 {
+		return ErrInvalidDeployHeader(DefaultCodespace, "timestamp must be positive")
+	}
+
+	dependencies := make(map[string]bool)
+	for _, dependency := range h.Dependencies {
+		deployHash, err := hex.DecodeString(dependency)
+		if err != nil || len(deployHash) != DeployHashLength {
+			return ErrInvalidDeployHeader(DefaultCodespace,
+				fmt.Sprintf("dependency %s is not a hex encoded %d byte deploy hash", dependency, DeployHashLength))
+		}
+		if dependencies[dependency] {
+			return ErrInvalidDeployHeader(DefaultCodespace, fmt.Sprintf("duplicated dependency %s", dependency))
+		}
+		dependencies[dependency] = true
+	}
+	return nil
+}
+
+// Expiry returns the time in unix milliseconds after which the deploy can't be executed
+func (h *DeployHeader) Expiry(config DeployConfig) int64 {
+	ttl := h.TTLMillis
+	if ttl == 0 {
+		ttl = config.MaxTtlMillis
+	}
+	return h.Timestamp + int64(ttl)
+}
+
+// Validate checks the header against the deploy config at the block time. A zero limit of the
+// config isn't enforced. A nil header is valid.
+func (h *DeployHeader) Validate(config DeployConfig, blockTime time.Time) sdk.Error {
+	if h == nil {
+		return nil
+	}
+	if err := h.ValidateBasic(); err != nil {
+		return err
+	}
+
+	if config.MaxTtlMillis > 0 && h.TTLMillis > config.MaxTtlMillis {
+		return ErrInvalidDeployHeader(DefaultCodespace,
+			fmt.Sprintf("ttl %d ms is over the max of %d ms", h.TTLMillis, config.MaxTtlMillis))
+	}
+	if config.MaxDependencies > 0 && uint32(len(h.Dependencies)) > config.MaxDependencies {
+		return ErrInvalidDeployHeader(DefaultCodespace,
+			fmt.Sprintf("%d dependencies are over the max of %d", len(h.Dependencies), config.MaxDependencies))
+	}
+
+	blockTimeMillis := blockTime.UnixNano() / int64(time.Millisecond)
+	if h.Timestamp > blockTimeMillis {
+		return ErrInvalidDeployHeader(DefaultCodespace,
+			fmt.Sprintf("timestamp %d is after the block time %d", h.Timestamp, blockTimeMillis))
+	}
+	if (h.TTLMillis > 0 || config.MaxTtlMillis > 0) && h.Expiry(config) < blockTimeMillis {
+		return ErrDeployExpired(DefaultCodespace, h.Expiry(config), blockTimeMillis)
+	}
+	return nil
+}
+
+// String returns a human readable string representation of a deploy header
+func (h DeployHeader) String() string {
+	feePayer := "sender"
+	if !h.FeePayer.Empty() {
+		feePayer = h.FeePayer.String()
+	}
+	return fmt.Sprintf(`Deploy Header:
+  Timestamp:     %d
+  TTL Millis:    %d
+  Dependencies:  %v
+  Fee Payer:     %s`, h.Timestamp, h.TTLMillis, h.Dependencies, feePayer)
+}
@@ -0,0 +1,87 @@
+package types
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/hdac-io/friday/types"
+)
+
+func TestDeployHeaderValidate(t *testing.T) {
+	config := DeployConfig{MaxTtlMillis: 60000, MaxDependencies: 2}
+	blockTime := time.Unix(1000, 0)
+	blockTimeMillis := int64(1000000)
+	dependency := strings.Repeat("ab", DeployHashLength)
+
+	var header *DeployHeader
+	require.Nil(t, header.Validate(config, blockTime))
+
+	header = NewDeployHeader(blockTimeMillis-1000, 0, []string{dependency})
+	require.Nil(t, header.Validate(config, blockTime))
+	require.Equal(t, blockTimeMillis+59000, header.Expiry(config))
+
+	// expired with the max TTL, and with its own TTL
+	header = NewDeployHeader(blockTimeMillis-60001, 0, nil)
+	require.Equal(t, CodeDeployExpired, header.Validate(config, blockTime).Code())
+	header = NewDeployHeader(blockTimeMillis-1001, 1000, nil)
+	require.Equal(t, CodeDeployExpired, header.Validate(config, blockTime).Code())
+
+	// over the limits, and in the future
+	header = NewDeployHeader(blockTimeMillis, 60001, nil)
+	require.Equal(t, CodeInvalidDeployHeader, header.Validate(config, blockTime).Code())
+	header = NewDeployHeader(blockTimeMillis, 0, []string{dependency, strings.Repeat("cd", DeployHashLength), strings.Repeat("ef", DeployHashLength)})
+	require.Equal(t, CodeInvalidDeployHeader, header.Validate(config, blockTime).Code())
+	header = NewDeployHeader(blockTimeMillis+1, 0, nil)
+	require.Equal(t, CodeInvalidDeployHeader, header.Validate(config, blockTime).Code())
+
+	// zero limits aren't enforced
+	header = NewDeployHeader(1, 0, []string{dependency, strings.Repeat("cd", DeployHashLength), strings.Repeat("ef", DeployHashLength)})
+	require.Nil(t, header.Validate(DeployConfig{}, blockTime))
+}
+
+func TestDeployHeaderValidateBasic(t *testing.T) {
+	dependency := strings.Repeat("ab", DeployHashLength)
+
+	require.Nil(t, NewDeployHeader(1, 0, []string{dependency}).ValidateBasic())
+	require.NotNil(t, NewDeployHeader(0, 0, nil).ValidateBasic())
+	require.NotNil(t, NewDeployHeader(1, 0, []string{"abcd"}).ValidateBasic())
+	require.NotNil(t, NewDeployHeader(1, 0, []string{strings.Repeat("zz", DeployHashLength)}).ValidateBasic())
+	require.NotNil(t, NewDeployHeader(1, 0, []string{dependency, dependency}).ValidateBasic())
+}
+
+func TestMsgDeployHeader(t *testing.T) {
+	addr := sdk.AccAddress([]byte("sender__________________________"))
+	msg := NewMsgTransfer("transfer", addr, addr, "100", "10")
+
+	// sign bytes without the header are kept as before
+	require.NotContains(t, string(msg.GetSignBytes()), "deploy_header")
+	require.Nil(t, msg.GetDeployHeader())
+
+	header := NewDeployHeader(1, 1000, nil)
+	deployMsg := msg.WithDeployHeader(header)
+	require.Equal(t, header, deployMsg.GetDeployHeader())
+	require.Contains(t, string(deployMsg.GetSignBytes()), "deploy_header")
+	require.Nil(t, msg.GetDeployHeader())
+
+	deployMsg = msg.WithDeployHeader(NewDeployHeader(0, 1000, nil))
+	require.NotNil(t, deployMsg.ValidateBasic())
+}
+
+func TestNewDeployHash(t *testing.T) {
+	addr := sdk.AccAddress([]byte("sender__________________________"))
+	hash := NewDeployHash("chain", addr, 1, 0)
+
+	require.Equal(t, DeployHashLength, len(hash))
+	require.Equal(t, hash, NewDeployHash("chain", addr, 1, 0))
+	require.NotEqual(t, hash, NewDeployHash("chain2", addr, 1, 0))
+	require.NotEqual(t, hash, NewDeployHash("chain", addr, 2, 0))
+	require.NotEqual(t, hash, NewDeployHash("chain", addr, 1, 1))
+	require.NotEqual(t, hash, NewDeployHash("chain", sdk.AccAddress([]byte("other")), 1, 0))
+
+	funding := NewFeeFundingDeployHash(hash, sdk.AccAddress([]byte("payer")))
+	require.Equal(t, DeployHashLength, len(funding))
+	require.NotEqual(t, hash, funding)
+}
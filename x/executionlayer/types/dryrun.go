@@ -0,0 +1,86 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Names of the transforms in a dry run result
+const (
+	TransformIdentity  = "identity"
+	TransformAddI32    = "add_i32"
+	TransformAddU64    = "add_u64"
+	TransformAddBigInt = "add_big_int"
+	TransformAddKeys   = "add_keys"
+	TransformWrite     = "write"
+	TransformFailure   = "failure"
+)
+
+// DryRunResult - outcome of an execution which is run against the state but never committed.
+// The EE reports no return value of a session, which returns its results by storing them under
+// named keys, so the return values are the values written under the named keys the session adds.
+type DryRunResult struct {
+	Success      bool                      `json:"success"`
+	Cost         string                    `json:"cost"`
+	Error        string                    `json:"error,omitempty"`
+	ReturnValues map[string]DecodedCLValue `json:"return_values,omitempty"` // by the named key
+	Effects      []DryRunEffect            `json:"effects"`
+}
+
+// DryRunEffect - a transform the execution would apply to a key
+type DryRunEffect struct {
+	Key       string      `json:"key"`
+	Transform string      `json:"transform"`
+	Value     interface{} `json:"value,omitempty"`
+}
+
+// String returns the result as a diff of the keys the execution would change.
+// Reads (identity transforms) are left out.
+func (r DryRunResult) String() string {
+	var b strings.Builder
+	if r.Success {
+		fmt.Fprintf(&b, "Dry run succeeded\n")
+	} else {
+		fmt.Fprintf(&b, "Dry run failed: %s\n", r.Error)
+	}
+	fmt.Fprintf(&b, "Cost: %s\n", r.Cost)
+
+	names := make([]string, 0, len(r.ReturnValues))
+	for name := range r.ReturnValues {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value, err := json.Marshal(r.ReturnValues[name].Value)
+		if err != nil {
+			value = []byte(fmt.Sprintf("%v", r.ReturnValues[name].Value))
+		}
+		fmt.Fprintf(&b, "Returned %s: %s %s\n", name, r.ReturnValues[name].Type, value)
+	}
+
+	for _, effect := range r.Effects {
+		var op string
+		switch effect.Transform {
+		case TransformIdentity:
+			continue
+		case TransformWrite:
+			op = "~"
+		case TransformAddKeys:
+			op = "+"
+		case TransformFailure:
+			op = "!"
+		default:
+			op = "+="
+		}
+
+		value, err := json.Marshal(effect.Value)
+		if err != nil {
+			value = []byte(fmt.Sprintf("%v", effect.Value))
+		}
+		fmt.Fprintf(&b, "%-2s %s (%s) %s\n", op, effect.Key, effect.Transform, value)
+	}
+
+	return strings.TrimSpace(b.String())
+}
@@ -0,0 +1,31 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDryRunResultString(t *testing.T) {
+	result := DryRunResult{
+		Success:      true,
+		Cost:         "12345",
+		ReturnValues: map[string]DecodedCLValue{"total": {Type: "U512", Value: json.Number("1000")}},
+		Effects: []DryRunEffect{
+			{Key: "fridaycontracthash1read", Transform: TransformIdentity},
+			{Key: "fridaycontracturef1purse", Transform: TransformAddBigInt, Value: json.Number("1000")},
+			{Key: "friday1account", Transform: TransformAddKeys, Value: map[string]string{"counter": "fridaycontracthash1counter"}},
+		},
+	}
+
+	expected := `Dry run succeeded
+Cost: 12345
+Returned total: U512 1000
++= fridaycontracturef1purse (add_big_int) 1000
++  friday1account (add_keys) {"counter":"fridaycontracthash1counter"}`
+	require.Equal(t, expected, result.String())
+
+	result = DryRunResult{Success: false, Cost: "100", Error: "out of gas"}
+	require.Equal(t, "Dry run failed: out of gas\nCost: 100", result.String())
+}
@@ -3,6 +3,7 @@ package types
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	sdk "github.com/hdac-io/friday/types"
 )
@@ -16,10 +17,30 @@ const (
 	CodeInvalidValidator           sdk.CodeType = 201
 	CodeInvalidDelegation          sdk.CodeType = 202
 	CodeInvalidInput               sdk.CodeType = 203
+	CodeInvalidStakeRequest        sdk.CodeType = 204
 	CodeInvalidAddress             sdk.CodeType = sdk.CodeInvalidAddress
 	CodeGRpcExecuteMissingParent   sdk.CodeType = 301
 	CodeGRpcExecuteDeployGasError  sdk.CodeType = 302
 	CodeGRpcExecuteDeployExecError sdk.CodeType = 303
+	CodeEEStateNotFound            sdk.CodeType = 401
+	CodeInvalidDeployHeader        sdk.CodeType = 501
+	CodeDeployExpired              sdk.CodeType = 502
+	CodeDeployDependencyNotFound   sdk.CodeType = 503
+	CodeBlockSizeLimitExceeded     sdk.CodeType = 504
+	CodeBlockCostLimitExceeded     sdk.CodeType = 505
+	CodeDuplicateDeploy            sdk.CodeType = 506
+	CodeInvalidGrant               sdk.CodeType = 601
+	CodeGrantNotFound              sdk.CodeType = 602
+	CodeGrantExpired               sdk.CodeType = 603
+	CodeMsgNotGranted              sdk.CodeType = 604
+	CodeSpendLimitExceeded         sdk.CodeType = 605
+	CodeInvalidExec                sdk.CodeType = 606
+	CodeInvalidFeeAllowance        sdk.CodeType = 701
+	CodeFeeAllowanceNotFound       sdk.CodeType = 702
+	CodeFeeAllowanceExpired        sdk.CodeType = 703
+	CodeFeePayerNotSigned          sdk.CodeType = 704
+	CodeInvalidSchedule            sdk.CodeType = 801
+	CodeScheduleNotFound           sdk.CodeType = 802
 )
 
 // ErrPublicKeyDecode is an error
@@ -42,6 +63,124 @@ func ErrTomlParse(codespace sdk.CodespaceType, keyString string) sdk.Error {
 		"Could not parse Toml with : %v", keyString)
 }
 
+// ErrEEStateNotFound is an error
+func ErrEEStateNotFound(codespace sdk.CodespaceType, height int64) sdk.Error {
+	return sdk.NewError(
+		codespace, CodeEEStateNotFound,
+		"EE state of height %d is not found, the height may have been pruned", height)
+}
+
+// ErrInvalidDeployHeader is an error
+func ErrInvalidDeployHeader(codespace sdk.CodespaceType, reason string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidDeployHeader, "invalid deploy header: %s", reason)
+}
+
+// ErrDeployExpired is an error
+func ErrDeployExpired(codespace sdk.CodespaceType, expiry, blockTime int64) sdk.Error {
+	return sdk.NewError(
+		codespace, CodeDeployExpired,
+		"deploy expired at %d, block time is %d (unix milliseconds)", expiry, blockTime)
+}
+
+// ErrDeployDependencyNotFound is an error
+func ErrDeployDependencyNotFound(codespace sdk.CodespaceType, deployHash string) sdk.Error {
+	return sdk.NewError(codespace, CodeDeployDependencyNotFound, "dependency deploy %s is not executed", deployHash)
+}
+
+// ErrBlockSizeLimitExceeded is an error
+func ErrBlockSizeLimitExceeded(codespace sdk.CodespaceType, size, limit uint64) sdk.Error {
+	return sdk.NewError(
+		codespace, CodeBlockSizeLimitExceeded,
+		"deploys of the block would be %d bytes, over the limit of %d bytes", size, limit)
+}
+
+// ErrBlockCostLimitExceeded is an error
+func ErrBlockCostLimitExceeded(codespace sdk.CodespaceType, cost, limit uint64) sdk.Error {
+	return sdk.NewError(
+		codespace, CodeBlockCostLimitExceeded,
+		"deploys of the block would cost up to %d, over the limit of %d", cost, limit)
+}
+
+// ErrDuplicateDeploy is an error
+func ErrDuplicateDeploy(codespace sdk.CodespaceType, deployHash string, height int64) sdk.Error {
+	return sdk.NewError(codespace, CodeDuplicateDeploy, "deploy %s is already executed at height %d", deployHash, height)
+}
+
+// ErrInvalidGrant is an error
+func ErrInvalidGrant(codespace sdk.CodespaceType, reason string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidGrant, "invalid grant: %s", reason)
+}
+
+// ErrGrantNotFound is an error
+func ErrGrantNotFound(codespace sdk.CodespaceType, granter, grantee sdk.AccAddress) sdk.Error {
+	return sdk.NewError(codespace, CodeGrantNotFound, "%s has no grant to %s", granter, grantee)
+}
+
+// ErrGrantExpired is an error
+func ErrGrantExpired(codespace sdk.CodespaceType, granter, grantee sdk.AccAddress, expiration time.Time) sdk.Error {
+	return sdk.NewError(codespace, CodeGrantExpired, "grant of %s to %s expired at %v", granter, grantee, expiration)
+}
+
+// ErrMsgNotGranted is an error
+func ErrMsgNotGranted(codespace sdk.CodespaceType, msgType string, granter, grantee sdk.AccAddress) sdk.Error {
+	return sdk.NewError(codespace, CodeMsgNotGranted, "%s msgs of %s are not granted to %s", msgType, granter, grantee)
+}
+
+// ErrMsgNotGrantable is an error
+func ErrMsgNotGrantable(codespace sdk.CodespaceType, msg sdk.Msg) sdk.Error {
+	return sdk.NewError(codespace, CodeMsgNotGranted, "%T cannot be executed by a grantee", msg)
+}
+
+// ErrSpendLimitExceeded is an error
+func ErrSpendLimitExceeded(codespace sdk.CodespaceType, amount, limit string) sdk.Error {
+	return sdk.NewError(codespace, CodeSpendLimitExceeded, "msg spends %s, over the remaining spend limit of %s", amount, limit)
+}
+
+// ErrInvalidExec is an error
+func ErrInvalidExec(codespace sdk.CodespaceType, reason string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidExec, "invalid exec: %s", reason)
+}
+
+// ErrInvalidFeeAllowance is an error
+func ErrInvalidFeeAllowance(codespace sdk.CodespaceType, reason string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidFeeAllowance, "invalid fee allowance: %s", reason)
+}
+
+// ErrFeeAllowanceNotFound is an error
+func ErrFeeAllowanceNotFound(codespace sdk.CodespaceType, payer, grantee sdk.AccAddress) sdk.Error {
+	return sdk.NewError(codespace, CodeFeeAllowanceNotFound, "%s has no fee allowance to %s", payer, grantee)
+}
+
+// ErrFeeAllowanceExpired is an error
+func ErrFeeAllowanceExpired(codespace sdk.CodespaceType, payer, grantee sdk.AccAddress, expiration time.Time) sdk.Error {
+	return sdk.NewError(codespace, CodeFeeAllowanceExpired, "fee allowance of %s to %s expired at %v", payer, grantee, expiration)
+}
+
+// ErrFeePayerNotSigned is an error
+func ErrFeePayerNotSigned(codespace sdk.CodespaceType, payer sdk.AccAddress) sdk.Error {
+	return sdk.NewError(codespace, CodeFeePayerNotSigned, "fee payer %s must sign the tx", payer)
+}
+
+// ErrInvalidStakeRequest is an error
+func ErrInvalidStakeRequest(codespace sdk.CodespaceType, reason string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidStakeRequest, "invalid stake request: %s", reason)
+}
+
+// ErrInvalidSchedule is an error
+func ErrInvalidSchedule(codespace sdk.CodespaceType, reason string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidSchedule, "invalid schedule: %s", reason)
+}
+
+// ErrScheduleNotFound is an error
+func ErrScheduleNotFound(codespace sdk.CodespaceType, id uint64, sender sdk.AccAddress) sdk.Error {
+	return sdk.NewError(codespace, CodeScheduleNotFound, "%s has no schedule %d", sender, id)
+}
+
+// ErrInvalidSpendAmount is an error
+func ErrInvalidSpendAmount(codespace sdk.CodespaceType, amount string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidInput, "invalid amount %s, must be a non-negative integer", amount)
+}
+
 func ErrNilValidatorAddr(codespace sdk.CodespaceType) sdk.Error {
 	return sdk.NewError(codespace, CodeInvalidInput, "validator address is nil")
 }
@@ -87,6 +226,51 @@ func ErrValidatorPubKeyExists(codespace sdk.CodespaceType) sdk.Error {
 	return sdk.NewError(codespace, CodeInvalidValidator, "validator already exist for this pubkey, must use new validator pubkey")
 }
 
+func ErrNoValidatorFound(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidValidator, "validator does not exist for that address")
+}
+
+func ErrConsKeyRotationCooldown(codespace sdk.CodespaceType, rotatedHeight, availableHeight int64) sdk.Error {
+	msg := fmt.Sprintf("consensus pubkey was rotated at height %d, can be rotated again from height %d", rotatedHeight, availableHeight)
+	return sdk.NewError(codespace, CodeInvalidValidator, msg)
+}
+
+func ErrCommissionEmpty(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidValidator, "commission rate, max rate and max change rate must be set")
+}
+
+func ErrCommissionNegative(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidValidator, "commission must be positive")
+}
+
+func ErrCommissionHuge(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidValidator, "commission cannot be more than 100%")
+}
+
+func ErrCommissionGTMaxRate(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidValidator, "commission cannot be more than the max rate")
+}
+
+func ErrCommissionUpdateTime(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidValidator, "commission cannot be changed more than once in 24h")
+}
+
+func ErrCommissionChangeRateNegative(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidValidator, "commission change rate must be positive")
+}
+
+func ErrCommissionChangeRateGTMaxRate(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidValidator, "commission change rate cannot be more than the max rate")
+}
+
+func ErrCommissionGTMaxChangeRate(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidValidator, "commission cannot be changed more than max change rate")
+}
+
+func ErrCommissionRatePrecision(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidValidator, "commission rate must be in whole percents")
+}
+
 func ErrValidatorPubKeyTypeNotSupported(codespace sdk.CodespaceType, keyType string, supportedTypes []string) sdk.Error {
 	msg := fmt.Sprintf("validator pubkey type %s is not supported, must use %s", keyType, strings.Join(supportedTypes, ","))
 	return sdk.NewError(codespace, CodeInvalidValidator, msg)
@@ -0,0 +1,51 @@
+package types
+
+// executionlayer module event types
+const (
+	EventTypeDeployContract       = "deploy_contract"
+	EventTypeSetContractSchema    = "set_contract_schema"
+	EventTypeCompleteUnbonding    = "complete_unbonding"
+	EventTypeCompleteRedelegation = "complete_redelegation"
+	EventTypeExecuteDeploy        = "execute_deploy"
+	EventTypeRotateConsPubKey     = "rotate_cons_pubkey"
+	EventTypeGrant                = "grant"
+	EventTypeRevoke               = "revoke"
+	EventTypeExec                 = "exec"
+	EventTypeGrantFeeAllowance    = "grant_fee_allowance"
+	EventTypeRevokeFeeAllowance   = "revoke_fee_allowance"
+	EventTypeUseFeeAllowance      = "use_fee_allowance"
+	EventTypeScheduleTransfer     = "schedule_transfer"
+	EventTypeCancelSchedule       = "cancel_schedule"
+	EventTypeExecuteSchedule      = "execute_schedule"
+	EventTypeScheduleFailed       = "schedule_failed"
+	EventTypeRefundSchedule       = "refund_schedule"
+
+	AttributeKeyDeployer     = "deployer"
+	AttributeKeyContractName = "contract_name"
+	AttributeKeyCodeHash     = "code_hash"
+	AttributeKeyContractKey  = "contract_key"
+
+	AttributeKeyDelegator      = "delegator"
+	AttributeKeyValidator      = "validator"
+	AttributeKeySrcValidator   = "source_validator"
+	AttributeKeyDstValidator   = "destination_validator"
+	AttributeKeyAmount         = "amount"
+	AttributeKeyCompletionTime = "completion_time"
+
+	AttributeKeyDeployHash = "deploy_hash"
+
+	AttributeKeyOldConsAddress = "old_cons_address"
+	AttributeKeyNewConsAddress = "new_cons_address"
+
+	AttributeKeyGranter  = "granter"
+	AttributeKeyGrantee  = "grantee"
+	AttributeKeyFeePayer = "fee_payer"
+	AttributeKeyFee      = "fee"
+
+	AttributeKeyScheduleID = "schedule_id"
+	AttributeKeySender     = "sender"
+	AttributeKeyRecipient  = "recipient"
+	AttributeKeyError      = "error"
+
+	AttributeValueCategory = ModuleName
+)
@@ -0,0 +1,193 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	sdk "github.com/hdac-io/friday/types"
+)
+
+// FeeAllowance - allowance of the payer to pay the fees of the deploys of the grantee until the
+// expiration, up to the spend limit
+type FeeAllowance struct {
+	Payer      sdk.AccAddress `json:"payer" yaml:"payer"`
+	Grantee    sdk.AccAddress `json:"grantee" yaml:"grantee"`
+	SpendLimit string         `json:"spend_limit" yaml:"spend_limit"` // remaining amount in bigsun, empty for no limit
+	Expiration time.Time      `json:"expiration" yaml:"expiration"`
+}
+
+// NewFeeAllowance creates a new FeeAllowance instance
+func NewFeeAllowance(payer, grantee sdk.AccAddress, spendLimit string, expiration time.Time) FeeAllowance {
+	return FeeAllowance{
+		Payer:      payer,
+		Grantee:    grantee,
+		SpendLimit: spendLimit,
+		Expiration: expiration,
+	}
+}
+
+// ValidateBasic runs stateless checks on the fee allowance
+func (a FeeAllowance) ValidateBasic() sdk.Error {
+	if a.Payer.Empty() || a.Grantee.Empty() {
+		return sdk.ErrInvalidAddress("payer and grantee cannot be empty")
+	}
+	if a.Payer.Equals(a.Grantee) {
+		return ErrInvalidFeeAllowance(DefaultCodespace, "payer and grantee must be different")
+	}
+	if a.SpendLimit != "" {
+		limit, err := parseSpendAmount(a.SpendLimit)
+		if err != nil {
+			return err
+		}
+		if !limit.IsPositive() {
+			return ErrInvalidFeeAllowance(DefaultCodespace, "spend limit must be positive")
+		}
+	}
+	if a.Expiration.IsZero() {
+		return ErrInvalidFeeAllowance(DefaultCodespace, "expiration must be set")
+	}
+	return nil
+}
+
+// IsExpired returns whether the fee allowance is expired at the time
+func (a FeeAllowance) IsExpired(blockTime time.Time) bool {
+	return !blockTime.Before(a.Expiration)
+}
+
+// Spend returns the fee allowance with the fee deducted from the spend limit.
+// An allowance without a limit is returned as is.
+func (a FeeAllowance) Spend(fee sdk.Int) (FeeAllowance, sdk.Error) {
+	if a.SpendLimit == "" {
+		return a, nil
+	}
+	limit, err := parseSpendAmount(a.SpendLimit)
+	if err != nil {
+		return a, err
+	}
+	if fee.GT(limit) {
+		return a, ErrSpendLimitExceeded(DefaultCodespace, fee.String(), a.SpendLimit)
+	}
+	a.SpendLimit = limit.Sub(fee).String()
+	return a, nil
+}
+
+// GetSponsoredCharge returns the amount a fee payer is charged for a deploy with the fee: the fee
+// transferred to the sender, and the fee of the transfer itself, which runs from the payer's account
+func GetSponsoredCharge(fee string) (sdk.Int, sdk.Error) {
+	amount, err := parseSpendAmount(fee)
+	if err != nil {
+		return amount, err
+	}
+	return amount.MulRaw(2), nil
+}
+
+// String returns a human readable string representation of a fee allowance
+func (a FeeAllowance) String() string {
+	spendLimit := a.SpendLimit
+	if spendLimit == "" {
+		spendLimit = "none"
+	}
+	return fmt.Sprintf(`Fee Allowance:
+  Payer:        %s
+  Grantee:      %s
+  Spend Limit:  %s
+  Expiration:   %v`, a.Payer, a.Grantee, spendLimit, a.Expiration)
+}
+
+// FeeAllowances is a collection of FeeAllowance
+type FeeAllowances []FeeAllowance
+
+func (a FeeAllowances) String() (out string) {
+	for _, allowance := range a {
+		out += allowance.String() + "\n"
+	}
+	return strings.TrimSpace(out)
+}
+
+//______________________________________________________________________
+
+// MsgGrantFeeAllowance - allows the grantee to have the payer pay the fees of its deploys.
+// An allowance replaces the previous allowance of the payer to the grantee.
+type MsgGrantFeeAllowance struct {
+	Payer      sdk.AccAddress `json:"payer" yaml:"payer"`
+	Grantee    sdk.AccAddress `json:"grantee" yaml:"grantee"`
+	SpendLimit string         `json:"spend_limit" yaml:"spend_limit"`
+	Expiration time.Time      `json:"expiration" yaml:"expiration"`
+}
+
+// NewMsgGrantFeeAllowance is a constructor function for MsgGrantFeeAllowance
+func NewMsgGrantFeeAllowance(payer, grantee sdk.AccAddress, spendLimit string, expiration time.Time) MsgGrantFeeAllowance {
+	return MsgGrantFeeAllowance{
+		Payer:      payer,
+		Grantee:    grantee,
+		SpendLimit: spendLimit,
+		Expiration: expiration,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgGrantFeeAllowance) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgGrantFeeAllowance) Type() string { return "grant_fee_allowance" }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgGrantFeeAllowance) ValidateBasic() sdk.Error {
+	return msg.FeeAllowance().ValidateBasic()
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgGrantFeeAllowance) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgGrantFeeAllowance) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Payer}
+}
+
+// FeeAllowance returns the fee allowance the msg creates
+func (msg MsgGrantFeeAllowance) FeeAllowance() FeeAllowance {
+	return NewFeeAllowance(msg.Payer, msg.Grantee, msg.SpendLimit, msg.Expiration)
+}
+
+//______________________________________________________________________
+
+// MsgRevokeFeeAllowance - revokes the fee allowance of the payer to the grantee
+type MsgRevokeFeeAllowance struct {
+	Payer   sdk.AccAddress `json:"payer" yaml:"payer"`
+	Grantee sdk.AccAddress `json:"grantee" yaml:"grantee"`
+}
+
+// NewMsgRevokeFeeAllowance is a constructor function for MsgRevokeFeeAllowance
+func NewMsgRevokeFeeAllowance(payer, grantee sdk.AccAddress) MsgRevokeFeeAllowance {
+	return MsgRevokeFeeAllowance{
+		Payer:   payer,
+		Grantee: grantee,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgRevokeFeeAllowance) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgRevokeFeeAllowance) Type() string { return "revoke_fee_allowance" }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgRevokeFeeAllowance) ValidateBasic() sdk.Error {
+	if msg.Payer.Empty() || msg.Grantee.Empty() {
+		return sdk.ErrInvalidAddress("payer and grantee cannot be empty")
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgRevokeFeeAllowance) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgRevokeFeeAllowance) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Payer}
+}
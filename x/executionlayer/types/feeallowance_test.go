@@ -0,0 +1,69 @@
+package types
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/hdac-io/friday/types"
+)
+
+func TestFeeAllowanceValidateBasic(t *testing.T) {
+	payer := sdk.AccAddress([]byte(strings.Repeat("p", 32)))
+	grantee := sdk.AccAddress([]byte(strings.Repeat("e", 32)))
+	expiration := time.Unix(1000, 0).UTC()
+
+	require.Nil(t, NewFeeAllowance(payer, grantee, "", expiration).ValidateBasic())
+	require.Nil(t, NewFeeAllowance(payer, grantee, "10", expiration).ValidateBasic())
+
+	require.NotNil(t, NewFeeAllowance(payer, payer, "", expiration).ValidateBasic())
+	require.NotNil(t, NewFeeAllowance(nil, grantee, "", expiration).ValidateBasic())
+	require.NotNil(t, NewFeeAllowance(payer, grantee, "0", expiration).ValidateBasic())
+	require.NotNil(t, NewFeeAllowance(payer, grantee, "-1", expiration).ValidateBasic())
+	require.NotNil(t, NewFeeAllowance(payer, grantee, "", time.Time{}).ValidateBasic())
+}
+
+func TestFeeAllowanceSpend(t *testing.T) {
+	payer := sdk.AccAddress([]byte(strings.Repeat("p", 32)))
+	grantee := sdk.AccAddress([]byte(strings.Repeat("e", 32)))
+	allowance := NewFeeAllowance(payer, grantee, "100", time.Unix(1000, 0).UTC())
+
+	allowance, err := allowance.Spend(sdk.NewInt(70))
+	require.Nil(t, err)
+	require.Equal(t, "30", allowance.SpendLimit)
+	_, err = allowance.Spend(sdk.NewInt(31))
+	require.NotNil(t, err)
+
+	unlimited, err := NewFeeAllowance(payer, grantee, "", time.Unix(1000, 0).UTC()).Spend(sdk.NewInt(1000))
+	require.Nil(t, err)
+	require.Equal(t, "", unlimited.SpendLimit)
+
+	require.False(t, allowance.IsExpired(time.Unix(999, 0)))
+	require.True(t, allowance.IsExpired(time.Unix(1000, 0)))
+
+	// a sponsored deploy charges its fee and the fee of the transfer funding it
+	charge, err := GetSponsoredCharge("15")
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewInt(30), charge)
+	_, err = GetSponsoredCharge("-1")
+	require.NotNil(t, err)
+}
+
+func TestDeployHeaderFeePayer(t *testing.T) {
+	payer := sdk.AccAddress([]byte(strings.Repeat("p", 32)))
+	sender := sdk.AccAddress([]byte(strings.Repeat("s", 32)))
+
+	header := NewDeployHeader(1000, 0, nil)
+	header.FeePayer = payer
+	msg := NewMsgTransfer("system:transfer", sender, payer, "10", "20").WithDeployHeader(header)
+	require.Equal(t, "20", GetDeployFee(msg))
+
+	var decoded MsgTransfer
+	ModuleCdc.MustUnmarshalJSON(ModuleCdc.MustMarshalJSON(msg), &decoded)
+	require.Equal(t, payer, decoded.DeployHeader.FeePayer)
+
+	// the fee payer is left out of a header without one
+	require.NotContains(t, string(ModuleCdc.MustMarshalJSON(NewDeployHeader(1000, 0, nil))), "fee_payer")
+}
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"fmt"
 	"os"
 	"strconv"
 	"strings"
@@ -14,11 +15,16 @@ import (
 
 // GenesisState : the executionlayer state that must be provided at genesis.
 type GenesisState struct {
-	GenesisConf GenesisConf `json:"genesis_conf"`
-	Accounts    []Account   `json:"accounts"`
-	ChainName   string      `json:"chain_name"`
-	Validators  []Validator `json:"validators"`
-	StateInfos  []string    `json:"state_infos"`
+	GenesisConf   GenesisConf    `json:"genesis_conf"`
+	Accounts      []Account      `json:"accounts"`
+	ChainName     string         `json:"chain_name"`
+	Validators    []Validator    `json:"validators"`
+	StateInfos    []string       `json:"state_infos"`
+	Params        Params         `json:"params"`
+	Grants        []Grant        `json:"grants"`
+	FeeAllowances []FeeAllowance `json:"fee_allowances"`
+	Schedules     []Schedule     `json:"schedules"`
+	StakeRequests []StakeRequest `json:"stake_requests"`
 }
 
 // GenesisConf : the executionlayer configuration that must be provided at genesis.
@@ -87,14 +93,15 @@ func NewGenesisState(genesisConf GenesisConf, accounts []Account, chainName stri
 	return GenesisState{GenesisConf: genesisConf, Accounts: accounts, ChainName: chainName, Validators: validators, StateInfos: stateInfos}
 }
 
-// DefaultGenesisState returns a default genesis state
-func DefaultGenesisState() GenesisState {
-	genesisConf := GenesisConf{
+// NewGenesisConf creates a genesis configuration with the default cost
+// tables around the given system contract installers.
+func NewGenesisConf(mintWasm, posWasm, standardPaymentWasm []byte) GenesisConf {
+	return GenesisConf{
 		Genesis: Genesis{
 			Timestamp:           0,
-			MintWasm:            util.LoadWasmFile(os.ExpandEnv(mintCodePath)),
-			PosWasm:             util.LoadWasmFile(os.ExpandEnv(posCodePath)),
-			StandardPaymentWasm: util.LoadWasmFile(os.ExpandEnv(standardPaymentCodePath)),
+			MintWasm:            mintWasm,
+			PosWasm:             posWasm,
+			StandardPaymentWasm: standardPaymentWasm,
 			ProtocolVersion:     "1.0.0",
 		},
 		WasmCosts: WasmCosts{
@@ -125,11 +132,67 @@ func DefaultGenesisState() GenesisState {
 			Ftt:                        0,
 		},
 	}
-	return NewGenesisState(genesisConf, nil, "friday-devnet", nil, nil)
+}
+
+// DefaultGenesisState returns a default genesis state
+func DefaultGenesisState() GenesisState {
+	genesisConf := NewGenesisConf(
+		util.LoadWasmFile(os.ExpandEnv(mintCodePath)),
+		util.LoadWasmFile(os.ExpandEnv(posCodePath)),
+		util.LoadWasmFile(os.ExpandEnv(standardPaymentCodePath)),
+	)
+	genesisState := NewGenesisState(genesisConf, nil, "friday-devnet", nil, nil)
+	genesisState.Params = DefaultParams()
+	return genesisState
 }
 
 // ValidateGenesis :
 func ValidateGenesis(data GenesisState) error {
+	if err := data.Params.Validate(); err != nil {
+		return err
+	}
+	seenGrants := map[string]bool{}
+	for _, grant := range data.Grants {
+		if err := grant.ValidateBasic(); err != nil {
+			return err
+		}
+		key := string(GetGrantKey(grant.Granter, grant.Grantee))
+		if seenGrants[key] {
+			return fmt.Errorf("duplicate grant of %s to %s", grant.Granter, grant.Grantee)
+		}
+		seenGrants[key] = true
+	}
+	seenFeeAllowances := map[string]bool{}
+	for _, allowance := range data.FeeAllowances {
+		if err := allowance.ValidateBasic(); err != nil {
+			return err
+		}
+		key := string(GetFeeAllowanceKey(allowance.Payer, allowance.Grantee))
+		if seenFeeAllowances[key] {
+			return fmt.Errorf("duplicate fee allowance of %s to %s", allowance.Payer, allowance.Grantee)
+		}
+		seenFeeAllowances[key] = true
+	}
+	seenSchedules := map[uint64]bool{}
+	for _, schedule := range data.Schedules {
+		if err := schedule.ValidateBasic(); err != nil {
+			return err
+		}
+		if seenSchedules[schedule.ID] {
+			return fmt.Errorf("duplicate schedule %d", schedule.ID)
+		}
+		seenSchedules[schedule.ID] = true
+	}
+	seenStakeRequests := map[uint64]bool{}
+	for _, request := range data.StakeRequests {
+		if err := request.ValidateBasic(); err != nil {
+			return err
+		}
+		if seenStakeRequests[request.ID] {
+			return fmt.Errorf("duplicate stake request %d", request.ID)
+		}
+		seenStakeRequests[request.ID] = true
+	}
 	_, err := ToChainSpecGenesisConfig(data)
 	return err
 }
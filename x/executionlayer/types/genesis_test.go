@@ -2,10 +2,14 @@ package types
 
 import (
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/casper/consensus/state"
 	"github.com/stretchr/testify/require"
+
+	sdk "github.com/hdac-io/friday/types"
 )
 
 func TestToProtocolVersion(t *testing.T) {
@@ -49,3 +53,35 @@ func TestToChainSpecGenesisConfig(t *testing.T) {
 	_, err = ToChainSpecGenesisConfig(genesisState)
 	require.NotNil(t, err)
 }
+
+func TestValidateGenesisGrants(t *testing.T) {
+	granter := sdk.AccAddress([]byte(strings.Repeat("g", 32)))
+	grantee := sdk.AccAddress([]byte(strings.Repeat("e", 32)))
+	grant := NewGrant(granter, grantee, []string{GrantMsgTypeClaim}, "", time.Unix(1000, 0).UTC())
+
+	genesisState := DefaultGenesisState()
+	genesisState.Grants = []Grant{grant}
+	require.Nil(t, ValidateGenesis(genesisState))
+
+	genesisState.Grants = []Grant{grant, grant}
+	require.NotNil(t, ValidateGenesis(genesisState))
+
+	genesisState.Grants = []Grant{NewGrant(granter, granter, []string{GrantMsgTypeClaim}, "", time.Unix(1000, 0).UTC())}
+	require.NotNil(t, ValidateGenesis(genesisState))
+}
+
+func TestValidateGenesisSchedules(t *testing.T) {
+	sender := sdk.AccAddress([]byte(strings.Repeat("s", 32)))
+	recipient := sdk.AccAddress([]byte(strings.Repeat("r", 32)))
+	schedule := NewSchedule(1, sender, recipient, "100", "10", 5, 10, 3)
+
+	genesisState := DefaultGenesisState()
+	genesisState.Schedules = []Schedule{schedule}
+	require.Nil(t, ValidateGenesis(genesisState))
+
+	genesisState.Schedules = []Schedule{schedule, schedule}
+	require.NotNil(t, ValidateGenesis(genesisState))
+
+	genesisState.Schedules = []Schedule{NewSchedule(1, sender, recipient, "100", "10", 5, 0, 3)}
+	require.NotNil(t, ValidateGenesis(genesisState))
+}
@@ -0,0 +1,217 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	sdk "github.com/hdac-io/friday/types"
+)
+
+// secondsPerYear - length of a year to annualize the yields, 365.25 days
+const secondsPerYear = 60 * 60 * 24 * 36525 / 100
+
+// DelegationStake - stake of a delegator delegated to a validator
+type DelegationStake struct {
+	Validator sdk.AccAddress `json:"validator" yaml:"validator"`
+	Amount    string         `json:"amount" yaml:"amount"`
+}
+
+// RewardHistoryEntry - reward of a delegator accrued by the step of a block and claimed in it.
+// The reward of the PoS contract is kept per delegator, not per validator.
+type RewardHistoryEntry struct {
+	Height      int64             `json:"height" yaml:"height"`
+	Time        time.Time         `json:"time" yaml:"time"`
+	Earned      string            `json:"earned" yaml:"earned"`           // accrued by the step of the block
+	Claimed     string            `json:"claimed" yaml:"claimed"`         // claimed by the txs of the block
+	Balance     string            `json:"balance" yaml:"balance"`         // claimable after the step
+	Delegations []DelegationStake `json:"delegations" yaml:"delegations"` // delegations after the step
+}
+
+// CommissionHistoryEntry - commission of a validator accrued by the step of a block and claimed in it
+type CommissionHistoryEntry struct {
+	Height  int64     `json:"height" yaml:"height"`
+	Time    time.Time `json:"time" yaml:"time"`
+	Earned  string    `json:"earned" yaml:"earned"`   // accrued by the step of the block
+	Claimed string    `json:"claimed" yaml:"claimed"` // claimed by the txs of the block
+	Balance string    `json:"balance" yaml:"balance"` // claimable after the step
+	Stake   string    `json:"stake" yaml:"stake"`     // total stake of the validator after the step
+}
+
+// HistoryPoint - earned and claimed amounts of a block in a reward or commission history
+type HistoryPoint struct {
+	Height  int64     `json:"height" yaml:"height"`
+	Time    time.Time `json:"time" yaml:"time"`
+	Earned  string    `json:"earned" yaml:"earned"`
+	Claimed string    `json:"claimed" yaml:"claimed"`
+	Balance string    `json:"balance" yaml:"balance"`
+	Stake   string    `json:"stake" yaml:"stake"`
+}
+
+// RewardHistory - timeline of the reward of a delegator over a height range. With a validator,
+// the reward of the delegator is split over its validators by the delegated stakes, and the
+// share of the validator is shown.
+type RewardHistory struct {
+	Delegator    sdk.AccAddress `json:"delegator" yaml:"delegator"`
+	Validator    sdk.AccAddress `json:"validator,omitempty" yaml:"validator,omitempty"`
+	FromHeight   int64          `json:"from_height" yaml:"from_height"`
+	ToHeight     int64          `json:"to_height" yaml:"to_height"`
+	Points       []HistoryPoint `json:"points" yaml:"points"`
+	TotalEarned  string         `json:"total_earned" yaml:"total_earned"`
+	TotalClaimed string         `json:"total_claimed" yaml:"total_claimed"`
+	Yield        sdk.Dec        `json:"yield" yaml:"yield"` // annualized earned over stake
+}
+
+// NewRewardHistory builds the reward history of the delegator from its entries, split to the
+// validator unless it's empty
+func NewRewardHistory(delegator, validator sdk.AccAddress, fromHeight, toHeight int64, entries []RewardHistoryEntry) RewardHistory {
+	points := make([]HistoryPoint, 0, len(entries))
+	for _, entry := range entries {
+		total := sdk.ZeroInt()
+		stake := sdk.ZeroInt()
+		for _, delegation := range entry.Delegations {
+			amount := parseAmount(delegation.Amount)
+			total = total.Add(amount)
+			if validator.Empty() || delegation.Validator.Equals(validator) {
+				stake = stake.Add(amount)
+			}
+		}
+
+		earned, claimed, balance := parseAmount(entry.Earned), parseAmount(entry.Claimed), parseAmount(entry.Balance)
+		if !validator.Empty() {
+			if stake.IsZero() {
+				continue
+			}
+			earned = earned.Mul(stake).Quo(total)
+			claimed = claimed.Mul(stake).Quo(total)
+			balance = balance.Mul(stake).Quo(total)
+		}
+
+		points = append(points, HistoryPoint{
+			Height:  entry.Height,
+			Time:    entry.Time,
+			Earned:  earned.String(),
+			Claimed: claimed.String(),
+			Balance: balance.String(),
+			Stake:   stake.String(),
+		})
+	}
+
+	totalEarned, totalClaimed, yield := summarizeHistory(points)
+	return RewardHistory{
+		Delegator:    delegator,
+		Validator:    validator,
+		FromHeight:   fromHeight,
+		ToHeight:     toHeight,
+		Points:       points,
+		TotalEarned:  totalEarned.String(),
+		TotalClaimed: totalClaimed.String(),
+		Yield:        yield,
+	}
+}
+
+// String returns a human readable string representation of a reward history
+func (h RewardHistory) String() string {
+	header := fmt.Sprintf("Reward History of %s", h.Delegator)
+	if !h.Validator.Empty() {
+		header += fmt.Sprintf(" delegated to %s", h.Validator)
+	}
+	return historyString(header, h.FromHeight, h.ToHeight, h.Points, h.TotalEarned, h.TotalClaimed, h.Yield)
+}
+
+// CommissionHistory - timeline of the commission of a validator over a height range
+type CommissionHistory struct {
+	Validator    sdk.AccAddress `json:"validator" yaml:"validator"`
+	FromHeight   int64          `json:"from_height" yaml:"from_height"`
+	ToHeight     int64          `json:"to_height" yaml:"to_height"`
+	Points       []HistoryPoint `json:"points" yaml:"points"`
+	TotalEarned  string         `json:"total_earned" yaml:"total_earned"`
+	TotalClaimed string         `json:"total_claimed" yaml:"total_claimed"`
+	Yield        sdk.Dec        `json:"yield" yaml:"yield"` // annualized commission over the stake of the validator
+}
+
+// NewCommissionHistory builds the commission history of the validator from its entries
+func NewCommissionHistory(validator sdk.AccAddress, fromHeight, toHeight int64, entries []CommissionHistoryEntry) CommissionHistory {
+	points := make([]HistoryPoint, len(entries))
+	for i, entry := range entries {
+		points[i] = HistoryPoint{
+			Height:  entry.Height,
+			Time:    entry.Time,
+			Earned:  parseAmount(entry.Earned).String(),
+			Claimed: parseAmount(entry.Claimed).String(),
+			Balance: parseAmount(entry.Balance).String(),
+			Stake:   parseAmount(entry.Stake).String(),
+		}
+	}
+
+	totalEarned, totalClaimed, yield := summarizeHistory(points)
+	return CommissionHistory{
+		Validator:    validator,
+		FromHeight:   fromHeight,
+		ToHeight:     toHeight,
+		Points:       points,
+		TotalEarned:  totalEarned.String(),
+		TotalClaimed: totalClaimed.String(),
+		Yield:        yield,
+	}
+}
+
+// String returns a human readable string representation of a commission history
+func (h CommissionHistory) String() string {
+	header := fmt.Sprintf("Commission History of %s", h.Validator)
+	return historyString(header, h.FromHeight, h.ToHeight, h.Points, h.TotalEarned, h.TotalClaimed, h.Yield)
+}
+
+// summarizeHistory sums the earned and claimed amounts of the points, and annualizes the
+// yield of the earned amounts over the stakes between the first and the last point
+func summarizeHistory(points []HistoryPoint) (earned, claimed sdk.Int, yield sdk.Dec) {
+	earned, claimed, yield = sdk.ZeroInt(), sdk.ZeroInt(), sdk.ZeroDec()
+	for _, point := range points {
+		earned = earned.Add(parseAmount(point.Earned))
+		claimed = claimed.Add(parseAmount(point.Claimed))
+	}
+	if len(points) < 2 {
+		return
+	}
+
+	seconds := int64(points[len(points)-1].Time.Sub(points[0].Time).Seconds())
+	if seconds <= 0 {
+		return
+	}
+
+	// the earning of the first point is of the period before the range
+	periodYield := sdk.ZeroDec()
+	for _, point := range points[1:] {
+		stake := parseAmount(point.Stake)
+		if stake.IsZero() {
+			continue
+		}
+		periodYield = periodYield.Add(parseAmount(point.Earned).ToDec().QuoInt(stake))
+	}
+	yield = periodYield.MulInt64(secondsPerYear).QuoInt64(seconds)
+	return
+}
+
+func historyString(header string, fromHeight, toHeight int64, points []HistoryPoint, totalEarned, totalClaimed string, yield sdk.Dec) string {
+	lines := []string{
+		fmt.Sprintf("%s from %d to %d", header, fromHeight, toHeight),
+		fmt.Sprintf("  Total Earned:  %s", totalEarned),
+		fmt.Sprintf("  Total Claimed: %s", totalClaimed),
+		fmt.Sprintf("  Yield:         %s", yield),
+		"  Height    Earned    Claimed    Balance    Stake",
+	}
+	for _, point := range points {
+		lines = append(lines, fmt.Sprintf("  %d    %s    %s    %s    %s",
+			point.Height, point.Earned, point.Claimed, point.Balance, point.Stake))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// parseAmount parses an amount of the PoS contract, an empty or invalid one being zero
+func parseAmount(amount string) sdk.Int {
+	value, ok := sdk.NewIntFromString(amount)
+	if !ok || value.IsNegative() {
+		return sdk.ZeroInt()
+	}
+	return value
+}
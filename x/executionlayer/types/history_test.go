@@ -0,0 +1,68 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/hdac-io/friday/types"
+)
+
+func TestNewRewardHistory(t *testing.T) {
+	delegator := sdk.AccAddress([]byte("delegator_______________________"))
+	validator := sdk.AccAddress([]byte("validator_______________________"))
+	otherValidator := sdk.AccAddress([]byte("other_validator_________________"))
+
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	delegations := []DelegationStake{
+		{Validator: validator, Amount: "3000"},
+		{Validator: otherValidator, Amount: "1000"},
+	}
+	entries := []RewardHistoryEntry{
+		{Height: 1, Time: start, Earned: "40", Claimed: "0", Balance: "40", Delegations: delegations},
+		{Height: 2, Time: start.Add(secondsPerYear * time.Second / 2), Earned: "200", Claimed: "240", Balance: "0", Delegations: delegations},
+	}
+
+	history := NewRewardHistory(delegator, nil, 1, 2, entries)
+	require.Equal(t, 2, len(history.Points))
+	require.Equal(t, "240", history.TotalEarned)
+	require.Equal(t, "240", history.TotalClaimed)
+	require.Equal(t, "4000", history.Points[1].Stake)
+	// 200 of 4000 in half a year
+	require.Equal(t, sdk.NewDecWithPrec(1, 1), history.Yield)
+
+	history = NewRewardHistory(delegator, validator, 1, 2, entries)
+	require.Equal(t, "30", history.Points[0].Earned)
+	require.Equal(t, "150", history.Points[1].Earned)
+	require.Equal(t, "180", history.Points[1].Claimed)
+	require.Equal(t, "3000", history.Points[1].Stake)
+	require.Equal(t, "180", history.TotalEarned)
+	require.Equal(t, sdk.NewDecWithPrec(1, 1), history.Yield)
+
+	// no delegation to the validator
+	history = NewRewardHistory(delegator, delegator, 1, 2, entries)
+	require.Equal(t, 0, len(history.Points))
+	require.Equal(t, "0", history.TotalEarned)
+	require.True(t, history.Yield.IsZero())
+}
+
+func TestNewCommissionHistory(t *testing.T) {
+	validator := sdk.AccAddress([]byte("validator_______________________"))
+
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	entries := []CommissionHistoryEntry{
+		{Height: 10, Time: start, Earned: "5", Claimed: "", Balance: "5", Stake: "1000"},
+		{Height: 11, Time: start.Add(secondsPerYear * time.Second), Earned: "50", Claimed: "55", Balance: "0", Stake: "1000"},
+	}
+
+	history := NewCommissionHistory(validator, 10, 11, entries)
+	require.Equal(t, "0", history.Points[0].Claimed)
+	require.Equal(t, "55", history.TotalEarned)
+	require.Equal(t, "55", history.TotalClaimed)
+	require.Equal(t, sdk.NewDecWithPrec(5, 2), history.Yield)
+
+	// a single point has no period to yield over
+	history = NewCommissionHistory(validator, 10, 10, entries[:1])
+	require.True(t, history.Yield.IsZero())
+}
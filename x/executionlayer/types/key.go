@@ -3,7 +3,9 @@ package types
 import (
 	"bytes"
 	"encoding/binary"
+	"time"
 
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/util"
 	sdk "github.com/hdac-io/friday/types"
 )
 
@@ -21,12 +23,51 @@ const (
 	CandidateBlockKey    = "candidateblock"
 	ProxyContractHashKey = "proxycontractkey"
 	ProtoclVersionKey    = "protocolversion"
+	DeployConfigKey      = "deployconfig"
 )
 
 var (
-	EEStateKey              = []byte{0x11}
-	ValidatorKey            = []byte{0x21}
-	ValidatorsByConsAddrKey = []byte{0x22}
+	EEStateKey                  = []byte{0x11}
+	ValidatorKey                = []byte{0x21}
+	ValidatorsByConsAddrKey     = []byte{0x22}
+	ConsKeyRotationKey          = []byte{0x23}
+	PendingConsKeyRotationKey   = []byte{0x24}
+	CommissionRateHistoryKey    = []byte{0x25}
+	StakeRequestKey             = []byte{0x26}
+	StakeRequestsByDelegatorKey = []byte{0x27}
+	StakeRequestQueueKey        = []byte{0x28}
+	NextStakeRequestIDKey       = []byte{0x29}
+
+	ContractInfoKey        = []byte{0x31}
+	ContractsByCodeHashKey = []byte{0x32}
+	ContractsByNameKey     = []byte{0x33}
+	ContractsByKeyKey      = []byte{0x34}
+
+	RewardHistoryKey         = []byte{0x41}
+	CommissionHistoryKey     = []byte{0x42}
+	RewardHistoryByHeightKey = []byte{0x43}
+	RewardBalanceKey         = []byte{0x44}
+	CommissionBalanceKey     = []byte{0x45}
+	PosBalancesHeightKey     = []byte{0x46}
+	RewardClaimKey           = []byte{0x47}
+	CommissionClaimKey       = []byte{0x48}
+
+	DeployKey         = []byte{0x51}
+	DeployByHeightKey = []byte{0x52}
+
+	GrantKey             = []byte{0x61}
+	GrantsByGranteeKey   = []byte{0x62}
+	GrantByExpirationKey = []byte{0x63}
+
+	FeeAllowanceKey             = []byte{0x71}
+	FeeAllowancesByGranteeKey   = []byte{0x72}
+	FeeAllowanceByExpirationKey = []byte{0x73}
+
+	ScheduleKey             = []byte{0x81}
+	SchedulesBySenderKey    = []byte{0x82}
+	SchedulesByRecipientKey = []byte{0x83}
+	ScheduleQueueKey        = []byte{0x84}
+	NextScheduleIDKey       = []byte{0x85}
 )
 
 type (
@@ -42,6 +83,12 @@ func GetEEStateKey(height int64) []byte {
 	return append(EEStateKey, heightBuffer.Bytes()...)
 }
 
+// GetHeightFromEEStateKey returns the height of an EE state key. The height is little-endian, so
+// the keys are not in the order of the heights.
+func GetHeightFromEEStateKey(key []byte) int64 {
+	return int64(binary.LittleEndian.Uint64(key[len(EEStateKey):]))
+}
+
 func GetValidatorKey(operatorAddr sdk.AccAddress) []byte {
 	return append(ValidatorKey, operatorAddr.Bytes()...)
 }
@@ -49,3 +96,234 @@ func GetValidatorKey(operatorAddr sdk.AccAddress) []byte {
 func GetValidatorByConsAddrKey(addr sdk.ConsAddress) []byte {
 	return append(ValidatorsByConsAddrKey, addr.Bytes()...)
 }
+
+// GetConsKeyRotationKey - key of the height a validator last rotated its consensus pubkey (prefix | operator)
+func GetConsKeyRotationKey(operatorAddr sdk.AccAddress) []byte {
+	return append(ConsKeyRotationKey, operatorAddr.Bytes()...)
+}
+
+// GetPendingConsKeyRotationKey - key of a rotation whose validator updates are not sent yet (prefix | operator)
+func GetPendingConsKeyRotationKey(operatorAddr sdk.AccAddress) []byte {
+	return append(PendingConsKeyRotationKey, operatorAddr.Bytes()...)
+}
+
+// GetCommissionRateChangeKey - key of a commission rate change (prefix | operator | height)
+func GetCommissionRateChangeKey(operatorAddr sdk.AccAddress, height int64) []byte {
+	return append(GetCommissionRateHistoryPrefix(operatorAddr), sdk.Uint64ToBigEndian(uint64(height))...)
+}
+
+// GetCommissionRateHistoryPrefix - prefix of the commission rate changes of a validator
+func GetCommissionRateHistoryPrefix(operatorAddr sdk.AccAddress) []byte {
+	return append(CommissionRateHistoryKey, operatorAddr.Bytes()...)
+}
+
+// GetStakeRequestKey - key of a stake request (prefix | id)
+func GetStakeRequestKey(id uint64) []byte {
+	return append(StakeRequestKey, sdk.Uint64ToBigEndian(id)...)
+}
+
+// GetStakeRequestByDelegatorKey - key of the delegator index of the stake requests (prefix | delegator | id)
+func GetStakeRequestByDelegatorKey(delegator sdk.AccAddress, id uint64) []byte {
+	return append(GetStakeRequestsByDelegatorPrefix(delegator), sdk.Uint64ToBigEndian(id)...)
+}
+
+// GetStakeRequestsByDelegatorPrefix - prefix of the delegator index of the delegator
+func GetStakeRequestsByDelegatorPrefix(delegator sdk.AccAddress) []byte {
+	return append(StakeRequestsByDelegatorKey, delegator.Bytes()...)
+}
+
+// GetStakeRequestQueueKey - key of the queue of the pending stake requests (prefix | height | id),
+// ordered by their completion heights
+func GetStakeRequestQueueKey(height int64, id uint64) []byte {
+	return append(GetStakeRequestQueueHeightPrefix(height), sdk.Uint64ToBigEndian(id)...)
+}
+
+// GetStakeRequestQueueHeightPrefix - prefix of the stake request queue of the height
+func GetStakeRequestQueueHeightPrefix(height int64) []byte {
+	return append(StakeRequestQueueKey, sdk.Uint64ToBigEndian(uint64(height))...)
+}
+
+// GetContractInfoKey - key of a contract info (prefix | deployer | name)
+func GetContractInfoKey(deployer sdk.AccAddress, name string) []byte {
+	return append(GetContractsByDeployerKey(deployer), []byte(name)...)
+}
+
+// GetContractsByDeployerKey - prefix of all contract infos of a deployer
+func GetContractsByDeployerKey(deployer sdk.AccAddress) []byte {
+	return append(ContractInfoKey, deployer.Bytes()...)
+}
+
+// GetContractsByCodeHashKey - prefix of the code hash index
+func GetContractsByCodeHashKey(codeHash []byte) []byte {
+	return append(ContractsByCodeHashKey, codeHash...)
+}
+
+// GetContractByCodeHashIndexKey - key of the code hash index (prefix | code hash | deployer | name)
+func GetContractByCodeHashIndexKey(codeHash []byte, deployer sdk.AccAddress, name string) []byte {
+	key := append(GetContractsByCodeHashKey(codeHash), deployer.Bytes()...)
+	return append(key, []byte(name)...)
+}
+
+// GetContractsByNameKey - prefix of the name index.
+// Name is hashed to keep fixed length, so a name is never a prefix of another one.
+func GetContractsByNameKey(name string) []byte {
+	return append(ContractsByNameKey, util.Blake2b256([]byte(name))...)
+}
+
+// GetContractByNameIndexKey - key of the name index (prefix | hash(name) | deployer)
+func GetContractByNameIndexKey(name string, deployer sdk.AccAddress) []byte {
+	return append(GetContractsByNameKey(name), deployer.Bytes()...)
+}
+
+// GetContractByKeyIndexKey - key of the contract key index (prefix | hash(contract key)), the
+// contract key being a bech32 contract hash or uref address
+func GetContractByKeyIndexKey(contractKey string) []byte {
+	return append(ContractsByKeyKey, util.Blake2b256([]byte(contractKey))...)
+}
+
+// GetRewardHistoryKey - key of a reward history entry (prefix | delegator | height)
+func GetRewardHistoryKey(delegator sdk.AccAddress, height int64) []byte {
+	return append(GetRewardHistoryPrefix(delegator), sdk.Uint64ToBigEndian(uint64(height))...)
+}
+
+// GetRewardHistoryPrefix - prefix of the reward history of a delegator
+func GetRewardHistoryPrefix(delegator sdk.AccAddress) []byte {
+	return append(RewardHistoryKey, delegator.Bytes()...)
+}
+
+// GetCommissionHistoryKey - key of a commission history entry (prefix | validator | height)
+func GetCommissionHistoryKey(validator sdk.AccAddress, height int64) []byte {
+	return append(GetCommissionHistoryPrefix(validator), sdk.Uint64ToBigEndian(uint64(height))...)
+}
+
+// GetCommissionHistoryPrefix - prefix of the commission history of a validator
+func GetCommissionHistoryPrefix(validator sdk.AccAddress) []byte {
+	return append(CommissionHistoryKey, validator.Bytes()...)
+}
+
+// GetRewardHistoryByHeightKey - key of the height index of the reward and commission
+// histories (prefix | height | history key), to prune the entries of the old heights
+func GetRewardHistoryByHeightKey(height int64, historyKey []byte) []byte {
+	return append(GetRewardHistoryByHeightPrefix(height), historyKey...)
+}
+
+// GetRewardHistoryByHeightPrefix - prefix of the height index of the height
+func GetRewardHistoryByHeightPrefix(height int64) []byte {
+	return append(RewardHistoryByHeightKey, sdk.Uint64ToBigEndian(uint64(height))...)
+}
+
+// GetDeployKey - key of an executed deploy (prefix | deploy hash)
+func GetDeployKey(deployHash []byte) []byte {
+	return append(DeployKey, deployHash...)
+}
+
+// GetDeployByHeightKey - key of the height index of the executed deploys
+// (prefix | height | deploy hash), to prune the deploys of the old heights
+func GetDeployByHeightKey(height int64, deployHash []byte) []byte {
+	return append(GetDeployByHeightPrefix(height), deployHash...)
+}
+
+// GetDeployByHeightPrefix - prefix of the height index of the height
+func GetDeployByHeightPrefix(height int64) []byte {
+	return append(DeployByHeightKey, sdk.Uint64ToBigEndian(uint64(height))...)
+}
+
+// GetGrantKey - key of a grant (prefix | granter | grantee)
+func GetGrantKey(granter, grantee sdk.AccAddress) []byte {
+	return append(GetGrantsByGranterPrefix(granter), grantee.Bytes()...)
+}
+
+// GetGrantsByGranterPrefix - prefix of the grants of a granter
+func GetGrantsByGranterPrefix(granter sdk.AccAddress) []byte {
+	return append(GrantKey, granter.Bytes()...)
+}
+
+// GetGrantByGranteeKey - key of the grantee index of the grants (prefix | grantee | granter)
+func GetGrantByGranteeKey(grantee, granter sdk.AccAddress) []byte {
+	return append(GetGrantsByGranteePrefix(grantee), granter.Bytes()...)
+}
+
+// GetGrantsByGranteePrefix - prefix of the grantee index of the grantee
+func GetGrantsByGranteePrefix(grantee sdk.AccAddress) []byte {
+	return append(GrantsByGranteeKey, grantee.Bytes()...)
+}
+
+// GetGrantByExpirationKey - key of the expiration queue of the grants
+// (prefix | expiration | granter | grantee), to prune the expired grants
+func GetGrantByExpirationKey(expiration time.Time, granter, grantee sdk.AccAddress) []byte {
+	key := append(GetGrantsByExpirationPrefix(expiration), granter.Bytes()...)
+	return append(key, grantee.Bytes()...)
+}
+
+// GetGrantsByExpirationPrefix - prefix of the expiration queue of the expiration
+func GetGrantsByExpirationPrefix(expiration time.Time) []byte {
+	return append(GrantByExpirationKey, sdk.FormatTimeBytes(expiration)...)
+}
+
+// GetFeeAllowanceKey - key of a fee allowance (prefix | payer | grantee)
+func GetFeeAllowanceKey(payer, grantee sdk.AccAddress) []byte {
+	return append(GetFeeAllowancesByPayerPrefix(payer), grantee.Bytes()...)
+}
+
+// GetFeeAllowancesByPayerPrefix - prefix of the fee allowances of a payer
+func GetFeeAllowancesByPayerPrefix(payer sdk.AccAddress) []byte {
+	return append(FeeAllowanceKey, payer.Bytes()...)
+}
+
+// GetFeeAllowanceByGranteeKey - key of the grantee index of the fee allowances (prefix | grantee | payer)
+func GetFeeAllowanceByGranteeKey(grantee, payer sdk.AccAddress) []byte {
+	return append(GetFeeAllowancesByGranteePrefix(grantee), payer.Bytes()...)
+}
+
+// GetFeeAllowancesByGranteePrefix - prefix of the grantee index of the grantee
+func GetFeeAllowancesByGranteePrefix(grantee sdk.AccAddress) []byte {
+	return append(FeeAllowancesByGranteeKey, grantee.Bytes()...)
+}
+
+// GetFeeAllowanceByExpirationKey - key of the expiration queue of the fee allowances
+// (prefix | expiration | payer | grantee), to prune the expired allowances
+func GetFeeAllowanceByExpirationKey(expiration time.Time, payer, grantee sdk.AccAddress) []byte {
+	key := append(GetFeeAllowancesByExpirationPrefix(expiration), payer.Bytes()...)
+	return append(key, grantee.Bytes()...)
+}
+
+// GetFeeAllowancesByExpirationPrefix - prefix of the expiration queue of the expiration
+func GetFeeAllowancesByExpirationPrefix(expiration time.Time) []byte {
+	return append(FeeAllowanceByExpirationKey, sdk.FormatTimeBytes(expiration)...)
+}
+
+// GetScheduleKey - key of a schedule (prefix | id)
+func GetScheduleKey(id uint64) []byte {
+	return append(ScheduleKey, sdk.Uint64ToBigEndian(id)...)
+}
+
+// GetScheduleBySenderKey - key of the sender index of the schedules (prefix | sender | id)
+func GetScheduleBySenderKey(sender sdk.AccAddress, id uint64) []byte {
+	return append(GetSchedulesBySenderPrefix(sender), sdk.Uint64ToBigEndian(id)...)
+}
+
+// GetSchedulesBySenderPrefix - prefix of the sender index of the sender
+func GetSchedulesBySenderPrefix(sender sdk.AccAddress) []byte {
+	return append(SchedulesBySenderKey, sender.Bytes()...)
+}
+
+// GetScheduleByRecipientKey - key of the recipient index of the schedules (prefix | recipient | id)
+func GetScheduleByRecipientKey(recipient sdk.AccAddress, id uint64) []byte {
+	return append(GetSchedulesByRecipientPrefix(recipient), sdk.Uint64ToBigEndian(id)...)
+}
+
+// GetSchedulesByRecipientPrefix - prefix of the recipient index of the recipient
+func GetSchedulesByRecipientPrefix(recipient sdk.AccAddress) []byte {
+	return append(SchedulesByRecipientKey, recipient.Bytes()...)
+}
+
+// GetScheduleQueueKey - key of the queue of the pending schedules (prefix | height | id),
+// ordered by the height of their next payouts
+func GetScheduleQueueKey(height int64, id uint64) []byte {
+	return append(GetScheduleQueueHeightPrefix(height), sdk.Uint64ToBigEndian(id)...)
+}
+
+// GetScheduleQueueHeightPrefix - prefix of the schedule queue of the height
+func GetScheduleQueueHeightPrefix(height int64) []byte {
+	return append(ScheduleQueueKey, sdk.Uint64ToBigEndian(uint64(height))...)
+}
@@ -20,6 +20,7 @@ type MsgExecute struct {
 	SessionCode     []byte            `json:"session_code"`
 	SessionArgs     string            `json:"session_args"`
 	Fee             string            `json:"fee"`
+	DeployHeader    *DeployHeader     `json:"deploy_header,omitempty"`
 }
 
 // NewMsgExecute is a constructor function for MsgSetName
@@ -49,6 +50,9 @@ func (msg MsgExecute) Type() string { return "executionengine" }
 
 // ValidateBasic runs stateless checks on the message
 func (msg MsgExecute) ValidateBasic() sdk.Error {
+	if err := msg.DeployHeader.ValidateBasic(); err != nil {
+		return err
+	}
 	if msg.ExecAddress.Equals(sdk.AccAddress("")) {
 		return sdk.ErrUnknownRequest("Address cannot be empty")
 	}
@@ -65,6 +69,17 @@ func (msg MsgExecute) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.ExecAddress}
 }
 
+// GetDeployHeader returns the header of the deploy of the msg
+func (msg MsgExecute) GetDeployHeader() *DeployHeader {
+	return msg.DeployHeader
+}
+
+// WithDeployHeader returns the msg with the deploy header
+func (msg MsgExecute) WithDeployHeader(header *DeployHeader) DeployMsg {
+	msg.DeployHeader = header
+	return msg
+}
+
 // MsgTransfer for sending deploy to execution engine
 type MsgTransfer struct {
 	ContractAddress string         `json:"contract_address" yaml:"contract_address"`
@@ -72,6 +87,7 @@ type MsgTransfer struct {
 	ToAddress       sdk.AccAddress `json:"to_address" yaml:"to_address"`
 	Amount          string         `json:"amount" yaml:"amount"`
 	Fee             string         `json:"fee" yaml:"fee"`
+	DeployHeader    *DeployHeader  `json:"deploy_header,omitempty" yaml:"deploy_header,omitempty"`
 }
 
 // NewMsgTransfer is a constructor function for MsgSetName
@@ -97,6 +113,9 @@ func (msg MsgTransfer) Type() string { return "executionengine" }
 
 // ValidateBasic runs stateless checks on the message
 func (msg MsgTransfer) ValidateBasic() sdk.Error {
+	if err := msg.DeployHeader.ValidateBasic(); err != nil {
+		return err
+	}
 	if msg.FromAddress.Equals(sdk.AccAddress("")) {
 		return sdk.ErrUnknownRequest("Address cannot be empty")
 	}
@@ -116,22 +135,37 @@ func (msg MsgTransfer) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.FromAddress}
 }
 
+// GetDeployHeader returns the header of the deploy of the msg
+func (msg MsgTransfer) GetDeployHeader() *DeployHeader {
+	return msg.DeployHeader
+}
+
+// WithDeployHeader returns the msg with the deploy header
+func (msg MsgTransfer) WithDeployHeader(header *DeployHeader) DeployMsg {
+	msg.DeployHeader = header
+	return msg
+}
+
 //______________________________________________________________________
 // MsgCreateValidator - struct for bonding transactions
 type MsgCreateValidator struct {
-	ContractAddress  string         `json:"contract_address" yaml:"contract_address"`
-	ValidatorAddress sdk.AccAddress `json:"validator_address" yaml:"validator_address"`
-	ConsPubKey       crypto.PubKey  `json:"cons_pubkey" yaml:"cons_pubkey"`
-	Description      Description    `json:"description" yaml:"description"`
-	Fee              string         `json:"fee" yaml:"fee"`
+	ContractAddress  string          `json:"contract_address" yaml:"contract_address"`
+	ValidatorAddress sdk.AccAddress  `json:"validator_address" yaml:"validator_address"`
+	ConsPubKey       crypto.PubKey   `json:"cons_pubkey" yaml:"cons_pubkey"`
+	Description      Description     `json:"description" yaml:"description"`
+	Commission       CommissionRates `json:"commission" yaml:"commission"`
+	Fee              string          `json:"fee" yaml:"fee"`
+	DeployHeader     *DeployHeader   `json:"deploy_header,omitempty" yaml:"deploy_header,omitempty"`
 }
 
 type msgCreateValidatorJSON struct {
-	ContractAddress  string         `json:"contract_address" yaml:"contarct_address"`
-	ValidatorAddress sdk.AccAddress `json:"validator_address" yaml:"validator_address"`
-	ConsPubKey       string         `json:"cons_pubkey" yaml:"cons_pubkey"`
-	Description      Description    `json:"description" yaml:"description"`
-	Fee              string         `json:"fee" yaml:"fee"`
+	ContractAddress  string          `json:"contract_address" yaml:"contarct_address"`
+	ValidatorAddress sdk.AccAddress  `json:"validator_address" yaml:"validator_address"`
+	ConsPubKey       string          `json:"cons_pubkey" yaml:"cons_pubkey"`
+	Description      Description     `json:"description" yaml:"description"`
+	Commission       CommissionRates `json:"commission" yaml:"commission"`
+	Fee              string          `json:"fee" yaml:"fee"`
+	DeployHeader     *DeployHeader   `json:"deploy_header,omitempty" yaml:"deploy_header,omitempty"`
 }
 
 // Default way to create validator. Delegator address and validator address are the same
@@ -140,6 +174,7 @@ func NewMsgCreateValidator(
 	valAddress sdk.AccAddress,
 	consPubKey crypto.PubKey,
 	description Description,
+	commission CommissionRates,
 	fee string,
 ) MsgCreateValidator {
 	return MsgCreateValidator{
@@ -147,6 +182,7 @@ func NewMsgCreateValidator(
 		ValidatorAddress: valAddress,
 		ConsPubKey:       consPubKey,
 		Description:      description,
+		Commission:       commission,
 		Fee:              fee,
 	}
 }
@@ -163,6 +199,17 @@ func (msg MsgCreateValidator) GetSigners() []sdk.AccAddress {
 	return addrs
 }
 
+// GetDeployHeader returns the header of the deploy of the msg
+func (msg MsgCreateValidator) GetDeployHeader() *DeployHeader {
+	return msg.DeployHeader
+}
+
+// WithDeployHeader returns the msg with the deploy header
+func (msg MsgCreateValidator) WithDeployHeader(header *DeployHeader) DeployMsg {
+	msg.DeployHeader = header
+	return msg
+}
+
 // MarshalJSON implements the json.Marshaler interface to provide custom JSON
 // serialization of the MsgCreateValidator type.
 func (msg MsgCreateValidator) MarshalJSON() ([]byte, error) {
@@ -171,7 +218,9 @@ func (msg MsgCreateValidator) MarshalJSON() ([]byte, error) {
 		ValidatorAddress: msg.ValidatorAddress,
 		ConsPubKey:       sdk.MustBech32ifyConsPub(msg.ConsPubKey),
 		Description:      msg.Description,
+		Commission:       msg.Commission,
 		Fee:              msg.Fee,
+		DeployHeader:     msg.DeployHeader,
 	})
 }
 
@@ -184,11 +233,13 @@ func (msg *MsgCreateValidator) UnmarshalJSON(bz []byte) error {
 	}
 
 	msg.Description = msgCreateValJSON.Description
+	msg.Commission = msgCreateValJSON.Commission
 	msg.ValidatorAddress = msgCreateValJSON.ValidatorAddress
 	var err error
 	msg.ConsPubKey, err = sdk.GetConsPubKeyBech32(msgCreateValJSON.ConsPubKey)
 	msg.ContractAddress = msgCreateValJSON.ContractAddress
 	msg.Fee = msgCreateValJSON.Fee
+	msg.DeployHeader = msgCreateValJSON.DeployHeader
 	if err != nil {
 		return err
 	}
@@ -204,6 +255,9 @@ func (msg MsgCreateValidator) GetSignBytes() []byte {
 
 // quick validity check
 func (msg MsgCreateValidator) ValidateBasic() sdk.Error {
+	if err := msg.DeployHeader.ValidateBasic(); err != nil {
+		return err
+	}
 	// note that unmarshaling from bech32 ensures either empty or valid
 	if msg.ValidatorAddress.Empty() {
 		return ErrNilValidatorAddr(DefaultCodespace)
@@ -211,6 +265,9 @@ func (msg MsgCreateValidator) ValidateBasic() sdk.Error {
 	if msg.Description == (Description{}) {
 		return sdk.NewError(DefaultCodespace, CodeInvalidInput, "description must be included")
 	}
+	if err := msg.Commission.Validate(); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -222,13 +279,20 @@ type MsgEditValidator struct {
 	ValidatorAddress sdk.AccAddress `json:"address" yaml:"address"`
 	Description      Description    `json:"description" yaml:"description"`
 	Fee              string         `json:"fee" yaml:"fee"`
+	DeployHeader     *DeployHeader  `json:"deploy_header,omitempty" yaml:"deploy_header,omitempty"`
+
+	// We pass a reference to the new commission rate as it's not mandatory to
+	// update. If not updated, the deserialized rate will be zero with no way to
+	// distinguish if an update was intended.
+	CommissionRate *sdk.Dec `json:"commission_rate,omitempty" yaml:"commission_rate,omitempty"`
 }
 
-func NewMsgEditValidator(contractAddress string, valAddr sdk.AccAddress, description Description, fee string) MsgEditValidator {
+func NewMsgEditValidator(contractAddress string, valAddr sdk.AccAddress, description Description, newRate *sdk.Dec, fee string) MsgEditValidator {
 	return MsgEditValidator{
 		ContractAddress:  contractAddress,
 		ValidatorAddress: valAddr,
 		Description:      description,
+		CommissionRate:   newRate,
 		Fee:              fee,
 	}
 }
@@ -240,6 +304,17 @@ func (msg MsgEditValidator) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.ValidatorAddress}
 }
 
+// GetDeployHeader returns the header of the deploy of the msg
+func (msg MsgEditValidator) GetDeployHeader() *DeployHeader {
+	return msg.DeployHeader
+}
+
+// WithDeployHeader returns the msg with the deploy header
+func (msg MsgEditValidator) WithDeployHeader(header *DeployHeader) DeployMsg {
+	msg.DeployHeader = header
+	return msg
+}
+
 // get the bytes for the message signer to sign on
 func (msg MsgEditValidator) GetSignBytes() []byte {
 	bz := ModuleCdc.MustMarshalJSON(msg)
@@ -248,13 +323,120 @@ func (msg MsgEditValidator) GetSignBytes() []byte {
 
 // quick validity check
 func (msg MsgEditValidator) ValidateBasic() sdk.Error {
+	if err := msg.DeployHeader.ValidateBasic(); err != nil {
+		return err
+	}
 	if msg.ValidatorAddress.Empty() {
 		return sdk.NewError(DefaultCodespace, CodeInvalidInput, "nil validator address")
 	}
 
-	if msg.Description == (Description{}) {
+	if msg.Description == (Description{}) && msg.CommissionRate == nil {
 		return sdk.NewError(DefaultCodespace, CodeInvalidInput, "transaction must include some information to modify")
 	}
+
+	if msg.CommissionRate != nil {
+		if msg.CommissionRate.GT(sdk.OneDec()) || msg.CommissionRate.LT(sdk.ZeroDec()) {
+			return sdk.NewError(DefaultCodespace, CodeInvalidInput, "commission rate must be between 0 and 1, inclusive")
+		}
+	}
+	return nil
+}
+
+//______________________________________________________________________
+// MsgRotateConsPubKey - struct for replacing the consensus public key of a validator
+type MsgRotateConsPubKey struct {
+	ContractAddress  string         `json:"contract_address" yaml:"contract_address"`
+	ValidatorAddress sdk.AccAddress `json:"validator_address" yaml:"validator_address"`
+	NewConsPubKey    crypto.PubKey  `json:"new_cons_pubkey" yaml:"new_cons_pubkey"`
+	Fee              string         `json:"fee" yaml:"fee"`
+	DeployHeader     *DeployHeader  `json:"deploy_header,omitempty" yaml:"deploy_header,omitempty"`
+}
+
+type msgRotateConsPubKeyJSON struct {
+	ContractAddress  string         `json:"contract_address" yaml:"contract_address"`
+	ValidatorAddress sdk.AccAddress `json:"validator_address" yaml:"validator_address"`
+	NewConsPubKey    string         `json:"new_cons_pubkey" yaml:"new_cons_pubkey"`
+	Fee              string         `json:"fee" yaml:"fee"`
+	DeployHeader     *DeployHeader  `json:"deploy_header,omitempty" yaml:"deploy_header,omitempty"`
+}
+
+func NewMsgRotateConsPubKey(contractAddress string, valAddr sdk.AccAddress, newConsPubKey crypto.PubKey, fee string) MsgRotateConsPubKey {
+	return MsgRotateConsPubKey{
+		ContractAddress:  contractAddress,
+		ValidatorAddress: valAddr,
+		NewConsPubKey:    newConsPubKey,
+		Fee:              fee,
+	}
+}
+
+//nolint
+func (msg MsgRotateConsPubKey) Route() string { return RouterKey }
+func (msg MsgRotateConsPubKey) Type() string  { return "rotate_cons_pubkey" }
+func (msg MsgRotateConsPubKey) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.ValidatorAddress}
+}
+
+// GetDeployHeader returns the header of the deploy of the msg
+func (msg MsgRotateConsPubKey) GetDeployHeader() *DeployHeader {
+	return msg.DeployHeader
+}
+
+// WithDeployHeader returns the msg with the deploy header
+func (msg MsgRotateConsPubKey) WithDeployHeader(header *DeployHeader) DeployMsg {
+	msg.DeployHeader = header
+	return msg
+}
+
+// MarshalJSON implements the json.Marshaler interface to provide custom JSON
+// serialization of the MsgRotateConsPubKey type.
+func (msg MsgRotateConsPubKey) MarshalJSON() ([]byte, error) {
+	return json.Marshal(msgRotateConsPubKeyJSON{
+		ContractAddress:  msg.ContractAddress,
+		ValidatorAddress: msg.ValidatorAddress,
+		NewConsPubKey:    sdk.MustBech32ifyConsPub(msg.NewConsPubKey),
+		Fee:              msg.Fee,
+		DeployHeader:     msg.DeployHeader,
+	})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface to provide custom
+// JSON deserialization of the MsgRotateConsPubKey type.
+func (msg *MsgRotateConsPubKey) UnmarshalJSON(bz []byte) error {
+	var msgRotateJSON msgRotateConsPubKeyJSON
+	if err := json.Unmarshal(bz, &msgRotateJSON); err != nil {
+		return err
+	}
+
+	newConsPubKey, err := sdk.GetConsPubKeyBech32(msgRotateJSON.NewConsPubKey)
+	if err != nil {
+		return err
+	}
+	msg.ContractAddress = msgRotateJSON.ContractAddress
+	msg.ValidatorAddress = msgRotateJSON.ValidatorAddress
+	msg.NewConsPubKey = newConsPubKey
+	msg.Fee = msgRotateJSON.Fee
+	msg.DeployHeader = msgRotateJSON.DeployHeader
+
+	return nil
+}
+
+// get the bytes for the message signer to sign on
+func (msg MsgRotateConsPubKey) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// quick validity check
+func (msg MsgRotateConsPubKey) ValidateBasic() sdk.Error {
+	if err := msg.DeployHeader.ValidateBasic(); err != nil {
+		return err
+	}
+	if msg.ValidatorAddress.Empty() {
+		return ErrNilValidatorAddr(DefaultCodespace)
+	}
+	if msg.NewConsPubKey == nil {
+		return sdk.NewError(DefaultCodespace, CodeInvalidInput, "new consensus pubkey must be included")
+	}
 	return nil
 }
 
@@ -264,6 +446,7 @@ type MsgBond struct {
 	FromAddress     sdk.AccAddress `json:"from_address" yaml:"from_address"`
 	Amount          string         `json:"amount" yaml:"amount"`
 	Fee             string         `json:"fee" yaml:"fee"`
+	DeployHeader    *DeployHeader  `json:"deploy_header,omitempty" yaml:"deploy_header,omitempty"`
 }
 
 // NewMsgBond is a constructor function for MsgSetName
@@ -288,6 +471,9 @@ func (msg MsgBond) Type() string { return "executionengine" }
 
 // ValidateBasic runs stateless checks on the message
 func (msg MsgBond) ValidateBasic() sdk.Error {
+	if err := msg.DeployHeader.ValidateBasic(); err != nil {
+		return err
+	}
 	if msg.FromAddress.Equals(sdk.AccAddress("")) {
 		return sdk.ErrUnknownRequest("Address cannot be empty")
 	}
@@ -304,12 +490,24 @@ func (msg MsgBond) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.FromAddress}
 }
 
+// GetDeployHeader returns the header of the deploy of the msg
+func (msg MsgBond) GetDeployHeader() *DeployHeader {
+	return msg.DeployHeader
+}
+
+// WithDeployHeader returns the msg with the deploy header
+func (msg MsgBond) WithDeployHeader(header *DeployHeader) DeployMsg {
+	msg.DeployHeader = header
+	return msg
+}
+
 //______________________________________________________________________
 type MsgUnBond struct {
 	ContractAddress string         `json:"contract_address" yaml:"contract_address"`
 	FromAddress     sdk.AccAddress `json:"from_address" yaml:"from_address"`
 	Amount          string         `json:"amount" yaml:"amount"`
 	Fee             string         `json:"fee" yaml:"fee"`
+	DeployHeader    *DeployHeader  `json:"deploy_header,omitempty" yaml:"deploy_header,omitempty"`
 }
 
 // NewMsgUnBond is a constructor function for MsgSetName
@@ -334,6 +532,9 @@ func (msg MsgUnBond) Type() string { return "executionengine" }
 
 // ValidateBasic runs stateless checks on the message
 func (msg MsgUnBond) ValidateBasic() sdk.Error {
+	if err := msg.DeployHeader.ValidateBasic(); err != nil {
+		return err
+	}
 	if msg.FromAddress.Equals(sdk.AccAddress("")) {
 		return sdk.ErrUnknownRequest("Address cannot be empty")
 	}
@@ -350,6 +551,17 @@ func (msg MsgUnBond) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.FromAddress}
 }
 
+// GetDeployHeader returns the header of the deploy of the msg
+func (msg MsgUnBond) GetDeployHeader() *DeployHeader {
+	return msg.DeployHeader
+}
+
+// WithDeployHeader returns the msg with the deploy header
+func (msg MsgUnBond) WithDeployHeader(header *DeployHeader) DeployMsg {
+	msg.DeployHeader = header
+	return msg
+}
+
 //______________________________________________________________________
 type MsgDelegate struct {
 	ContractAddress string         `json:"contract_address" yaml:"contract_address"`
@@ -357,6 +569,7 @@ type MsgDelegate struct {
 	ValAddress      sdk.AccAddress `json:"val_address" yaml:"val_address"`
 	Amount          string         `json:"amount" yaml:"amount"`
 	Fee             string         `json:"fee" yaml:"fee"`
+	DeployHeader    *DeployHeader  `json:"deploy_header,omitempty" yaml:"deploy_header,omitempty"`
 }
 
 // NewMsgDelegate is a constructor function for MsgSetName
@@ -382,6 +595,9 @@ func (msg MsgDelegate) Type() string { return "executionengine" }
 
 // ValidateBasic runs stateless checks on the message
 func (msg MsgDelegate) ValidateBasic() sdk.Error {
+	if err := msg.DeployHeader.ValidateBasic(); err != nil {
+		return err
+	}
 	if msg.FromAddress.Equals(sdk.AccAddress("")) {
 		return sdk.ErrUnknownRequest("Address cannot be empty")
 	}
@@ -398,6 +614,17 @@ func (msg MsgDelegate) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.FromAddress}
 }
 
+// GetDeployHeader returns the header of the deploy of the msg
+func (msg MsgDelegate) GetDeployHeader() *DeployHeader {
+	return msg.DeployHeader
+}
+
+// WithDeployHeader returns the msg with the deploy header
+func (msg MsgDelegate) WithDeployHeader(header *DeployHeader) DeployMsg {
+	msg.DeployHeader = header
+	return msg
+}
+
 //______________________________________________________________________
 type MsgUndelegate struct {
 	ContractAddress string         `json:"contract_address" yaml:"contract_address"`
@@ -405,6 +632,7 @@ type MsgUndelegate struct {
 	ValAddress      sdk.AccAddress `json:"val_address" yaml:"val_address"`
 	Amount          string         `json:"amount" yaml:"amount"`
 	Fee             string         `json:"fee" yaml:"fee"`
+	DeployHeader    *DeployHeader  `json:"deploy_header,omitempty" yaml:"deploy_header,omitempty"`
 }
 
 // NewMsgUndelegate is a constructor function for MsgSetName
@@ -430,6 +658,9 @@ func (msg MsgUndelegate) Type() string { return "executionengine" }
 
 // ValidateBasic runs stateless checks on the message
 func (msg MsgUndelegate) ValidateBasic() sdk.Error {
+	if err := msg.DeployHeader.ValidateBasic(); err != nil {
+		return err
+	}
 	if msg.FromAddress.Equals(sdk.AccAddress("")) {
 		return sdk.ErrUnknownRequest("Address cannot be empty")
 	}
@@ -446,6 +677,17 @@ func (msg MsgUndelegate) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.FromAddress}
 }
 
+// GetDeployHeader returns the header of the deploy of the msg
+func (msg MsgUndelegate) GetDeployHeader() *DeployHeader {
+	return msg.DeployHeader
+}
+
+// WithDeployHeader returns the msg with the deploy header
+func (msg MsgUndelegate) WithDeployHeader(header *DeployHeader) DeployMsg {
+	msg.DeployHeader = header
+	return msg
+}
+
 //______________________________________________________________________
 type MsgRedelegate struct {
 	ContractAddress string         `json:"contract_address" yaml:"contract_address"`
@@ -454,6 +696,7 @@ type MsgRedelegate struct {
 	DestValAddress  sdk.AccAddress `json:"dest_val_address" yaml:"dest_val_address"`
 	Amount          string         `json:"amount" yaml:"amount"`
 	Fee             string         `json:"fee" yaml:"fee"`
+	DeployHeader    *DeployHeader  `json:"deploy_header,omitempty" yaml:"deploy_header,omitempty"`
 }
 
 // MsgRedelegate is a constructor function for MsgSetName
@@ -480,6 +723,9 @@ func (msg MsgRedelegate) Type() string { return "executionengine" }
 
 // ValidateBasic runs stateless checks on the message
 func (msg MsgRedelegate) ValidateBasic() sdk.Error {
+	if err := msg.DeployHeader.ValidateBasic(); err != nil {
+		return err
+	}
 	if msg.FromAddress.Equals(sdk.AccAddress("")) {
 		return sdk.ErrUnknownRequest("Address cannot be empty")
 	}
@@ -496,6 +742,17 @@ func (msg MsgRedelegate) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.FromAddress}
 }
 
+// GetDeployHeader returns the header of the deploy of the msg
+func (msg MsgRedelegate) GetDeployHeader() *DeployHeader {
+	return msg.DeployHeader
+}
+
+// WithDeployHeader returns the msg with the deploy header
+func (msg MsgRedelegate) WithDeployHeader(header *DeployHeader) DeployMsg {
+	msg.DeployHeader = header
+	return msg
+}
+
 //______________________________________________________________________
 type MsgVote struct {
 	ContractAddress       string         `json:"contract_address" yaml:"contract_address"`
@@ -503,6 +760,7 @@ type MsgVote struct {
 	TargetContractAddress string         `json:"target_contract_address" yaml:"target_contract_address"`
 	Amount                string         `json:"amount" yaml:"amount"`
 	Fee                   string         `json:"fee" yaml:"fee"`
+	DeployHeader          *DeployHeader  `json:"deploy_header,omitempty" yaml:"deploy_header,omitempty"`
 }
 
 // NewMsgVote is a constructor function for MsgSetName
@@ -529,6 +787,9 @@ func (msg MsgVote) Type() string { return "executionengine" }
 
 // ValidateBasic runs stateless checks on the message
 func (msg MsgVote) ValidateBasic() sdk.Error {
+	if err := msg.DeployHeader.ValidateBasic(); err != nil {
+		return err
+	}
 	if msg.FromAddress.Equals(sdk.AccAddress("")) {
 		return sdk.ErrUnknownRequest("Address cannot be empty")
 	}
@@ -563,6 +824,17 @@ func (msg MsgVote) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.FromAddress}
 }
 
+// GetDeployHeader returns the header of the deploy of the msg
+func (msg MsgVote) GetDeployHeader() *DeployHeader {
+	return msg.DeployHeader
+}
+
+// WithDeployHeader returns the msg with the deploy header
+func (msg MsgVote) WithDeployHeader(header *DeployHeader) DeployMsg {
+	msg.DeployHeader = header
+	return msg
+}
+
 //______________________________________________________________________
 type MsgUnvote struct {
 	ContractAddress       string         `json:"contract_address" yaml:"contract_address"`
@@ -570,6 +842,7 @@ type MsgUnvote struct {
 	TargetContractAddress string         `json:"target_contract_address" yaml:"target_contract_address"`
 	Amount                string         `json:"amount" yaml:"amount"`
 	Fee                   string         `json:"fee" yaml:"fee"`
+	DeployHeader          *DeployHeader  `json:"deploy_header,omitempty" yaml:"deploy_header,omitempty"`
 }
 
 // NewMsgUnvote is a constructor function for MsgSetName
@@ -596,6 +869,9 @@ func (msg MsgUnvote) Type() string { return "executionengine" }
 
 // ValidateBasic runs stateless checks on the message
 func (msg MsgUnvote) ValidateBasic() sdk.Error {
+	if err := msg.DeployHeader.ValidateBasic(); err != nil {
+		return err
+	}
 	if msg.FromAddress.Equals(sdk.AccAddress("")) {
 		return sdk.ErrUnknownRequest("Address cannot be empty")
 	}
@@ -630,12 +906,24 @@ func (msg MsgUnvote) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.FromAddress}
 }
 
+// GetDeployHeader returns the header of the deploy of the msg
+func (msg MsgUnvote) GetDeployHeader() *DeployHeader {
+	return msg.DeployHeader
+}
+
+// WithDeployHeader returns the msg with the deploy header
+func (msg MsgUnvote) WithDeployHeader(header *DeployHeader) DeployMsg {
+	msg.DeployHeader = header
+	return msg
+}
+
 //______________________________________________________________________
 type MsgClaim struct {
 	ContractAddress    string         `json:"contract_address" yaml:"contract_address"`
 	FromAddress        sdk.AccAddress `json:"from_address" yaml:"from_address"`
 	RewardOrCommission bool           `json:"reward_or_commission" yaml:"reward_or_commission"`
 	Fee                string         `json:"fee" yaml:"fee"`
+	DeployHeader       *DeployHeader  `json:"deploy_header,omitempty" yaml:"deploy_header,omitempty"`
 }
 
 // NewMsgClaim is a constructor function for MsgSetName
@@ -661,6 +949,9 @@ func (msg MsgClaim) Type() string { return "executionengine" }
 
 // ValidateBasic runs stateless checks on the message
 func (msg MsgClaim) ValidateBasic() sdk.Error {
+	if err := msg.DeployHeader.ValidateBasic(); err != nil {
+		return err
+	}
 	if msg.FromAddress.Equals(sdk.AccAddress("")) {
 		return sdk.ErrUnknownRequest("Address cannot be empty")
 	}
@@ -676,3 +967,151 @@ func (msg MsgClaim) GetSignBytes() []byte {
 func (msg MsgClaim) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.FromAddress}
 }
+
+// GetDeployHeader returns the header of the deploy of the msg
+func (msg MsgClaim) GetDeployHeader() *DeployHeader {
+	return msg.DeployHeader
+}
+
+// WithDeployHeader returns the msg with the deploy header
+func (msg MsgClaim) WithDeployHeader(header *DeployHeader) DeployMsg {
+	msg.DeployHeader = header
+	return msg
+}
+
+//______________________________________________________________________
+// MsgDeployContract - deploys a WASM contract and records it in the contract registry
+type MsgDeployContract struct {
+	ContractAddress string         `json:"contract_address" yaml:"contract_address"`
+	FromAddress     sdk.AccAddress `json:"from_address" yaml:"from_address"`
+	Name            string         `json:"name" yaml:"name"`
+	Code            []byte         `json:"code" yaml:"code"`
+	SessionArgs     string         `json:"session_args" yaml:"session_args"`
+	Fee             string         `json:"fee" yaml:"fee"`
+	DeployHeader    *DeployHeader  `json:"deploy_header,omitempty" yaml:"deploy_header,omitempty"`
+	// Schema of the entry points of the contract, published in its registry record
+	Schema *ContractSchema `json:"schema,omitempty" yaml:"schema,omitempty"`
+}
+
+// NewMsgDeployContract is a constructor function for MsgDeployContract
+func NewMsgDeployContract(
+	contractAddress string,
+	fromAddress sdk.AccAddress,
+	name string,
+	code []byte,
+	sessionArgs, fee string,
+	schema *ContractSchema,
+) MsgDeployContract {
+	return MsgDeployContract{
+		ContractAddress: contractAddress,
+		FromAddress:     fromAddress,
+		Name:            name,
+		Code:            code,
+		SessionArgs:     sessionArgs,
+		Fee:             fee,
+		Schema:          schema,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgDeployContract) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgDeployContract) Type() string { return "deploy_contract" }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgDeployContract) ValidateBasic() sdk.Error {
+	if err := msg.DeployHeader.ValidateBasic(); err != nil {
+		return err
+	}
+	if msg.FromAddress.Equals(sdk.AccAddress("")) {
+		return sdk.ErrUnknownRequest("Address cannot be empty")
+	}
+	if strings.TrimSpace(msg.Name) == "" {
+		return sdk.NewError(DefaultCodespace, CodeInvalidInput, "contract name cannot be empty")
+	}
+	if len(msg.Name) > MaxContractNameLength {
+		return sdk.NewError(DefaultCodespace, CodeInvalidInput, "contract name is longer than %d", MaxContractNameLength)
+	}
+	if len(msg.Code) == 0 {
+		return sdk.NewError(DefaultCodespace, CodeInvalidInput, "contract code cannot be empty")
+	}
+	if msg.Schema != nil {
+		if err := msg.Schema.Validate(); err != nil {
+			return sdk.NewError(DefaultCodespace, CodeInvalidInput, err.Error())
+		}
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgDeployContract) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgDeployContract) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.FromAddress}
+}
+
+// GetDeployHeader returns the header of the deploy of the msg
+func (msg MsgDeployContract) GetDeployHeader() *DeployHeader {
+	return msg.DeployHeader
+}
+
+// WithDeployHeader returns the msg with the deploy header
+func (msg MsgDeployContract) WithDeployHeader(header *DeployHeader) DeployMsg {
+	msg.DeployHeader = header
+	return msg
+}
+
+//______________________________________________________________________
+
+// MsgSetContractSchema - publishes the schema of the entry points of a registered contract.
+// Only the deployer of the contract can set it, and a nil schema removes it.
+type MsgSetContractSchema struct {
+	FromAddress sdk.AccAddress  `json:"from_address" yaml:"from_address"`
+	Name        string          `json:"name" yaml:"name"`
+	Schema      *ContractSchema `json:"schema" yaml:"schema"`
+}
+
+// NewMsgSetContractSchema is a constructor function for MsgSetContractSchema
+func NewMsgSetContractSchema(fromAddress sdk.AccAddress, name string, schema *ContractSchema) MsgSetContractSchema {
+	return MsgSetContractSchema{
+		FromAddress: fromAddress,
+		Name:        name,
+		Schema:      schema,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgSetContractSchema) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgSetContractSchema) Type() string { return "set_contract_schema" }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgSetContractSchema) ValidateBasic() sdk.Error {
+	if msg.FromAddress.Equals(sdk.AccAddress("")) {
+		return sdk.ErrUnknownRequest("Address cannot be empty")
+	}
+	if strings.TrimSpace(msg.Name) == "" {
+		return sdk.NewError(DefaultCodespace, CodeInvalidInput, "contract name cannot be empty")
+	}
+	if msg.Schema != nil {
+		if err := msg.Schema.Validate(); err != nil {
+			return sdk.NewError(DefaultCodespace, CodeInvalidInput, err.Error())
+		}
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgSetContractSchema) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgSetContractSchema) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.FromAddress}
+}
@@ -0,0 +1,119 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/hdac-io/friday/x/params"
+)
+
+// DefaultParamspace - default paramspace of the executionlayer module
+const DefaultParamspace = ModuleName
+
+// MigrationUpgradeName - name of the upgrade whose handler migrates the store of a chain started
+// with an older version of the module: it sets the missing parameters to their defaults and the
+// commission rates of the validators created before the rates, and indexes the contract registry
+// by the contract keys
+const MigrationUpgradeName = "executionlayer-migration"
+
+// Parameter store keys
+var (
+	KeyRewardHistoryRetention  = []byte("RewardHistoryRetention")
+	KeyDeployIndexRetention    = []byte("DeployIndexRetention")
+	KeyConsKeyRotationCooldown = []byte("ConsKeyRotationCooldown")
+	KeyMaxScheduledTransfers   = []byte("MaxScheduledTransfers")
+	KeyUnbondingDelay          = []byte("UnbondingDelay")
+)
+
+// Params - executionlayer parameters
+type Params struct {
+	// RewardHistoryRetention is the number of the recent blocks whose reward and commission
+	// accruals are kept in the history. Zero stops recording the history.
+	RewardHistoryRetention int64 `json:"reward_history_retention" yaml:"reward_history_retention"`
+
+	// DeployIndexRetention is the number of the recent blocks whose executed deploy hashes are
+	// indexed, to reject the duplicates and to check the dependencies of the deploys.
+	DeployIndexRetention int64 `json:"deploy_index_retention" yaml:"deploy_index_retention"`
+
+	// ConsKeyRotationCooldown is the number of blocks a validator has to wait after rotating
+	// its consensus public key before rotating it again.
+	ConsKeyRotationCooldown int64 `json:"cons_key_rotation_cooldown" yaml:"cons_key_rotation_cooldown"`
+
+	// MaxScheduledTransfers is the max number of the scheduled transfers executed in a block.
+	// The due schedules over the max are left to the next blocks.
+	MaxScheduledTransfers int64 `json:"max_scheduled_transfers" yaml:"max_scheduled_transfers"`
+
+	// UnbondingDelay is the number of blocks after which the step of the PoS contract completes an
+	// unbonding, undelegation or redelegation. It has to match the delay of the PoS contract of the
+	// chain. Zero completes the requests in the block they're deployed in.
+	UnbondingDelay int64 `json:"unbonding_delay" yaml:"unbonding_delay"`
+}
+
+// ParamKeyTable for executionlayer module
+func ParamKeyTable() params.KeyTable {
+	return params.NewKeyTable().RegisterParamSet(&Params{})
+}
+
+// NewParams creates a new Params instance
+func NewParams(rewardHistoryRetention, deployIndexRetention, consKeyRotationCooldown, maxScheduledTransfers,
+	unbondingDelay int64) Params {
+	return Params{
+		RewardHistoryRetention:  rewardHistoryRetention,
+		DeployIndexRetention:    deployIndexRetention,
+		ConsKeyRotationCooldown: consKeyRotationCooldown,
+		MaxScheduledTransfers:   maxScheduledTransfers,
+		UnbondingDelay:          unbondingDelay,
+	}
+}
+
+// DefaultParams returns default executionlayer parameters
+func DefaultParams() Params {
+	return Params{
+		RewardHistoryRetention:  60 * 60 * 24 * 30 / 5, // 30 days of 5 second blocks
+		DeployIndexRetention:    60 * 60 * 24 / 5,      // a day of 5 second blocks, the default max TTL
+		ConsKeyRotationCooldown: 60 * 60 * 24 / 5,      // a day of 5 second blocks
+		MaxScheduledTransfers:   100,
+		UnbondingDelay:          0, // the PoS contract of the EE completes the requests in its next step
+	}
+}
+
+// Validate validates the params
+func (p Params) Validate() error {
+	if p.RewardHistoryRetention < 0 {
+		return fmt.Errorf("executionlayer parameter RewardHistoryRetention must not be negative, is %d", p.RewardHistoryRetention)
+	}
+	if p.DeployIndexRetention <= 0 {
+		return fmt.Errorf("executionlayer parameter DeployIndexRetention must be positive, is %d", p.DeployIndexRetention)
+	}
+	if p.ConsKeyRotationCooldown <= 0 {
+		return fmt.Errorf("executionlayer parameter ConsKeyRotationCooldown must be positive, is %d", p.ConsKeyRotationCooldown)
+	}
+	if p.MaxScheduledTransfers <= 0 {
+		return fmt.Errorf("executionlayer parameter MaxScheduledTransfers must be positive, is %d", p.MaxScheduledTransfers)
+	}
+	if p.UnbondingDelay < 0 {
+		return fmt.Errorf("executionlayer parameter UnbondingDelay must not be negative, is %d", p.UnbondingDelay)
+	}
+	return nil
+}
+
+func (p Params) String() string {
+	return fmt.Sprintf(`Execution Layer Params:
+  Reward History Retention:    %d
+  Deploy Index Retention:      %d
+  Cons Key Rotation Cooldown:  %d
+  Max Scheduled Transfers:     %d
+  Unbonding Delay:             %d
+`, p.RewardHistoryRetention, p.DeployIndexRetention, p.ConsKeyRotationCooldown, p.MaxScheduledTransfers,
+		p.UnbondingDelay)
+}
+
+// Implements params.ParamSet
+func (p *Params) ParamSetPairs() params.ParamSetPairs {
+	return params.ParamSetPairs{
+		{KeyRewardHistoryRetention, &p.RewardHistoryRetention},
+		{KeyDeployIndexRetention, &p.DeployIndexRetention},
+		{KeyConsKeyRotationCooldown, &p.ConsKeyRotationCooldown},
+		{KeyMaxScheduledTransfers, &p.MaxScheduledTransfers},
+		{KeyUnbondingDelay, &p.UnbondingDelay},
+	}
+}
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"encoding/hex"
 	"fmt"
 
 	sdk "github.com/hdac-io/friday/types"
@@ -177,3 +178,171 @@ func NewQueryGetCommission(address sdk.AccAddress) QueryGetCommission {
 func (q QueryGetCommission) String() string {
 	return fmt.Sprintf("Query public key or readable name: %s", q.Address)
 }
+
+// defines the params for the following queries:
+// - 'custom/%s/querycontract'
+// Every non-empty field works as a filter.
+type QueryContractParams struct {
+	Deployer sdk.AccAddress `json:"deployer"`
+	Name     string         `json:"name"`
+	CodeHash []byte         `json:"code_hash"`
+}
+
+func NewQueryContractParams(deployer sdk.AccAddress, name string, codeHash []byte) QueryContractParams {
+	return QueryContractParams{
+		Deployer: deployer,
+		Name:     name,
+		CodeHash: codeHash,
+	}
+}
+
+// implement fmt.Stringer
+func (q QueryContractParams) String() string {
+	return fmt.Sprintf("Deployer: %s\nName: %s\nCode hash: %X", q.Deployer, q.Name, q.CodeHash)
+}
+
+// defines the params for the following queries:
+// - 'custom/%s/querycontractbykey'
+// The contract key is a bech32 contract hash or uref address.
+type QueryContractByKeyParams struct {
+	ContractKey string `json:"contract_key"`
+}
+
+func NewQueryContractByKeyParams(contractKey string) QueryContractByKeyParams {
+	return QueryContractByKeyParams{
+		ContractKey: contractKey,
+	}
+}
+
+// implement fmt.Stringer
+func (q QueryContractByKeyParams) String() string {
+	return fmt.Sprintf("Contract key: %s", q.ContractKey)
+}
+
+// defines the params for the following queries:
+// - 'custom/%s/queryrewardhistory'
+// Without a validator, the whole reward of the delegator is returned.
+// A zero height of the range means the oldest or the latest height.
+type QueryRewardHistoryParams struct {
+	Delegator  sdk.AccAddress `json:"delegator"`
+	Validator  sdk.AccAddress `json:"validator"`
+	FromHeight int64          `json:"from_height"`
+	ToHeight   int64          `json:"to_height"`
+}
+
+func NewQueryRewardHistoryParams(delegator, validator sdk.AccAddress, fromHeight, toHeight int64) QueryRewardHistoryParams {
+	return QueryRewardHistoryParams{
+		Delegator:  delegator,
+		Validator:  validator,
+		FromHeight: fromHeight,
+		ToHeight:   toHeight,
+	}
+}
+
+// defines the params for the following queries:
+// - 'custom/%s/querycommissionhistory'
+// A zero height of the range means the oldest or the latest height.
+type QueryCommissionHistoryParams struct {
+	Validator  sdk.AccAddress `json:"validator"`
+	FromHeight int64          `json:"from_height"`
+	ToHeight   int64          `json:"to_height"`
+}
+
+func NewQueryCommissionHistoryParams(validator sdk.AccAddress, fromHeight, toHeight int64) QueryCommissionHistoryParams {
+	return QueryCommissionHistoryParams{
+		Validator:  validator,
+		FromHeight: fromHeight,
+		ToHeight:   toHeight,
+	}
+}
+
+// defines the params for the following queries:
+// - 'custom/%s/querydryrun'
+// Session args of the message are JSON encoded as in MsgExecute.
+type QueryDryRunParams struct {
+	Msg MsgExecute `json:"msg"`
+}
+
+func NewQueryDryRunParams(msg MsgExecute) QueryDryRunParams {
+	return QueryDryRunParams{
+		Msg: msg,
+	}
+}
+
+// implement fmt.Stringer
+func (q QueryDryRunParams) String() string {
+	return fmt.Sprintf("Executor: %s\nContract: %s\nArgs: %s\nFee: %s",
+		q.Msg.ExecAddress, q.Msg.ContractAddress, q.Msg.SessionArgs, q.Msg.Fee)
+}
+
+// QueryEEStateResponse is the EE state root of a height
+type QueryEEStateResponse struct {
+	Height  int64  `json:"height"`
+	EEState string `json:"ee_state"`
+}
+
+func NewQueryEEStateResponse(height int64, eeState []byte) QueryEEStateResponse {
+	return QueryEEStateResponse{
+		Height:  height,
+		EEState: hex.EncodeToString(eeState),
+	}
+}
+
+// implement fmt.Stringer
+func (q QueryEEStateResponse) String() string {
+	return fmt.Sprintf("Height: %d\nEE state: %s", q.Height, q.EEState)
+}
+
+// defines the params for the following queries:
+// - 'custom/%s/querydeploy'
+type QueryDeployParams struct {
+	DeployHash []byte `json:"deploy_hash"`
+}
+
+func NewQueryDeployParams(deployHash []byte) QueryDeployParams {
+	return QueryDeployParams{
+		DeployHash: deployHash,
+	}
+}
+
+// defines the params for the following queries:
+// - 'custom/%s/querygrants'
+type QueryGrantsParams struct {
+	Granter sdk.AccAddress `json:"granter"`
+	Grantee sdk.AccAddress `json:"grantee"`
+}
+
+func NewQueryGrantsParams(granter, grantee sdk.AccAddress) QueryGrantsParams {
+	return QueryGrantsParams{
+		Granter: granter,
+		Grantee: grantee,
+	}
+}
+
+// defines the params for the following queries:
+// - 'custom/%s/queryfeeallowances'
+type QueryFeeAllowancesParams struct {
+	Payer   sdk.AccAddress `json:"payer"`
+	Grantee sdk.AccAddress `json:"grantee"`
+}
+
+func NewQueryFeeAllowancesParams(payer, grantee sdk.AccAddress) QueryFeeAllowancesParams {
+	return QueryFeeAllowancesParams{
+		Payer:   payer,
+		Grantee: grantee,
+	}
+}
+
+// defines the params for the following queries:
+// - 'custom/%s/queryschedules'
+type QuerySchedulesParams struct {
+	Sender    sdk.AccAddress `json:"sender"`
+	Recipient sdk.AccAddress `json:"recipient"`
+}
+
+func NewQuerySchedulesParams(sender, recipient sdk.AccAddress) QuerySchedulesParams {
+	return QuerySchedulesParams{
+		Sender:    sender,
+		Recipient: recipient,
+	}
+}
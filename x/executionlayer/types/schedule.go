@@ -0,0 +1,300 @@
+package types
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/util"
+
+	sdk "github.com/hdac-io/friday/types"
+)
+
+// ScheduleEscrowAddress returns the account holding the tokens of the remaining payouts of a
+// schedule. Each schedule has its own escrow, so the fees of its failed deploys are charged to its
+// own tokens. The payouts and the refund of the schedule are transferred from it by the chain.
+func ScheduleEscrowAddress(scheduleID uint64) sdk.AccAddress {
+	return sdk.AccAddress(util.Blake2b256(append([]byte("executionlayer/schedule_escrow"), sdk.Uint64ToBigEndian(scheduleID)...)))
+}
+
+// Statuses of a schedule
+const (
+	ScheduleStatusPending   = "pending"
+	ScheduleStatusFailed    = "failed"
+	ScheduleStatusCancelled = "cancelled" // to be refunded by the EndBlocker
+)
+
+// NewScheduleDeployHash derives the hash of the deploy of the nth payout of a schedule. The refund
+// of a cancelled schedule is the deploy after its last payout.
+func NewScheduleDeployHash(chainID string, scheduleID uint64, n uint64) []byte {
+	bz := append(sdk.Uint64ToBigEndian(uint64(len(chainID))), []byte(chainID)...)
+	bz = append(bz, ScheduleEscrowAddress(scheduleID).Bytes()...)
+	bz = append(bz, sdk.Uint64ToBigEndian(scheduleID)...)
+	bz = append(bz, sdk.Uint64ToBigEndian(n)...)
+	return util.Blake2b256(bz)
+}
+
+// Schedule - transfers of the amount from the sender to the recipient, the first at the next height
+// and then every interval blocks, until no payout remains. The tokens of the remaining payouts and
+// their fees are held by the escrow of the schedule, less the fees of its failed deploys.
+type Schedule struct {
+	ID         uint64         `json:"id" yaml:"id"`
+	Sender     sdk.AccAddress `json:"sender" yaml:"sender"`
+	Recipient  sdk.AccAddress `json:"recipient" yaml:"recipient"`
+	Amount     string         `json:"amount" yaml:"amount"` // of each payout in bigsun
+	Fee        string         `json:"fee" yaml:"fee"`       // of each payout in bigsun
+	NextHeight int64          `json:"next_height" yaml:"next_height"`
+	Interval   int64          `json:"interval" yaml:"interval"` // zero for a single payout
+	Executed   uint64         `json:"executed" yaml:"executed"`
+	Remaining  uint64         `json:"remaining" yaml:"remaining"`
+	Escrow     string         `json:"escrow" yaml:"escrow"` // held by the escrow in bigsun
+	Status     string         `json:"status" yaml:"status"`
+}
+
+// NewSchedule creates a new pending Schedule instance
+func NewSchedule(id uint64, sender, recipient sdk.AccAddress, amount, fee string, startHeight, interval int64, count uint64) Schedule {
+	return Schedule{
+		ID:         id,
+		Sender:     sender,
+		Recipient:  recipient,
+		Amount:     amount,
+		Fee:        fee,
+		NextHeight: startHeight,
+		Interval:   interval,
+		Remaining:  count,
+		Escrow:     escrowAmount(amount, fee, count).String(),
+		Status:     ScheduleStatusPending,
+	}
+}
+
+// ValidateBasic runs stateless checks on the schedule
+func (s Schedule) ValidateBasic() sdk.Error {
+	if s.Sender.Empty() || s.Recipient.Empty() {
+		return sdk.ErrInvalidAddress("sender and recipient cannot be empty")
+	}
+	if err := validateSchedule(s.Amount, s.Fee, s.NextHeight, s.Interval, s.Remaining+s.Executed); err != nil {
+		return err
+	}
+	if _, _, _, err := s.Amounts(); err != nil {
+		return err
+	}
+	switch s.Status {
+	case ScheduleStatusPending, ScheduleStatusFailed, ScheduleStatusCancelled:
+		return nil
+	default:
+		return ErrInvalidSchedule(DefaultCodespace, fmt.Sprintf("unknown status %s", s.Status))
+	}
+}
+
+// EscrowAddress returns the account holding the tokens of the schedule
+func (s Schedule) EscrowAddress() sdk.AccAddress {
+	return ScheduleEscrowAddress(s.ID)
+}
+
+// Amounts returns the amount and the fee of each payout, and the amount held by the escrow
+func (s Schedule) Amounts() (amount, fee, escrow sdk.Int, err sdk.Error) {
+	var ok bool
+	if amount, ok = sdk.NewIntFromString(s.Amount); !ok {
+		return amount, fee, escrow, ErrInvalidSchedule(DefaultCodespace, fmt.Sprintf("invalid amount %s", s.Amount))
+	}
+	if fee, ok = sdk.NewIntFromString(s.Fee); !ok {
+		return amount, fee, escrow, ErrInvalidSchedule(DefaultCodespace, fmt.Sprintf("invalid fee %s", s.Fee))
+	}
+	if escrow, ok = sdk.NewIntFromString(s.Escrow); !ok || escrow.IsNegative() {
+		return amount, fee, escrow, ErrInvalidSchedule(DefaultCodespace, fmt.Sprintf("invalid escrow %s", s.Escrow))
+	}
+	return amount, fee, escrow, nil
+}
+
+// Next returns the schedule after a payout, with the escrow left
+func (s Schedule) Next(escrow sdk.Int) Schedule {
+	s.Executed++
+	s.Remaining--
+	s.NextHeight += s.Interval
+	s.Escrow = escrow.String()
+	return s
+}
+
+// Charge returns the schedule with the fee of a failed deploy charged to its escrow
+func (s Schedule) Charge(escrow, fee sdk.Int) Schedule {
+	s.Escrow = sdk.MaxInt(escrow.Sub(fee), sdk.ZeroInt()).String()
+	return s
+}
+
+// String returns a human readable string representation of a schedule
+func (s Schedule) String() string {
+	return fmt.Sprintf(`Schedule %d:
+  Sender:       %s
+  Recipient:    %s
+  Amount:       %s
+  Fee:          %s
+  Next Height:  %d
+  Interval:     %d
+  Executed:     %d
+  Remaining:    %d
+  Escrow:       %s
+  Status:       %s`, s.ID, s.Sender, s.Recipient, s.Amount, s.Fee, s.NextHeight, s.Interval,
+		s.Executed, s.Remaining, s.Escrow, s.Status)
+}
+
+// Schedules is a collection of Schedule
+type Schedules []Schedule
+
+func (s Schedules) String() (out string) {
+	for _, schedule := range s {
+		out += schedule.String() + "\n"
+	}
+	return strings.TrimSpace(out)
+}
+
+func validateSchedule(amount, fee string, startHeight, interval int64, count uint64) sdk.Error {
+	for _, value := range []string{amount, fee} {
+		parsed, ok := sdk.NewIntFromString(value)
+		if !ok || !parsed.IsPositive() {
+			return ErrInvalidSchedule(DefaultCodespace, fmt.Sprintf("amount and fee must be positive integers, got %s", value))
+		}
+	}
+	if startHeight <= 0 {
+		return ErrInvalidSchedule(DefaultCodespace, "start height must be positive")
+	}
+	if interval < 0 {
+		return ErrInvalidSchedule(DefaultCodespace, "interval must not be negative")
+	}
+	if count == 0 {
+		return ErrInvalidSchedule(DefaultCodespace, "count must be positive")
+	}
+	if interval == 0 && count != 1 {
+		return ErrInvalidSchedule(DefaultCodespace, "recurring transfers need an interval")
+	}
+	return nil
+}
+
+func escrowAmount(amount, fee string, count uint64) sdk.Int {
+	amountInt, _ := sdk.NewIntFromString(amount)
+	feeInt, _ := sdk.NewIntFromString(fee)
+	return amountInt.Add(feeInt).Mul(sdk.NewIntFromBigInt(new(big.Int).SetUint64(count)))
+}
+
+//______________________________________________________________________
+
+// MsgScheduleTransfer - schedules count transfers of the amount to the recipient, the first at the
+// start height and then every interval blocks. The amounts and the fees of the payouts are
+// transferred to the escrow with the deploy of the msg.
+type MsgScheduleTransfer struct {
+	ContractAddress string         `json:"contract_address" yaml:"contract_address"`
+	FromAddress     sdk.AccAddress `json:"from_address" yaml:"from_address"`
+	ToAddress       sdk.AccAddress `json:"to_address" yaml:"to_address"`
+	Amount          string         `json:"amount" yaml:"amount"`
+	PayoutFee       string         `json:"payout_fee" yaml:"payout_fee"`
+	StartHeight     int64          `json:"start_height" yaml:"start_height"`
+	Interval        int64          `json:"interval" yaml:"interval"`
+	Count           uint64         `json:"count" yaml:"count"`
+	Fee             string         `json:"fee" yaml:"fee"`
+	DeployHeader    *DeployHeader  `json:"deploy_header,omitempty" yaml:"deploy_header,omitempty"`
+}
+
+// NewMsgScheduleTransfer is a constructor function for MsgScheduleTransfer
+func NewMsgScheduleTransfer(
+	tokenContractAddress string,
+	fromAddress, toAddress sdk.AccAddress,
+	amount, payoutFee string,
+	startHeight, interval int64,
+	count uint64,
+	fee string,
+) MsgScheduleTransfer {
+	return MsgScheduleTransfer{
+		ContractAddress: tokenContractAddress,
+		FromAddress:     fromAddress,
+		ToAddress:       toAddress,
+		Amount:          amount,
+		PayoutFee:       payoutFee,
+		StartHeight:     startHeight,
+		Interval:        interval,
+		Count:           count,
+		Fee:             fee,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgScheduleTransfer) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgScheduleTransfer) Type() string { return "schedule_transfer" }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgScheduleTransfer) ValidateBasic() sdk.Error {
+	if err := msg.DeployHeader.ValidateBasic(); err != nil {
+		return err
+	}
+	if msg.FromAddress.Empty() || msg.ToAddress.Empty() {
+		return sdk.ErrInvalidAddress("sender and recipient cannot be empty")
+	}
+	return validateSchedule(msg.Amount, msg.PayoutFee, msg.StartHeight, msg.Interval, msg.Count)
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgScheduleTransfer) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgScheduleTransfer) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.FromAddress}
+}
+
+// GetDeployHeader returns the header of the deploy of the msg
+func (msg MsgScheduleTransfer) GetDeployHeader() *DeployHeader {
+	return msg.DeployHeader
+}
+
+// WithDeployHeader returns the msg with the deploy header
+func (msg MsgScheduleTransfer) WithDeployHeader(header *DeployHeader) DeployMsg {
+	msg.DeployHeader = header
+	return msg
+}
+
+// EscrowAmount returns the amount transferred to the escrow for the payouts and their fees
+func (msg MsgScheduleTransfer) EscrowAmount() sdk.Int {
+	return escrowAmount(msg.Amount, msg.PayoutFee, msg.Count)
+}
+
+//______________________________________________________________________
+
+// MsgCancelSchedule - cancels a pending or failed schedule of the sender. The tokens of the
+// remaining payouts are refunded from the escrow by the EndBlocker, less the fee of the refund.
+type MsgCancelSchedule struct {
+	FromAddress sdk.AccAddress `json:"from_address" yaml:"from_address"`
+	ScheduleID  uint64         `json:"schedule_id" yaml:"schedule_id"`
+}
+
+// NewMsgCancelSchedule is a constructor function for MsgCancelSchedule
+func NewMsgCancelSchedule(fromAddress sdk.AccAddress, scheduleID uint64) MsgCancelSchedule {
+	return MsgCancelSchedule{
+		FromAddress: fromAddress,
+		ScheduleID:  scheduleID,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgCancelSchedule) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgCancelSchedule) Type() string { return "cancel_schedule" }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgCancelSchedule) ValidateBasic() sdk.Error {
+	if msg.FromAddress.Empty() {
+		return sdk.ErrInvalidAddress("sender cannot be empty")
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgCancelSchedule) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgCancelSchedule) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.FromAddress}
+}
@@ -0,0 +1,69 @@
+package types
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/hdac-io/friday/types"
+)
+
+func TestMsgScheduleTransferValidateBasic(t *testing.T) {
+	sender := sdk.AccAddress([]byte(strings.Repeat("s", 32)))
+	recipient := sdk.AccAddress([]byte(strings.Repeat("r", 32)))
+
+	require.Nil(t, NewMsgScheduleTransfer("transfer", sender, recipient, "100", "10", 5, 0, 1, "10").ValidateBasic())
+	require.Nil(t, NewMsgScheduleTransfer("transfer", sender, recipient, "100", "10", 5, 10, 3, "10").ValidateBasic())
+
+	require.NotNil(t, NewMsgScheduleTransfer("transfer", nil, recipient, "100", "10", 5, 0, 1, "10").ValidateBasic())
+	require.NotNil(t, NewMsgScheduleTransfer("transfer", sender, recipient, "0", "10", 5, 0, 1, "10").ValidateBasic())
+	require.NotNil(t, NewMsgScheduleTransfer("transfer", sender, recipient, "100", "ten", 5, 0, 1, "10").ValidateBasic())
+	require.NotNil(t, NewMsgScheduleTransfer("transfer", sender, recipient, "100", "10", 0, 0, 1, "10").ValidateBasic())
+	require.NotNil(t, NewMsgScheduleTransfer("transfer", sender, recipient, "100", "10", 5, -1, 1, "10").ValidateBasic())
+	require.NotNil(t, NewMsgScheduleTransfer("transfer", sender, recipient, "100", "10", 5, 10, 0, "10").ValidateBasic())
+	require.NotNil(t, NewMsgScheduleTransfer("transfer", sender, recipient, "100", "10", 5, 0, 3, "10").ValidateBasic())
+
+	require.Equal(t, sdk.NewInt(330), NewMsgScheduleTransfer("transfer", sender, recipient, "100", "10", 5, 10, 3, "10").EscrowAmount())
+}
+
+func TestScheduleNext(t *testing.T) {
+	sender := sdk.AccAddress([]byte(strings.Repeat("s", 32)))
+	recipient := sdk.AccAddress([]byte(strings.Repeat("r", 32)))
+
+	schedule := NewSchedule(1, sender, recipient, "100", "10", 5, 10, 3)
+	require.Nil(t, schedule.ValidateBasic())
+	amount, fee, escrow, err := schedule.Amounts()
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewInt(100), amount)
+	require.Equal(t, sdk.NewInt(10), fee)
+	require.Equal(t, sdk.NewInt(330), escrow)
+
+	next := schedule.Next(escrow.Sub(amount.Add(fee)))
+	require.Equal(t, int64(15), next.NextHeight)
+	require.Equal(t, uint64(1), next.Executed)
+	require.Equal(t, uint64(2), next.Remaining)
+	require.Equal(t, "220", next.Escrow)
+	require.Nil(t, next.ValidateBasic())
+
+	// the fees of the failed deploys are charged to the escrow, down to zero
+	require.Equal(t, "210", next.Charge(sdk.NewInt(220), fee).Escrow)
+	require.Equal(t, "0", next.Charge(sdk.NewInt(5), fee).Escrow)
+
+	// every schedule has its own escrow
+	require.NotEqual(t, ScheduleEscrowAddress(1), ScheduleEscrowAddress(2))
+	require.Equal(t, ScheduleEscrowAddress(1), schedule.EscrowAddress())
+
+	schedule.Status = ScheduleStatusCancelled
+	require.Nil(t, schedule.ValidateBasic())
+	schedule.Escrow = "-1"
+	require.NotNil(t, schedule.ValidateBasic())
+	schedule.Escrow = "330"
+	schedule.Status = "done"
+	require.NotNil(t, schedule.ValidateBasic())
+
+	// every payout of a schedule is a distinct deploy
+	require.NotEqual(t, NewScheduleDeployHash("chain", 1, 0), NewScheduleDeployHash("chain", 1, 1))
+	require.NotEqual(t, NewScheduleDeployHash("chain", 1, 0), NewScheduleDeployHash("chain", 2, 0))
+	require.NotEqual(t, NewScheduleDeployHash("chain", 1, 0), NewScheduleDeployHash("other", 1, 0))
+}
@@ -0,0 +1,281 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/gogo/protobuf/jsonpb"
+
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/casper/consensus/state"
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/storedvalue"
+)
+
+// CallEntryPoint is the name of the entry point taking the arguments of a contract call directly.
+// The other entry points are called with their name as the first argument, a String, followed by
+// their arguments, as the proxy contract is.
+const CallEntryPoint = "call"
+
+// MaxContractEntryPoints - maximum number of the entry points of a contract schema
+const MaxContractEntryPoints = 64
+
+// ContractArg - argument of an entry point of a contract
+type ContractArg struct {
+	Name string `json:"name" yaml:"name"`
+	Type string `json:"type" yaml:"type"` // CLType such as U512, String or List(U8)
+}
+
+// ContractEntryPoint - method of a contract and its arguments in order
+type ContractEntryPoint struct {
+	Name        string        `json:"name" yaml:"name"`
+	Args        []ContractArg `json:"args" yaml:"args"`
+	Description string        `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+// ContractSchema - entry points of a contract published in its registry record
+type ContractSchema struct {
+	EntryPoints []ContractEntryPoint `json:"entry_points" yaml:"entry_points"`
+}
+
+// ParseContractSchema parses and validates a schema in JSON
+func ParseContractSchema(bz []byte) (ContractSchema, error) {
+	var schema ContractSchema
+	decoder := json.NewDecoder(bytes.NewReader(bz))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&schema); err != nil {
+		return schema, fmt.Errorf("failed to parse contract schema: %s", err.Error())
+	}
+	return schema, schema.Validate()
+}
+
+// Validate checks the entry points have unique names and the arguments have known types
+func (s ContractSchema) Validate() error {
+	if len(s.EntryPoints) == 0 {
+		return fmt.Errorf("contract schema has no entry point")
+	}
+	if len(s.EntryPoints) > MaxContractEntryPoints {
+		return fmt.Errorf("contract schema has more than %d entry points", MaxContractEntryPoints)
+	}
+
+	names := make(map[string]bool)
+	for _, entryPoint := range s.EntryPoints {
+		if strings.TrimSpace(entryPoint.Name) == "" {
+			return fmt.Errorf("entry point name cannot be empty")
+		}
+		if names[entryPoint.Name] {
+			return fmt.Errorf("duplicated entry point %s", entryPoint.Name)
+		}
+		names[entryPoint.Name] = true
+
+		for i, arg := range entryPoint.Args {
+			if strings.TrimSpace(arg.Name) == "" {
+				return fmt.Errorf("entry point %s: argument %d has no name", entryPoint.Name, i)
+			}
+			if _, err := parseCLTypeName(arg.Type); err != nil {
+				return fmt.Errorf("entry point %s: argument %s: %s", entryPoint.Name, arg.Name, err.Error())
+			}
+		}
+	}
+	return nil
+}
+
+// GetEntryPoint returns the entry point of the name
+func (s ContractSchema) GetEntryPoint(name string) (ContractEntryPoint, bool) {
+	for _, entryPoint := range s.EntryPoints {
+		if entryPoint.Name == name {
+			return entryPoint, true
+		}
+	}
+	return ContractEntryPoint{}, false
+}
+
+// ValidateArgs checks the session arguments in JSON against the entry point they call, and returns
+// the name of the entry point. The first argument, a String, selects the entry point unless the
+// schema has only the call entry point.
+func (s ContractSchema) ValidateArgs(sessionArgs string) (string, error) {
+	args, err := parseSessionArgTypes(sessionArgs)
+	if err != nil {
+		return "", err
+	}
+
+	if len(args) != 0 && args[0].method != "" {
+		if entryPoint, ok := s.GetEntryPoint(args[0].method); ok && entryPoint.Name != CallEntryPoint {
+			return entryPoint.Name, entryPoint.validateArgs(args[1:])
+		}
+	}
+	if entryPoint, ok := s.GetEntryPoint(CallEntryPoint); ok {
+		return entryPoint.Name, entryPoint.validateArgs(args)
+	}
+
+	names := make([]string, len(s.EntryPoints))
+	for i, entryPoint := range s.EntryPoints {
+		names[i] = entryPoint.Name
+	}
+	if len(args) == 0 || args[0].method == "" {
+		return "", fmt.Errorf("the first argument must be the name of an entry point, one of %s", strings.Join(names, ", "))
+	}
+	return "", fmt.Errorf("unknown entry point %s, expected one of %s", args[0].method, strings.Join(names, ", "))
+}
+
+func (e ContractEntryPoint) validateArgs(args []sessionArgType) error {
+	if len(args) != len(e.Args) {
+		return fmt.Errorf("entry point %s takes %d arguments (%s), but %d are given",
+			e.Name, len(e.Args), e.argList(), len(args))
+	}
+	for i, arg := range e.Args {
+		if args[i].name != "" && args[i].name != arg.Name {
+			return fmt.Errorf("entry point %s: argument %d must be %s, not %s", e.Name, i+1, arg.Name, args[i].name)
+		}
+		expected, _ := parseCLTypeName(arg.Type)
+		if expected.tag != storedvalue.TAG_ANY && expected.String() != args[i].typ.String() {
+			return fmt.Errorf("entry point %s: argument %s must be %s, not %s", e.Name, arg.Name, expected, args[i].typ)
+		}
+	}
+	return nil
+}
+
+func (e ContractEntryPoint) argList() string {
+	args := make([]string, len(e.Args))
+	for i, arg := range e.Args {
+		args[i] = fmt.Sprintf("%s: %s", arg.Name, arg.Type)
+	}
+	return strings.Join(args, ", ")
+}
+
+// String returns a human readable string representation of the entry points
+func (s ContractSchema) String() string {
+	lines := make([]string, len(s.EntryPoints))
+	for i, entryPoint := range s.EntryPoints {
+		lines[i] = fmt.Sprintf("    %s(%s)", entryPoint.Name, entryPoint.argList())
+		if entryPoint.Description != "" {
+			lines[i] += "  # " + entryPoint.Description
+		}
+	}
+	return "Entry Points:\n" + strings.Join(lines, "\n")
+}
+
+// sessionArgType - name and type of a session argument, with the value of a String argument
+type sessionArgType struct {
+	name   string
+	typ    clType
+	method string
+}
+
+// parseSessionArgTypes reads the names and types of the session arguments in the JSON format of
+// the deploy arguments. The values other than the strings aren't parsed, so that the bech32
+// addresses in them are accepted before they're converted for the EE.
+func parseSessionArgTypes(sessionArgs string) ([]sessionArgType, error) {
+	if strings.TrimSpace(sessionArgs) == "" {
+		return nil, nil
+	}
+
+	var rawArgs []struct {
+		Name  string `json:"name"`
+		Value struct {
+			ClType      json.RawMessage `json:"cl_type"`
+			ClTypeCamel json.RawMessage `json:"clType"`
+			Value       struct {
+				StrValue      *string `json:"str_value"`
+				StrValueCamel *string `json:"strValue"`
+			} `json:"value"`
+		} `json:"value"`
+	}
+	if err := json.Unmarshal([]byte(sessionArgs), &rawArgs); err != nil {
+		return nil, fmt.Errorf("failed to parse the arguments: %s", err.Error())
+	}
+
+	args := make([]sessionArgType, len(rawArgs))
+	for i, rawArg := range rawArgs {
+		rawType := rawArg.Value.ClType
+		if rawType == nil {
+			rawType = rawArg.Value.ClTypeCamel
+		}
+		if rawType == nil {
+			return nil, fmt.Errorf("argument %d has no cl_type", i+1)
+		}
+
+		var stateType state.CLType
+		if err := jsonpb.Unmarshal(bytes.NewReader(rawType), &stateType); err != nil {
+			return nil, fmt.Errorf("invalid cl_type of argument %d: %s", i+1, err.Error())
+		}
+		typ, err := clTypeFromState(&stateType)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cl_type of argument %d: %s", i+1, err.Error())
+		}
+
+		args[i] = sessionArgType{name: rawArg.Name, typ: typ}
+		if typ.tag == storedvalue.TAG_STRING {
+			if str := rawArg.Value.Value.StrValue; str != nil {
+				args[i].method = *str
+			} else if str := rawArg.Value.Value.StrValueCamel; str != nil {
+				args[i].method = *str
+			}
+		}
+	}
+	return args, nil
+}
+
+// parseCLTypeName parses a CLType in the form of its String, e.g. Option(List(U8)). Names are
+// case insensitive.
+func parseCLTypeName(name string) (clType, error) {
+	typ, rest, err := parseCLTypeNamePrefix(strings.Replace(name, " ", "", -1))
+	if err != nil {
+		return clType{}, err
+	}
+	if rest != "" {
+		return clType{}, fmt.Errorf("invalid CLType %s", name)
+	}
+	return typ, nil
+}
+
+func parseCLTypeNamePrefix(s string) (clType, string, error) {
+	end := strings.IndexAny(s, "(),")
+	if end < 0 {
+		end = len(s)
+	}
+
+	var typ clType
+	found := false
+	for tag, tagName := range clTypeNames {
+		if strings.EqualFold(tagName, s[:end]) {
+			typ, found = clType{tag: tag}, true
+			break
+		}
+	}
+	if !found {
+		return clType{}, "", fmt.Errorf("unknown CLType %s", s[:end])
+	}
+
+	innerCount := clTypeInnerCount(typ.tag)
+	rest := s[end:]
+	if innerCount == 0 {
+		return typ, rest, nil
+	}
+	if !strings.HasPrefix(rest, "(") {
+		return clType{}, "", fmt.Errorf("%s requires %d inner types", clTypeNames[typ.tag], innerCount)
+	}
+	rest = rest[1:]
+
+	for i := 0; i < innerCount; i++ {
+		if i > 0 {
+			if !strings.HasPrefix(rest, ",") {
+				return clType{}, "", fmt.Errorf("%s requires %d inner types", clTypeNames[typ.tag], innerCount)
+			}
+			rest = rest[1:]
+		}
+
+		var inner clType
+		var err error
+		inner, rest, err = parseCLTypeNamePrefix(rest)
+		if err != nil {
+			return clType{}, "", err
+		}
+		typ.inner = append(typ.inner, inner)
+	}
+
+	if !strings.HasPrefix(rest, ")") {
+		return clType{}, "", fmt.Errorf("%s requires %d inner types", clTypeNames[typ.tag], innerCount)
+	}
+	return typ, rest[1:], nil
+}
@@ -0,0 +1,95 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const counterSchema = `{
+  "entry_points": [
+    {"name": "inc", "args": [{"name": "amount", "type": "U512"}], "description": "increase the counter"},
+    {"name": "set", "args": [{"name": "values", "type": "List(U8)"}, {"name": "owner", "type": "any"}]}
+  ]
+}`
+
+func TestParseContractSchema(t *testing.T) {
+	schema, err := ParseContractSchema([]byte(counterSchema))
+	require.NoError(t, err)
+	require.Equal(t, 2, len(schema.EntryPoints))
+	require.Equal(t, "Entry Points:\n"+
+		"    inc(amount: U512)  # increase the counter\n"+
+		"    set(values: List(U8), owner: any)", schema.String())
+
+	_, err = ParseContractSchema([]byte(`{"entry_points": [{"name": "inc", "args": [{"name": "a", "type": "U513"}]}]}`))
+	require.Error(t, err)
+	_, err = ParseContractSchema([]byte(`{"entry_points": [{"name": "inc"}, {"name": "inc"}]}`))
+	require.Error(t, err)
+	_, err = ParseContractSchema([]byte(`{"entry_points": [{"name": "inc", "argument": []}]}`))
+	require.Error(t, err)
+	_, err = ParseContractSchema([]byte(`{"entry_points": []}`))
+	require.Error(t, err)
+}
+
+func TestParseCLTypeName(t *testing.T) {
+	for _, name := range []string{"U512", "List(U8)", "Option(List(U8))", "Map(String, Key)", "Tuple3(Bool, I32, URef)"} {
+		typ, err := parseCLTypeName(name)
+		require.NoError(t, err, name)
+		require.Equal(t, name, typ.String())
+	}
+
+	typ, err := parseCLTypeName("option(u8)")
+	require.NoError(t, err)
+	require.Equal(t, "Option(U8)", typ.String())
+	typ, err = parseCLTypeName("Map(String,Key)")
+	require.NoError(t, err)
+	require.Equal(t, "Map(String, Key)", typ.String())
+
+	for _, name := range []string{"", "List", "List()", "List(U8", "Map(String)", "U8)", "U8(U8)", "Foo"} {
+		_, err := parseCLTypeName(name)
+		require.Error(t, err, name)
+	}
+}
+
+func TestContractSchemaValidateArgs(t *testing.T) {
+	schema, err := ParseContractSchema([]byte(counterSchema))
+	require.NoError(t, err)
+
+	method := func(name string) string {
+		return `{"name": "method", "value": {"cl_type": {"simple_type": "STRING"}, "value": {"str_value": "` + name + `"}}}`
+	}
+	amount := `{"name": "amount", "value": {"cl_type": {"simple_type": "U512"}, "value": {"u512": {"value": "100"}}}}`
+	values := `{"name": "values", "value": {"cl_type": {"list_type": {"inner": {"simple_type": "U8"}}}, "value": {"bytes_value": "AQI="}}}`
+	owner := `{"name": "owner", "value": {"cl_type": {"simple_type": "KEY"}, "value": {"key": {"address": {"account": "friday1"}}}}}`
+
+	entryPoint, err := schema.ValidateArgs("[" + method("inc") + "," + amount + "]")
+	require.NoError(t, err)
+	require.Equal(t, "inc", entryPoint)
+	entryPoint, err = schema.ValidateArgs("[" + method("set") + "," + values + "," + owner + "]")
+	require.NoError(t, err)
+	require.Equal(t, "set", entryPoint)
+
+	// wrong count, name and type
+	_, err = schema.ValidateArgs("[" + method("inc") + "]")
+	require.Error(t, err)
+	_, err = schema.ValidateArgs("[" + method("inc") + "," + owner + "]")
+	require.Error(t, err)
+	_, err = schema.ValidateArgs("[" + method("set") + "," + amount + "," + owner + "]")
+	require.Error(t, err)
+	// unknown entry point, and no entry point
+	_, err = schema.ValidateArgs("[" + method("dec") + "," + amount + "]")
+	require.Error(t, err)
+	_, err = schema.ValidateArgs("[" + amount + "]")
+	require.Error(t, err)
+	_, err = schema.ValidateArgs("")
+	require.Error(t, err)
+
+	// the call entry point takes the arguments directly
+	schema.EntryPoints = append(schema.EntryPoints, ContractEntryPoint{Name: CallEntryPoint, Args: []ContractArg{{"amount", "U512"}}})
+	entryPoint, err = schema.ValidateArgs("[" + amount + "]")
+	require.NoError(t, err)
+	require.Equal(t, CallEntryPoint, entryPoint)
+	entryPoint, err = schema.ValidateArgs("[" + method("inc") + "," + amount + "]")
+	require.NoError(t, err)
+	require.Equal(t, "inc", entryPoint)
+}
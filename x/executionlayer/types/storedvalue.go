@@ -0,0 +1,679 @@
+package types
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/casper/consensus/state"
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/storedvalue"
+	sdk "github.com/hdac-io/friday/types"
+)
+
+const (
+	clResultErrTag = 0
+	clResultOkTag  = 1
+
+	clOptionNoneTag = 0
+	clOptionSomeTag = 1
+)
+
+// DecodedStoredValue - human readable form of a StoredValue queried from the EE.
+// Exactly one of the fields is set depending on the type of the stored value.
+type DecodedStoredValue struct {
+	CLValue  *DecodedCLValue  `json:"cl_value,omitempty"`
+	Account  *DecodedAccount  `json:"account,omitempty"`
+	Contract *DecodedContract `json:"contract,omitempty"`
+}
+
+// DecodedCLValue - CLValue with its type name and natively typed value
+type DecodedCLValue struct {
+	Type  string      `json:"type"`
+	Value interface{} `json:"value"`
+}
+
+// DecodedAccount - account stored value with bech32 encoded keys
+type DecodedAccount struct {
+	Address          string                  `json:"address"`
+	NamedKeys        map[string]string       `json:"named_keys"`
+	MainPurse        string                  `json:"main_purse"`
+	AssociatedKeys   []DecodedAssociatedKey  `json:"associated_keys"`
+	ActionThresholds DecodedActionThresholds `json:"action_thresholds"`
+}
+
+// DecodedAssociatedKey - associated key of an account
+type DecodedAssociatedKey struct {
+	Address string `json:"address"`
+	Weight  uint32 `json:"weight"`
+}
+
+// DecodedActionThresholds - action thresholds of an account
+type DecodedActionThresholds struct {
+	Deployment    uint32 `json:"deployment"`
+	KeyManagement uint32 `json:"key_management"`
+}
+
+// DecodedContract - contract stored value with bech32 encoded keys
+type DecodedContract struct {
+	NamedKeys       map[string]string `json:"named_keys"`
+	ProtocolVersion string            `json:"protocol_version"`
+	BodySize        int               `json:"body_size"`
+}
+
+var clTypeNames = map[storedvalue.CL_TYPE_TAG]string{
+	storedvalue.TAG_BOOL:       "Bool",
+	storedvalue.TAG_I32:        "I32",
+	storedvalue.TAG_I64:        "I64",
+	storedvalue.TAG_U8:         "U8",
+	storedvalue.TAG_U32:        "U32",
+	storedvalue.TAG_U64:        "U64",
+	storedvalue.TAG_U128:       "U128",
+	storedvalue.TAG_U256:       "U256",
+	storedvalue.TAG_U512:       "U512",
+	storedvalue.TAG_UNIT:       "Unit",
+	storedvalue.TAG_STRING:     "String",
+	storedvalue.TAG_KEY:        "Key",
+	storedvalue.TAG_UREF:       "URef",
+	storedvalue.TAG_OPTION:     "Option",
+	storedvalue.TAG_LIST:       "List",
+	storedvalue.TAG_FIXED_LIST: "FixedList",
+	storedvalue.TAG_RESULT:     "Result",
+	storedvalue.TAG_MAP:        "Map",
+	storedvalue.TAG_TUPLE1:     "Tuple1",
+	storedvalue.TAG_TUPLE2:     "Tuple2",
+	storedvalue.TAG_TUPLE3:     "Tuple3",
+	storedvalue.TAG_ANY:        "Any",
+}
+
+// clType - parsed type tree of a CLValue
+type clType struct {
+	tag    storedvalue.CL_TYPE_TAG
+	inner  []clType
+	length int // number of the elements of a FixedList, or clUnknownLength
+}
+
+// clUnknownLength is the length of a FixedList parsed from the type tags of a CLValue, which drop it
+const clUnknownLength = -1
+
+// DecodeStoredValue walks the given StoredValue and decodes every CLType into natural Go values.
+func DecodeStoredValue(value storedvalue.StoredValue) (DecodedStoredValue, error) {
+	switch value.Type {
+	case storedvalue.TYPE_CL_VALUE:
+		clValue, err := DecodeCLValue(value.ClValue)
+		if err != nil {
+			return DecodedStoredValue{}, err
+		}
+		return DecodedStoredValue{CLValue: &clValue}, nil
+	case storedvalue.TYPE_ACCOUNT:
+		account := decodeAccount(value.Account)
+		return DecodedStoredValue{Account: &account}, nil
+	case storedvalue.TYPE_CONTRACT:
+		contract := decodeContract(value.Contract)
+		return DecodedStoredValue{Contract: &contract}, nil
+	default:
+		return DecodedStoredValue{}, fmt.Errorf("unknown stored value type: %d", value.Type)
+	}
+}
+
+// DecodeCLValue decodes the serialized bytes of a CLValue according to its type tags.
+func DecodeCLValue(value storedvalue.CLValue) (DecodedCLValue, error) {
+	typ, rest, err := parseCLType(value.Tags)
+	if err != nil {
+		return DecodedCLValue{}, err
+	}
+	if len(rest) != 0 {
+		return DecodedCLValue{}, fmt.Errorf("unexpected trailing type tags: %v", rest)
+	}
+
+	decoded, remain, err := decodeCLBytes(typ, value.Bytes)
+	if err != nil {
+		return DecodedCLValue{}, err
+	}
+	if len(remain) != 0 {
+		return DecodedCLValue{}, fmt.Errorf("%d bytes are left after decoding %s", len(remain), typ)
+	}
+
+	return DecodedCLValue{Type: typ.String(), Value: decoded}, nil
+}
+
+// MarshalStoredValueJSON decodes the given StoredValue and returns it as indented JSON.
+func MarshalStoredValueJSON(value storedvalue.StoredValue) ([]byte, error) {
+	decoded, err := DecodeStoredValue(value)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(decoded, "", "  ")
+}
+
+// DecodeStateStoredValue decodes a protobuf StoredValue, as found in the effects of an execution.
+func DecodeStateStoredValue(value *state.StoredValue) (DecodedStoredValue, error) {
+	switch value.GetVariants().(type) {
+	case *state.StoredValue_ClValue:
+		clValue, err := DecodeStateCLValue(value.GetClValue())
+		if err != nil {
+			return DecodedStoredValue{}, err
+		}
+		return DecodedStoredValue{CLValue: &clValue}, nil
+	case *state.StoredValue_Account:
+		var account storedvalue.Account
+		account, err := account.FromStateValue(value.GetAccount())
+		if err != nil {
+			return DecodedStoredValue{}, err
+		}
+		decoded := decodeAccount(account)
+		return DecodedStoredValue{Account: &decoded}, nil
+	case *state.StoredValue_Contract:
+		var contract storedvalue.Contract
+		contract, err := contract.FromStateValue(value.GetContract())
+		if err != nil {
+			return DecodedStoredValue{}, err
+		}
+		decoded := decodeContract(contract)
+		return DecodedStoredValue{Contract: &decoded}, nil
+	default:
+		return DecodedStoredValue{}, fmt.Errorf("unknown stored value: %s", value.String())
+	}
+}
+
+// DecodeStateCLValue decodes a protobuf CLValue which carries its type as a tree instead of tags.
+func DecodeStateCLValue(value *state.CLValue) (DecodedCLValue, error) {
+	typ, err := clTypeFromState(value.GetClType())
+	if err != nil {
+		return DecodedCLValue{}, err
+	}
+
+	decoded, remain, err := decodeCLBytes(typ, value.GetSerializedValue())
+	if err != nil {
+		return DecodedCLValue{}, err
+	}
+	if len(remain) != 0 {
+		return DecodedCLValue{}, fmt.Errorf("%d bytes are left after decoding %s", len(remain), typ)
+	}
+
+	return DecodedCLValue{Type: typ.String(), Value: decoded}, nil
+}
+
+// StateKeyToString returns the bech32 form of account, hash and uref keys and hex of local keys
+func StateKeyToString(key *state.Key) string {
+	switch key.GetValue().(type) {
+	case *state.Key_Address_:
+		return sdk.AccAddress(key.GetAddress().GetAccount()).String()
+	case *state.Key_Hash_:
+		return sdk.ContractHashAddress(key.GetHash().GetHash()).String()
+	case *state.Key_Uref:
+		return sdk.ContractUrefAddress(key.GetUref().GetUref()).String()
+	case *state.Key_Local_:
+		return hex.EncodeToString(key.GetLocal().GetHash())
+	default:
+		return ""
+	}
+}
+
+func decodeAccount(account storedvalue.Account) DecodedAccount {
+	associatedKeys := make([]DecodedAssociatedKey, len(account.AssociatedKeys))
+	for i, associatedKey := range account.AssociatedKeys {
+		associatedKeys[i] = DecodedAssociatedKey{
+			Address: sdk.AccAddress(associatedKey.PublicKey).String(),
+			Weight:  associatedKey.Weight,
+		}
+	}
+
+	return DecodedAccount{
+		Address:        sdk.AccAddress(account.PublicKey).String(),
+		NamedKeys:      decodeNamedKeys(account.NamedKeys),
+		MainPurse:      sdk.ContractUrefAddress(account.MainPurse.Address).String(),
+		AssociatedKeys: associatedKeys,
+		ActionThresholds: DecodedActionThresholds{
+			Deployment:    account.ActionThresholds.DeploymentThreshold,
+			KeyManagement: account.ActionThresholds.KeyManagementThreshold,
+		},
+	}
+}
+
+func decodeContract(contract storedvalue.Contract) DecodedContract {
+	version := contract.ProtocolVersion
+	return DecodedContract{
+		NamedKeys:       decodeNamedKeys(contract.NamedKeys),
+		ProtocolVersion: fmt.Sprintf("%d.%d.%d", version.Major, version.Minor, version.Patch),
+		BodySize:        len(contract.Body),
+	}
+}
+
+func decodeNamedKeys(namedKeys storedvalue.NamedKeys) map[string]string {
+	res := make(map[string]string, len(namedKeys))
+	for _, namedKey := range namedKeys {
+		res[namedKey.Name] = keyToString(namedKey.Key)
+	}
+	return res
+}
+
+// keyToString returns the bech32 form of account, hash and uref keys and hex of local keys
+func keyToString(key storedvalue.Key) string {
+	switch key.KeyID {
+	case storedvalue.KEY_ID_ACCOUNT:
+		return sdk.AccAddress(key.Account.PublicKey).String()
+	case storedvalue.KEY_ID_HASH:
+		return sdk.ContractHashAddress(key.Hash).String()
+	case storedvalue.KEY_ID_UREF:
+		return sdk.ContractUrefAddress(key.Uref.Address).String()
+	case storedvalue.KEY_ID_LOCAL:
+		return hex.EncodeToString(key.Local)
+	default:
+		return ""
+	}
+}
+
+// parseCLType reads one type from the flattened tag list and returns the remaining tags
+func parseCLType(tags []storedvalue.CL_TYPE_TAG) (clType, []storedvalue.CL_TYPE_TAG, error) {
+	if len(tags) == 0 {
+		return clType{}, nil, fmt.Errorf("missing CLType tag")
+	}
+
+	typ := clType{tag: tags[0]}
+	rest := tags[1:]
+	if typ.tag == storedvalue.TAG_FIXED_LIST {
+		typ.length = clUnknownLength
+	}
+
+	innerCount := clTypeInnerCount(typ.tag)
+	if innerCount < 0 {
+		return clType{}, nil, fmt.Errorf("unknown CLType tag: %d", typ.tag)
+	}
+
+	for i := 0; i < innerCount; i++ {
+		var inner clType
+		var err error
+		inner, rest, err = parseCLType(rest)
+		if err != nil {
+			return clType{}, nil, err
+		}
+		typ.inner = append(typ.inner, inner)
+	}
+
+	return typ, rest, nil
+}
+
+// clTypeInnerCount returns the number of the inner types of the tag, or -1 for an unknown tag
+func clTypeInnerCount(tag storedvalue.CL_TYPE_TAG) int {
+	switch tag {
+	case storedvalue.TAG_BOOL, storedvalue.TAG_I32, storedvalue.TAG_I64, storedvalue.TAG_U8,
+		storedvalue.TAG_U32, storedvalue.TAG_U64, storedvalue.TAG_U128, storedvalue.TAG_U256,
+		storedvalue.TAG_U512, storedvalue.TAG_UNIT, storedvalue.TAG_STRING, storedvalue.TAG_KEY,
+		storedvalue.TAG_UREF, storedvalue.TAG_ANY:
+		return 0
+	case storedvalue.TAG_OPTION, storedvalue.TAG_LIST, storedvalue.TAG_FIXED_LIST, storedvalue.TAG_TUPLE1:
+		return 1
+	case storedvalue.TAG_RESULT, storedvalue.TAG_MAP, storedvalue.TAG_TUPLE2:
+		return 2
+	case storedvalue.TAG_TUPLE3:
+		return 3
+	default:
+		return -1
+	}
+}
+
+// clTypeFromState converts a protobuf CLType tree. Simple types share their numbers with the tags.
+func clTypeFromState(t *state.CLType) (clType, error) {
+	var tag storedvalue.CL_TYPE_TAG
+	var inner []*state.CLType
+	length := 0
+
+	switch t.GetVariants().(type) {
+	case *state.CLType_SimpleType:
+		return clType{tag: storedvalue.CL_TYPE_TAG(t.GetSimpleType())}, nil
+	case *state.CLType_OptionType:
+		tag, inner = storedvalue.TAG_OPTION, []*state.CLType{t.GetOptionType().GetInner()}
+	case *state.CLType_ListType:
+		tag, inner = storedvalue.TAG_LIST, []*state.CLType{t.GetListType().GetInner()}
+	case *state.CLType_FixedListType:
+		tag, inner = storedvalue.TAG_FIXED_LIST, []*state.CLType{t.GetFixedListType().GetInner()}
+		length = int(t.GetFixedListType().GetLen())
+	case *state.CLType_ResultType:
+		tag, inner = storedvalue.TAG_RESULT, []*state.CLType{t.GetResultType().GetOk(), t.GetResultType().GetErr()}
+	case *state.CLType_MapType:
+		tag, inner = storedvalue.TAG_MAP, []*state.CLType{t.GetMapType().GetKey(), t.GetMapType().GetValue()}
+	case *state.CLType_Tuple1Type:
+		tag, inner = storedvalue.TAG_TUPLE1, []*state.CLType{t.GetTuple1Type().GetType0()}
+	case *state.CLType_Tuple2Type:
+		tag, inner = storedvalue.TAG_TUPLE2, []*state.CLType{t.GetTuple2Type().GetType0(), t.GetTuple2Type().GetType1()}
+	case *state.CLType_Tuple3Type:
+		tag, inner = storedvalue.TAG_TUPLE3,
+			[]*state.CLType{t.GetTuple3Type().GetType0(), t.GetTuple3Type().GetType1(), t.GetTuple3Type().GetType2()}
+	case *state.CLType_AnyType:
+		return clType{tag: storedvalue.TAG_ANY}, nil
+	default:
+		return clType{}, fmt.Errorf("unknown CLType: %s", t.String())
+	}
+
+	typ := clType{tag: tag, length: length}
+	for _, innerType := range inner {
+		converted, err := clTypeFromState(innerType)
+		if err != nil {
+			return clType{}, err
+		}
+		typ.inner = append(typ.inner, converted)
+	}
+	return typ, nil
+}
+
+// decodeCLBytes decodes one value of the given type and returns the remaining bytes
+func decodeCLBytes(typ clType, src []byte) (interface{}, []byte, error) {
+	switch typ.tag {
+	case storedvalue.TAG_BOOL:
+		if len(src) < 1 {
+			return nil, nil, errShortCLValue(typ)
+		}
+		return src[0] == 1, src[1:], nil
+	case storedvalue.TAG_I32:
+		if len(src) < storedvalue.INT32_LENGTH {
+			return nil, nil, errShortCLValue(typ)
+		}
+		return int32(binary.LittleEndian.Uint32(src)), src[storedvalue.INT32_LENGTH:], nil
+	case storedvalue.TAG_I64:
+		if len(src) < storedvalue.LONG_LENGTH {
+			return nil, nil, errShortCLValue(typ)
+		}
+		return int64(binary.LittleEndian.Uint64(src)), src[storedvalue.LONG_LENGTH:], nil
+	case storedvalue.TAG_U8:
+		if len(src) < 1 {
+			return nil, nil, errShortCLValue(typ)
+		}
+		return src[0], src[1:], nil
+	case storedvalue.TAG_U32:
+		if len(src) < storedvalue.UINT32_LENGTH {
+			return nil, nil, errShortCLValue(typ)
+		}
+		return binary.LittleEndian.Uint32(src), src[storedvalue.UINT32_LENGTH:], nil
+	case storedvalue.TAG_U64:
+		if len(src) < storedvalue.LONG_LENGTH {
+			return nil, nil, errShortCLValue(typ)
+		}
+		return binary.LittleEndian.Uint64(src), src[storedvalue.LONG_LENGTH:], nil
+	case storedvalue.TAG_U128, storedvalue.TAG_U256, storedvalue.TAG_U512:
+		if len(src) < storedvalue.BIGINT_SIZE_LENGTH {
+			return nil, nil, errShortCLValue(typ)
+		}
+		size := int(src[0])
+		src = src[storedvalue.BIGINT_SIZE_LENGTH:]
+		if len(src) < size {
+			return nil, nil, errShortCLValue(typ)
+		}
+		// little endian to big endian
+		bigEndian := make([]byte, size)
+		for i := 0; i < size; i++ {
+			bigEndian[size-i-1] = src[i]
+		}
+		return json.Number(new(big.Int).SetBytes(bigEndian).String()), src[size:], nil
+	case storedvalue.TAG_UNIT:
+		return nil, src, nil
+	case storedvalue.TAG_STRING:
+		length, rest, err := readCLLength(typ, src)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(rest) < length {
+			return nil, nil, errShortCLValue(typ)
+		}
+		return string(rest[:length]), rest[length:], nil
+	case storedvalue.TAG_KEY:
+		return decodeCLKey(typ, src)
+	case storedvalue.TAG_UREF:
+		var uref storedvalue.URef
+		uref, err, pos := uref.FromBytes(src)
+		if err != nil {
+			return nil, nil, err
+		}
+		return sdk.ContractUrefAddress(uref.Address).String(), src[pos:], nil
+	case storedvalue.TAG_OPTION:
+		if len(src) < storedvalue.OPTION_SIZE_LENGTH {
+			return nil, nil, errShortCLValue(typ)
+		}
+		switch src[0] {
+		case clOptionNoneTag:
+			return nil, src[storedvalue.OPTION_SIZE_LENGTH:], nil
+		case clOptionSomeTag:
+			return decodeCLBytes(typ.inner[0], src[storedvalue.OPTION_SIZE_LENGTH:])
+		default:
+			return nil, nil, fmt.Errorf("invalid option tag: %d", src[0])
+		}
+	case storedvalue.TAG_LIST:
+		length, rest, err := readCLCount(typ, clMinLength(typ.inner[0]), src)
+		if err != nil {
+			return nil, nil, err
+		}
+		if typ.inner[0].tag == storedvalue.TAG_U8 {
+			if len(rest) < length {
+				return nil, nil, errShortCLValue(typ)
+			}
+			return hex.EncodeToString(rest[:length]), rest[length:], nil
+		}
+		return decodeCLList(typ.inner[0], length, rest)
+	case storedvalue.TAG_FIXED_LIST:
+		// the length of a fixed list is a part of its type, not of the serialized value
+		if typ.length != clUnknownLength {
+			if err := checkCLCount(typ, typ.length, clMinLength(typ.inner[0]), src); err != nil {
+				return nil, nil, err
+			}
+			if typ.inner[0].tag == storedvalue.TAG_U8 {
+				return hex.EncodeToString(src[:typ.length]), src[typ.length:], nil
+			}
+			return decodeCLList(typ.inner[0], typ.length, src)
+		}
+		// the type tags only have a fixed list at the top level, so the list takes all bytes
+		if typ.inner[0].tag == storedvalue.TAG_U8 {
+			return hex.EncodeToString(src), nil, nil
+		}
+		values := []interface{}{}
+		for len(src) > 0 {
+			var value interface{}
+			var err error
+			value, src, err = decodeCLBytes(typ.inner[0], src)
+			if err != nil {
+				return nil, nil, err
+			}
+			values = append(values, value)
+		}
+		return values, src, nil
+	case storedvalue.TAG_RESULT:
+		if len(src) < 1 {
+			return nil, nil, errShortCLValue(typ)
+		}
+		switch src[0] {
+		case clResultOkTag:
+			value, rest, err := decodeCLBytes(typ.inner[0], src[1:])
+			return map[string]interface{}{"ok": value}, rest, err
+		case clResultErrTag:
+			value, rest, err := decodeCLBytes(typ.inner[1], src[1:])
+			return map[string]interface{}{"err": value}, rest, err
+		default:
+			return nil, nil, fmt.Errorf("invalid result tag: %d", src[0])
+		}
+	case storedvalue.TAG_MAP:
+		return decodeCLMap(typ, src)
+	case storedvalue.TAG_TUPLE1, storedvalue.TAG_TUPLE2, storedvalue.TAG_TUPLE3:
+		values := make([]interface{}, len(typ.inner))
+		for i, inner := range typ.inner {
+			var err error
+			values[i], src, err = decodeCLBytes(inner, src)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+		return values, src, nil
+	case storedvalue.TAG_ANY:
+		return hex.EncodeToString(src), nil, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown CLType tag: %d", typ.tag)
+	}
+}
+
+// decodeCLKey decodes a key. Account keys carry only the 32 byte address in a CLValue.
+func decodeCLKey(typ clType, src []byte) (interface{}, []byte, error) {
+	if len(src) < storedvalue.KEY_ID_LENGTH+storedvalue.ADDRESS_LENGTH {
+		return nil, nil, errShortCLValue(typ)
+	}
+
+	keyID := storedvalue.KEY_ID(src[storedvalue.KEY_ID_POS])
+	src = src[storedvalue.KEY_ID_LENGTH:]
+	address := src[:storedvalue.ADDRESS_LENGTH]
+
+	switch keyID {
+	case storedvalue.KEY_ID_ACCOUNT:
+		return sdk.AccAddress(address).String(), src[storedvalue.ADDRESS_LENGTH:], nil
+	case storedvalue.KEY_ID_HASH:
+		return sdk.ContractHashAddress(address).String(), src[storedvalue.ADDRESS_LENGTH:], nil
+	case storedvalue.KEY_ID_UREF:
+		if len(src) < storedvalue.ADDRESS_LENGTH+storedvalue.UREF_ACCESS_RIGHTS_SERIALIZED_LENGTH {
+			return nil, nil, errShortCLValue(typ)
+		}
+		return sdk.ContractUrefAddress(address).String(),
+			src[storedvalue.ADDRESS_LENGTH+storedvalue.UREF_ACCESS_RIGHTS_SERIALIZED_LENGTH:], nil
+	case storedvalue.KEY_ID_LOCAL:
+		return hex.EncodeToString(address), src[storedvalue.ADDRESS_LENGTH:], nil
+	default:
+		return nil, nil, fmt.Errorf("unknown key id: %d", keyID)
+	}
+}
+
+func decodeCLList(inner clType, length int, src []byte) (interface{}, []byte, error) {
+	values := make([]interface{}, length)
+	for i := 0; i < length; i++ {
+		var err error
+		values[i], src, err = decodeCLBytes(inner, src)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return values, src, nil
+}
+
+// decodeCLMap renders a map with string keys as a JSON object, otherwise as a list of key/value pairs
+func decodeCLMap(typ clType, src []byte) (interface{}, []byte, error) {
+	keyType, valueType := typ.inner[0], typ.inner[1]
+	length, src, err := readCLCount(typ, clMinLength(keyType)+clMinLength(valueType), src)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if keyType.tag == storedvalue.TAG_STRING {
+		res := make(map[string]interface{}, length)
+		for i := 0; i < length; i++ {
+			var key, value interface{}
+			if key, src, err = decodeCLBytes(keyType, src); err != nil {
+				return nil, nil, err
+			}
+			if value, src, err = decodeCLBytes(valueType, src); err != nil {
+				return nil, nil, err
+			}
+			res[key.(string)] = value
+		}
+		return res, src, nil
+	}
+
+	res := make([]map[string]interface{}, length)
+	for i := 0; i < length; i++ {
+		var key, value interface{}
+		if key, src, err = decodeCLBytes(keyType, src); err != nil {
+			return nil, nil, err
+		}
+		if value, src, err = decodeCLBytes(valueType, src); err != nil {
+			return nil, nil, err
+		}
+		res[i] = map[string]interface{}{"key": key, "value": value}
+	}
+	return res, src, nil
+}
+
+func readCLLength(typ clType, src []byte) (int, []byte, error) {
+	if len(src) < storedvalue.SIZE_LENGTH {
+		return 0, nil, errShortCLValue(typ)
+	}
+	return int(binary.LittleEndian.Uint32(src)), src[storedvalue.SIZE_LENGTH:], nil
+}
+
+// readCLCount reads the number of the elements of a list or the entries of a map, each taking at
+// least minLength bytes, and checks it with checkCLCount
+func readCLCount(typ clType, minLength int, src []byte) (int, []byte, error) {
+	length, rest, err := readCLLength(typ, src)
+	if err != nil {
+		return 0, nil, err
+	}
+	if err := checkCLCount(typ, length, minLength, rest); err != nil {
+		return 0, nil, err
+	}
+	return length, rest, nil
+}
+
+// checkCLCount checks the remaining bytes can hold length elements of at least minLength bytes.
+// The count is untrusted, so it's bounded by the remaining bytes before anything is allocated for
+// it. An element of no bytes, as a unit, counts as one byte.
+func checkCLCount(typ clType, length int, minLength int, src []byte) error {
+	if minLength < 1 {
+		minLength = 1
+	}
+	if length > len(src)/minLength {
+		return errShortCLValue(typ)
+	}
+	return nil
+}
+
+// clMinLength returns the least number of bytes a value of the type is serialized in
+func clMinLength(typ clType) int {
+	switch typ.tag {
+	case storedvalue.TAG_BOOL, storedvalue.TAG_U8:
+		return 1
+	case storedvalue.TAG_I32, storedvalue.TAG_U32:
+		return storedvalue.INT32_LENGTH
+	case storedvalue.TAG_I64, storedvalue.TAG_U64:
+		return storedvalue.LONG_LENGTH
+	case storedvalue.TAG_U128, storedvalue.TAG_U256, storedvalue.TAG_U512:
+		return storedvalue.BIGINT_SIZE_LENGTH
+	case storedvalue.TAG_STRING, storedvalue.TAG_LIST, storedvalue.TAG_MAP:
+		return storedvalue.SIZE_LENGTH
+	case storedvalue.TAG_KEY:
+		return storedvalue.KEY_ID_LENGTH + storedvalue.ADDRESS_LENGTH
+	case storedvalue.TAG_UREF:
+		return storedvalue.ADDRESS_LENGTH
+	case storedvalue.TAG_OPTION:
+		return storedvalue.OPTION_SIZE_LENGTH
+	case storedvalue.TAG_RESULT:
+		return 1
+	case storedvalue.TAG_FIXED_LIST:
+		if typ.length == clUnknownLength {
+			return 0
+		}
+		return typ.length * clMinLength(typ.inner[0])
+	case storedvalue.TAG_TUPLE1, storedvalue.TAG_TUPLE2, storedvalue.TAG_TUPLE3:
+		length := 0
+		for _, inner := range typ.inner {
+			length += clMinLength(inner)
+		}
+		return length
+	default:
+		return 0
+	}
+}
+
+func errShortCLValue(typ clType) error {
+	return fmt.Errorf("not enough bytes to decode %s", typ)
+}
+
+// String returns the type name like Map(String, U512)
+func (t clType) String() string {
+	name, ok := clTypeNames[t.tag]
+	if !ok {
+		name = fmt.Sprintf("Unknown(%d)", t.tag)
+	}
+	if len(t.inner) == 0 {
+		return name
+	}
+
+	inner := make([]string, len(t.inner))
+	for i, typ := range t.inner {
+		inner[i] = typ.String()
+	}
+	return fmt.Sprintf("%s(%s)", name, strings.Join(inner, ", "))
+}
@@ -0,0 +1,158 @@
+package types
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/casper/consensus/state"
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/storedvalue"
+	sdk "github.com/hdac-io/friday/types"
+)
+
+func clString(str string) []byte {
+	res := make([]byte, storedvalue.SIZE_LENGTH)
+	binary.LittleEndian.PutUint32(res, uint32(len(str)))
+	return append(res, []byte(str)...)
+}
+
+func TestDecodeCLValue(t *testing.T) {
+	address := make([]byte, storedvalue.ADDRESS_LENGTH)
+	for i := range address {
+		address[i] = byte(i)
+	}
+
+	// U512 of 1000000000000000000 (0x0de0b6b3a7640000) in little endian
+	u512 := []byte{8, 0x00, 0x00, 0x64, 0xa7, 0xb3, 0xb6, 0xe0, 0x0d}
+
+	decoded, err := DecodeCLValue(storedvalue.NewClValue(u512, []storedvalue.CL_TYPE_TAG{storedvalue.TAG_U512}))
+	require.NoError(t, err)
+	require.Equal(t, "U512", decoded.Type)
+	require.Equal(t, json.Number("1000000000000000000"), decoded.Value)
+
+	decoded, err = DecodeCLValue(storedvalue.NewClValue(clString("counter"), []storedvalue.CL_TYPE_TAG{storedvalue.TAG_STRING}))
+	require.NoError(t, err)
+	require.Equal(t, "counter", decoded.Value)
+
+	hashKey := append([]byte{byte(storedvalue.KEY_ID_HASH)}, address...)
+	decoded, err = DecodeCLValue(storedvalue.NewClValue(hashKey, []storedvalue.CL_TYPE_TAG{storedvalue.TAG_KEY}))
+	require.NoError(t, err)
+	require.Equal(t, sdk.ContractHashAddress(address).String(), decoded.Value)
+
+	accountKey := append([]byte{byte(storedvalue.KEY_ID_ACCOUNT)}, address...)
+	decoded, err = DecodeCLValue(storedvalue.NewClValue(accountKey, []storedvalue.CL_TYPE_TAG{storedvalue.TAG_KEY}))
+	require.NoError(t, err)
+	require.Equal(t, sdk.AccAddress(address).String(), decoded.Value)
+
+	// Map(String, U512) with one entry
+	mapBytes := []byte{1, 0, 0, 0}
+	mapBytes = append(mapBytes, clString("total")...)
+	mapBytes = append(mapBytes, u512...)
+	decoded, err = DecodeCLValue(storedvalue.NewClValue(mapBytes,
+		[]storedvalue.CL_TYPE_TAG{storedvalue.TAG_MAP, storedvalue.TAG_STRING, storedvalue.TAG_U512}))
+	require.NoError(t, err)
+	require.Equal(t, "Map(String, U512)", decoded.Type)
+	require.Equal(t, map[string]interface{}{"total": json.Number("1000000000000000000")}, decoded.Value)
+
+	// List(Option(U32)) of [None, Some(7)]
+	listBytes := []byte{2, 0, 0, 0, 0, 1, 7, 0, 0, 0}
+	decoded, err = DecodeCLValue(storedvalue.NewClValue(listBytes,
+		[]storedvalue.CL_TYPE_TAG{storedvalue.TAG_LIST, storedvalue.TAG_OPTION, storedvalue.TAG_U32}))
+	require.NoError(t, err)
+	require.Equal(t, "List(Option(U32))", decoded.Type)
+	require.Equal(t, []interface{}{nil, uint32(7)}, decoded.Value)
+
+	decoded, err = DecodeCLValue(storedvalue.NewClValue([]byte{3, 0, 0, 0, 0xca, 0xfe, 0x01},
+		[]storedvalue.CL_TYPE_TAG{storedvalue.TAG_LIST, storedvalue.TAG_U8}))
+	require.NoError(t, err)
+	require.Equal(t, "cafe01", decoded.Value)
+
+	// wrong length
+	_, err = DecodeCLValue(storedvalue.NewClValue([]byte{8, 0x00}, []storedvalue.CL_TYPE_TAG{storedvalue.TAG_U512}))
+	require.Error(t, err)
+
+	_, err = DecodeCLValue(storedvalue.NewClValue([]byte{1, 0, 0, 0, 0}, []storedvalue.CL_TYPE_TAG{storedvalue.TAG_U32}))
+	require.Error(t, err)
+
+	// counts over the remaining bytes are rejected before the allocation
+	_, err = DecodeCLValue(storedvalue.NewClValue([]byte{0xff, 0xff, 0xff, 0xff, 0, 0, 0, 0},
+		[]storedvalue.CL_TYPE_TAG{storedvalue.TAG_LIST, storedvalue.TAG_U32}))
+	require.Error(t, err)
+	_, err = DecodeCLValue(storedvalue.NewClValue([]byte{0xff, 0xff, 0xff, 0xff},
+		[]storedvalue.CL_TYPE_TAG{storedvalue.TAG_LIST, storedvalue.TAG_UNIT}))
+	require.Error(t, err)
+	_, err = DecodeCLValue(storedvalue.NewClValue(append([]byte{2, 0, 0, 0}, clString("total")...),
+		[]storedvalue.CL_TYPE_TAG{storedvalue.TAG_MAP, storedvalue.TAG_STRING, storedvalue.TAG_U512}))
+	require.Error(t, err)
+}
+
+func TestDecodeStoredValueAccount(t *testing.T) {
+	publicKey := make([]byte, storedvalue.ADDRESS_LENGTH)
+	publicKey[0] = 1
+	purse := make([]byte, storedvalue.ADDRESS_LENGTH)
+	purse[0] = 2
+	hash := make([]byte, storedvalue.ADDRESS_LENGTH)
+	hash[0] = 3
+
+	account := storedvalue.NewAccount(
+		publicKey,
+		storedvalue.NamedKeys{storedvalue.NewNamedKey("counter", storedvalue.NewKeyFromHash(hash))},
+		storedvalue.NewURef(purse, state.Key_URef_READ_ADD_WRITE),
+		[]storedvalue.AssociatedKey{storedvalue.NewAssociatedKey(publicKey, 1)},
+		storedvalue.NewActionThresholds(1, 1),
+	)
+
+	decoded, err := DecodeStoredValue(storedvalue.NewStoredValueFromAccount(account))
+	require.NoError(t, err)
+	require.Nil(t, decoded.CLValue)
+	require.Nil(t, decoded.Contract)
+	require.Equal(t, sdk.AccAddress(publicKey).String(), decoded.Account.Address)
+	require.Equal(t, sdk.ContractUrefAddress(purse).String(), decoded.Account.MainPurse)
+	require.Equal(t, map[string]string{"counter": sdk.ContractHashAddress(hash).String()}, decoded.Account.NamedKeys)
+	require.Equal(t, []DecodedAssociatedKey{{Address: sdk.AccAddress(publicKey).String(), Weight: 1}}, decoded.Account.AssociatedKeys)
+
+	bz, err := MarshalStoredValueJSON(storedvalue.NewStoredValueFromAccount(account))
+	require.NoError(t, err)
+	require.Contains(t, string(bz), `"account"`)
+	require.NotContains(t, string(bz), `"cl_value"`)
+}
+
+func TestDecodeStateCLValue(t *testing.T) {
+	// Map(String, List(U64)) of {"counts": [1]}
+	clType := &state.CLType{Variants: &state.CLType_MapType{MapType: &state.CLType_Map{
+		Key: &state.CLType{Variants: &state.CLType_SimpleType{SimpleType: state.CLType_STRING}},
+		Value: &state.CLType{Variants: &state.CLType_ListType{ListType: &state.CLType_List{
+			Inner: &state.CLType{Variants: &state.CLType_SimpleType{SimpleType: state.CLType_U64}}}}},
+	}}}
+
+	serialized := []byte{1, 0, 0, 0}
+	serialized = append(serialized, clString("counts")...)
+	serialized = append(serialized, 1, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0)
+
+	decoded, err := DecodeStateCLValue(&state.CLValue{ClType: clType, SerializedValue: serialized})
+	require.NoError(t, err)
+	require.Equal(t, "Map(String, List(U64))", decoded.Type)
+	require.Equal(t, map[string]interface{}{"counts": []interface{}{uint64(1)}}, decoded.Value)
+}
+
+func TestDecodeStateCLValueFixedList(t *testing.T) {
+	// Tuple2(FixedList(U8, 2), String) of ("0102", "hdac")
+	fixedList := &state.CLType_FixedList{
+		Inner: &state.CLType{Variants: &state.CLType_SimpleType{SimpleType: state.CLType_U8}}, Len: 2}
+	clType := &state.CLType{Variants: &state.CLType_Tuple2Type{Tuple2Type: &state.CLType_Tuple2{
+		Type0: &state.CLType{Variants: &state.CLType_FixedListType{FixedListType: fixedList}},
+		Type1: &state.CLType{Variants: &state.CLType_SimpleType{SimpleType: state.CLType_STRING}},
+	}}}
+
+	serialized := append([]byte{1, 2}, clString("hdac")...)
+	decoded, err := DecodeStateCLValue(&state.CLValue{ClType: clType, SerializedValue: serialized})
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{"0102", "hdac"}, decoded.Value)
+
+	// a fixed list longer than the remaining bytes
+	fixedList.Len = 100
+	_, err = DecodeStateCLValue(&state.CLValue{ClType: clType, SerializedValue: serialized})
+	require.Error(t, err)
+}
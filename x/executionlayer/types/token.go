@@ -0,0 +1,222 @@
+package types
+
+import (
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/casper/consensus"
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/casper/consensus/state"
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/util"
+
+	sdk "github.com/hdac-io/friday/types"
+)
+
+// Entry points of the fungible-token interface. They're called with their name as the first
+// argument, as the entry points of a contract schema are.
+const (
+	TokenMethodTransfer     = "transfer"
+	TokenMethodApprove      = "approve"
+	TokenMethodTransferFrom = "transfer_from"
+)
+
+// Named keys of a token contract holding its state
+const (
+	TokenKeyName        = "name"         // String
+	TokenKeySymbol      = "symbol"       // String
+	TokenKeyDecimals    = "decimals"     // U8
+	TokenKeyTotalSupply = "total_supply" // U512
+
+	tokenKeyBalancePrefix   = "balance_"   // U512, followed by the hex encoded owner
+	tokenKeyAllowancePrefix = "allowance_" // U512, followed by the hex encoded owner and spender
+)
+
+// TokenSchema - entry points of the fungible-token interface. A registered token contract
+// publishing a schema must publish these entry points.
+var TokenSchema = ContractSchema{
+	EntryPoints: []ContractEntryPoint{
+		{
+			Name:        TokenMethodTransfer,
+			Args:        []ContractArg{{Name: "recipient", Type: "List(U8)"}, {Name: "amount", Type: "U512"}},
+			Description: "transfer the amount of the sender to the recipient",
+		},
+		{
+			Name:        TokenMethodApprove,
+			Args:        []ContractArg{{Name: "spender", Type: "List(U8)"}, {Name: "amount", Type: "U512"}},
+			Description: "allow the spender to transfer up to the amount of the sender",
+		},
+		{
+			Name:        TokenMethodTransferFrom,
+			Args:        []ContractArg{{Name: "owner", Type: "List(U8)"}, {Name: "recipient", Type: "List(U8)"}, {Name: "amount", Type: "U512"}},
+			Description: "transfer the amount of the owner to the recipient within the allowance of the sender",
+		},
+	},
+}
+
+// MaxTokenDecimals - maximum decimals of a token amount
+const MaxTokenDecimals = 77
+
+// ValidateTokenSchema checks the schema of a contract publishes the entry points of the
+// fungible-token interface with their arguments
+func ValidateTokenSchema(schema ContractSchema) error {
+	for _, expected := range TokenSchema.EntryPoints {
+		entryPoint, ok := schema.GetEntryPoint(expected.Name)
+		if !ok {
+			return fmt.Errorf("the contract has no %s entry point of the token interface", expected.Name)
+		}
+		if entryPoint.argList() != expected.argList() {
+			return fmt.Errorf("the %s entry point of the contract takes (%s), but the token interface takes (%s)",
+				expected.Name, entryPoint.argList(), expected.argList())
+		}
+	}
+	return nil
+}
+
+// TokenBalanceKey returns the named key of the balance of the owner in a token contract
+func TokenBalanceKey(owner sdk.AccAddress) string {
+	return tokenKeyBalancePrefix + hex.EncodeToString(owner)
+}
+
+// TokenAllowanceKey returns the named key of the amount the spender may transfer of the owner's
+// balance in a token contract
+func TokenAllowanceKey(owner, spender sdk.AccAddress) string {
+	return tokenKeyAllowancePrefix + hex.EncodeToString(owner) + "_" + hex.EncodeToString(spender)
+}
+
+// NewTokenTransferArgs returns the session args of a transfer of the token amount to the recipient
+func NewTokenTransferArgs(recipient sdk.AccAddress, amount string) (string, error) {
+	return tokenSessionArgs(TokenMethodTransfer, []tokenAddressArg{{"recipient", recipient}}, amount)
+}
+
+// NewTokenApproveArgs returns the session args allowing the spender to transfer up to the token amount
+func NewTokenApproveArgs(spender sdk.AccAddress, amount string) (string, error) {
+	return tokenSessionArgs(TokenMethodApprove, []tokenAddressArg{{"spender", spender}}, amount)
+}
+
+// NewTokenTransferFromArgs returns the session args of a transfer of the token amount of the owner
+// to the recipient, within the allowance of the sender
+func NewTokenTransferFromArgs(owner, recipient sdk.AccAddress, amount string) (string, error) {
+	return tokenSessionArgs(TokenMethodTransferFrom, []tokenAddressArg{{"owner", owner}, {"recipient", recipient}}, amount)
+}
+
+type tokenAddressArg struct {
+	name    string
+	address sdk.AccAddress
+}
+
+func tokenSessionArgs(method string, addresses []tokenAddressArg, amount string) (string, error) {
+	if _, ok := sdk.NewIntFromString(amount); !ok {
+		return "", fmt.Errorf("token amount must be an integer, got %s", amount)
+	}
+
+	args := []*consensus.Deploy_Arg{
+		&consensus.Deploy_Arg{
+			Name: "method",
+			Value: &state.CLValueInstance{
+				ClType: &state.CLType{Variants: &state.CLType_SimpleType{SimpleType: state.CLType_STRING}},
+				Value: &state.CLValueInstance_Value{
+					Value: &state.CLValueInstance_Value_StrValue{
+						StrValue: method}}}}}
+	for _, arg := range addresses {
+		args = append(args, &consensus.Deploy_Arg{
+			Name: arg.name,
+			Value: &state.CLValueInstance{
+				ClType: &state.CLType{Variants: &state.CLType_ListType{ListType: &state.CLType_List{Inner: &state.CLType{Variants: &state.CLType_SimpleType{SimpleType: state.CLType_U8}}}}},
+				Value: &state.CLValueInstance_Value{
+					Value: &state.CLValueInstance_Value_BytesValue{
+						BytesValue: arg.address}}}})
+	}
+	args = append(args, &consensus.Deploy_Arg{
+		Name: "amount",
+		Value: &state.CLValueInstance{
+			ClType: &state.CLType{Variants: &state.CLType_SimpleType{SimpleType: state.CLType_U512}},
+			Value: &state.CLValueInstance_Value{
+				Value: &state.CLValueInstance_Value_U512{
+					U512: &state.CLValueInstance_U512{
+						Value: amount}}}}})
+
+	return util.DeployArgsToJsonString(args)
+}
+
+var tokenAmountRegexp = regexp.MustCompile(`^[0-9]+(\.[0-9]*)?$`)
+
+// ParseTokenAmount converts the decimal token amount into the integer amount of the token's
+// smallest unit, e.g. 1.5 into 1500 with 3 decimals
+func ParseTokenAmount(amount string, decimals uint8) (string, error) {
+	if decimals > MaxTokenDecimals {
+		return "", fmt.Errorf("decimals must be %d or less, got %d", MaxTokenDecimals, decimals)
+	}
+	if !tokenAmountRegexp.MatchString(amount) {
+		return "", fmt.Errorf("token amount must be a decimal number, got %s", amount)
+	}
+
+	parts := strings.SplitN(amount, ".", 2)
+	fraction := ""
+	if len(parts) == 2 {
+		fraction = strings.TrimRight(parts[1], "0")
+	}
+	if len(fraction) > int(decimals) {
+		return "", fmt.Errorf("the decimal place of the token must be %d digits or less, got %s", decimals, amount)
+	}
+
+	res := strings.TrimLeft(parts[0]+fraction+strings.Repeat("0", int(decimals)-len(fraction)), "0")
+	if res == "" {
+		return "0", nil
+	}
+	return res, nil
+}
+
+// FormatTokenAmount converts the integer amount of the token's smallest unit into the decimal
+// token amount, e.g. 1500 into 1.5 with 3 decimals
+func FormatTokenAmount(amount string, decimals uint8) (string, error) {
+	parsed, ok := sdk.NewIntFromString(amount)
+	if !ok || parsed.IsNegative() {
+		return "", fmt.Errorf("token amount must be a non-negative integer, got %s", amount)
+	}
+
+	digits := parsed.String()
+	if len(digits) <= int(decimals) {
+		digits = strings.Repeat("0", int(decimals)-len(digits)+1) + digits
+	}
+	integer, fraction := digits[:len(digits)-int(decimals)], strings.TrimRight(digits[len(digits)-int(decimals):], "0")
+	if fraction == "" {
+		return integer, nil
+	}
+	return integer + "." + fraction, nil
+}
+
+// TokenMetadata - metadata and total supply of a token contract
+type TokenMetadata struct {
+	Contract    string `json:"contract" yaml:"contract"`
+	Name        string `json:"name" yaml:"name"`
+	Symbol      string `json:"symbol" yaml:"symbol"`
+	Decimals    uint8  `json:"decimals" yaml:"decimals"`
+	TotalSupply string `json:"total_supply" yaml:"total_supply"` // in the decimals of the token
+}
+
+// String returns a human readable string representation of the token metadata
+func (m TokenMetadata) String() string {
+	return fmt.Sprintf(`Token %s:
+  Name:          %s
+  Symbol:        %s
+  Decimals:      %d
+  Total Supply:  %s`, m.Contract, m.Name, m.Symbol, m.Decimals, m.TotalSupply)
+}
+
+// TokenAmount - amount of a token held by an owner, or allowed to a spender by the owner
+type TokenAmount struct {
+	Contract string         `json:"contract" yaml:"contract"`
+	Owner    sdk.AccAddress `json:"owner" yaml:"owner"`
+	Spender  sdk.AccAddress `json:"spender,omitempty" yaml:"spender,omitempty"`
+	Amount   string         `json:"amount" yaml:"amount"` // in the decimals of the token
+	Symbol   string         `json:"symbol" yaml:"symbol"`
+}
+
+// String returns a human readable string representation of the token amount
+func (a TokenAmount) String() string {
+	if a.Spender.Empty() {
+		return fmt.Sprintf("%s %s of %s in %s", a.Amount, a.Symbol, a.Owner, a.Contract)
+	}
+	return fmt.Sprintf("%s %s of %s allowed to %s in %s", a.Amount, a.Symbol, a.Owner, a.Spender, a.Contract)
+}
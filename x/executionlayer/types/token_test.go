@@ -0,0 +1,117 @@
+package types
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/util"
+
+	sdk "github.com/hdac-io/friday/types"
+)
+
+func TestTokenAmount(t *testing.T) {
+	for _, tc := range []struct {
+		amount   string
+		decimals uint8
+		parsed   string
+	}{
+		{"1.5", 3, "1500"},
+		{"1.500", 3, "1500"},
+		{"0.001", 3, "1"},
+		{"12", 0, "12"},
+		{"12.", 2, "1200"},
+		{"0", 18, "0"},
+		{"0.0", 2, "0"},
+		{"007", 1, "70"},
+	} {
+		parsed, err := ParseTokenAmount(tc.amount, tc.decimals)
+		require.NoError(t, err, tc.amount)
+		require.Equal(t, tc.parsed, parsed, tc.amount)
+	}
+
+	for _, amount := range []string{"", ".5", "1.2.3", "-1", "1e3", "0.0001"} {
+		_, err := ParseTokenAmount(amount, 3)
+		require.Error(t, err, amount)
+	}
+	_, err := ParseTokenAmount("1", MaxTokenDecimals+1)
+	require.Error(t, err)
+
+	for _, tc := range []struct {
+		amount    string
+		decimals  uint8
+		formatted string
+	}{
+		{"1500", 3, "1.5"},
+		{"1", 3, "0.001"},
+		{"1000", 3, "1"},
+		{"0", 3, "0"},
+		{"12", 0, "12"},
+	} {
+		formatted, err := FormatTokenAmount(tc.amount, tc.decimals)
+		require.NoError(t, err, tc.amount)
+		require.Equal(t, tc.formatted, formatted, tc.amount)
+	}
+	_, err = FormatTokenAmount("-1", 3)
+	require.Error(t, err)
+	_, err = FormatTokenAmount("1.5", 3)
+	require.Error(t, err)
+}
+
+func TestTokenSessionArgs(t *testing.T) {
+	require.NoError(t, TokenSchema.Validate())
+
+	owner := sdk.AccAddress([]byte(strings.Repeat("o", 32)))
+	recipient := sdk.AccAddress([]byte(strings.Repeat("r", 32)))
+
+	transfer, err := NewTokenTransferArgs(recipient, "100")
+	require.NoError(t, err)
+	approve, err := NewTokenApproveArgs(recipient, "100")
+	require.NoError(t, err)
+	transferFrom, err := NewTokenTransferFromArgs(owner, recipient, "100")
+	require.NoError(t, err)
+	_, err = NewTokenTransferArgs(recipient, "1.5")
+	require.Error(t, err)
+
+	// the args call the entry points of the interface, and are read by the EE
+	for method, sessionArgs := range map[string]string{
+		TokenMethodTransfer:     transfer,
+		TokenMethodApprove:      approve,
+		TokenMethodTransferFrom: transferFrom,
+	} {
+		entryPoint, err := TokenSchema.ValidateArgs(sessionArgs)
+		require.NoError(t, err)
+		require.Equal(t, method, entryPoint)
+
+		deployArgs, err := util.JsonStringToDeployArgs(sessionArgs)
+		require.NoError(t, err)
+		require.Equal(t, "100", deployArgs[len(deployArgs)-1].GetValue().GetValue().GetU512().GetValue())
+	}
+
+	require.Equal(t, "balance_"+strings.Repeat("6f", 32), TokenBalanceKey(owner))
+	require.Equal(t, "allowance_"+strings.Repeat("6f", 32)+"_"+strings.Repeat("72", 32), TokenAllowanceKey(owner, recipient))
+}
+
+func TestValidateTokenSchema(t *testing.T) {
+	require.NoError(t, ValidateTokenSchema(TokenSchema))
+
+	schema, err := ParseContractSchema([]byte(`{"entry_points": [
+		{"name": "transfer", "args": [{"name": "recipient", "type": "List(U8)"}, {"name": "amount", "type": "U512"}]},
+		{"name": "approve", "args": [{"name": "spender", "type": "List(U8)"}, {"name": "amount", "type": "U512"}]},
+		{"name": "transfer_from", "args": [{"name": "owner", "type": "List(U8)"}, {"name": "recipient", "type": "List(U8)"}, {"name": "amount", "type": "U512"}]},
+		{"name": "mint", "args": [{"name": "amount", "type": "U512"}]}
+	]}`))
+	require.NoError(t, err)
+	require.NoError(t, ValidateTokenSchema(schema))
+
+	// missing and mismatched entry points
+	schema.EntryPoints = schema.EntryPoints[1:]
+	require.Error(t, ValidateTokenSchema(schema))
+	schema, err = ParseContractSchema([]byte(counterSchema))
+	require.NoError(t, err)
+	require.Error(t, ValidateTokenSchema(schema))
+	schema.EntryPoints = append(append([]ContractEntryPoint{}, TokenSchema.EntryPoints...), ContractEntryPoint{Name: "inc"})
+	schema.EntryPoints[0].Args = schema.EntryPoints[0].Args[:1]
+	require.Error(t, ValidateTokenSchema(schema))
+}
@@ -28,6 +28,13 @@ const (
 	ClaimRewardMethodName     = "claim_reward"
 	ClaimCommissionMethodName = "claim_commission"
 
+	SetCommissionRateMethodName = "set_commission_rate"
+
+	AddAssociatedKeyMethodName    = "add_associated_key"
+	RemoveAssociatedKeyMethodName = "remove_associated_key"
+	UpdateAssociatedKeyMethodName = "update_associated_key"
+	SetActionThresholdMethodName  = "set_action_threshold"
+
 	SYSTEM_ACCOUNT_BALANCE       = "1000000000000000000000000000000"
 	TRANSFER_BALANCE             = "999999999999000000000000000000"
 	SYSTEM_ACCOUNT_BONDED_AMOUNT = "0"
@@ -0,0 +1,131 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/hdac-io/friday/types"
+)
+
+// Kinds of the stake requests taken by the PoS contract
+const (
+	StakeRequestUnbond     = "unbond"
+	StakeRequestUndelegate = "undelegate"
+	StakeRequestRedelegate = "redelegate"
+)
+
+// StakeRequest - an unbonding, undelegation or redelegation deployed to the PoS contract, pending
+// until the step of the PoS contract completes it at the completion height.
+// The PoS contract keeps the requests in its local state rather than in its named keys, which
+// only hold the delegations (d_), the validators (v_), the rewards (r_), the commissions (c_) and
+// its purses, so they're recorded when deployed, with the completion height given by the
+// UnbondingDelay parameter.
+type StakeRequest struct {
+	ID                  uint64         `json:"id" yaml:"id"`
+	Kind                string         `json:"kind" yaml:"kind"`
+	DelegatorAddress    sdk.AccAddress `json:"delegator_address" yaml:"delegator_address"`
+	ValidatorAddress    sdk.AccAddress `json:"validator_address" yaml:"validator_address"`
+	ValidatorDstAddress sdk.AccAddress `json:"validator_dst_address,omitempty" yaml:"validator_dst_address"` // redelegation only
+	Amount              string         `json:"amount" yaml:"amount"`
+	Height              int64          `json:"height" yaml:"height"`
+	CompletionHeight    int64          `json:"completion_height" yaml:"completion_height"`
+}
+
+// NewStakeRequest creates a new StakeRequest instance
+func NewStakeRequest(id uint64, kind string, delegator, validator, dstValidator sdk.AccAddress, amount string,
+	height, completionHeight int64) StakeRequest {
+	return StakeRequest{
+		ID:                  id,
+		Kind:                kind,
+		DelegatorAddress:    delegator,
+		ValidatorAddress:    validator,
+		ValidatorDstAddress: dstValidator,
+		Amount:              amount,
+		Height:              height,
+		CompletionHeight:    completionHeight,
+	}
+}
+
+// ValidateBasic runs stateless checks on the stake request
+func (r StakeRequest) ValidateBasic() sdk.Error {
+	if r.DelegatorAddress.Empty() || r.ValidatorAddress.Empty() {
+		return sdk.ErrInvalidAddress("delegator and validator cannot be empty")
+	}
+	switch r.Kind {
+	case StakeRequestUnbond, StakeRequestUndelegate:
+	case StakeRequestRedelegate:
+		if r.ValidatorDstAddress.Empty() {
+			return sdk.ErrInvalidAddress("destination validator cannot be empty")
+		}
+	default:
+		return ErrInvalidStakeRequest(DefaultCodespace, fmt.Sprintf("unknown kind %s", r.Kind))
+	}
+	if amount, ok := sdk.NewIntFromString(r.Amount); !ok || !amount.IsPositive() {
+		return ErrInvalidStakeRequest(DefaultCodespace, fmt.Sprintf("invalid amount %s", r.Amount))
+	}
+	if r.CompletionHeight < r.Height {
+		return ErrInvalidStakeRequest(DefaultCodespace,
+			fmt.Sprintf("completion height %d is before the height %d", r.CompletionHeight, r.Height))
+	}
+	return nil
+}
+
+// IsRedelegation returns whether the request moves the stake to another validator
+func (r StakeRequest) IsRedelegation() bool {
+	return r.Kind == StakeRequestRedelegate
+}
+
+// String returns a human readable string representation of a stake request.
+func (r StakeRequest) String() string {
+	return fmt.Sprintf(`Stake Request %d
+  Kind:                  %s
+  Delegator:             %s
+  Validator:             %s
+  Destination Validator: %s
+  Amount:                %s
+  Height:                %d
+  Completion Height:     %d`, r.ID, r.Kind, r.DelegatorAddress, r.ValidatorAddress, r.ValidatorDstAddress,
+		r.Amount, r.Height, r.CompletionHeight)
+}
+
+// StakeRequests is a collection of StakeRequest
+type StakeRequests []StakeRequest
+
+// Unbondings returns the unbondings and undelegations of the validator, or of every validator
+// when it's empty
+func (r StakeRequests) Unbondings(validator sdk.AccAddress) StakeRequests {
+	filtered := StakeRequests{}
+	for _, request := range r {
+		if request.IsRedelegation() {
+			continue
+		}
+		if !validator.Empty() && !request.ValidatorAddress.Equals(validator) {
+			continue
+		}
+		filtered = append(filtered, request)
+	}
+	return filtered
+}
+
+// Redelegations returns the redelegations from or to the validator, or of every validator when
+// it's empty
+func (r StakeRequests) Redelegations(validator sdk.AccAddress) StakeRequests {
+	filtered := StakeRequests{}
+	for _, request := range r {
+		if !request.IsRedelegation() {
+			continue
+		}
+		if !validator.Empty() && !request.ValidatorAddress.Equals(validator) && !request.ValidatorDstAddress.Equals(validator) {
+			continue
+		}
+		filtered = append(filtered, request)
+	}
+	return filtered
+}
+
+func (r StakeRequests) String() (out string) {
+	for _, val := range r {
+		out += val.String() + "\n"
+	}
+	return strings.TrimSpace(out)
+}
@@ -0,0 +1,42 @@
+package types
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/hdac-io/friday/types"
+)
+
+func TestStakeRequestValidateBasic(t *testing.T) {
+	delegator := sdk.AccAddress([]byte(strings.Repeat("d", 32)))
+	validator := sdk.AccAddress([]byte(strings.Repeat("v", 32)))
+
+	require.Nil(t, NewStakeRequest(1, StakeRequestUnbond, validator, validator, nil, "10", 5, 5).ValidateBasic())
+	require.Nil(t, NewStakeRequest(1, StakeRequestRedelegate, delegator, validator, delegator, "10", 5, 8).ValidateBasic())
+
+	require.NotNil(t, NewStakeRequest(1, StakeRequestUndelegate, nil, validator, nil, "10", 5, 8).ValidateBasic())
+	require.NotNil(t, NewStakeRequest(1, StakeRequestRedelegate, delegator, validator, nil, "10", 5, 8).ValidateBasic())
+	require.NotNil(t, NewStakeRequest(1, "withdraw", delegator, validator, nil, "10", 5, 8).ValidateBasic())
+	require.NotNil(t, NewStakeRequest(1, StakeRequestUndelegate, delegator, validator, nil, "0", 5, 8).ValidateBasic())
+	require.NotNil(t, NewStakeRequest(1, StakeRequestUndelegate, delegator, validator, nil, "10", 5, 4).ValidateBasic())
+}
+
+func TestStakeRequestsFilter(t *testing.T) {
+	delegator := sdk.AccAddress([]byte(strings.Repeat("d", 32)))
+	validator := sdk.AccAddress([]byte(strings.Repeat("v", 32)))
+	other := sdk.AccAddress([]byte(strings.Repeat("o", 32)))
+
+	unbond := NewStakeRequest(1, StakeRequestUnbond, validator, validator, nil, "10", 5, 8)
+	undelegate := NewStakeRequest(2, StakeRequestUndelegate, delegator, other, nil, "20", 5, 8)
+	redelegate := NewStakeRequest(3, StakeRequestRedelegate, delegator, other, validator, "30", 5, 8)
+	requests := StakeRequests{unbond, undelegate, redelegate}
+
+	require.Equal(t, StakeRequests{unbond, undelegate}, requests.Unbondings(nil))
+	require.Equal(t, StakeRequests{unbond}, requests.Unbondings(validator))
+	require.Equal(t, StakeRequests{redelegate}, requests.Redelegations(nil))
+	require.Equal(t, StakeRequests{redelegate}, requests.Redelegations(validator))
+	require.Equal(t, StakeRequests{redelegate}, requests.Redelegations(other))
+	require.Equal(t, StakeRequests{}, requests.Redelegations(delegator))
+}
@@ -24,6 +24,7 @@ type Validator struct {
 	ConsPubKey      crypto.PubKey  `json:"consensus_pubkey" yaml:"consensus_pubkey"` // the consensus public key of the validator; bech encoded in JSON
 	Description     Description    `json:"description" yaml:"description"`           // description terms for the validator
 	Stake           string         `json:"stake" yaml:"stake"`
+	Commission      Commission     `json:"commission" yaml:"commission"` // commission parameters
 }
 
 // NewValidator - initialize a new validator
@@ -36,6 +37,17 @@ func NewValidator(operator sdk.AccAddress, pubKey crypto.PubKey, description Des
 	}
 }
 
+// SetInitialCommission attempts to set a validator's initial commission. An
+// error is returned if the commission is invalid.
+func (v Validator) SetInitialCommission(commission Commission) (Validator, sdk.Error) {
+	if err := commission.Validate(); err != nil {
+		return v, err
+	}
+
+	v.Commission = commission
+	return v, nil
+}
+
 // return the redelegation
 func MustMarshalValidator(cdc *codec.Codec, validator Validator) []byte {
 	return cdc.MustMarshalBinaryLengthPrefixed(validator)
@@ -66,7 +78,8 @@ func (v Validator) String() string {
   Operator Address:           %s
   Validator Consensus Pubkey: %s
   Description:                %s
-  Stake:					  %s`, v.OperatorAddress, bechConsPubKey, v.Description, v.Stake)
+  Stake:					  %s
+  Commission:                 %s`, v.OperatorAddress, bechConsPubKey, v.Description, v.Stake, v.Commission)
 }
 
 // constant used in flags to indicate that description field should not be updated
@@ -138,6 +151,7 @@ type bechValidator struct {
 	ConsPubKey  string      `json:"consensus_pubkey" yaml:"consensus_pubkey"` // the bech32 consensus public key of the validator
 	Description Description `json:"description" yaml:"description"`           // description terms for the validator
 	Stake       string      `json:"stake" yaml:"stake"`
+	Commission  Commission  `json:"commission" yaml:"commission"` // commission parameters
 }
 
 // MarshalJSON marshals the validator to JSON using Bech32
@@ -152,6 +166,7 @@ func (v Validator) MarshalJSON() ([]byte, error) {
 		ConsPubKey:  bechConsPubKey,
 		Description: v.Description,
 		Stake:       v.Stake,
+		Commission:  v.Commission,
 	})
 }
 
@@ -174,6 +189,7 @@ func (v *Validator) UnmarshalJSON(data []byte) error {
 		ConsPubKey:      consPubKey,
 		Description:     bv.Description,
 		Stake:           bv.Stake,
+		Commission:      bv.Commission,
 	}
 	return nil
 }
@@ -190,6 +206,21 @@ func (v Validator) ConsAddress() sdk.ConsAddress {
 	return sdk.ConsAddress(v.ConsPubKey.Address())
 }
 
+// ConsKeyRotation - consensus pubkey a validator rotated away from in the current block,
+// whose removal is sent to tendermint with the new key at the end of the block
+type ConsKeyRotation struct {
+	OperatorAddress sdk.AccAddress `json:"operator_address" yaml:"operator_address"`
+	OldConsPubKey   crypto.PubKey  `json:"old_consensus_pubkey" yaml:"old_consensus_pubkey"`
+}
+
+// NewConsKeyRotation creates a new ConsKeyRotation instance
+func NewConsKeyRotation(operator sdk.AccAddress, oldConsPubKey crypto.PubKey) ConsKeyRotation {
+	return ConsKeyRotation{
+		OperatorAddress: operator,
+		OldConsPubKey:   oldConsPubKey,
+	}
+}
+
 // Validators is a collection of Validator
 type Validators []Validator
 
@@ -50,3 +50,17 @@ func TestUpdateDescription(t *testing.T) {
 	require.Nil(t, err)
 	require.Equal(t, d, d3)
 }
+
+func TestMsgRotateConsPubKeyJSON(t *testing.T) {
+	acc, _ := sdk.AccAddressFromBech32("friday1gp2u22697kz6slwa25k2tkhz6st2l0zx3hkfc5wdlpjaauv5czsq2dwu8m")
+	consPubKey, _ := sdk.GetConsPubKeyBech32("fridayvalconspub16jrl8jvqq98x7jjxfcm8252pwd4nv6fetpzk6nzx2ddyc3fn0p2rz4mwf44nqjtfga5k5at4xad82sjhx9r9zdfcwuc5uvt90934jjr4d4xk242909rxks28v9erv3jvwfcx2wp4fe8h54fsddu9zar5v3tyknrs8pykk2mw2p29j4n6w455c7j2d3x4ykft9akx6s24gsu8ys2nvayrykqst965z")
+	msg := NewMsgRotateConsPubKey("system:rotate_cons_pubkey", acc, consPubKey, "100")
+	require.Nil(t, msg.ValidateBasic())
+	require.Contains(t, string(msg.GetSignBytes()), "fridayvalconspub")
+
+	var decoded MsgRotateConsPubKey
+	require.Nil(t, ModuleCdc.UnmarshalJSON(ModuleCdc.MustMarshalJSON(msg), &decoded))
+	require.Equal(t, msg, decoded)
+
+	require.NotNil(t, NewMsgRotateConsPubKey("system:rotate_cons_pubkey", acc, nil, "100").ValidateBasic())
+}
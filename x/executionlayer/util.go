@@ -3,12 +3,16 @@ package executionlayer
 import (
 	"encoding/base64"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"regexp"
 	"strings"
 
 	"github.com/golang/protobuf/jsonpb"
 	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/casper/consensus"
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/casper/consensus/state"
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/ipc/transforms"
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/util"
 	sdk "github.com/hdac-io/friday/types"
 	"github.com/hdac-io/friday/x/executionlayer/types"
 	"github.com/hdac-io/friday/x/nickname"
@@ -136,3 +140,127 @@ func ReplaceFromBech32ToHex(valueStr string) (string, []sdk.AccAddress, error) {
 
 	return res, addrList, nil
 }
+
+// getContractKeysFromEffects collects bech32 addresses of contracts created by an execution.
+// A stored contract shows up in the effects as a write of a contract value under a hash or uref key.
+func getContractKeysFromEffects(effects []*transforms.TransformEntry) []string {
+	contractKeys := []string{}
+	found := map[string]bool{}
+	add := func(key *state.Key) {
+		var address sdk.Address
+		switch key.GetValue().(type) {
+		case *state.Key_Hash_:
+			address = sdk.ContractHashAddress(key.GetHash().GetHash())
+		case *state.Key_Uref:
+			address = sdk.ContractUrefAddress(key.GetUref().GetUref())
+		default:
+			return
+		}
+		if !found[address.String()] {
+			found[address.String()] = true
+			contractKeys = append(contractKeys, address.String())
+		}
+	}
+
+	for _, effect := range effects {
+		switch effect.GetTransform().GetTransformInstance().(type) {
+		case *transforms.Transform_Write:
+			if effect.GetTransform().GetWrite().GetValue().GetContract() != nil {
+				add(effect.GetKey())
+			}
+		}
+	}
+
+	return contractKeys
+}
+
+// getDryRunEffects converts transforms of an execution into readable effects.
+// Keys are bech32 encoded and written values are decoded by their types.
+func getDryRunEffects(effects []*transforms.TransformEntry) ([]types.DryRunEffect, error) {
+	res := make([]types.DryRunEffect, 0, len(effects))
+	for _, effect := range effects {
+		dryRunEffect := types.DryRunEffect{Key: types.StateKeyToString(effect.GetKey())}
+
+		transform := effect.GetTransform()
+		switch transform.GetTransformInstance().(type) {
+		case *transforms.Transform_Identity:
+			dryRunEffect.Transform = types.TransformIdentity
+		case *transforms.Transform_AddI32:
+			dryRunEffect.Transform = types.TransformAddI32
+			dryRunEffect.Value = transform.GetAddI32().GetValue()
+		case *transforms.Transform_AddU64:
+			dryRunEffect.Transform = types.TransformAddU64
+			dryRunEffect.Value = transform.GetAddU64().GetValue()
+		case *transforms.Transform_AddBigInt:
+			dryRunEffect.Transform = types.TransformAddBigInt
+			dryRunEffect.Value = json.Number(transform.GetAddBigInt().GetValue().GetValue())
+		case *transforms.Transform_AddKeys:
+			dryRunEffect.Transform = types.TransformAddKeys
+			namedKeys := map[string]string{}
+			for _, namedKey := range transform.GetAddKeys().GetValue() {
+				namedKeys[namedKey.GetName()] = types.StateKeyToString(namedKey.GetKey())
+			}
+			dryRunEffect.Value = namedKeys
+		case *transforms.Transform_Write:
+			dryRunEffect.Transform = types.TransformWrite
+			value, err := types.DecodeStateStoredValue(transform.GetWrite().GetValue())
+			if err != nil {
+				return nil, err
+			}
+			dryRunEffect.Value = value
+		case *transforms.Transform_Failure:
+			dryRunEffect.Transform = types.TransformFailure
+			dryRunEffect.Value = transform.GetFailure().String()
+		}
+
+		res = append(res, dryRunEffect)
+	}
+
+	return res, nil
+}
+
+// getDryRunReturnValues returns the CLValues written under the named keys added by the effects,
+// by the names
+func getDryRunReturnValues(effects []types.DryRunEffect) map[string]types.DecodedCLValue {
+	written := map[string]types.DecodedCLValue{}
+	for _, effect := range effects {
+		if value, ok := effect.Value.(types.DecodedStoredValue); ok && effect.Transform == types.TransformWrite && value.CLValue != nil {
+			written[effect.Key] = *value.CLValue
+		}
+	}
+
+	returnValues := map[string]types.DecodedCLValue{}
+	for _, effect := range effects {
+		namedKeys, ok := effect.Value.(map[string]string)
+		if !ok || effect.Transform != types.TransformAddKeys {
+			continue
+		}
+		for name, key := range namedKeys {
+			if value, found := written[key]; found {
+				returnValues[name] = value
+			}
+		}
+	}
+	return returnValues
+}
+
+// sessionArgsToAbi converts JSON encoded session args into the ABI bytes for the EE.
+// Accounts referred in the args by bech32 addresses are returned together.
+func sessionArgsToAbi(sessionArgs string) ([]byte, []sdk.AccAddress, error) {
+	replacedSessionArgs, addrList, err := ReplaceFromBech32ToHex(sessionArgs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	deployArgs, err := util.JsonStringToDeployArgs(replacedSessionArgs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	deployAbi, err := util.AbiDeployArgsTobytes(deployArgs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return deployAbi, addrList, nil
+}
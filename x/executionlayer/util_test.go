@@ -1,10 +1,14 @@
 package executionlayer
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
 
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/casper/consensus/state"
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/ipc/transforms"
 	sdk "github.com/hdac-io/friday/types"
+	"github.com/hdac-io/friday/x/executionlayer/types"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -29,3 +33,79 @@ func TestReplaceFromBech32ToHex(t *testing.T) {
 	unitAddr, _ := sdk.AccAddressFromBech32("friday1k568qc388n6x5ks8hkwly2q9ruepns8rr9sgqyjxk9cy6a2qq8gs4v2kpm")
 	assert.Equal(t, addrList, []sdk.AccAddress{unitAddr})
 }
+
+func TestGetContractKeysFromEffects(t *testing.T) {
+	hash := []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32}
+	uref := []byte{32, 31, 30, 29, 28, 27, 26, 25, 24, 23, 22, 21, 20, 19, 18, 17, 16, 15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1}
+	contractValue := &state.StoredValue{Variants: &state.StoredValue_Contract{Contract: &state.Contract{Body: []byte{0, 97, 115, 109}}}}
+	clValue := &state.StoredValue{Variants: &state.StoredValue_ClValue{ClValue: &state.CLValue{ClType: &state.CLType{Variants: &state.CLType_SimpleType{SimpleType: state.CLType_BOOL}}, SerializedValue: []byte{1}}}}
+
+	effects := []*transforms.TransformEntry{
+		&transforms.TransformEntry{
+			Key:       &state.Key{Value: &state.Key_Hash_{Hash: &state.Key_Hash{Hash: hash}}},
+			Transform: &transforms.Transform{TransformInstance: &transforms.Transform_Write{Write: &transforms.TransformWrite{Value: contractValue}}}},
+		&transforms.TransformEntry{
+			Key:       &state.Key{Value: &state.Key_Uref{Uref: &state.Key_URef{Uref: uref}}},
+			Transform: &transforms.Transform{TransformInstance: &transforms.Transform_Write{Write: &transforms.TransformWrite{Value: contractValue}}}},
+		&transforms.TransformEntry{
+			Key:       &state.Key{Value: &state.Key_Uref{Uref: &state.Key_URef{Uref: hash}}},
+			Transform: &transforms.Transform{TransformInstance: &transforms.Transform_Write{Write: &transforms.TransformWrite{Value: clValue}}}},
+		&transforms.TransformEntry{
+			Key:       &state.Key{Value: &state.Key_Hash_{Hash: &state.Key_Hash{Hash: hash}}},
+			Transform: &transforms.Transform{TransformInstance: &transforms.Transform_Write{Write: &transforms.TransformWrite{Value: contractValue}}}},
+	}
+
+	res := getContractKeysFromEffects(effects)
+	assert.Equal(t, []string{sdk.ContractHashAddress(hash).String(), sdk.ContractUrefAddress(uref).String()}, res)
+}
+
+func TestGetDryRunEffects(t *testing.T) {
+	hash := []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32}
+	uref := []byte{32, 31, 30, 29, 28, 27, 26, 25, 24, 23, 22, 21, 20, 19, 18, 17, 16, 15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1}
+	clValue := &state.StoredValue{Variants: &state.StoredValue_ClValue{ClValue: &state.CLValue{ClType: &state.CLType{Variants: &state.CLType_SimpleType{SimpleType: state.CLType_U512}}, SerializedValue: []byte{2, 0xe8, 0x03}}}}
+
+	effects := []*transforms.TransformEntry{
+		&transforms.TransformEntry{
+			Key:       &state.Key{Value: &state.Key_Hash_{Hash: &state.Key_Hash{Hash: hash}}},
+			Transform: &transforms.Transform{TransformInstance: &transforms.Transform_Identity{Identity: &transforms.TransformIdentity{}}}},
+		&transforms.TransformEntry{
+			Key:       &state.Key{Value: &state.Key_Uref{Uref: &state.Key_URef{Uref: uref}}},
+			Transform: &transforms.Transform{TransformInstance: &transforms.Transform_Write{Write: &transforms.TransformWrite{Value: clValue}}}},
+		&transforms.TransformEntry{
+			Key:       &state.Key{Value: &state.Key_Uref{Uref: &state.Key_URef{Uref: uref}}},
+			Transform: &transforms.Transform{TransformInstance: &transforms.Transform_AddBigInt{AddBigInt: &transforms.TransformAddBigInt{Value: &state.BigInt{Value: "1000", BitWidth: 512}}}}},
+		&transforms.TransformEntry{
+			Key: &state.Key{Value: &state.Key_Address_{Address: &state.Key_Address{Account: hash}}},
+			Transform: &transforms.Transform{TransformInstance: &transforms.Transform_AddKeys{AddKeys: &transforms.TransformAddKeys{Value: []*state.NamedKey{
+				&state.NamedKey{Name: "counter", Key: &state.Key{Value: &state.Key_Hash_{Hash: &state.Key_Hash{Hash: hash}}}}}}}}},
+	}
+
+	res, err := getDryRunEffects(effects)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, len(res))
+
+	assert.Equal(t, types.DryRunEffect{Key: sdk.ContractHashAddress(hash).String(), Transform: types.TransformIdentity}, res[0])
+
+	assert.Equal(t, sdk.ContractUrefAddress(uref).String(), res[1].Key)
+	assert.Equal(t, types.TransformWrite, res[1].Transform)
+	written := res[1].Value.(types.DecodedStoredValue)
+	assert.Equal(t, "U512", written.CLValue.Type)
+	assert.Equal(t, json.Number("1000"), written.CLValue.Value)
+
+	assert.Equal(t, types.DryRunEffect{Key: sdk.ContractUrefAddress(uref).String(), Transform: types.TransformAddBigInt, Value: json.Number("1000")}, res[2])
+
+	assert.Equal(t, types.DryRunEffect{
+		Key:       sdk.AccAddress(hash).String(),
+		Transform: types.TransformAddKeys,
+		Value:     map[string]string{"counter": sdk.ContractHashAddress(hash).String()},
+	}, res[3])
+
+	// the values written under the added named keys are the return values
+	assert.Equal(t, 0, len(getDryRunReturnValues(res)))
+	res = append(res, types.DryRunEffect{
+		Key:       sdk.AccAddress(hash).String(),
+		Transform: types.TransformAddKeys,
+		Value:     map[string]string{"total": sdk.ContractUrefAddress(uref).String()},
+	})
+	assert.Equal(t, map[string]types.DecodedCLValue{"total": {Type: "U512", Value: json.Number("1000")}}, getDryRunReturnValues(res))
+}
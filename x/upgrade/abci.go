@@ -0,0 +1,50 @@
+package upgrade
+
+import (
+	"fmt"
+	"strconv"
+
+	sdk "github.com/hdac-io/friday/types"
+)
+
+// BeginBlocker applies the scheduled upgrade at the block it is due at.
+//
+// A binary without the handler of the upgrade halts the node there, so that the operator
+// can switch to the new binary. The new binary runs the handler before the block, and
+// must not be started before the upgrade is due.
+func BeginBlocker(ctx sdk.Context, k Keeper) {
+	plan, found := k.GetUpgradePlan(ctx)
+	if !found {
+		return
+	}
+
+	if plan.ShouldExecute(ctx) {
+		if !k.HasHandler(plan.Name) {
+			upgradeMsg := fmt.Sprintf("UPGRADE \"%s\" NEEDED at %s: %s", plan.Name, plan.DueAt(), plan.Info)
+			// We don't have an upgrade handler for this upgrade name, meaning this software is out of date so shutdown
+			k.Logger(ctx).Error(upgradeMsg)
+			panic(upgradeMsg)
+		}
+
+		// We have an upgrade handler for this upgrade name, so apply the upgrade
+		k.Logger(ctx).Info(fmt.Sprintf("applying upgrade \"%s\" at %s", plan.Name, plan.DueAt()))
+		ctx = ctx.WithBlockGasMeter(sdk.NewInfiniteGasMeter())
+		k.ApplyUpgrade(ctx, plan)
+
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				EventTypeUpgrade,
+				sdk.NewAttribute(AttributeKeyName, plan.Name),
+				sdk.NewAttribute(AttributeKeyHeight, strconv.FormatInt(ctx.BlockHeight(), 10)),
+			),
+		)
+		return
+	}
+
+	// if we have a pending upgrade, but it is not yet time, make sure we did not set the handler already
+	if k.HasHandler(plan.Name) {
+		downgradeMsg := fmt.Sprintf("BINARY UPDATED BEFORE TRIGGER! UPGRADE \"%s\" - in binary but not executed on chain", plan.Name)
+		k.Logger(ctx).Error(downgradeMsg)
+		panic(downgradeMsg)
+	}
+}
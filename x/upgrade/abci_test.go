@@ -0,0 +1,171 @@
+package upgrade
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/hdac-io/tendermint/abci/types"
+	"github.com/hdac-io/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/hdac-io/friday/codec"
+	"github.com/hdac-io/friday/store"
+	sdk "github.com/hdac-io/friday/types"
+	govtypes "github.com/hdac-io/friday/x/gov/types"
+)
+
+type testInput struct {
+	cdc        *codec.Codec
+	cms        sdk.CommitMultiStore
+	key        *sdk.KVStoreKey
+	migrateKey *sdk.KVStoreKey
+}
+
+func setupTestInput(t *testing.T) testInput {
+	db := dbm.NewMemDB()
+	key := sdk.NewKVStoreKey(StoreKey)
+	migrateKey := sdk.NewKVStoreKey("migrate")
+
+	cms := store.NewCommitMultiStore(db)
+	cms.MountStoreWithDB(key, sdk.StoreTypeIAVL, db)
+	cms.MountStoreWithDB(migrateKey, sdk.StoreTypeIAVL, db)
+	require.NoError(t, cms.LoadLatestVersion())
+
+	cdc := codec.New()
+	RegisterCodec(cdc)
+
+	return testInput{cdc: cdc, cms: cms, key: key, migrateKey: migrateKey}
+}
+
+func (input testInput) ctx(height int64, blockTime time.Time) sdk.Context {
+	return sdk.NewContext(input.cms, abci.Header{Height: height, Time: blockTime}, false, log.NewNopLogger()).
+		WithEventManager(sdk.NewEventManager())
+}
+
+func queryApplied(t *testing.T, ctx sdk.Context, k Keeper, name string) (AppliedUpgrades, sdk.Error) {
+	querier := NewQuerier(k)
+	bz := ModuleCdc.MustMarshalJSON(NewQueryAppliedParams(name))
+	res, err := querier(ctx, []string{QueryApplied}, abci.RequestQuery{Data: bz})
+	if err != nil {
+		return nil, err
+	}
+
+	var upgrades AppliedUpgrades
+	require.NoError(t, ModuleCdc.UnmarshalJSON(res, &upgrades))
+	return upgrades, nil
+}
+
+func TestMockUpgrade(t *testing.T) {
+	input := setupTestInput(t)
+	blockTime := time.Now().UTC()
+
+	// the old binary, without the handler of the upgrade
+	oldKeeper := NewKeeper(input.cdc, input.key)
+	govHandler := NewSoftwareUpgradeProposalHandler(oldKeeper)
+
+	ctx := input.ctx(1, blockTime)
+	input.cms.GetKVStore(input.migrateKey).Set([]byte("a/1"), []byte("one"))
+	input.cms.GetKVStore(input.migrateKey).Set([]byte("a/2"), []byte("two"))
+	input.cms.GetKVStore(input.migrateKey).Set([]byte("b/1"), []byte("untouched"))
+
+	// a plan in the past is rejected
+	err := govHandler(ctx, NewSoftwareUpgradeProposal("title", "desc", Plan{Name: "test", Height: 1}))
+	require.NotNil(t, err)
+
+	err = govHandler(ctx, NewSoftwareUpgradeProposal("title", "desc", Plan{Name: "test", Height: 10, Info: "v2"}))
+	require.Nil(t, err)
+
+	res, err := NewQuerier(oldKeeper)(ctx, []string{QueryCurrent}, abci.RequestQuery{})
+	require.Nil(t, err)
+	var plan Plan
+	require.NoError(t, ModuleCdc.UnmarshalJSON(res, &plan))
+	require.Equal(t, Plan{Name: "test", Height: 10, Info: "v2"}, plan)
+
+	for height := int64(2); height < 10; height++ {
+		require.NotPanics(t, func() { BeginBlocker(input.ctx(height, blockTime), oldKeeper) })
+	}
+
+	// the old binary halts at the upgrade height
+	require.Panics(t, func() { BeginBlocker(input.ctx(10, blockTime), oldKeeper) })
+
+	// the new binary running before the upgrade height halts as well
+	newKeeper := NewKeeper(input.cdc, input.key)
+	newKeeper.SetUpgradeHandler("test", func(ctx sdk.Context, plan Plan) {
+		migrated := MigrateStore(ctx.KVStore(input.migrateKey), []byte("a/"), func(key, value []byte) ([]byte, []byte) {
+			return append([]byte("c/"), key[2:]...), append(value, '!')
+		})
+		require.Equal(t, 2, migrated)
+	})
+	require.Panics(t, func() { BeginBlocker(input.ctx(9, blockTime), newKeeper) })
+
+	// the new binary migrates the store at the upgrade height
+	ctx = input.ctx(10, blockTime)
+	require.NotPanics(t, func() { BeginBlocker(ctx, newKeeper) })
+
+	migrateStore := input.cms.GetKVStore(input.migrateKey)
+	require.Nil(t, migrateStore.Get([]byte("a/1")))
+	require.Equal(t, []byte("one!"), migrateStore.Get([]byte("c/1")))
+	require.Equal(t, []byte("two!"), migrateStore.Get([]byte("c/2")))
+	require.Equal(t, []byte("untouched"), migrateStore.Get([]byte("b/1")))
+
+	events := ctx.EventManager().Events()
+	require.Len(t, events, 1)
+	require.Equal(t, EventTypeUpgrade, events[0].Type)
+
+	_, found := newKeeper.GetUpgradePlan(ctx)
+	require.False(t, found)
+	res, err = NewQuerier(newKeeper)(ctx, []string{QueryCurrent}, abci.RequestQuery{})
+	require.Nil(t, err)
+	require.Nil(t, res)
+
+	upgrades, err := queryApplied(t, ctx, newKeeper, "")
+	require.Nil(t, err)
+	require.Equal(t, AppliedUpgrades{{Name: "test", Height: 10}}, upgrades)
+	upgrades, err = queryApplied(t, ctx, newKeeper, "test")
+	require.Nil(t, err)
+	require.Equal(t, AppliedUpgrades{{Name: "test", Height: 10}}, upgrades)
+	_, err = queryApplied(t, ctx, newKeeper, "unknown")
+	require.NotNil(t, err)
+
+	// the next blocks run normally, and the upgrade cannot be scheduled again
+	ctx = input.ctx(11, blockTime)
+	require.NotPanics(t, func() { BeginBlocker(ctx, newKeeper) })
+	err = NewSoftwareUpgradeProposalHandler(newKeeper)(ctx,
+		NewSoftwareUpgradeProposal("title", "desc", Plan{Name: "test", Height: 20}))
+	require.NotNil(t, err)
+	require.Equal(t, CodeAlreadyApplied, err.Code())
+}
+
+func TestTimeBasedUpgradeAndCancel(t *testing.T) {
+	input := setupTestInput(t)
+	blockTime := time.Now().UTC()
+
+	k := NewKeeper(input.cdc, input.key)
+	govHandler := NewSoftwareUpgradeProposalHandler(k)
+
+	ctx := input.ctx(1, blockTime)
+	plan := Plan{Name: "timed", Time: blockTime.Add(time.Hour)}
+	require.Nil(t, govHandler(ctx, NewSoftwareUpgradeProposal("title", "desc", plan)))
+
+	require.NotPanics(t, func() { BeginBlocker(input.ctx(2, blockTime.Add(time.Minute)), k) })
+	require.Panics(t, func() { BeginBlocker(input.ctx(3, blockTime.Add(time.Hour)), k) })
+
+	// a cancelled upgrade does not halt the node
+	require.Nil(t, govHandler(ctx, NewCancelSoftwareUpgradeProposal("title", "desc")))
+	_, found := k.GetUpgradePlan(ctx)
+	require.False(t, found)
+	require.NotPanics(t, func() { BeginBlocker(input.ctx(3, blockTime.Add(time.Hour)), k) })
+
+	require.NotNil(t, govHandler(ctx, govtypes.NewTextProposal("title", "desc")))
+}
+
+func TestPlanValidateBasic(t *testing.T) {
+	require.Nil(t, Plan{Name: "h", Height: 1}.ValidateBasic())
+	require.Nil(t, Plan{Name: "t", Time: time.Now()}.ValidateBasic())
+	require.NotNil(t, Plan{Height: 1}.ValidateBasic())
+	require.NotNil(t, Plan{Name: "none"}.ValidateBasic())
+	require.NotNil(t, Plan{Name: "both", Height: 1, Time: time.Now()}.ValidateBasic())
+	require.NotNil(t, Plan{Name: "negative", Height: -1}.ValidateBasic())
+}
@@ -0,0 +1,59 @@
+// nolint
+// aliases generated for the following subdirectories:
+// ALIASGEN: github.com/hdac-io/friday/x/upgrade/internal/keeper
+// ALIASGEN: github.com/hdac-io/friday/x/upgrade/internal/types
+package upgrade
+
+import (
+	"github.com/hdac-io/friday/x/upgrade/internal/keeper"
+	"github.com/hdac-io/friday/x/upgrade/internal/types"
+)
+
+const (
+	ModuleName                        = types.ModuleName
+	RouterKey                         = types.RouterKey
+	StoreKey                          = types.StoreKey
+	QuerierRoute                      = types.QuerierRoute
+	QueryCurrent                      = types.QueryCurrent
+	QueryApplied                      = types.QueryApplied
+	DefaultCodespace                  = types.DefaultCodespace
+	CodeInvalidPlan                   = types.CodeInvalidPlan
+	CodeAlreadyApplied                = types.CodeAlreadyApplied
+	EventTypeUpgrade                  = types.EventTypeUpgrade
+	AttributeKeyName                  = types.AttributeKeyName
+	AttributeKeyHeight                = types.AttributeKeyHeight
+	AttributeValueCategory            = types.AttributeValueCategory
+	ProposalTypeSoftwareUpgrade       = types.ProposalTypeSoftwareUpgrade
+	ProposalTypeCancelSoftwareUpgrade = types.ProposalTypeCancelSoftwareUpgrade
+)
+
+var (
+	// functions aliases
+	NewKeeper                        = keeper.NewKeeper
+	NewQuerier                       = keeper.NewQuerier
+	MigrateStore                     = keeper.MigrateStore
+	GetDoneKey                       = types.GetDoneKey
+	ErrInvalidPlan                   = types.ErrInvalidPlan
+	ErrAlreadyApplied                = types.ErrAlreadyApplied
+	NewSoftwareUpgradeProposal       = types.NewSoftwareUpgradeProposal
+	NewCancelSoftwareUpgradeProposal = types.NewCancelSoftwareUpgradeProposal
+	NewQueryAppliedParams            = types.NewQueryAppliedParams
+	RegisterCodec                    = types.RegisterCodec
+
+	// variable aliases
+	ModuleCdc = types.ModuleCdc
+	PlanKey   = types.PlanKey
+	DoneKey   = types.DoneKey
+)
+
+type (
+	Keeper                        = keeper.Keeper
+	UpgradeHandler                = keeper.UpgradeHandler
+	MigrateFn                     = keeper.MigrateFn
+	Plan                          = types.Plan
+	AppliedUpgrade                = types.AppliedUpgrade
+	AppliedUpgrades               = types.AppliedUpgrades
+	SoftwareUpgradeProposal       = types.SoftwareUpgradeProposal
+	CancelSoftwareUpgradeProposal = types.CancelSoftwareUpgradeProposal
+	QueryAppliedParams            = types.QueryAppliedParams
+)
@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hdac-io/friday/client"
+	"github.com/hdac-io/friday/client/context"
+	"github.com/hdac-io/friday/codec"
+	"github.com/hdac-io/friday/x/upgrade/internal/types"
+)
+
+// GetQueryCmd returns the cli query commands for the upgrade module.
+func GetQueryCmd(cdc *codec.Codec) *cobra.Command {
+	upgradeQueryCmd := &cobra.Command{
+		Use:                        types.ModuleName,
+		Short:                      "Querying commands for the upgrade module",
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+
+	upgradeQueryCmd.AddCommand(
+		client.GetCommands(
+			GetCmdQueryCurrentPlan(cdc),
+			GetCmdQueryApplied(cdc),
+		)...,
+	)
+
+	return upgradeQueryCmd
+}
+
+// GetCmdQueryCurrentPlan implements a command to return the scheduled upgrade.
+func GetCmdQueryCurrentPlan(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "plan",
+		Short: "Query the scheduled upgrade plan",
+		Long:  "Gets the currently scheduled upgrade plan, if one exists",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			route := fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryCurrent)
+			res, _, err := cliCtx.QueryWithData(route, nil)
+			if err != nil {
+				return err
+			}
+			if len(res) == 0 {
+				return fmt.Errorf("no upgrade scheduled")
+			}
+
+			var plan types.Plan
+			if err := cdc.UnmarshalJSON(res, &plan); err != nil {
+				return err
+			}
+
+			return cliCtx.PrintOutput(plan)
+		},
+	}
+}
+
+// GetCmdQueryApplied implements a command to return the applied upgrades.
+func GetCmdQueryApplied(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "applied [upgrade-name]",
+		Short: "Query the applied upgrades",
+		Long: `Gets the applied upgrades with the heights they were applied at.
+With an upgrade name, only the upgrade is returned, or an error if it was not applied.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			name := ""
+			if len(args) == 1 {
+				name = args[0]
+			}
+			bz, err := cdc.MarshalJSON(types.NewQueryAppliedParams(name))
+			if err != nil {
+				return err
+			}
+
+			route := fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryApplied)
+			res, _, err := cliCtx.QueryWithData(route, bz)
+			if err != nil {
+				return err
+			}
+
+			var upgrades types.AppliedUpgrades
+			if err := cdc.UnmarshalJSON(res, &upgrades); err != nil {
+				return err
+			}
+
+			return cliCtx.PrintOutput(upgrades)
+		},
+	}
+}
@@ -0,0 +1,141 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/hdac-io/friday/client/context"
+	"github.com/hdac-io/friday/codec"
+	sdk "github.com/hdac-io/friday/types"
+	"github.com/hdac-io/friday/version"
+	"github.com/hdac-io/friday/x/auth"
+	"github.com/hdac-io/friday/x/auth/client/utils"
+	"github.com/hdac-io/friday/x/gov"
+	govcli "github.com/hdac-io/friday/x/gov/client/cli"
+	"github.com/hdac-io/friday/x/upgrade/internal/types"
+)
+
+// Upgrade proposal flags
+const (
+	// TimeFormat specifies ISO UTC format for submitting the upgrade-time for a new upgrade proposal
+	TimeFormat = "2006-01-02T15:04:05Z"
+
+	FlagUpgradeHeight = "upgrade-height"
+	FlagUpgradeTime   = "upgrade-time"
+	FlagUpgradeInfo   = "upgrade-info"
+)
+
+func parseArgsToContent(name string) (gov.Content, error) {
+	height := viper.GetInt64(FlagUpgradeHeight)
+	timeStr := viper.GetString(FlagUpgradeTime)
+	if height != 0 && len(timeStr) != 0 {
+		return nil, fmt.Errorf("only one of --%s or --%s should be specified", FlagUpgradeTime, FlagUpgradeHeight)
+	}
+
+	var upgradeTime time.Time
+	if len(timeStr) != 0 {
+		var err error
+		upgradeTime, err = time.Parse(TimeFormat, timeStr)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	plan := types.Plan{Name: name, Time: upgradeTime, Height: height, Info: viper.GetString(FlagUpgradeInfo)}
+	content := types.NewSoftwareUpgradeProposal(
+		viper.GetString(govcli.FlagTitle), viper.GetString(govcli.FlagDescription), plan)
+	return content, nil
+}
+
+// GetCmdSubmitUpgradeProposal implements a command handler for submitting a software upgrade proposal transaction.
+func GetCmdSubmitUpgradeProposal(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "software-upgrade [name] (--upgrade-height [height] | --upgrade-time [time]) (--upgrade-info [info]) [flags]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Submit a software upgrade proposal",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Submit a software upgrade proposal along with an initial deposit.
+Once the proposal passes, the node halts at the upgrade height or time unless its binary
+has the handler of the upgrade. The new binary then migrates the stores before the next block.
+
+Example:
+$ %s tx gov submit-proposal software-upgrade v0.2 --upgrade-height 100000 \
+	--upgrade-info "https://github.com/hdac-io/friday/releases/tag/v0.2" \
+	--title "Upgrade to v0.2" --description "..." --deposit 10000stake --from=<key_or_address>
+`,
+				version.ClientName,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			content, err := parseArgsToContent(args[0])
+			if err != nil {
+				return err
+			}
+
+			deposit, err := sdk.ParseCoins(viper.GetString(govcli.FlagDeposit))
+			if err != nil {
+				return err
+			}
+
+			from := cliCtx.GetFromAddress()
+			msg := gov.NewMsgSubmitProposal(content, deposit, from)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(govcli.FlagTitle, "", "title of proposal")
+	cmd.Flags().String(govcli.FlagDescription, "", "description of proposal")
+	cmd.Flags().String(govcli.FlagDeposit, "", "deposit of proposal")
+	cmd.Flags().Int64(FlagUpgradeHeight, 0, "The height at which the upgrade must happen (not to be used together with --upgrade-time)")
+	cmd.Flags().String(FlagUpgradeTime, "", fmt.Sprintf("The time at which the upgrade must happen (ex. %s) (not to be used together with --upgrade-height)", TimeFormat))
+	cmd.Flags().String(FlagUpgradeInfo, "", "Optional info for the planned upgrade such as commit hash, etc.")
+
+	return cmd
+}
+
+// GetCmdSubmitCancelUpgradeProposal implements a command handler for submitting a software upgrade cancel proposal transaction.
+func GetCmdSubmitCancelUpgradeProposal(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cancel-software-upgrade [flags]",
+		Args:  cobra.ExactArgs(0),
+		Short: "Submit a proposal to cancel the scheduled software upgrade",
+		Long:  "Cancel the scheduled software upgrade along with an initial deposit.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			deposit, err := sdk.ParseCoins(viper.GetString(govcli.FlagDeposit))
+			if err != nil {
+				return err
+			}
+
+			content := types.NewCancelSoftwareUpgradeProposal(
+				viper.GetString(govcli.FlagTitle), viper.GetString(govcli.FlagDescription))
+
+			from := cliCtx.GetFromAddress()
+			msg := gov.NewMsgSubmitProposal(content, deposit, from)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(govcli.FlagTitle, "", "title of proposal")
+	cmd.Flags().String(govcli.FlagDescription, "", "description of proposal")
+	cmd.Flags().String(govcli.FlagDeposit, "", "deposit of proposal")
+
+	return cmd
+}
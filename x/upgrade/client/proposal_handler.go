@@ -0,0 +1,13 @@
+package client
+
+import (
+	govclient "github.com/hdac-io/friday/x/gov/client"
+	"github.com/hdac-io/friday/x/upgrade/client/cli"
+	"github.com/hdac-io/friday/x/upgrade/client/rest"
+)
+
+// software upgrade proposal handlers
+var (
+	ProposalHandler       = govclient.NewProposalHandler(cli.GetCmdSubmitUpgradeProposal, rest.ProposalRESTHandler)
+	CancelProposalHandler = govclient.NewProposalHandler(cli.GetCmdSubmitCancelUpgradeProposal, rest.ProposalCancelRESTHandler)
+)
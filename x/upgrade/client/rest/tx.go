@@ -0,0 +1,103 @@
+package rest
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/hdac-io/friday/client/context"
+	sdk "github.com/hdac-io/friday/types"
+	"github.com/hdac-io/friday/types/rest"
+	"github.com/hdac-io/friday/x/auth/client/utils"
+	"github.com/hdac-io/friday/x/gov"
+	govrest "github.com/hdac-io/friday/x/gov/client/rest"
+	"github.com/hdac-io/friday/x/upgrade/internal/types"
+)
+
+type (
+	// PlanRequest defines a proposal for a new upgrade plan.
+	PlanRequest struct {
+		BaseReq       rest.BaseReq   `json:"base_req" yaml:"base_req"`
+		Title         string         `json:"title" yaml:"title"`
+		Description   string         `json:"description" yaml:"description"`
+		Deposit       sdk.Coins      `json:"deposit" yaml:"deposit"`
+		Proposer      sdk.AccAddress `json:"proposer" yaml:"proposer"`
+		UpgradeName   string         `json:"name" yaml:"name"`
+		UpgradeHeight int64          `json:"upgrade_height" yaml:"upgrade_height"`
+		UpgradeTime   time.Time      `json:"upgrade_time" yaml:"upgrade_time"`
+		UpgradeInfo   string         `json:"upgrade_info" yaml:"upgrade_info"`
+	}
+
+	// CancelRequest defines a proposal to cancel the scheduled upgrade.
+	CancelRequest struct {
+		BaseReq     rest.BaseReq   `json:"base_req" yaml:"base_req"`
+		Title       string         `json:"title" yaml:"title"`
+		Description string         `json:"description" yaml:"description"`
+		Deposit     sdk.Coins      `json:"deposit" yaml:"deposit"`
+		Proposer    sdk.AccAddress `json:"proposer" yaml:"proposer"`
+	}
+)
+
+// ProposalRESTHandler returns a ProposalRESTHandler that exposes the software upgrade REST handler with a given sub-route.
+func ProposalRESTHandler(cliCtx context.CLIContext) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "upgrade",
+		Handler:  postPlanHandlerFn(cliCtx),
+	}
+}
+
+// ProposalCancelRESTHandler returns a ProposalRESTHandler that exposes the cancel upgrade REST handler with a given sub-route.
+func ProposalCancelRESTHandler(cliCtx context.CLIContext) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "cancel_upgrade",
+		Handler:  cancelPlanHandlerFn(cliCtx),
+	}
+}
+
+func postPlanHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req PlanRequest
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		plan := types.Plan{Name: req.UpgradeName, Time: req.UpgradeTime, Height: req.UpgradeHeight, Info: req.UpgradeInfo}
+		content := types.NewSoftwareUpgradeProposal(req.Title, req.Description, plan)
+
+		msg := gov.NewMsgSubmitProposal(content, req.Deposit, req.Proposer)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+func cancelPlanHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req CancelRequest
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		content := types.NewCancelSoftwareUpgradeProposal(req.Title, req.Description)
+
+		msg := gov.NewMsgSubmitProposal(content, req.Deposit, req.Proposer)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
@@ -0,0 +1,36 @@
+package upgrade
+
+import (
+	"fmt"
+
+	sdk "github.com/hdac-io/friday/types"
+	govtypes "github.com/hdac-io/friday/x/gov/types"
+)
+
+// NewSoftwareUpgradeProposalHandler creates a governance handler to manage the scheduled upgrade
+func NewSoftwareUpgradeProposalHandler(k Keeper) govtypes.Handler {
+	return func(ctx sdk.Context, content govtypes.Content) sdk.Error {
+		switch c := content.(type) {
+		case SoftwareUpgradeProposal:
+			return handleSoftwareUpgradeProposal(ctx, k, c)
+
+		case CancelSoftwareUpgradeProposal:
+			return handleCancelSoftwareUpgradeProposal(ctx, k, c)
+
+		default:
+			errMsg := fmt.Sprintf("unrecognized software upgrade proposal content type: %T", c)
+			return sdk.ErrUnknownRequest(errMsg)
+		}
+	}
+}
+
+func handleSoftwareUpgradeProposal(ctx sdk.Context, k Keeper, p SoftwareUpgradeProposal) sdk.Error {
+	k.Logger(ctx).Info(fmt.Sprintf("scheduling upgrade %s at %s", p.Plan.Name, p.Plan.DueAt()))
+	return k.ScheduleUpgrade(ctx, p.Plan)
+}
+
+func handleCancelSoftwareUpgradeProposal(ctx sdk.Context, k Keeper, _ CancelSoftwareUpgradeProposal) sdk.Error {
+	k.Logger(ctx).Info("cancelling the scheduled upgrade")
+	k.ClearUpgradePlan(ctx)
+	return nil
+}
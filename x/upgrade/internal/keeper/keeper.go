@@ -0,0 +1,139 @@
+package keeper
+
+import (
+	"fmt"
+
+	"github.com/hdac-io/tendermint/libs/log"
+
+	"github.com/hdac-io/friday/codec"
+	sdk "github.com/hdac-io/friday/types"
+	"github.com/hdac-io/friday/x/upgrade/internal/types"
+)
+
+// UpgradeHandler runs the store migrations of an upgrade on the new binary,
+// at the beginning of the block the upgrade is due at
+type UpgradeHandler func(ctx sdk.Context, plan types.Plan)
+
+// Keeper of the upgrade store
+type Keeper struct {
+	cdc             *codec.Codec
+	storeKey        sdk.StoreKey
+	upgradeHandlers map[string]UpgradeHandler
+}
+
+// NewKeeper creates a new upgrade Keeper instance
+func NewKeeper(cdc *codec.Codec, key sdk.StoreKey) Keeper {
+	return Keeper{
+		cdc:             cdc,
+		storeKey:        key,
+		upgradeHandlers: map[string]UpgradeHandler{},
+	}
+}
+
+//______________________________________________________________________
+
+// Logger returns a module-specific logger.
+func (k Keeper) Logger(ctx sdk.Context) log.Logger {
+	return ctx.Logger().With("module", fmt.Sprintf("x/%s", types.ModuleName))
+}
+
+// SetUpgradeHandler registers the handler of the named upgrade.
+// A binary which registers it can run past the height the upgrade is due at.
+func (k Keeper) SetUpgradeHandler(name string, upgradeHandler UpgradeHandler) {
+	k.upgradeHandlers[name] = upgradeHandler
+}
+
+// HasHandler returns true if the handler of the named upgrade is registered
+func (k Keeper) HasHandler(name string) bool {
+	_, ok := k.upgradeHandlers[name]
+	return ok
+}
+
+// ScheduleUpgrade schedules the upgrade of the plan, replacing the scheduled one if any
+func (k Keeper) ScheduleUpgrade(ctx sdk.Context, plan types.Plan) sdk.Error {
+	if err := plan.ValidateBasic(); err != nil {
+		return err
+	}
+
+	if plan.Time.Unix() > 0 {
+		if !plan.Time.After(ctx.BlockTime()) {
+			return types.ErrInvalidPlan(types.DefaultCodespace, "upgrade cannot be scheduled in the past")
+		}
+	} else if plan.Height <= ctx.BlockHeight() {
+		return types.ErrInvalidPlan(types.DefaultCodespace, "upgrade cannot be scheduled in the past")
+	}
+
+	if height := k.GetDoneHeight(ctx, plan.Name); height != 0 {
+		return types.ErrAlreadyApplied(types.DefaultCodespace, plan.Name, height)
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.PlanKey, k.cdc.MustMarshalBinaryBare(plan))
+
+	return nil
+}
+
+// GetUpgradePlan returns the scheduled upgrade if any
+func (k Keeper) GetUpgradePlan(ctx sdk.Context) (plan types.Plan, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.PlanKey)
+	if bz == nil {
+		return plan, false
+	}
+
+	k.cdc.MustUnmarshalBinaryBare(bz, &plan)
+	return plan, true
+}
+
+// ClearUpgradePlan clears the scheduled upgrade if any
+func (k Keeper) ClearUpgradePlan(ctx sdk.Context) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.PlanKey)
+}
+
+// GetDoneHeight returns the height the named upgrade was applied at, or 0 if it was not applied
+func (k Keeper) GetDoneHeight(ctx sdk.Context, name string) int64 {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetDoneKey(name))
+	if bz == nil {
+		return 0
+	}
+
+	var height int64
+	k.cdc.MustUnmarshalBinaryBare(bz, &height)
+	return height
+}
+
+// GetAppliedUpgrades returns all the applied upgrades ordered by name
+func (k Keeper) GetAppliedUpgrades(ctx sdk.Context) types.AppliedUpgrades {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.DoneKey)
+	defer iterator.Close()
+
+	upgrades := types.AppliedUpgrades{}
+	for ; iterator.Valid(); iterator.Next() {
+		var height int64
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &height)
+		upgrades = append(upgrades, types.AppliedUpgrade{
+			Name:   string(iterator.Key()[len(types.DoneKey):]),
+			Height: height,
+		})
+	}
+	return upgrades
+}
+
+// ApplyUpgrade runs the handler of the plan, then clears the plan and records the upgrade as applied.
+// The handler must be registered.
+func (k Keeper) ApplyUpgrade(ctx sdk.Context, plan types.Plan) {
+	handler, ok := k.upgradeHandlers[plan.Name]
+	if !ok {
+		panic(fmt.Sprintf("no handler of the upgrade %s", plan.Name))
+	}
+
+	handler(ctx, plan)
+
+	k.ClearUpgradePlan(ctx)
+
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetDoneKey(plan.Name), k.cdc.MustMarshalBinaryBare(ctx.BlockHeight()))
+}
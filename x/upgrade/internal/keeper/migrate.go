@@ -0,0 +1,31 @@
+package keeper
+
+import (
+	sdk "github.com/hdac-io/friday/types"
+)
+
+// MigrateFn returns the new key and value of a store entry, or a nil key to delete the entry
+type MigrateFn func(key, value []byte) (newKey, newValue []byte)
+
+// MigrateStore rewrites every entry under the prefix of the store, e.g. to change the encoding
+// of the values or the layout of the keys in an upgrade handler. It returns the number of migrated entries.
+func MigrateStore(store sdk.KVStore, prefix []byte, migrate MigrateFn) int {
+	// collect the entries first, as the store cannot be written while iterating
+	var keys, values [][]byte
+	iterator := sdk.KVStorePrefixIterator(store, prefix)
+	for ; iterator.Valid(); iterator.Next() {
+		keys = append(keys, append([]byte{}, iterator.Key()...))
+		values = append(values, append([]byte{}, iterator.Value()...))
+	}
+	iterator.Close()
+
+	for i, key := range keys {
+		newKey, newValue := migrate(key, values[i])
+		store.Delete(key)
+		if newKey != nil {
+			store.Set(newKey, newValue)
+		}
+	}
+
+	return len(keys)
+}
@@ -0,0 +1,66 @@
+package keeper
+
+import (
+	"fmt"
+
+	abci "github.com/hdac-io/tendermint/abci/types"
+
+	"github.com/hdac-io/friday/codec"
+	sdk "github.com/hdac-io/friday/types"
+	"github.com/hdac-io/friday/x/upgrade/internal/types"
+)
+
+// NewQuerier returns an upgrade Querier handler.
+func NewQuerier(k Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+		switch path[0] {
+		case types.QueryCurrent:
+			return queryCurrent(ctx, k)
+
+		case types.QueryApplied:
+			return queryApplied(ctx, req, k)
+
+		default:
+			return nil, sdk.ErrUnknownRequest(fmt.Sprintf("unknown upgrade query endpoint: %s", path[0]))
+		}
+	}
+}
+
+func queryCurrent(ctx sdk.Context, k Keeper) ([]byte, sdk.Error) {
+	plan, found := k.GetUpgradePlan(ctx)
+	if !found {
+		return nil, nil
+	}
+
+	res, err := codec.MarshalJSONIndent(k.cdc, plan)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("failed to marshal JSON", err.Error()))
+	}
+
+	return res, nil
+}
+
+func queryApplied(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, sdk.Error) {
+	var params types.QueryAppliedParams
+	if len(req.Data) != 0 {
+		if err := k.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+			return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+		}
+	}
+
+	upgrades := k.GetAppliedUpgrades(ctx)
+	if params.Name != "" {
+		height := k.GetDoneHeight(ctx, params.Name)
+		if height == 0 {
+			return nil, sdk.ErrUnknownRequest(fmt.Sprintf("upgrade %s is not applied", params.Name))
+		}
+		upgrades = types.AppliedUpgrades{{Name: params.Name, Height: height}}
+	}
+
+	res, err := codec.MarshalJSONIndent(k.cdc, upgrades)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("failed to marshal JSON", err.Error()))
+	}
+
+	return res, nil
+}
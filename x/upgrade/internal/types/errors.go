@@ -0,0 +1,23 @@
+package types
+
+import (
+	sdk "github.com/hdac-io/friday/types"
+)
+
+// Upgrade module codespace constants
+const (
+	DefaultCodespace sdk.CodespaceType = ModuleName
+
+	CodeInvalidPlan    sdk.CodeType = 1
+	CodeAlreadyApplied sdk.CodeType = 2
+)
+
+// ErrInvalidPlan returns an error for a malformed or outdated plan.
+func ErrInvalidPlan(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidPlan, "invalid upgrade plan: %s", msg)
+}
+
+// ErrAlreadyApplied returns an error for a plan of an upgrade which was applied before.
+func ErrAlreadyApplied(codespace sdk.CodespaceType, name string, height int64) sdk.Error {
+	return sdk.NewError(codespace, CodeAlreadyApplied, "upgrade %s was applied at height %d", name, height)
+}
@@ -0,0 +1,32 @@
+package types
+
+const (
+	// ModuleName is the name of this module
+	ModuleName = "upgrade"
+
+	// RouterKey is used to route governance proposals
+	RouterKey = ModuleName
+
+	// StoreKey is the prefix under which we store this module's data
+	StoreKey = ModuleName
+
+	// QuerierRoute is the querier route for the upgrade store
+	QuerierRoute = StoreKey
+
+	// Query endpoints supported by the upgrade querier
+	QueryCurrent = "current"
+	QueryApplied = "applied"
+)
+
+var (
+	// PlanKey is the key under which the current plan is saved
+	PlanKey = []byte{0x00}
+
+	// DoneKey is the prefix of the heights the upgrades were applied at (prefix | name)
+	DoneKey = []byte{0x01}
+)
+
+// GetDoneKey - key of the height an upgrade was applied at
+func GetDoneKey(name string) []byte {
+	return append(DoneKey, []byte(name)...)
+}
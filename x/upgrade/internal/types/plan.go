@@ -0,0 +1,91 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	sdk "github.com/hdac-io/friday/types"
+)
+
+// Plan specifies information about a planned upgrade and when it should occur
+type Plan struct {
+	// Name of the upgrade. The new binary registers the handler of the upgrade under this name.
+	Name string `json:"name" yaml:"name"`
+
+	// The time after which the upgrade must be performed. Leave it empty to use Height instead.
+	Time time.Time `json:"time" yaml:"time"`
+
+	// The height at which the upgrade must be performed. Only used if Time is not set.
+	Height int64 `json:"height" yaml:"height"`
+
+	// Any application specific upgrade info, e.g. where to download the new binary
+	Info string `json:"info" yaml:"info"`
+}
+
+// String implements the Stringer interface.
+func (p Plan) String() string {
+	return fmt.Sprintf(`Upgrade Plan
+  Name: %s
+  %s
+  Info: %s`, p.Name, p.DueAt(), p.Info)
+}
+
+// ValidateBasic does basic validation of a plan
+func (p Plan) ValidateBasic() sdk.Error {
+	if len(strings.TrimSpace(p.Name)) == 0 {
+		return ErrInvalidPlan(DefaultCodespace, "name cannot be empty")
+	}
+	if p.Height < 0 {
+		return ErrInvalidPlan(DefaultCodespace, "height cannot be negative")
+	}
+	isValidTime := p.Time.Unix() > 0
+	if !isValidTime && p.Height == 0 {
+		return ErrInvalidPlan(DefaultCodespace, "must set either time or height")
+	}
+	if isValidTime && p.Height != 0 {
+		return ErrInvalidPlan(DefaultCodespace, "cannot set both time and height")
+	}
+
+	return nil
+}
+
+// ShouldExecute returns true if the plan is due at the block of the context
+func (p Plan) ShouldExecute(ctx sdk.Context) bool {
+	if p.Time.Unix() > 0 {
+		return !ctx.BlockTime().Before(p.Time)
+	}
+	if p.Height > 0 {
+		return p.Height <= ctx.BlockHeight()
+	}
+	return false
+}
+
+// DueAt is a string representation of when the plan is due
+func (p Plan) DueAt() string {
+	if p.Time.Unix() > 0 {
+		return fmt.Sprintf("time: %s", p.Time.UTC().Format(time.RFC3339))
+	}
+	return fmt.Sprintf("height: %d", p.Height)
+}
+
+// AppliedUpgrade is the record of an upgrade applied on the chain
+type AppliedUpgrade struct {
+	Name   string `json:"name" yaml:"name"`
+	Height int64  `json:"height" yaml:"height"`
+}
+
+// String implements the Stringer interface.
+func (u AppliedUpgrade) String() string {
+	return fmt.Sprintf("%s applied at height %d", u.Name, u.Height)
+}
+
+// AppliedUpgrades is a collection of AppliedUpgrade
+type AppliedUpgrades []AppliedUpgrade
+
+func (u AppliedUpgrades) String() (out string) {
+	for _, val := range u {
+		out += val.String() + "\n"
+	}
+	return strings.TrimSpace(out)
+}
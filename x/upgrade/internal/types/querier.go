@@ -0,0 +1,12 @@
+package types
+
+// QueryAppliedParams defines the params of the applied upgrades query.
+// All the applied upgrades are returned when the name is empty.
+type QueryAppliedParams struct {
+	Name string `json:"name" yaml:"name"`
+}
+
+// NewQueryAppliedParams creates a new instance of QueryAppliedParams
+func NewQueryAppliedParams(name string) QueryAppliedParams {
+	return QueryAppliedParams{Name: name}
+}